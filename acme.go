@@ -1,8 +1,164 @@
 package digicert
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
 type ACMEService struct {
 	client *Client
 }
 
-// ACME service handles ACME protocol operations
-// This is a placeholder implementation
\ No newline at end of file
+// ACME service handles ACME directory lookup and auditing of the accounts and
+// orders created through a profile's ACME front door
+
+type ACMEDirectory struct {
+	ProfileID                      string `json:"profile_id,omitempty"`
+	DirectoryURL                   string `json:"directory_url"`
+	ExternalAccountBindingRequired bool   `json:"external_account_binding_required,omitempty"`
+}
+
+type ACMEAccount struct {
+	ID           string     `json:"id,omitempty"`
+	ProfileID    string     `json:"profile_id,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	Contacts     []string   `json:"contacts,omitempty"`
+	KeyID        string     `json:"key_id,omitempty"`
+	OrderCount   int        `json:"order_count,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	LastActiveAt *time.Time `json:"last_active_at,omitempty"`
+}
+
+type ACMEOrder struct {
+	ID            string     `json:"id,omitempty"`
+	AccountID     string     `json:"account_id,omitempty"`
+	ProfileID     string     `json:"profile_id,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	Identifiers   []string   `json:"identifiers,omitempty"`
+	CertificateID string     `json:"certificate_id,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+}
+
+type ACMEAccountListOptions struct {
+	PaginationParams
+	Status string `url:"status,omitempty"`
+}
+
+type ACMEAccountListResponse struct {
+	ListResponse
+	Accounts []ACMEAccount `json:"accounts"`
+}
+
+type ACMEOrderListOptions struct {
+	PaginationParams
+	ProfileID string `url:"profile_id,omitempty"`
+	AccountID string `url:"account_id,omitempty"`
+	Status    string `url:"status,omitempty"`
+}
+
+type ACMEOrderListResponse struct {
+	ListResponse
+	Orders []ACMEOrder `json:"orders"`
+}
+
+// GetDirectory retrieves the ACME directory URL for a profile
+func (s *ACMEService) GetDirectory(ctx context.Context, profileID string) (*ACMEDirectory, *Response, error) {
+	u := fmt.Sprintf("profiles/%s/acme", profileID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dir ACMEDirectory
+	resp, err := s.client.Do(ctx, httpReq, &dir)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &dir, resp, nil
+}
+
+// ListAccounts lists the ACME accounts registered against a profile
+func (s *ACMEService) ListAccounts(ctx context.Context, profileID string, opts *ACMEAccountListOptions) (*ACMEAccountListResponse, *Response, error) {
+	u := fmt.Sprintf("profiles/%s/acme/accounts", profileID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Status != "" {
+			q.Add("status", opts.Status)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result ACMEAccountListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// ListOrders lists ACME orders, optionally filtered by profile or account
+func (s *ACMEService) ListOrders(ctx context.Context, opts *ACMEOrderListOptions) (*ACMEOrderListResponse, *Response, error) {
+	u := "acme/orders"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.ProfileID != "" {
+			q.Add("profile_id", opts.ProfileID)
+		}
+		if opts.AccountID != "" {
+			q.Add("account_id", opts.AccountID)
+		}
+		if opts.Status != "" {
+			q.Add("status", opts.Status)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result ACMEOrderListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// RevokeAccount deactivates an ACME account so it can no longer place orders
+func (s *ACMEService) RevokeAccount(ctx context.Context, accountID string) (*Response, error) {
+	u := fmt.Sprintf("acme/accounts/%s/revoke", accountID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPut, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, httpReq, nil)
+	return resp, err
+}
@@ -0,0 +1,133 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestACMEService_GetDirectory(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/profiles/profile-123/acme" {
+			t.Errorf("Expected path /mpki/api/v1/profiles/profile-123/acme, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ACMEDirectory{
+			ProfileID:                      "profile-123",
+			DirectoryURL:                   "https://one.digicert.com/mpki/api/v1/acme/v2/directory",
+			ExternalAccountBindingRequired: true,
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	dir, _, err := client.ACME.GetDirectory(ctx, "profile-123")
+	if err != nil {
+		t.Fatalf("GetDirectory() error = %v", err)
+	}
+	if dir.DirectoryURL != "https://one.digicert.com/mpki/api/v1/acme/v2/directory" {
+		t.Errorf("DirectoryURL = %v", dir.DirectoryURL)
+	}
+	if !dir.ExternalAccountBindingRequired {
+		t.Error("ExternalAccountBindingRequired = false, want true")
+	}
+}
+
+func TestACMEService_ListAccounts(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/profiles/profile-123/acme/accounts" {
+			t.Errorf("Expected path /mpki/api/v1/profiles/profile-123/acme/accounts, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("status") != "valid" {
+			t.Errorf("status = %v, want %v", r.URL.Query().Get("status"), "valid")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ACMEAccountListResponse{
+			ListResponse: ListResponse{Total: 1},
+			Accounts:     []ACMEAccount{{ID: "acct-1", Status: "valid", OrderCount: 42}},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	result, _, err := client.ACME.ListAccounts(ctx, "profile-123", &ACMEAccountListOptions{Status: "valid"})
+	if err != nil {
+		t.Fatalf("ListAccounts() error = %v", err)
+	}
+	if len(result.Accounts) != 1 || result.Accounts[0].OrderCount != 42 {
+		t.Errorf("Accounts = %+v, want one account with 42 orders", result.Accounts)
+	}
+}
+
+func TestACMEService_ListOrders(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/acme/orders" {
+			t.Errorf("Expected path /mpki/api/v1/acme/orders, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("profile_id") != "profile-123" || q.Get("account_id") != "acct-1" {
+			t.Errorf("query = %v, want profile_id and account_id", q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ACMEOrderListResponse{
+			ListResponse: ListResponse{Total: 1},
+			Orders:       []ACMEOrder{{ID: "order-1", Identifiers: []string{"www.example.com"}}},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	result, _, err := client.ACME.ListOrders(ctx, &ACMEOrderListOptions{ProfileID: "profile-123", AccountID: "acct-1"})
+	if err != nil {
+		t.Fatalf("ListOrders() error = %v", err)
+	}
+	if len(result.Orders) != 1 || result.Orders[0].ID != "order-1" {
+		t.Errorf("Orders = %+v, want order-1", result.Orders)
+	}
+}
+
+func TestACMEService_RevokeAccount(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/acme/accounts/acct-1/revoke" {
+			t.Errorf("Expected path /mpki/api/v1/acme/accounts/acct-1/revoke, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	resp, err := client.ACME.RevokeAccount(ctx, "acct-1")
+	if err != nil {
+		t.Fatalf("RevokeAccount() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %v, want %v", resp.StatusCode, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,215 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AdminsService struct {
+	client *Client
+}
+
+// Admins service manages account-level administrators, as opposed to the
+// administrators of a single business unit managed by BusinessUnits, for
+// joiner, mover and leaver automation
+
+// AdminRole is the role of an account administrator.
+type AdminRole string
+
+const (
+	AdminRoleAdministrator AdminRole = "ADMINISTRATOR"
+	AdminRoleManager       AdminRole = "MANAGER"
+	AdminRoleAuditor       AdminRole = "AUDITOR"
+)
+
+// Valid reports whether r is a role the API recognises.
+func (r AdminRole) Valid() bool {
+	switch r {
+	case AdminRoleAdministrator, AdminRoleManager, AdminRoleAuditor:
+		return true
+	}
+	return false
+}
+
+// Account administrator statuses reported in Admin.Status.
+const (
+	AdminStatusInvited  = "invited"
+	AdminStatusActive   = "active"
+	AdminStatusInactive = "inactive"
+)
+
+type Admin struct {
+	ID          string     `json:"id,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	FirstName   string     `json:"first_name,omitempty"`
+	LastName    string     `json:"last_name,omitempty"`
+	Role        AdminRole  `json:"role,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	InvitedAt   *time.Time `json:"invited_at,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// IsActive reports whether the administrator can sign in. Invited
+// administrators that have not accepted yet are not active.
+func (a *Admin) IsActive() bool {
+	return strings.EqualFold(a.Status, AdminStatusActive)
+}
+
+type AdminListOptions struct {
+	PaginationParams
+	Email  string    `url:"email,omitempty"`
+	Role   AdminRole `url:"role,omitempty"`
+	Status string    `url:"status,omitempty"`
+}
+
+type AdminListResponse struct {
+	ListResponse
+	Admins []Admin `json:"admins"`
+}
+
+type AdminInviteRequest struct {
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Role      AdminRole `json:"role"`
+}
+
+// AdminUpdateRequest changes the fields that are set, leaving the others
+// as they are.
+type AdminUpdateRequest struct {
+	FirstName *string    `json:"first_name,omitempty"`
+	LastName  *string    `json:"last_name,omitempty"`
+	Role      *AdminRole `json:"role,omitempty"`
+}
+
+// List lists account administrators
+func (s *AdminsService) List(ctx context.Context, opts *AdminListOptions) (*AdminListResponse, *Response, error) {
+	u := "admin"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Email != "" {
+			q.Add("email", opts.Email)
+		}
+		if opts.Role != "" {
+			q.Add("role", string(opts.Role))
+		}
+		if opts.Status != "" {
+			q.Add("status", opts.Status)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result AdminListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// Get retrieves an account administrator by ID
+func (s *AdminsService) Get(ctx context.Context, adminID string) (*Admin, *Response, error) {
+	u := fmt.Sprintf("admin/%s", adminID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var admin Admin
+	resp, err := s.client.Do(ctx, httpReq, &admin)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &admin, resp, nil
+}
+
+// Invite invites a new account administrator, who is listed with status
+// invited until they accept.
+func (s *AdminsService) Invite(ctx context.Context, req *AdminInviteRequest) (*Admin, *Response, error) {
+	if req == nil {
+		return nil, nil, fmt.Errorf("admin invite request cannot be nil")
+	}
+	if req.Email == "" {
+		return nil, nil, fmt.Errorf("admin email is required")
+	}
+	if !req.Role.Valid() {
+		return nil, nil, fmt.Errorf("unknown admin role %q", req.Role)
+	}
+
+	u := "admin"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var admin Admin
+	resp, err := s.client.Do(ctx, httpReq, &admin)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &admin, resp, nil
+}
+
+// Update changes the name or role of an account administrator
+func (s *AdminsService) Update(ctx context.Context, adminID string, req *AdminUpdateRequest) (*Admin, *Response, error) {
+	if req == nil {
+		return nil, nil, fmt.Errorf("admin update request cannot be nil")
+	}
+	if req.Role != nil && !req.Role.Valid() {
+		return nil, nil, fmt.Errorf("unknown admin role %q", *req.Role)
+	}
+
+	u := fmt.Sprintf("admin/%s", adminID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPatch, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var admin Admin
+	resp, err := s.client.Do(ctx, httpReq, &admin)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &admin, resp, nil
+}
+
+// Deactivate deactivates an account administrator, revoking their access
+// while keeping them in the audit trail. Pending invitations are withdrawn.
+func (s *AdminsService) Deactivate(ctx context.Context, adminID string) (*Admin, *Response, error) {
+	u := fmt.Sprintf("admin/%s/deactivate", adminID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var admin Admin
+	resp, err := s.client.Do(ctx, httpReq, &admin)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &admin, resp, nil
+}
@@ -0,0 +1,91 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminsService_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/admin" {
+			t.Errorf("Expected path /mpki/api/v1/admin, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("role") != "AUDITOR" || r.URL.Query().Get("status") != AdminStatusActive {
+			t.Errorf("query = %v, want role and status filters", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AdminListResponse{
+			ListResponse: ListResponse{Total: 1},
+			Admins:       []Admin{{ID: "adm-1", Email: "audit@example.com", Role: AdminRoleAuditor, Status: "ACTIVE"}},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, _, err := client.Admins.List(context.Background(), &AdminListOptions{Role: AdminRoleAuditor, Status: AdminStatusActive})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(result.Admins) != 1 || !result.Admins[0].IsActive() {
+		t.Errorf("Admins = %+v, want one active auditor", result.Admins)
+	}
+}
+
+func TestAdminsService_Lifecycle(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			if r.URL.Path == "/mpki/api/v1/admin" && body["role"] != "MANAGER" {
+				t.Errorf("invite body = %v, want the manager role", body)
+			}
+			json.NewEncoder(w).Encode(Admin{ID: "adm-2", Status: AdminStatusInvited})
+		case http.MethodPatch:
+			if len(body) != 1 || body["role"] != "ADMINISTRATOR" {
+				t.Errorf("update body = %v, want only the role", body)
+			}
+			json.NewEncoder(w).Encode(Admin{ID: "adm-2", Role: AdminRoleAdministrator})
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	admin, _, err := client.Admins.Invite(ctx, &AdminInviteRequest{Email: "new@example.com", Role: AdminRoleManager})
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if admin.IsActive() {
+		t.Error("IsActive() = true for an invited admin")
+	}
+	role := AdminRoleAdministrator
+	if _, _, err := client.Admins.Update(ctx, "adm-2", &AdminUpdateRequest{Role: &role}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, _, err := client.Admins.Deactivate(ctx, "adm-2"); err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+
+	want := []string{"POST /mpki/api/v1/admin", "PATCH /mpki/api/v1/admin/adm-2", "POST /mpki/api/v1/admin/adm-2/deactivate"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d = %v, want %v", i, calls[i], want[i])
+		}
+	}
+
+	if _, _, err := client.Admins.Invite(ctx, &AdminInviteRequest{Email: "x@example.com", Role: "admin"}); err == nil {
+		t.Error("Invite() expected error for an unknown role")
+	}
+}
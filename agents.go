@@ -1,8 +1,172 @@
 package digicert
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
 type AgentsService struct {
 	client *Client
 }
 
 // Agents service handles certificate discovery and management agents
-// This is a placeholder implementation
\ No newline at end of file
+
+type AgentEnrollmentTokenOptions struct {
+	// ValidityHours is how long the token can be used to register agents.
+	// Zero uses the account default.
+	ValidityHours int `json:"validity_hours,omitempty"`
+	// MaxUses limits how many agents can register with the token.
+	// Zero allows unlimited registrations while the token is valid.
+	MaxUses int      `json:"max_uses,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+type AgentInstaller struct {
+	Platform     string `json:"platform"`
+	Architecture string `json:"architecture,omitempty"`
+	URL          string `json:"url"`
+	SHA256       string `json:"sha256,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+type AgentEnrollmentToken struct {
+	Token          string           `json:"token"`
+	BusinessUnitID string           `json:"business_unit_id,omitempty"`
+	ExpiresAt      *time.Time       `json:"expires_at,omitempty"`
+	MaxUses        int              `json:"max_uses,omitempty"`
+	Installers     []AgentInstaller `json:"installers,omitempty"`
+}
+
+// InstallerFor returns the installer for a platform and architecture, or nil
+// if none is available. An empty architecture matches any.
+func (t *AgentEnrollmentToken) InstallerFor(platform, architecture string) *AgentInstaller {
+	for i := range t.Installers {
+		inst := &t.Installers[i]
+		if inst.Platform == platform && (architecture == "" || inst.Architecture == architecture) {
+			return inst
+		}
+	}
+	return nil
+}
+
+type ScanTarget struct {
+	// Host is a host name, IP address or CIDR range.
+	Host  string `json:"host"`
+	Ports []int  `json:"ports,omitempty"`
+}
+
+type ScanRequest struct {
+	Name    string       `json:"name,omitempty"`
+	Targets []ScanTarget `json:"targets"`
+	// Ports scanned for targets that do not list their own.
+	Ports []int `json:"ports,omitempty"`
+}
+
+type AgentScan struct {
+	ID          string     `json:"id"`
+	AgentID     string     `json:"agent_id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ScanFinding is a certificate observed on a network endpoint during a scan.
+type ScanFinding struct {
+	Host         string     `json:"host"`
+	IPAddress    string     `json:"ip_address,omitempty"`
+	Port         int        `json:"port"`
+	TLSVersion   string     `json:"tls_version,omitempty"`
+	CommonName   string     `json:"common_name,omitempty"`
+	SerialNumber string     `json:"serial_number,omitempty"`
+	Thumbprint   string     `json:"thumbprint,omitempty"`
+	Issuer       string     `json:"issuer,omitempty"`
+	ValidTo      *time.Time `json:"valid_to,omitempty"`
+	Certificate  string     `json:"certificate,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+type ScanResultsOptions struct {
+	PaginationParams
+}
+
+type ScanResultsResponse struct {
+	ListResponse
+	Scan    AgentScan     `json:"scan"`
+	Results []ScanFinding `json:"results"`
+}
+
+// CreateEnrollmentToken creates an agent registration token for a business unit
+// and returns it together with the installer download URLs
+func (s *AgentsService) CreateEnrollmentToken(ctx context.Context, buID string, opts *AgentEnrollmentTokenOptions) (*AgentEnrollmentToken, *Response, error) {
+	u := "agents/enrollment-token"
+
+	req := struct {
+		BusinessUnitID string `json:"business_unit_id"`
+		*AgentEnrollmentTokenOptions
+	}{
+		BusinessUnitID:              buID,
+		AgentEnrollmentTokenOptions: opts,
+	}
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var token AgentEnrollmentToken
+	resp, err := s.client.Do(ctx, httpReq, &token)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &token, resp, nil
+}
+
+// TriggerScan starts an on-demand network scan on an agent
+func (s *AgentsService) TriggerScan(ctx context.Context, agentID string, req *ScanRequest) (*AgentScan, *Response, error) {
+	u := fmt.Sprintf("agents/%s/scan", agentID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var scan AgentScan
+	resp, err := s.client.Do(ctx, httpReq, &scan)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &scan, resp, nil
+}
+
+// GetScanResults retrieves the status and findings of a scan
+func (s *AgentsService) GetScanResults(ctx context.Context, scanID string, opts *ScanResultsOptions) (*ScanResultsResponse, *Response, error) {
+	u := fmt.Sprintf("agents/scans/%s/results", scanID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result ScanResultsResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
@@ -0,0 +1,143 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAgentsService_CreateEnrollmentToken(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/agents/enrollment-token" {
+			t.Errorf("Expected path /mpki/api/v1/agents/enrollment-token, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if body["business_unit_id"] != "bu-123" {
+			t.Errorf("business_unit_id = %v, want %v", body["business_unit_id"], "bu-123")
+		}
+		if body["max_uses"] != float64(50) {
+			t.Errorf("max_uses = %v, want %v", body["max_uses"], 50)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(AgentEnrollmentToken{
+			Token:          "agent-token-abc",
+			BusinessUnitID: "bu-123",
+			Installers: []AgentInstaller{
+				{Platform: "linux", Architecture: "amd64", URL: "https://downloads.example.com/agent-linux-amd64.tar.gz"},
+				{Platform: "windows", Architecture: "amd64", URL: "https://downloads.example.com/agent-windows.msi"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	token, _, err := client.Agents.CreateEnrollmentToken(ctx, "bu-123", &AgentEnrollmentTokenOptions{MaxUses: 50})
+	if err != nil {
+		t.Fatalf("CreateEnrollmentToken() error = %v", err)
+	}
+	if token.Token != "agent-token-abc" {
+		t.Errorf("Token = %v, want %v", token.Token, "agent-token-abc")
+	}
+
+	installer := token.InstallerFor("windows", "")
+	if installer == nil || installer.URL != "https://downloads.example.com/agent-windows.msi" {
+		t.Errorf("InstallerFor(windows) = %+v", installer)
+	}
+	if token.InstallerFor("darwin", "arm64") != nil {
+		t.Error("InstallerFor(darwin) should be nil")
+	}
+}
+
+func TestAgentsService_TriggerScan(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/agents/agent-1/scan" {
+			t.Errorf("Expected path /mpki/api/v1/agents/agent-1/scan, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		var req ScanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if len(req.Targets) != 1 || req.Targets[0].Host != "10.0.0.0/24" {
+			t.Errorf("Targets = %+v, want 10.0.0.0/24", req.Targets)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(AgentScan{ID: "scan-1", AgentID: "agent-1", Status: "queued"})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	scan, resp, err := client.Agents.TriggerScan(ctx, "agent-1", &ScanRequest{
+		Targets: []ScanTarget{{Host: "10.0.0.0/24"}},
+		Ports:   []int{443, 8443},
+	})
+	if err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("StatusCode = %v, want %v", resp.StatusCode, http.StatusAccepted)
+	}
+	if scan.ID != "scan-1" {
+		t.Errorf("ID = %v, want %v", scan.ID, "scan-1")
+	}
+}
+
+func TestAgentsService_GetScanResults(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/agents/scans/scan-1/results" {
+			t.Errorf("Expected path /mpki/api/v1/agents/scans/scan-1/results, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("offset") != "50" || r.URL.Query().Get("limit") != "50" {
+			t.Errorf("offset/limit = %v/%v, want 50/50", r.URL.Query().Get("offset"), r.URL.Query().Get("limit"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ScanResultsResponse{
+			ListResponse: ListResponse{Total: 51, Offset: 50, Limit: 50},
+			Scan:         AgentScan{ID: "scan-1", Status: "completed"},
+			Results:      []ScanFinding{{Host: "10.0.0.7", Port: 443, SerialNumber: "ABC123"}},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	opts := &ScanResultsOptions{}
+	opts.Offset = 50
+	opts.Limit = 50
+
+	result, _, err := client.Agents.GetScanResults(ctx, "scan-1", opts)
+	if err != nil {
+		t.Fatalf("GetScanResults() error = %v", err)
+	}
+	if result.Scan.Status != "completed" || len(result.Results) != 1 {
+		t.Errorf("result = %+v, want completed scan with one finding", result)
+	}
+}
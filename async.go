@@ -0,0 +1,189 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// OperationStatus is the state of an asynchronous server-side operation.
+type OperationStatus string
+
+const (
+	OperationStatusPending   OperationStatus = "pending"
+	OperationStatusRunning   OperationStatus = "running"
+	OperationStatusSucceeded OperationStatus = "succeeded"
+	OperationStatusFailed    OperationStatus = "failed"
+)
+
+// IsTerminal reports whether the operation has finished, successfully or not.
+func (s OperationStatus) IsTerminal() bool {
+	return s == OperationStatusSucceeded || s == OperationStatusFailed
+}
+
+// AsyncOperation tracks a request the server accepted with 202 Accepted and
+// is completing in the background. Call Wait to block until it finishes.
+type AsyncOperation struct {
+	ID     string          `json:"id,omitempty"`
+	Status OperationStatus `json:"status,omitempty"`
+	// Result holds the operation output once it has succeeded. Use Decode to
+	// unmarshal it into the expected type.
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *APIError       `json:"error,omitempty"`
+
+	// StatusURL is polled by Wait to refresh the operation.
+	StatusURL string `json:"-"`
+	// PollInterval overrides the interval between status checks. When zero,
+	// the server's Retry-After hint or DefaultPollInterval is used.
+	PollInterval time.Duration `json:"-"`
+
+	client     *Client
+	retryAfter time.Duration
+}
+
+// OperationError is returned by Wait when an operation finishes unsuccessfully.
+type OperationError struct {
+	OperationID string
+	Err         *APIError
+}
+
+func (e *OperationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("digicert: operation %s failed: %s", e.OperationID, e.Err.Message)
+	}
+	return fmt.Sprintf("digicert: operation %s failed", e.OperationID)
+}
+
+func (e *OperationError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// Done reports whether the operation has reached a terminal state.
+func (op *AsyncOperation) Done() bool {
+	return op.Status.IsTerminal()
+}
+
+// Refresh fetches the current state of the operation
+func (op *AsyncOperation) Refresh(ctx context.Context) (*Response, error) {
+	if op.StatusURL == "" {
+		return nil, fmt.Errorf("operation %s has no status URL", op.ID)
+	}
+
+	httpReq, err := op.client.newAbsoluteRequest(ctx, http.MethodGet, op.StatusURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest AsyncOperation
+	resp, err := op.client.Do(ctx, httpReq, &latest)
+	if err != nil {
+		return resp, err
+	}
+
+	if latest.ID != "" {
+		op.ID = latest.ID
+	}
+	op.Status = latest.Status
+	op.Result = latest.Result
+	op.Error = latest.Error
+	op.retryAfter = retryAfter(resp.Response)
+	return resp, nil
+}
+
+// Wait polls the operation until it finishes or ctx is done. It returns an
+// *OperationError if the operation failed
+func (op *AsyncOperation) Wait(ctx context.Context) error {
+	interval := op.PollInterval
+	if interval <= 0 {
+		interval = op.retryAfter
+	}
+
+	err := poll(ctx, &PollOptions{Interval: interval}, func(ctx context.Context) (bool, error) {
+		if op.Done() {
+			return true, nil
+		}
+		if _, err := op.Refresh(ctx); err != nil {
+			return false, err
+		}
+		return op.Done(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if op.Status == OperationStatusFailed {
+		return &OperationError{OperationID: op.ID, Err: op.Error}
+	}
+	return nil
+}
+
+// Decode unmarshals the result of a succeeded operation into v
+func (op *AsyncOperation) Decode(v interface{}) error {
+	if op.Status != OperationStatusSucceeded {
+		return fmt.Errorf("operation %s has not succeeded (status %q)", op.ID, op.Status)
+	}
+	if len(op.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(op.Result, v)
+}
+
+// newAsyncOperation builds an operation from a 202 Accepted response, or
+// returns nil if the response does not point at a status resource.
+func (c *Client) newAsyncOperation(resp *http.Response, data []byte) *AsyncOperation {
+	loc := operationLocation(resp)
+	if loc == "" {
+		return nil
+	}
+
+	op := &AsyncOperation{}
+	if len(data) > 0 {
+		// The body is optional and may describe the accepted resource rather
+		// than the operation, so decoding failures are ignored.
+		_ = json.Unmarshal(data, op)
+	}
+	if !op.Status.IsTerminal() {
+		op.Status = OperationStatusPending
+	}
+	op.StatusURL = loc
+	op.client = c
+	op.retryAfter = retryAfter(resp)
+	return op
+}
+
+// operationLocation returns the absolute status URL advertised by resp.
+func operationLocation(resp *http.Response) string {
+	loc := resp.Header.Get("Operation-Location")
+	if loc == "" {
+		loc = resp.Header.Get("Location")
+	}
+	if loc == "" {
+		return ""
+	}
+	if resp.Request == nil || resp.Request.URL == nil {
+		return loc
+	}
+	u, err := resp.Request.URL.Parse(loc)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
+
+// retryAfter returns the delay requested by a Retry-After header in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
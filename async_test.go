@@ -0,0 +1,164 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncOperation_Issue(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate":
+			w.Header().Set("Location", "/mpki/api/v1/operations/op-1")
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]string{"id": "op-1", "status": "pending"})
+		case "/mpki/api/v1/operations/op-1":
+			if atomic.AddInt32(&polls, 1) < 2 {
+				json.NewEncoder(w).Encode(map[string]string{"id": "op-1", "status": "running"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":     "op-1",
+				"status": "succeeded",
+				"result": CertificateResponse{Certificate: &Certificate{SerialNumber: "0A"}},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	cert, resp, err := client.Certificates.Issue(ctx, &CertificateRequest{Profile: ProfileReference{ID: "profile-1"}})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("StatusCode = %v, want %v", resp.StatusCode, http.StatusAccepted)
+	}
+	op := cert.Operation
+	if op == nil {
+		t.Fatal("Operation = nil, want pending operation")
+	}
+	if op.Status != OperationStatusPending || op.StatusURL != server.URL+"/mpki/api/v1/operations/op-1" {
+		t.Errorf("Operation = %+v, want pending with absolute status URL", op)
+	}
+
+	op.PollInterval = time.Millisecond
+	if err := op.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	var issued CertificateResponse
+	if err := op.Decode(&issued); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if issued.Certificate == nil || issued.Certificate.SerialNumber != "0A" {
+		t.Errorf("Decode() = %+v, want serial 0A", issued)
+	}
+}
+
+func TestAsyncOperation_Failed(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.Header().Set("Operation-Location", "operations/op-2")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":     "op-2",
+			"status": "failed",
+			"error":  map[string]string{"code": "already_revoked", "message": "Certificate already revoked"},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	resp, err := client.Certificates.Revoke(ctx, "0A", &RevokeRequest{Reason: "superseded"})
+	if err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if resp.Operation == nil {
+		t.Fatal("Operation = nil, want pending operation")
+	}
+
+	resp.Operation.PollInterval = time.Millisecond
+	err = resp.Operation.Wait(ctx)
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("Wait() error = %v, want *OperationError", err)
+	}
+	if opErr.Err == nil || opErr.Err.Code != "already_revoked" {
+		t.Errorf("OperationError = %+v, want already_revoked", opErr)
+	}
+	if err := resp.Operation.Decode(&struct{}{}); err == nil {
+		t.Error("Expected Decode error for failed operation")
+	}
+}
+
+func TestCertificatesService_BulkImport(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	t.Run("synchronous", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/certificate/import" {
+				t.Errorf("Expected path /mpki/api/v1/certificate/import, got %s", r.URL.Path)
+			}
+			var req CertificateImportRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Certificates) != 2 {
+				t.Errorf("Certificates = %v, want 2", len(req.Certificates))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CertificateImportResponse{Imported: 2})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		result, _, err := client.Certificates.BulkImport(ctx, &CertificateImportRequest{
+			Certificates: []CertificateImportItem{{Certificate: "pem-1"}, {Certificate: "pem-2"}},
+		})
+		if err != nil {
+			t.Fatalf("BulkImport() error = %v", err)
+		}
+		if result.Imported != 2 || result.Operation != nil {
+			t.Errorf("result = %+v, want 2 imported synchronously", result)
+		}
+	})
+
+	t.Run("202 without status URL", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		result, _, err := client.Certificates.BulkImport(ctx, &CertificateImportRequest{})
+		if err != nil {
+			t.Fatalf("BulkImport() error = %v", err)
+		}
+		if result.Operation != nil {
+			t.Errorf("Operation = %+v, want nil", result.Operation)
+		}
+	})
+}
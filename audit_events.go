@@ -0,0 +1,148 @@
+package digicert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuditEventType identifies what an audit event records, in the form
+// "<category>.<action>".
+type AuditEventType string
+
+const (
+	AuditEventCertificateIssued   AuditEventType = "certificate.issued"
+	AuditEventCertificateRenewed  AuditEventType = "certificate.renewed"
+	AuditEventCertificateRevoked  AuditEventType = "certificate.revoked"
+	AuditEventBusinessUnitUpdated AuditEventType = "bu.updated"
+	AuditEventAdminLogin          AuditEventType = "admin.login"
+)
+
+// Category returns the category part of the event type
+func (t AuditEventType) Category() AuditCategory {
+	category, _, _ := strings.Cut(string(t), ".")
+	return AuditCategory(category)
+}
+
+// AuditCategory groups audit event types by the kind of resource they
+// concern.
+type AuditCategory string
+
+const (
+	AuditCategoryCertificate  AuditCategory = "certificate"
+	AuditCategoryBusinessUnit AuditCategory = "bu"
+	AuditCategoryAdmin        AuditCategory = "admin"
+)
+
+// AuditSeverity is the severity TLM assigns an audit event.
+type AuditSeverity string
+
+const (
+	AuditSeverityInfo     AuditSeverity = "info"
+	AuditSeverityWarning  AuditSeverity = "warning"
+	AuditSeverityCritical AuditSeverity = "critical"
+)
+
+var auditSeverityRank = map[AuditSeverity]int{
+	AuditSeverityInfo:     1,
+	AuditSeverityWarning:  2,
+	AuditSeverityCritical: 3,
+}
+
+// AtLeast reports whether s is as severe as min. Unknown severities rank
+// below info.
+func (s AuditSeverity) AtLeast(min AuditSeverity) bool {
+	return auditSeverityRank[s] >= auditSeverityRank[min]
+}
+
+// CertificateIssuedDetails is the Details payload of certificate.issued
+// events.
+type CertificateIssuedDetails struct {
+	SerialNumber   string     `json:"serial_number,omitempty"`
+	CommonName     string     `json:"common_name,omitempty"`
+	ProfileID      string     `json:"profile_id,omitempty"`
+	BusinessUnitID string     `json:"business_unit_id,omitempty"`
+	ValidTo        *time.Time `json:"valid_to,omitempty"`
+}
+
+// CertificateRenewedDetails is the Details payload of certificate.renewed
+// events.
+type CertificateRenewedDetails struct {
+	SerialNumber         string `json:"serial_number,omitempty"`
+	PreviousSerialNumber string `json:"previous_serial_number,omitempty"`
+}
+
+// CertificateRevokedDetails is the Details payload of certificate.revoked
+// events.
+type CertificateRevokedDetails struct {
+	SerialNumber string `json:"serial_number,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// AuditFieldChange records one field changed by an update.
+type AuditFieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// BusinessUnitUpdatedDetails is the Details payload of bu.updated events.
+type BusinessUnitUpdatedDetails struct {
+	BusinessUnitID string             `json:"business_unit_id,omitempty"`
+	Name           string             `json:"name,omitempty"`
+	Changes        []AuditFieldChange `json:"changes,omitempty"`
+}
+
+// AdminLoginDetails is the Details payload of admin.login events.
+type AdminLoginDetails struct {
+	// Method is how the administrator authenticated, such as "password",
+	// "sso" or "api_key".
+	Method        string `json:"method,omitempty"`
+	MFA           bool   `json:"mfa,omitempty"`
+	Success       bool   `json:"success"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	UserAgent     string `json:"user_agent,omitempty"`
+}
+
+// Category returns the category of the event type
+func (e AuditEvent) Category() AuditCategory {
+	return e.EventType.Category()
+}
+
+// DecodeDetails unmarshals the Details payload into v
+func (e AuditEvent) DecodeDetails(v interface{}) error {
+	if len(e.Details) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(e.Details)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ParsedDetails returns the Details payload decoded into the type for the
+// event type, such as *CertificateIssuedDetails for certificate.issued. For
+// event types without a typed payload it returns Details unchanged.
+func (e AuditEvent) ParsedDetails() (interface{}, error) {
+	var v interface{}
+	switch e.EventType {
+	case AuditEventCertificateIssued:
+		v = &CertificateIssuedDetails{}
+	case AuditEventCertificateRenewed:
+		v = &CertificateRenewedDetails{}
+	case AuditEventCertificateRevoked:
+		v = &CertificateRevokedDetails{}
+	case AuditEventBusinessUnitUpdated:
+		v = &BusinessUnitUpdatedDetails{}
+	case AuditEventAdminLogin:
+		v = &AdminLoginDetails{}
+	default:
+		return e.Details, nil
+	}
+	if err := e.DecodeDetails(v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s details: %w", e.EventType, err)
+	}
+	return v, nil
+}
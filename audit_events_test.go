@@ -0,0 +1,109 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuditEvent_ParsedDetails(t *testing.T) {
+	data := `{
+		"events": [
+			{"id": "evt-1", "event_type": "certificate.issued", "severity": "info",
+			 "details": {"serial_number": "0A1B", "common_name": "www.example.com", "valid_to": "2025-01-01T00:00:00Z"}},
+			{"id": "evt-2", "event_type": "bu.updated", "severity": "warning",
+			 "details": {"business_unit_id": "bu-1", "changes": [{"field": "name", "old": "Ops", "new": "Platform"}]}},
+			{"id": "evt-3", "event_type": "admin.login", "severity": "critical",
+			 "details": {"method": "sso", "success": false, "failure_reason": "mfa_failed"}},
+			{"id": "evt-4", "event_type": "agent.registered", "details": {"agent_id": "a-1"}}
+		]
+	}`
+
+	var page AuditLogSearchResponse
+	if err := json.Unmarshal([]byte(data), &page); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	issued, err := page.Events[0].ParsedDetails()
+	if err != nil {
+		t.Fatalf("ParsedDetails() error = %v", err)
+	}
+	cert, ok := issued.(*CertificateIssuedDetails)
+	if !ok {
+		t.Fatalf("ParsedDetails() = %T, want *CertificateIssuedDetails", issued)
+	}
+	if cert.SerialNumber != "0A1B" || cert.ValidTo == nil || cert.ValidTo.Year() != 2025 {
+		t.Errorf("details = %+v, want serial 0A1B valid to 2025", cert)
+	}
+	if got := page.Events[0].Category(); got != AuditCategoryCertificate {
+		t.Errorf("Category() = %v, want %v", got, AuditCategoryCertificate)
+	}
+
+	updated, _ := page.Events[1].ParsedDetails()
+	bu, ok := updated.(*BusinessUnitUpdatedDetails)
+	if !ok || len(bu.Changes) != 1 || bu.Changes[0].New != "Platform" {
+		t.Errorf("ParsedDetails() = %+v, want one name change", updated)
+	}
+	if got := page.Events[1].Category(); got != AuditCategoryBusinessUnit {
+		t.Errorf("Category() = %v, want %v", got, AuditCategoryBusinessUnit)
+	}
+
+	login, _ := page.Events[2].ParsedDetails()
+	admin, ok := login.(*AdminLoginDetails)
+	if !ok || admin.Success || admin.FailureReason != "mfa_failed" {
+		t.Errorf("ParsedDetails() = %+v, want failed login", login)
+	}
+
+	other, err := page.Events[3].ParsedDetails()
+	if err != nil {
+		t.Fatalf("ParsedDetails() error = %v", err)
+	}
+	if m, ok := other.(map[string]interface{}); !ok || m["agent_id"] != "a-1" {
+		t.Errorf("ParsedDetails() = %#v, want raw details", other)
+	}
+}
+
+func TestAuditSeverity_AtLeast(t *testing.T) {
+	tests := []struct {
+		severity AuditSeverity
+		min      AuditSeverity
+		want     bool
+	}{
+		{AuditSeverityCritical, AuditSeverityWarning, true},
+		{AuditSeverityWarning, AuditSeverityWarning, true},
+		{AuditSeverityInfo, AuditSeverityWarning, false},
+		{"", AuditSeverityInfo, false},
+		{"debug", "", true},
+	}
+	for _, tt := range tests {
+		if got := tt.severity.AtLeast(tt.min); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.severity, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestAuditLogService_SearchByCategory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("category") != "admin" {
+			t.Errorf("category = %v, want %v", q.Get("category"), "admin")
+		}
+		if q.Get("min_severity") != "warning" {
+			t.Errorf("min_severity = %v, want %v", q.Get("min_severity"), "warning")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AuditLogSearchResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	_, _, err := client.AuditLog.Search(context.Background(), &AuditLogSearchOptions{
+		Category:    AuditCategoryAdmin,
+		MinSeverity: AuditSeverityWarning,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+}
@@ -1,8 +1,252 @@
 package digicert
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
 type AuditLogService struct {
 	client *Client
 }
 
-// AuditLog service handles audit log retrieval
-// This is a placeholder implementation
\ No newline at end of file
+// AuditLog service handles audit log retrieval and streaming
+
+const DefaultAuditStreamInterval = 30 * time.Second
+
+type AuditEvent struct {
+	ID           string                 `json:"id"`
+	Timestamp    *time.Time             `json:"timestamp,omitempty"`
+	EventType    AuditEventType         `json:"event_type,omitempty"`
+	Action       string                 `json:"action,omitempty"`
+	Severity     AuditSeverity          `json:"severity,omitempty"`
+	ResourceType string                 `json:"resource_type,omitempty"`
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	ActorID      string                 `json:"actor_id,omitempty"`
+	ActorEmail   string                 `json:"actor_email,omitempty"`
+	IPAddress    string                 `json:"ip_address,omitempty"`
+	Message      string                 `json:"message,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+}
+
+type AuditLogSearchOptions struct {
+	PaginationParams
+	// Cursor resumes a previous search after the last event it returned.
+	Cursor    string         `url:"cursor,omitempty"`
+	From      time.Time      `url:"from,omitempty"`
+	To        time.Time      `url:"to,omitempty"`
+	EventType AuditEventType `url:"event_type,omitempty"`
+	// Category restricts results to event types of one category.
+	Category AuditCategory `url:"category,omitempty"`
+	// MinSeverity restricts results to events at least this severe.
+	MinSeverity  AuditSeverity `url:"min_severity,omitempty"`
+	ActorID      string        `url:"actor_id,omitempty"`
+	ResourceType string        `url:"resource_type,omitempty"`
+	ResourceID   string        `url:"resource_id,omitempty"`
+}
+
+type AuditLogSearchResponse struct {
+	ListResponse
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	HasMore    bool         `json:"has_more,omitempty"`
+}
+
+// Search searches the audit log
+func (s *AuditLogService) Search(ctx context.Context, opts *AuditLogSearchOptions) (*AuditLogSearchResponse, *Response, error) {
+	u := "audit-log"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Cursor != "" {
+			q.Add("cursor", opts.Cursor)
+		}
+		if !opts.From.IsZero() {
+			q.Add("from", opts.From.UTC().Format(time.RFC3339))
+		}
+		if !opts.To.IsZero() {
+			q.Add("to", opts.To.UTC().Format(time.RFC3339))
+		}
+		if opts.EventType != "" {
+			q.Add("event_type", string(opts.EventType))
+		}
+		if opts.Category != "" {
+			q.Add("category", string(opts.Category))
+		}
+		if opts.MinSeverity != "" {
+			q.Add("min_severity", string(opts.MinSeverity))
+		}
+		if opts.ActorID != "" {
+			q.Add("actor_id", opts.ActorID)
+		}
+		if opts.ResourceType != "" {
+			q.Add("resource_type", opts.ResourceType)
+		}
+		if opts.ResourceID != "" {
+			q.Add("resource_id", opts.ResourceID)
+		}
+		if opts.Offset > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+		if opts.Limit > 0 {
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result AuditLogSearchResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AuditEventHandler processes a streamed audit event. Returning an error stops
+// the stream without advancing the checkpoint, so the event is delivered again
+// when the stream is resumed.
+type AuditEventHandler func(ctx context.Context, event AuditEvent) error
+
+// Checkpointer persists the audit log cursor so a stream can resume where it
+// stopped after a restart.
+type Checkpointer interface {
+	// Load returns the saved cursor, or an empty string if none has been saved.
+	Load(ctx context.Context) (string, error)
+	Save(ctx context.Context, cursor string) error
+}
+
+// FileCheckpointer stores the cursor in a file at the given path.
+type FileCheckpointer string
+
+func (f FileCheckpointer) Load(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(data)), err
+}
+
+func (f FileCheckpointer) Save(ctx context.Context, cursor string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(string(f)), filepath.Base(string(f))+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(cursor); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
+
+type AuditStreamOptions struct {
+	// Interval between polls once the stream has caught up. Defaults to
+	// DefaultAuditStreamInterval.
+	Interval time.Duration
+	// PageSize is the number of events requested per poll.
+	PageSize int
+	// Checkpointer, if set, supplies the starting cursor when sinceCursor is
+	// empty and is updated after every page the handler fully processes.
+	Checkpointer Checkpointer
+	// Filter restricts the events streamed. Cursor and pagination fields are
+	// managed by the stream and ignored.
+	Filter *AuditLogSearchOptions
+}
+
+// Stream tails the audit log from sinceCursor, calling handler for each event
+// in order. The cursor is checkpointed only after the handler has returned
+// successfully for every event in a page, giving at-least-once delivery. Stream
+// runs until ctx is cancelled, the handler or a checkpoint fails, or a search
+// fails with a non-retryable error such as a 401; transient failures are
+// retried on the next poll.
+func (s *AuditLogService) Stream(ctx context.Context, sinceCursor string, handler AuditEventHandler, opts *AuditStreamOptions) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+	if opts == nil {
+		opts = &AuditStreamOptions{}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultAuditStreamInterval
+	}
+
+	cursor := sinceCursor
+	if cursor == "" && opts.Checkpointer != nil {
+		saved, err := opts.Checkpointer.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load audit log checkpoint: %w", err)
+		}
+		cursor = saved
+	}
+
+	search := &AuditLogSearchOptions{}
+	if opts.Filter != nil {
+		*search = *opts.Filter
+	}
+	search.Offset = 0
+	search.Limit = opts.PageSize
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		search.Cursor = cursor
+		page, _, err := s.Search(ctx, search)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !RetryableError(err) {
+				return fmt.Errorf("failed to search audit log: %w", err)
+			}
+			// Transient API failures are retried on the next tick.
+			timer.Reset(interval)
+			continue
+		}
+
+		for _, event := range page.Events {
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor != "" && page.NextCursor != cursor {
+			cursor = page.NextCursor
+			if opts.Checkpointer != nil {
+				if err := opts.Checkpointer.Save(ctx, cursor); err != nil {
+					return fmt.Errorf("failed to save audit log checkpoint: %w", err)
+				}
+			}
+		}
+
+		if page.HasMore && len(page.Events) > 0 {
+			timer.Reset(0)
+		} else {
+			timer.Reset(interval)
+		}
+	}
+}
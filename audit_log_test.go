@@ -0,0 +1,215 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAuditLogService_Search(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/audit-log" {
+			t.Errorf("Expected path /mpki/api/v1/audit-log, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("cursor") != "cur-1" {
+			t.Errorf("cursor = %v, want %v", q.Get("cursor"), "cur-1")
+		}
+		if q.Get("from") != "2024-01-01T00:00:00Z" {
+			t.Errorf("from = %v, want %v", q.Get("from"), "2024-01-01T00:00:00Z")
+		}
+		if q.Get("event_type") != "certificate.issued" {
+			t.Errorf("event_type = %v, want %v", q.Get("event_type"), "certificate.issued")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AuditLogSearchResponse{
+			Events:     []AuditEvent{{ID: "evt-1", EventType: "certificate.issued"}},
+			NextCursor: "cur-2",
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	result, _, err := client.AuditLog.Search(ctx, &AuditLogSearchOptions{
+		Cursor:    "cur-1",
+		From:      from,
+		EventType: "certificate.issued",
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result.Events) != 1 || result.NextCursor != "cur-2" {
+		t.Errorf("result = %+v, want one event and cursor cur-2", result)
+	}
+}
+
+type memoryCheckpointer struct {
+	mu     sync.Mutex
+	cursor string
+	saves  []string
+}
+
+func (m *memoryCheckpointer) Load(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursor, nil
+}
+
+func (m *memoryCheckpointer) Save(ctx context.Context, cursor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursor = cursor
+	m.saves = append(m.saves, cursor)
+	return nil
+}
+
+func TestAuditLogService_Stream(t *testing.T) {
+	ctx := context.Background()
+
+	// pages maps the requested cursor to the page the server returns for it.
+	pages := map[string]AuditLogSearchResponse{
+		"": {
+			Events:     []AuditEvent{{ID: "evt-1"}, {ID: "evt-2"}},
+			NextCursor: "cur-2",
+			HasMore:    true,
+		},
+		"cur-2": {
+			Events:     []AuditEvent{{ID: "evt-3"}},
+			NextCursor: "cur-3",
+		},
+		"cur-3": {NextCursor: "cur-3"},
+	}
+
+	newServer := func(t *testing.T) (*Client, *httptest.Server) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(pages[r.URL.Query().Get("cursor")])
+		}))
+		client, _ := NewClient("test-key")
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+		return client, server
+	}
+
+	t.Run("delivers events and checkpoints", func(t *testing.T) {
+		client, server := newServer(t)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		cp := &memoryCheckpointer{}
+		var seen []string
+		err := client.AuditLog.Stream(ctx, "", func(ctx context.Context, e AuditEvent) error {
+			seen = append(seen, e.ID)
+			if e.ID == "evt-3" {
+				cancel()
+			}
+			return nil
+		}, &AuditStreamOptions{Interval: time.Millisecond, Checkpointer: cp})
+
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Stream() error = %v, want %v", err, context.Canceled)
+		}
+		if len(seen) != 3 {
+			t.Errorf("seen = %v, want 3 events", seen)
+		}
+		if cp.cursor != "cur-3" {
+			t.Errorf("checkpoint = %v, want %v", cp.cursor, "cur-3")
+		}
+	})
+
+	t.Run("handler error does not advance checkpoint", func(t *testing.T) {
+		client, server := newServer(t)
+		defer server.Close()
+
+		cp := &memoryCheckpointer{cursor: "cur-2"}
+		handlerErr := errors.New("downstream unavailable")
+		err := client.AuditLog.Stream(ctx, "", func(ctx context.Context, e AuditEvent) error {
+			return handlerErr
+		}, &AuditStreamOptions{Interval: time.Millisecond, Checkpointer: cp})
+
+		if !errors.Is(err, handlerErr) {
+			t.Fatalf("Stream() error = %v, want %v", err, handlerErr)
+		}
+		if len(cp.saves) != 0 || cp.cursor != "cur-2" {
+			t.Errorf("checkpoint = %v (saves %v), want cur-2 unchanged", cp.cursor, cp.saves)
+		}
+	})
+
+	t.Run("sends page size on first poll", func(t *testing.T) {
+		var limit string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit = r.URL.Query().Get("limit")
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(pages[""])
+		}))
+		defer server.Close()
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+
+		stop := errors.New("stop")
+		err := client.AuditLog.Stream(ctx, "", func(ctx context.Context, e AuditEvent) error {
+			return stop
+		}, &AuditStreamOptions{Interval: time.Millisecond, PageSize: 50})
+
+		if !errors.Is(err, stop) {
+			t.Fatalf("Stream() error = %v, want %v", err, stop)
+		}
+		if limit != "50" {
+			t.Errorf("limit = %q, want %q", limit, "50")
+		}
+	})
+
+	t.Run("non-retryable error stops stream", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+
+		err := client.AuditLog.Stream(ctx, "", func(ctx context.Context, e AuditEvent) error {
+			return nil
+		}, &AuditStreamOptions{Interval: time.Millisecond})
+
+		if !IsUnauthorized(err) {
+			t.Fatalf("Stream() error = %v, want 401", err)
+		}
+	})
+
+	t.Run("nil handler", func(t *testing.T) {
+		client, _ := NewClient("test-key")
+		if err := client.AuditLog.Stream(ctx, "", nil, nil); err == nil {
+			t.Error("Expected error for nil handler")
+		}
+	})
+}
+
+func TestFileCheckpointer(t *testing.T) {
+	ctx := context.Background()
+	cp := FileCheckpointer(filepath.Join(t.TempDir(), "audit.cursor"))
+
+	cursor, err := cp.Load(ctx)
+	if err != nil || cursor != "" {
+		t.Fatalf("Load() = %q, %v, want empty cursor", cursor, err)
+	}
+
+	if err := cp.Save(ctx, "cur-42"); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cursor, err = cp.Load(ctx)
+	if err != nil || cursor != "cur-42" {
+		t.Errorf("Load() = %q, %v, want %q", cursor, err, "cur-42")
+	}
+}
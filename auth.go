@@ -0,0 +1,54 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+)
+
+// APIKeyProvider resolves the API key to use for a request, typically by
+// reading it from a secrets manager or a cache that is refreshed on rotation.
+type APIKeyProvider func(ctx context.Context) (string, error)
+
+// WithAPIKeyProvider resolves the API key on every request instead of using
+// the key passed to NewClient, which may then be left empty
+func WithAPIKeyProvider(provider APIKeyProvider) ClientOption {
+	return func(c *Client) error {
+		if provider == nil {
+			return fmt.Errorf("API key provider cannot be nil")
+		}
+		c.apiKeyProvider = provider
+		return nil
+	}
+}
+
+// SetAPIKey replaces the API key used by subsequent requests. It is safe to
+// call while requests are in flight. A key provider configured with
+// WithAPIKeyProvider takes precedence over the key set here.
+func (c *Client) SetAPIKey(apiKey string) error {
+	if apiKey == "" {
+		return fmt.Errorf("API key is required")
+	}
+	c.mu.Lock()
+	c.apiKey = apiKey
+	c.capabilities = nil
+	c.mu.Unlock()
+	return nil
+}
+
+// resolveAPIKey returns the API key for a request made with ctx.
+func (c *Client) resolveAPIKey(ctx context.Context) (string, error) {
+	if c.apiKeyProvider != nil {
+		key, err := c.apiKeyProvider(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve API key: %w", err)
+		}
+		if key == "" {
+			return "", fmt.Errorf("API key provider returned an empty key")
+		}
+		return key, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiKey, nil
+}
@@ -0,0 +1,75 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithAPIKeyProvider(t *testing.T) {
+	ctx := context.Background()
+
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("X-API-Key"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("resolved per request", func(t *testing.T) {
+		seen = nil
+		var n int32
+		client, err := NewClient("", WithBaseURL(server.URL+"/"), WithAPIKeyProvider(func(ctx context.Context) (string, error) {
+			if atomic.AddInt32(&n, 1) == 1 {
+				return "key-1", nil
+			}
+			return "key-2", nil
+		}))
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+
+		client.Certificates.Revoke(ctx, "01", &RevokeRequest{})
+		client.Certificates.Revoke(ctx, "02", &RevokeRequest{})
+		if len(seen) != 2 || seen[0] != "key-1" || seen[1] != "key-2" {
+			t.Errorf("keys = %v, want [key-1 key-2]", seen)
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		providerErr := errors.New("vault sealed")
+		client, _ := NewClient("", WithAPIKeyProvider(func(ctx context.Context) (string, error) {
+			return "", providerErr
+		}))
+		_, err := client.NewRequest(ctx, http.MethodGet, "profiles", nil)
+		if !errors.Is(err, providerErr) {
+			t.Errorf("NewRequest() error = %v, want %v", err, providerErr)
+		}
+	})
+
+	t.Run("nil provider", func(t *testing.T) {
+		if _, err := NewClient("", WithAPIKeyProvider(nil)); err == nil {
+			t.Error("Expected error for nil provider")
+		}
+	})
+}
+
+func TestClient_SetAPIKey(t *testing.T) {
+	ctx := context.Background()
+	client, _ := NewClient("old-key")
+
+	if err := client.SetAPIKey(""); err == nil {
+		t.Error("Expected error for empty key")
+	}
+	if err := client.SetAPIKey("new-key"); err != nil {
+		t.Fatalf("SetAPIKey() error = %v", err)
+	}
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "profiles", nil)
+	if got := req.Header.Get("X-API-Key"); got != "new-key" {
+		t.Errorf("X-API-Key = %v, want %v", got, "new-key")
+	}
+}
@@ -0,0 +1,40 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AutoRenewRequest turns automatic renewal of a certificate on or off
+type AutoRenewRequest struct {
+	Enabled bool   `json:"enabled"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// SetAutoRenew turns automatic renewal of a certificate on or off,
+// overriding its profile's setting. Pausing it freezes a certificate under
+// investigation without revoking it; the certificate's AutoRenew field
+// reports the current setting.
+func (s *CertificatesService) SetAutoRenew(ctx context.Context, serialNumber string, enabled bool) (*Response, error) {
+	return s.SetAutoRenewWithRequest(ctx, serialNumber, &AutoRenewRequest{Enabled: enabled})
+}
+
+// SetAutoRenewWithRequest is SetAutoRenew with a comment recorded in the
+// audit log.
+func (s *CertificatesService) SetAutoRenewWithRequest(ctx context.Context, serialNumber string, req *AutoRenewRequest) (*Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("auto-renew request cannot be nil")
+	}
+	if err := s.client.requireCapability("SetAutoRenew", CapabilityIssueCertificates); err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("certificate/%s/auto-renew", serialNumber)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPut, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, httpReq, nil)
+}
@@ -0,0 +1,50 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCertificatesService_SetAutoRenew(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/mpki/api/v1/certificate/0A/auto-renew" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(data)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	if _, err := client.Certificates.SetAutoRenew(ctx, "0A", false); err != nil {
+		t.Fatalf("SetAutoRenew() error = %v", err)
+	}
+	if _, err := client.Certificates.SetAutoRenewWithRequest(ctx, "0A", &AutoRenewRequest{Enabled: true, Comment: "INC-42 closed"}); err != nil {
+		t.Fatalf("SetAutoRenewWithRequest() error = %v", err)
+	}
+	if _, err := client.Certificates.SetAutoRenewWithRequest(ctx, "0A", nil); err == nil {
+		t.Error("SetAutoRenewWithRequest(nil) expected error")
+	}
+
+	want := []string{`{"enabled":false}`, `{"enabled":true,"comment":"INC-42 closed"}`}
+	if len(bodies) != len(want) || bodies[0] != want[0] || bodies[1] != want[1] {
+		t.Errorf("bodies = %q, want %q", bodies, want)
+	}
+
+	var cert Certificate
+	if err := json.Unmarshal([]byte(`{"serial_number":"0A","auto_renew":false}`), &cert); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if cert.AutoRenew == nil || *cert.AutoRenew {
+		t.Errorf("AutoRenew = %v, want paused", cert.AutoRenew)
+	}
+}
@@ -0,0 +1,361 @@
+// Package autocert provides a crypto/tls GetCertificate implementation backed by
+// DigiCert Trust Lifecycle Manager issuance.
+//
+// Certificates are issued on first use for each permitted host name, cached in
+// memory and optionally on disk, and renewed in the background as they approach
+// expiry:
+//
+//	m := &autocert.Manager{
+//		Client:     client,
+//		ProfileID:  "profile-id",
+//		HostPolicy: autocert.HostWhitelist("www.example.com"),
+//		Cache:      autocert.DirCache("/var/cache/tlm"),
+//	}
+//	server := &http.Server{Addr: ":443", TLSConfig: m.TLSConfig()}
+package autocert
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+const (
+	DefaultRenewBefore = 30 * 24 * time.Hour
+	issueTimeout       = 2 * time.Minute
+)
+
+// ErrCacheMiss is returned by a Cache when no entry exists for a key.
+var ErrCacheMiss = errors.New("autocert: certificate cache miss")
+
+// HostPolicy decides whether a certificate may be requested for host.
+type HostPolicy func(ctx context.Context, host string) error
+
+// HostWhitelist returns a policy permitting only the given host names.
+func HostWhitelist(hosts ...string) HostPolicy {
+	allowed := make(map[string]bool, len(hosts))
+	for _, h := range hosts {
+		allowed[strings.ToLower(strings.TrimSuffix(h, "."))] = true
+	}
+	return func(_ context.Context, host string) error {
+		if !allowed[host] {
+			return fmt.Errorf("autocert: host %q not configured in HostWhitelist", host)
+		}
+		return nil
+	}
+}
+
+// Manager obtains and renews certificates for incoming TLS handshakes.
+type Manager struct {
+	Client     *digicert.Client
+	ProfileID  string
+	SeatID     string
+	HostPolicy HostPolicy
+	Cache      Cache
+
+	// Sink, if set, receives every newly issued certificate, e.g. to share it
+	// with other processes.
+	Sink digicert.CertificateSink
+
+	// RenewBefore is how long before expiry a certificate is renewed.
+	// Defaults to DefaultRenewBefore.
+	RenewBefore time.Duration
+
+	// Key describes the private keys generated for new certificates.
+	// Defaults to ECDSA P-256.
+	Key digicert.KeyRequest
+	// KeyPolicy, if set, is enforced when generating keys.
+	KeyPolicy *digicert.KeyPolicy
+
+	mu       sync.Mutex
+	certs    map[string]*tls.Certificate
+	inflight map[string]*issuance
+	renewing map[string]bool
+}
+
+type issuance struct {
+	done chan struct{}
+	cert *tls.Certificate
+	err  error
+}
+
+// TLSConfig returns a tls.Config using the manager's GetCertificate.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: m.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
+
+// GetCertificate implements the tls.Config.GetCertificate hook.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	host := strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))
+	if host == "" {
+		return nil, errors.New("autocert: missing server name")
+	}
+	if strings.ContainsAny(host, `+/\`) {
+		return nil, fmt.Errorf("autocert: server name contains invalid character")
+	}
+
+	ctx := hello.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if cert, ok := m.cached(ctx, host); ok {
+		if m.needsRenewal(cert) {
+			m.renewInBackground(host)
+		}
+		return cert, nil
+	}
+
+	if m.HostPolicy != nil {
+		if err := m.HostPolicy(ctx, host); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.obtain(ctx, host)
+}
+
+func (m *Manager) cached(ctx context.Context, host string) (*tls.Certificate, bool) {
+	m.mu.Lock()
+	cert, ok := m.certs[host]
+	m.mu.Unlock()
+	if ok && time.Now().Before(cert.Leaf.NotAfter) {
+		return cert, true
+	}
+
+	if m.Cache == nil {
+		return nil, false
+	}
+	data, err := m.Cache.Get(ctx, host)
+	if err != nil {
+		return nil, false
+	}
+	cert, err = parseBundle(data)
+	if err != nil || time.Now().After(cert.Leaf.NotAfter) {
+		return nil, false
+	}
+
+	m.store(host, cert)
+	return cert, true
+}
+
+func (m *Manager) store(host string, cert *tls.Certificate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.certs == nil {
+		m.certs = make(map[string]*tls.Certificate)
+	}
+	m.certs[host] = cert
+}
+
+func (m *Manager) needsRenewal(cert *tls.Certificate) bool {
+	renewBefore := m.RenewBefore
+	if renewBefore <= 0 {
+		renewBefore = DefaultRenewBefore
+	}
+	return time.Until(cert.Leaf.NotAfter) < renewBefore
+}
+
+func (m *Manager) renewInBackground(host string) {
+	m.mu.Lock()
+	if m.renewing == nil {
+		m.renewing = make(map[string]bool)
+	}
+	if m.renewing[host] {
+		m.mu.Unlock()
+		return
+	}
+	m.renewing[host] = true
+	m.mu.Unlock()
+
+	go func() {
+		defer func() {
+			m.mu.Lock()
+			delete(m.renewing, host)
+			m.mu.Unlock()
+		}()
+		// Failures are retried on the next handshake. The current certificate
+		// is served until it expires, after which handshakes wait for a new
+		// one to be issued.
+		m.obtain(context.Background(), host)
+	}()
+}
+
+// obtain issues a certificate for host, collapsing concurrent requests for the
+// same host into a single issuance.
+func (m *Manager) obtain(ctx context.Context, host string) (*tls.Certificate, error) {
+	m.mu.Lock()
+	if m.inflight == nil {
+		m.inflight = make(map[string]*issuance)
+	}
+	if call, ok := m.inflight[host]; ok {
+		m.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.cert, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &issuance{done: make(chan struct{})}
+	m.inflight[host] = call
+	m.mu.Unlock()
+
+	issueCtx, cancel := context.WithTimeout(context.Background(), issueTimeout)
+	call.cert, call.err = m.issue(issueCtx, host)
+	cancel()
+
+	m.mu.Lock()
+	delete(m.inflight, host)
+	m.mu.Unlock()
+	close(call.done)
+
+	return call.cert, call.err
+}
+
+func (m *Manager) issue(ctx context.Context, host string) (*tls.Certificate, error) {
+	keyReq := m.Key
+	if keyReq.Type == "" {
+		keyReq.Type = digicert.KeyTypeECDSA
+	}
+	key, err := digicert.GenerateKey(keyReq, m.KeyPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: host},
+		DNSNames: []string{host},
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &digicert.CertificateRequest{
+		Profile:        digicert.ProfileReference{ID: m.ProfileID},
+		CSR:            string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})),
+		IncludeCAChain: true,
+		Attributes: &digicert.CertificateAttributes{
+			CommonName: host,
+			SANs:       &digicert.SubjectAltNames{DNSNames: []string{host}},
+		},
+	}
+	if m.SeatID != "" {
+		req.Seat = &digicert.SeatReference{SeatID: m.SeatID}
+	}
+
+	result, _, err := m.Client.Certificates.Issue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if result.Certificate == nil || result.Certificate.Certificate == "" {
+		return nil, fmt.Errorf("autocert: no certificate returned for %s (request %s)", host, result.RequestID)
+	}
+
+	bundle, err := encodeBundle(key, result)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := parseBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Cache != nil {
+		if err := m.Cache.Put(ctx, host, bundle); err != nil {
+			return nil, err
+		}
+	}
+	if m.Sink != nil {
+		if err := storeInSink(ctx, m.Sink, host, bundle); err != nil {
+			return nil, err
+		}
+	}
+	m.store(host, cert)
+
+	return cert, nil
+}
+
+// encodeBundle serialises the private key followed by the certificate chain,
+// the format stored in the Cache.
+func encodeBundle(key crypto.Signer, result *digicert.CertificateResponse) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		return nil, err
+	}
+	for _, c := range append([]string{result.Certificate.Certificate}, result.Chain...) {
+		buf.WriteString(strings.TrimSpace(c))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// storeInSink splits a cache bundle into the key, leaf and chain expected by
+// a CertificateSink.
+func storeInSink(ctx context.Context, sink digicert.CertificateSink, name string, bundle []byte) error {
+	var key, leaf, chain []byte
+	for rest := bundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case strings.Contains(block.Type, "PRIVATE KEY"):
+			key = pem.EncodeToMemory(block)
+		case leaf == nil:
+			leaf = pem.EncodeToMemory(block)
+		default:
+			chain = append(chain, pem.EncodeToMemory(block)...)
+		}
+	}
+	return sink.Store(ctx, name, leaf, key, chain)
+}
+
+func parseBundle(data []byte) (*tls.Certificate, error) {
+	var keyPEM, certPEM []byte
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if strings.Contains(block.Type, "PRIVATE KEY") {
+			keyPEM = pem.EncodeToMemory(block)
+		} else {
+			certPEM = append(certPEM, pem.EncodeToMemory(block)...)
+		}
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("autocert: invalid cached certificate: %w", err)
+	}
+	if cert.Leaf == nil {
+		cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &cert, nil
+}
@@ -0,0 +1,195 @@
+package autocert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+// newSigningServer returns a client whose API signs submitted CSRs with a
+// throwaway CA, issuing certificates valid for the given lifetime.
+func newSigningServer(t *testing.T, lifetime time.Duration, calls *int32) *digicert.Client {
+	t.Helper()
+
+	caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour * 365),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, _ := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		var req digicert.CertificateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		block, _ := pem.Decode([]byte(req.CSR))
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			t.Fatalf("ParseCertificateRequest() error = %v", err)
+		}
+
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(time.Now().UnixNano()),
+			Subject:      csr.Subject,
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Minute),
+			NotAfter:     time.Now().Add(lifetime),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+		der, _ := x509.CreateCertificate(rand.Reader, tmpl, caCert, csr.PublicKey, caKey)
+
+		json.NewEncoder(w).Encode(digicert.CertificateResponse{
+			Certificate: &digicert.Certificate{
+				Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+				Status:      "issued",
+			},
+			Chain: []string{string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := digicert.NewClient("test-key", digicert.WithBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestManager_GetCertificate(t *testing.T) {
+	t.Run("issues once and serves from memory", func(t *testing.T) {
+		var calls int32
+		m := &Manager{
+			Client:     newSigningServer(t, 90*24*time.Hour, &calls),
+			ProfileID:  "profile-123",
+			HostPolicy: HostWhitelist("www.example.com"),
+		}
+
+		for i := 0; i < 3; i++ {
+			cert, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "www.example.com"})
+			if err != nil {
+				t.Fatalf("GetCertificate() error = %v", err)
+			}
+			if cert.Leaf.Subject.CommonName != "www.example.com" {
+				t.Errorf("CommonName = %v, want %v", cert.Leaf.Subject.CommonName, "www.example.com")
+			}
+		}
+
+		if calls != 1 {
+			t.Errorf("issuance calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("host policy rejects unknown host", func(t *testing.T) {
+		var calls int32
+		m := &Manager{
+			Client:     newSigningServer(t, time.Hour, &calls),
+			HostPolicy: HostWhitelist("www.example.com"),
+		}
+
+		if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "evil.example.com"}); err == nil {
+			t.Error("Expected error for host not in whitelist")
+		}
+		if calls != 0 {
+			t.Errorf("issuance calls = %d, want 0", calls)
+		}
+	})
+
+	t.Run("persists to cache", func(t *testing.T) {
+		var calls int32
+		cache := DirCache(t.TempDir())
+		client := newSigningServer(t, 90*24*time.Hour, &calls)
+
+		first := &Manager{Client: client, Cache: cache}
+		if _, err := first.GetCertificate(&tls.ClientHelloInfo{ServerName: "cached.example.com"}); err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+
+		second := &Manager{Client: client, Cache: cache}
+		if _, err := second.GetCertificate(&tls.ClientHelloInfo{ServerName: "cached.example.com"}); err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+
+		if calls != 1 {
+			t.Errorf("issuance calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("replaces expired certificate synchronously", func(t *testing.T) {
+		var calls int32
+		m := &Manager{Client: newSigningServer(t, -time.Second, &calls)}
+
+		for i := 0; i < 2; i++ {
+			if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "expired.example.com"}); err != nil {
+				t.Fatalf("GetCertificate() error = %v", err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("issuance calls = %d, want 2", n)
+		}
+	})
+
+	t.Run("renews near expiry", func(t *testing.T) {
+		var calls int32
+		m := &Manager{
+			Client:      newSigningServer(t, time.Hour, &calls),
+			RenewBefore: 2 * time.Hour,
+		}
+
+		if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "renew.example.com"}); err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+		if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "renew.example.com"}); err != nil {
+			t.Fatalf("GetCertificate() error = %v", err)
+		}
+
+		deadline := time.Now().Add(2 * time.Second)
+		for atomic.LoadInt32(&calls) < 2 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if atomic.LoadInt32(&calls) < 2 {
+			t.Error("Expected background renewal to issue a new certificate")
+		}
+	})
+}
+
+func TestDirCache(t *testing.T) {
+	ctx := context.Background()
+	cache := DirCache(t.TempDir())
+
+	if _, err := cache.Get(ctx, "missing"); err != ErrCacheMiss {
+		t.Errorf("Get() error = %v, want ErrCacheMiss", err)
+	}
+	if err := cache.Put(ctx, "host", []byte("data")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	data, err := cache.Get(ctx, "host")
+	if err != nil || string(data) != "data" {
+		t.Errorf("Get() = %q, %v, want %q", data, err, "data")
+	}
+	if err := cache.Delete(ctx, "host"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package autocert
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Cache persists issued certificate bundles (private key and chain in PEM)
+// between process restarts.
+type Cache interface {
+	// Get returns the bundle for key or ErrCacheMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, data []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// DirCache implements Cache using a directory on the local filesystem.
+// Files are written with 0600 permissions as they contain private keys.
+type DirCache string
+
+func (d DirCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(string(d), key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	return data, err
+}
+
+func (d DirCache) Put(ctx context.Context, key string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(string(d), key+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(string(d), key))
+}
+
+func (d DirCache) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(string(d), key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
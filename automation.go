@@ -1,8 +1,211 @@
 package digicert
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
 type AutomationService struct {
 	client *Client
 }
 
 // Automation service handles certificate lifecycle automation
-// This is a placeholder implementation
\ No newline at end of file
+
+// CredentialReference points at credentials stored in TLM or an external vault;
+// the credentials themselves are never sent through this client.
+type CredentialReference struct {
+	ID   string `json:"id"`
+	Type string `json:"type,omitempty"`
+}
+
+type AutomationTarget struct {
+	ID             string                     `json:"id,omitempty"`
+	Name           string                     `json:"name,omitempty"`
+	Host           string                     `json:"host"`
+	Port           int                        `json:"port"`
+	Type           string                     `json:"type,omitempty"`
+	AgentID        string                     `json:"agent_id,omitempty"`
+	BusinessUnitID string                     `json:"business_unit_id,omitempty"`
+	Credentials    *CredentialReference       `json:"credentials,omitempty"`
+	Status         string                     `json:"status,omitempty"`
+	Bindings       []AutomationProfileBinding `json:"bindings,omitempty"`
+	LastVerifiedAt *time.Time                 `json:"last_verified_at,omitempty"`
+	CreatedAt      *time.Time                 `json:"created_at,omitempty"`
+}
+
+type AutomationTargetRequest struct {
+	Name string `json:"name,omitempty"`
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// Type identifies the integration used for installation, e.g. "F5",
+	// "IIS", "APACHE" or "NGINX".
+	Type           string               `json:"type"`
+	AgentID        string               `json:"agent_id,omitempty"`
+	BusinessUnitID string               `json:"business_unit_id,omitempty"`
+	Credentials    *CredentialReference `json:"credentials,omitempty"`
+}
+
+type AutomationProfileBinding struct {
+	ProfileID       string `json:"profile_id"`
+	AutoInstall     bool   `json:"auto_install"`
+	AutoRenew       bool   `json:"auto_renew"`
+	RenewBeforeDays int    `json:"renew_before_days,omitempty"`
+}
+
+type AutomationTargetVerification struct {
+	TargetID      string     `json:"target_id,omitempty"`
+	Reachable     bool       `json:"reachable"`
+	Authenticated bool       `json:"authenticated"`
+	Message       string     `json:"message,omitempty"`
+	CheckedAt     *time.Time `json:"checked_at,omitempty"`
+}
+
+type AutomationTargetListOptions struct {
+	PaginationParams
+	BusinessUnitID string `url:"business_unit_id,omitempty"`
+	Type           string `url:"type,omitempty"`
+	Status         string `url:"status,omitempty"`
+}
+
+type AutomationTargetListResponse struct {
+	ListResponse
+	Targets []AutomationTarget `json:"targets"`
+}
+
+// CreateTarget registers a network target for automated certificate installation
+func (s *AutomationService) CreateTarget(ctx context.Context, req *AutomationTargetRequest) (*AutomationTarget, *Response, error) {
+	u := "automation/targets"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var target AutomationTarget
+	resp, err := s.client.Do(ctx, httpReq, &target)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &target, resp, nil
+}
+
+// GetTarget retrieves an automation target by ID
+func (s *AutomationService) GetTarget(ctx context.Context, targetID string) (*AutomationTarget, *Response, error) {
+	u := fmt.Sprintf("automation/targets/%s", targetID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var target AutomationTarget
+	resp, err := s.client.Do(ctx, httpReq, &target)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &target, resp, nil
+}
+
+// ListTargets lists automation targets
+func (s *AutomationService) ListTargets(ctx context.Context, opts *AutomationTargetListOptions) (*AutomationTargetListResponse, *Response, error) {
+	u := "automation/targets"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.BusinessUnitID != "" {
+			q.Add("business_unit_id", opts.BusinessUnitID)
+		}
+		if opts.Type != "" {
+			q.Add("type", opts.Type)
+		}
+		if opts.Status != "" {
+			q.Add("status", opts.Status)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result AutomationTargetListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// DeleteTarget removes an automation target
+func (s *AutomationService) DeleteTarget(ctx context.Context, targetID string) (*Response, error) {
+	u := fmt.Sprintf("automation/targets/%s", targetID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, httpReq, nil)
+	return resp, err
+}
+
+// VerifyTarget checks that the target is reachable and its credentials are accepted
+func (s *AutomationService) VerifyTarget(ctx context.Context, targetID string) (*AutomationTargetVerification, *Response, error) {
+	u := fmt.Sprintf("automation/targets/%s/verify", targetID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var verification AutomationTargetVerification
+	resp, err := s.client.Do(ctx, httpReq, &verification)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &verification, resp, nil
+}
+
+// BindProfile binds a profile to a target so certificates issued from it are
+// installed and renewed on the target automatically
+func (s *AutomationService) BindProfile(ctx context.Context, targetID string, binding *AutomationProfileBinding) (*AutomationTarget, *Response, error) {
+	u := fmt.Sprintf("automation/targets/%s/bindings", targetID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, binding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var target AutomationTarget
+	resp, err := s.client.Do(ctx, httpReq, &target)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &target, resp, nil
+}
+
+// UnbindProfile removes a profile binding from a target
+func (s *AutomationService) UnbindProfile(ctx context.Context, targetID, profileID string) (*Response, error) {
+	u := fmt.Sprintf("automation/targets/%s/bindings/%s", targetID, profileID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(ctx, httpReq, nil)
+	return resp, err
+}
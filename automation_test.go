@@ -0,0 +1,124 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAutomationService_CreateTarget(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/automation/targets" {
+			t.Errorf("Expected path /mpki/api/v1/automation/targets, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		var req AutomationTargetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if req.Credentials == nil || req.Credentials.ID != "cred-1" {
+			t.Errorf("Credentials = %+v, want cred-1", req.Credentials)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(AutomationTarget{ID: "target-1", Host: req.Host, Port: req.Port, Status: "unverified"})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	target, _, err := client.Automation.CreateTarget(ctx, &AutomationTargetRequest{
+		Host:        "lb.example.com",
+		Port:        443,
+		Type:        "F5",
+		Credentials: &CredentialReference{ID: "cred-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTarget() error = %v", err)
+	}
+	if target.ID != "target-1" || target.Port != 443 {
+		t.Errorf("target = %+v, want target-1 on port 443", target)
+	}
+}
+
+func TestAutomationService_VerifyTarget(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/automation/targets/target-1/verify" {
+			t.Errorf("Expected path /mpki/api/v1/automation/targets/target-1/verify, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AutomationTargetVerification{TargetID: "target-1", Reachable: true, Authenticated: false, Message: "invalid credentials"})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	result, _, err := client.Automation.VerifyTarget(ctx, "target-1")
+	if err != nil {
+		t.Fatalf("VerifyTarget() error = %v", err)
+	}
+	if !result.Reachable || result.Authenticated {
+		t.Errorf("result = %+v, want reachable but not authenticated", result)
+	}
+}
+
+func TestAutomationService_BindProfile(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if r.URL.Path != "/mpki/api/v1/automation/targets/target-1/bindings" {
+				t.Errorf("Expected path /mpki/api/v1/automation/targets/target-1/bindings, got %s", r.URL.Path)
+			}
+			var binding AutomationProfileBinding
+			if err := json.NewDecoder(r.Body).Decode(&binding); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(AutomationTarget{ID: "target-1", Bindings: []AutomationProfileBinding{binding}})
+		case http.MethodDelete:
+			if r.URL.Path != "/mpki/api/v1/automation/targets/target-1/bindings/profile-1" {
+				t.Errorf("Expected path /mpki/api/v1/automation/targets/target-1/bindings/profile-1, got %s", r.URL.Path)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	target, _, err := client.Automation.BindProfile(ctx, "target-1", &AutomationProfileBinding{
+		ProfileID:       "profile-1",
+		AutoInstall:     true,
+		AutoRenew:       true,
+		RenewBeforeDays: 30,
+	})
+	if err != nil {
+		t.Fatalf("BindProfile() error = %v", err)
+	}
+	if len(target.Bindings) != 1 || target.Bindings[0].RenewBeforeDays != 30 {
+		t.Errorf("Bindings = %+v, want one binding renewing 30 days before expiry", target.Bindings)
+	}
+
+	if _, err := client.Automation.UnbindProfile(ctx, "target-1", "profile-1"); err != nil {
+		t.Fatalf("UnbindProfile() error = %v", err)
+	}
+}
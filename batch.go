@@ -0,0 +1,341 @@
+package digicert
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBatchConcurrency = 4
+	DefaultBatchMaxRetries  = 3
+	DefaultBatchRetryDelay  = time.Second
+)
+
+// BatchProgress receives per-item events from the batch helpers. Methods are
+// called from worker goroutines and must be safe for concurrent use.
+type BatchProgress interface {
+	OnItemStart(serialNumber string)
+	// OnItemDone is called once per item with the final error, if any.
+	OnItemDone(serialNumber string, err error)
+	// OnRetry is called before retry attempt (starting at 1) after err.
+	OnRetry(serialNumber string, attempt int, err error)
+}
+
+// BatchProgressFuncs adapts optional functions to BatchProgress.
+type BatchProgressFuncs struct {
+	Start func(serialNumber string)
+	Done  func(serialNumber string, err error)
+	Retry func(serialNumber string, attempt int, err error)
+}
+
+func (f BatchProgressFuncs) OnItemStart(serialNumber string) {
+	if f.Start != nil {
+		f.Start(serialNumber)
+	}
+}
+
+func (f BatchProgressFuncs) OnItemDone(serialNumber string, err error) {
+	if f.Done != nil {
+		f.Done(serialNumber, err)
+	}
+}
+
+func (f BatchProgressFuncs) OnRetry(serialNumber string, attempt int, err error) {
+	if f.Retry != nil {
+		f.Retry(serialNumber, attempt, err)
+	}
+}
+
+type BatchOptions struct {
+	// Concurrency is the number of items processed in parallel. Defaults to
+	// DefaultBatchConcurrency.
+	Concurrency int
+	// MaxRetries is the number of retries per item after a rate limit, server
+	// error or network failure. Defaults to DefaultBatchMaxRetries; use a
+	// negative value to disable retries.
+	MaxRetries int
+	// RetryDelay is the base delay before a retry, doubled on each attempt.
+	// Defaults to DefaultBatchRetryDelay.
+	RetryDelay time.Duration
+	// Progress, if set, receives per-item events.
+	Progress BatchProgress
+	// Journal, if set, receives a line-delimited JSON record of the batch and
+	// of every item that completes, which LoadBatchJournal can read back to
+	// resume an interrupted batch.
+	Journal io.Writer
+}
+
+type BatchItemResult struct {
+	SerialNumber string
+	Attempts     int
+	Err          error
+	// Certificate is the renewed certificate for RenewBatch.
+	Certificate *CertificateResponse
+}
+
+type BatchResult struct {
+	Items     []BatchItemResult
+	Succeeded int
+	Failed    int
+}
+
+// Err returns an error summarising failed items, or nil if all succeeded.
+func (r *BatchResult) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	errs := make([]error, 0, r.Failed)
+	for _, item := range r.Items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", item.SerialNumber, item.Err))
+		}
+	}
+	return fmt.Errorf("%d of %d batch items failed: %w", r.Failed, len(r.Items), errors.Join(errs...))
+}
+
+// RevokeBatch revokes every certificate in serialNumbers with the same
+// request. Items that fail with a retryable error are retried with backoff.
+// Per-item outcomes are reported in the result; the returned error is only
+// set if the journal could not be written or ctx was cancelled, in which case
+// the result covers the items processed so far.
+func (s *CertificatesService) RevokeBatch(ctx context.Context, serialNumbers []string, req *RevokeRequest, opts *BatchOptions) (*BatchResult, error) {
+	return runBatch(ctx, "revoke", serialNumbers, opts, func(ctx context.Context, serial string, item *BatchItemResult) error {
+		_, err := s.Revoke(ctx, serial, req)
+		return err
+	})
+}
+
+// RenewBatch renews every certificate in serialNumbers with the same request,
+// with the same retry, progress and journal behaviour as RevokeBatch.
+func (s *CertificatesService) RenewBatch(ctx context.Context, serialNumbers []string, req *RenewRequest, opts *BatchOptions) (*BatchResult, error) {
+	return runBatch(ctx, "renew", serialNumbers, opts, func(ctx context.Context, serial string, item *BatchItemResult) error {
+		cert, _, err := s.Renew(ctx, serial, req)
+		item.Certificate = cert
+		return err
+	})
+}
+
+// batchJournalEntry is one line of a batch journal.
+type batchJournalEntry struct {
+	Type      string   `json:"type"`
+	Operation string   `json:"operation,omitempty"`
+	Items     []string `json:"items,omitempty"`
+	Serial    string   `json:"serial,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	Time      string   `json:"time,omitempty"`
+}
+
+// BatchJournal is the state of a batch read back from its journal.
+type BatchJournal struct {
+	Operation string
+	Items     []string
+	Done      map[string]bool
+	Failed    map[string]string
+}
+
+// Pending returns the items that have not completed successfully, in their
+// original order. Failed items are included so a resumed batch retries them.
+func (j *BatchJournal) Pending() []string {
+	var pending []string
+	for _, serial := range j.Items {
+		if !j.Done[serial] {
+			pending = append(pending, serial)
+		}
+	}
+	return pending
+}
+
+// LoadBatchJournal reads a journal written by RevokeBatch or RenewBatch. A
+// truncated final line, as left by a crash mid-write, is ignored.
+func LoadBatchJournal(r io.Reader) (*BatchJournal, error) {
+	j := &BatchJournal{Done: make(map[string]bool), Failed: make(map[string]string)}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	seen := make(map[string]bool)
+	var pendingErr error
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		if pendingErr != nil {
+			return nil, pendingErr
+		}
+
+		var e batchJournalEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			pendingErr = fmt.Errorf("invalid batch journal entry: %w", err)
+			continue
+		}
+		switch e.Type {
+		case "batch":
+			// A resumed batch appends a new header for its pending items
+			// to the same journal.
+			j.Operation = e.Operation
+			for _, serial := range e.Items {
+				if !seen[serial] {
+					seen[serial] = true
+					j.Items = append(j.Items, serial)
+				}
+			}
+		case "done":
+			j.Done[e.Serial] = true
+			delete(j.Failed, e.Serial)
+		case "failed":
+			if !j.Done[e.Serial] {
+				j.Failed[e.Serial] = e.Error
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if j.Operation == "" {
+		return nil, fmt.Errorf("batch journal has no batch header")
+	}
+	return j, nil
+}
+
+type batchJournal struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+func (j *batchJournal) write(e batchJournalEntry) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return
+	}
+	e.Time = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.Marshal(e)
+	if err == nil {
+		_, err = j.w.Write(append(data, '\n'))
+	}
+	j.err = err
+}
+
+func runBatch(ctx context.Context, operation string, serials []string, opts *BatchOptions, do func(ctx context.Context, serial string, item *BatchItemResult) error) (*BatchResult, error) {
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultBatchMaxRetries
+	}
+	delay := opts.RetryDelay
+	if delay <= 0 {
+		delay = DefaultBatchRetryDelay
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = BatchProgressFuncs{}
+	}
+
+	var journal *batchJournal
+	if opts.Journal != nil {
+		journal = &batchJournal{w: opts.Journal}
+		journal.write(batchJournalEntry{Type: "batch", Operation: operation, Items: serials})
+	}
+
+	result := &BatchResult{Items: make([]BatchItemResult, len(serials))}
+	processed := make([]bool, len(serials))
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				item := &result.Items[i]
+				item.SerialNumber = serials[i]
+				progress.OnItemStart(item.SerialNumber)
+
+				for {
+					item.Attempts++
+					item.Err = do(ctx, item.SerialNumber, item)
+					if item.Err == nil || item.Attempts > maxRetries || !RetryableError(item.Err) || ctx.Err() != nil {
+						break
+					}
+					progress.OnRetry(item.SerialNumber, item.Attempts, item.Err)
+					if !sleepCtx(ctx, delay<<(item.Attempts-1)) {
+						item.Err = ctx.Err()
+						break
+					}
+				}
+
+				if ctx.Err() != nil && item.Err != nil {
+					// The item was abandoned rather than failed; leave it
+					// pending in the journal so it is retried on resume.
+					progress.OnItemDone(item.SerialNumber, item.Err)
+					processed[i] = true
+					continue
+				}
+				if item.Err != nil {
+					journal.write(batchJournalEntry{Type: "failed", Serial: item.SerialNumber, Error: item.Err.Error()})
+				} else {
+					journal.write(batchJournalEntry{Type: "done", Serial: item.SerialNumber})
+				}
+				progress.OnItemDone(item.SerialNumber, item.Err)
+				processed[i] = true
+			}
+		}()
+	}
+
+dispatch:
+	for i := range serials {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case work <- i:
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	items := result.Items[:0]
+	for i, item := range result.Items {
+		if !processed[i] {
+			continue
+		}
+		if item.Err != nil {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		items = append(items, item)
+	}
+	result.Items = items
+
+	if journal != nil && journal.err != nil {
+		return result, fmt.Errorf("failed to write batch journal: %w", journal.err)
+	}
+	return result, ctx.Err()
+}
+
+// sleepCtx waits for d or until ctx is done, reporting whether d elapsed.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
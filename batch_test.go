@@ -0,0 +1,186 @@
+package digicert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingProgress struct {
+	mu      sync.Mutex
+	started []string
+	done    map[string]error
+	retries map[string]int
+}
+
+func (p *recordingProgress) OnItemStart(serial string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = append(p.started, serial)
+}
+
+func (p *recordingProgress) OnItemDone(serial string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done[serial] = err
+}
+
+func (p *recordingProgress) OnRetry(serial string, attempt int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retries[serial] = attempt
+}
+
+func TestCertificatesService_RevokeBatch(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serial := strings.Split(strings.TrimPrefix(r.URL.Path, "/mpki/api/v1/certificate/"), "/")[0]
+		mu.Lock()
+		attempts[serial]++
+		n := attempts[serial]
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case serial == "flaky" && n == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"code": "unavailable", "message": "try again"}`))
+		case serial == "bad":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": "already_revoked", "message": "already revoked"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	progress := &recordingProgress{done: map[string]error{}, retries: map[string]int{}}
+	var journal bytes.Buffer
+	result, err := client.Certificates.RevokeBatch(ctx, []string{"ok", "flaky", "bad"}, &RevokeRequest{Reason: "superseded"}, &BatchOptions{
+		Concurrency: 2,
+		RetryDelay:  time.Millisecond,
+		Progress:    progress,
+		Journal:     &journal,
+	})
+	if err != nil {
+		t.Fatalf("RevokeBatch() error = %v", err)
+	}
+
+	if result.Succeeded != 2 || result.Failed != 1 {
+		t.Errorf("Succeeded = %v, Failed = %v, want 2 and 1", result.Succeeded, result.Failed)
+	}
+	if result.Err() == nil || !strings.Contains(result.Err().Error(), "bad") {
+		t.Errorf("Err() = %v, want failure for bad", result.Err())
+	}
+	if attempts["bad"] != 1 {
+		t.Errorf("attempts[bad] = %v, want 1 (client errors are not retried)", attempts["bad"])
+	}
+	if progress.retries["flaky"] != 1 || len(progress.started) != 3 || progress.done["flaky"] != nil {
+		t.Errorf("progress = %+v, want one retry for flaky and three items", progress)
+	}
+
+	loaded, err := LoadBatchJournal(&journal)
+	if err != nil {
+		t.Fatalf("LoadBatchJournal() error = %v", err)
+	}
+	if loaded.Operation != "revoke" {
+		t.Errorf("Operation = %v, want %v", loaded.Operation, "revoke")
+	}
+	pending := loaded.Pending()
+	if len(pending) != 1 || pending[0] != "bad" {
+		t.Errorf("Pending() = %v, want [bad]", pending)
+	}
+	if loaded.Failed["bad"] == "" {
+		t.Errorf("Failed = %v, want error recorded for bad", loaded.Failed)
+	}
+}
+
+func TestCertificatesService_RenewBatch_Resume(t *testing.T) {
+	client, _ := NewClient("test-key")
+
+	var mu sync.Mutex
+	var renewed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serial := strings.Split(strings.TrimPrefix(r.URL.Path, "/mpki/api/v1/certificate/"), "/")[0]
+		mu.Lock()
+		renewed = append(renewed, serial)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificateResponse{Certificate: &Certificate{SerialNumber: serial + "-new"}})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	// Simulate an interrupted run: one item done and a torn final line.
+	journal := bytes.NewBufferString(`{"type":"batch","operation":"renew","items":["a","b","c"]}` + "\n" +
+		`{"type":"done","serial":"a"}` + "\n" +
+		`{"type":"do`)
+
+	loaded, err := LoadBatchJournal(journal)
+	if err != nil {
+		t.Fatalf("LoadBatchJournal() error = %v", err)
+	}
+
+	var resumed bytes.Buffer
+	result, err := client.Certificates.RenewBatch(context.Background(), loaded.Pending(), &RenewRequest{}, &BatchOptions{Journal: &resumed})
+	if err != nil {
+		t.Fatalf("RenewBatch() error = %v", err)
+	}
+	if result.Succeeded != 2 || len(renewed) != 2 {
+		t.Errorf("renewed = %v, want b and c only", renewed)
+	}
+	for _, item := range result.Items {
+		if item.Certificate == nil || item.Certificate.Certificate.SerialNumber != item.SerialNumber+"-new" {
+			t.Errorf("item %s Certificate = %+v, want renewed certificate", item.SerialNumber, item.Certificate)
+		}
+	}
+
+	final, err := LoadBatchJournal(&resumed)
+	if err != nil {
+		t.Fatalf("LoadBatchJournal() error = %v", err)
+	}
+	if len(final.Pending()) != 0 {
+		t.Errorf("Pending() = %v, want none", final.Pending())
+	}
+}
+
+func TestRevokeBatch_Cancelled(t *testing.T) {
+	client, _ := NewClient("test-key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	var journal bytes.Buffer
+	serials := []string{"1", "2", "3", "4", "5"}
+	_, err := client.Certificates.RevokeBatch(ctx, serials, &RevokeRequest{}, &BatchOptions{Concurrency: 1, Journal: &journal})
+	if err != context.Canceled {
+		t.Fatalf("RevokeBatch() error = %v, want %v", err, context.Canceled)
+	}
+
+	loaded, err := LoadBatchJournal(&journal)
+	if err != nil {
+		t.Fatalf("LoadBatchJournal() error = %v", err)
+	}
+	if len(loaded.Pending()) != len(serials) {
+		t.Errorf("Pending() = %v, want all items pending", loaded.Pending())
+	}
+}
@@ -0,0 +1,103 @@
+package digicert
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BusinessUnitRole is the role of a business unit administrator.
+type BusinessUnitRole string
+
+const (
+	BusinessUnitRoleAdministrator BusinessUnitRole = "Administrator"
+	BusinessUnitRoleManager       BusinessUnitRole = "Manager"
+	BusinessUnitRoleViewer        BusinessUnitRole = "Viewer"
+)
+
+// BusinessUnitPermission is a permission granted to a business unit
+// administrator in addition to those of its role.
+type BusinessUnitPermission string
+
+const (
+	BusinessUnitPermissionRead  BusinessUnitPermission = "read"
+	BusinessUnitPermissionWrite BusinessUnitPermission = "write"
+	BusinessUnitPermissionAdmin BusinessUnitPermission = "admin"
+)
+
+// businessUnitRolePermissions lists the permissions each role grants.
+var businessUnitRolePermissions = map[BusinessUnitRole][]BusinessUnitPermission{
+	BusinessUnitRoleAdministrator: {BusinessUnitPermissionRead, BusinessUnitPermissionWrite, BusinessUnitPermissionAdmin},
+	BusinessUnitRoleManager:       {BusinessUnitPermissionRead, BusinessUnitPermissionWrite},
+	BusinessUnitRoleViewer:        {BusinessUnitPermissionRead},
+}
+
+// Valid reports whether r is a role the API recognises.
+func (r BusinessUnitRole) Valid() bool {
+	_, ok := businessUnitRolePermissions[r]
+	return ok
+}
+
+// Permissions returns the permissions the role grants.
+func (r BusinessUnitRole) Permissions() []BusinessUnitPermission {
+	return slices.Clone(businessUnitRolePermissions[r])
+}
+
+// Valid reports whether p is a permission the API recognises.
+func (p BusinessUnitPermission) Valid() bool {
+	switch p {
+	case BusinessUnitPermissionRead, BusinessUnitPermissionWrite, BusinessUnitPermissionAdmin:
+		return true
+	}
+	return false
+}
+
+// Validate checks the role and permissions, which the API would otherwise
+// accept and silently grant nothing for when misspelled.
+func (r *BusinessUnitAdminRequest) Validate() error {
+	if r.Email == "" {
+		return fmt.Errorf("business unit admin email is required")
+	}
+	if !r.Role.Valid() {
+		return fmt.Errorf("unknown business unit role %q (want one of %s)", r.Role, strings.Join(businessUnitRoleNames(), ", "))
+	}
+	for _, p := range r.Permissions {
+		if !p.Valid() {
+			return fmt.Errorf("unknown business unit permission %q", p)
+		}
+	}
+	return nil
+}
+
+// Validate checks the role and permissions being set.
+func (r *BusinessUnitAdminUpdateRequest) Validate() error {
+	if r.Role != nil && !r.Role.Valid() {
+		return fmt.Errorf("unknown business unit role %q (want one of %s)", *r.Role, strings.Join(businessUnitRoleNames(), ", "))
+	}
+	if r.Permissions != nil {
+		for _, p := range *r.Permissions {
+			if !p.Valid() {
+				return fmt.Errorf("unknown business unit permission %q", p)
+			}
+		}
+	}
+	if r.Role == nil && r.Permissions == nil && r.IsActive == nil {
+		return fmt.Errorf("business unit admin update changes nothing")
+	}
+	return nil
+}
+
+// HasPermission reports whether the administrator holds p, through its role
+// or an explicit grant.
+func (a *BusinessUnitAdmin) HasPermission(p BusinessUnitPermission) bool {
+	return slices.Contains(a.Permissions, p) || slices.Contains(businessUnitRolePermissions[a.Role], p)
+}
+
+func businessUnitRoleNames() []string {
+	names := make([]string, 0, len(businessUnitRolePermissions))
+	for r := range businessUnitRolePermissions {
+		names = append(names, string(r))
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,82 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBusinessUnitAdminRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     BusinessUnitAdminRequest
+		wantErr bool
+	}{
+		{"valid", BusinessUnitAdminRequest{Email: "a@example.com", Role: BusinessUnitRoleManager, Permissions: []BusinessUnitPermission{BusinessUnitPermissionAdmin}}, false},
+		{"misspelled role", BusinessUnitAdminRequest{Email: "a@example.com", Role: "Adminstrator"}, true},
+		{"lower case role", BusinessUnitAdminRequest{Email: "a@example.com", Role: "manager"}, true},
+		{"unknown permission", BusinessUnitAdminRequest{Email: "a@example.com", Role: BusinessUnitRoleViewer, Permissions: []BusinessUnitPermission{"delete"}}, true},
+		{"no email", BusinessUnitAdminRequest{Role: BusinessUnitRoleViewer}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	client, _ := NewClient("test-key", WithBaseURL("http://127.0.0.1:1/"))
+	if _, _, err := client.BusinessUnits.AddAdmin(context.Background(), "bu-1", &BusinessUnitAdminRequest{Email: "a@example.com", Role: "Owner"}); err == nil {
+		t.Error("AddAdmin() expected validation error before sending")
+	}
+}
+
+func TestBusinessUnitAdmin_HasPermission(t *testing.T) {
+	viewer := &BusinessUnitAdmin{Role: BusinessUnitRoleViewer, Permissions: []BusinessUnitPermission{BusinessUnitPermissionWrite}}
+	if !viewer.HasPermission(BusinessUnitPermissionRead) || !viewer.HasPermission(BusinessUnitPermissionWrite) {
+		t.Error("HasPermission() = false for a role or explicit grant")
+	}
+	if viewer.HasPermission(BusinessUnitPermissionAdmin) {
+		t.Error("HasPermission(admin) = true for a viewer")
+	}
+	if got := BusinessUnitRoleAdministrator.Permissions(); len(got) != 3 {
+		t.Errorf("Administrator.Permissions() = %v, want all three", got)
+	}
+}
+
+func TestBusinessUnitsService_UpdateAdmin(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/mpki/api/v1/business-unit/bu-1/admin/admin-2" {
+			t.Errorf("request = %s %s, want PATCH of admin-2", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"admin-2","role":"Manager","is_active":true}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	role := BusinessUnitRoleManager
+	none := []BusinessUnitPermission{}
+	admin, _, err := client.BusinessUnits.UpdateAdmin(context.Background(), "bu-1", "admin-2", &BusinessUnitAdminUpdateRequest{Role: &role, Permissions: &none})
+	if err != nil {
+		t.Fatalf("UpdateAdmin() error = %v", err)
+	}
+	if admin.Role != BusinessUnitRoleManager {
+		t.Errorf("Role = %v, want Manager", admin.Role)
+	}
+	if perms, ok := body["permissions"].([]interface{}); len(body) != 2 || body["role"] != "Manager" || !ok || len(perms) != 0 {
+		t.Errorf("body = %v, want the role and an empty permission list only", body)
+	}
+
+	bad := BusinessUnitRole("Owner")
+	for _, req := range []*BusinessUnitAdminUpdateRequest{nil, {}, {Role: &bad}} {
+		if _, _, err := client.BusinessUnits.UpdateAdmin(context.Background(), "bu-1", "admin-2", req); err == nil {
+			t.Errorf("UpdateAdmin(%+v) expected error", req)
+		}
+	}
+}
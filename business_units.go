@@ -2,6 +2,8 @@ package digicert
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -25,8 +27,31 @@ type BusinessUnit struct {
 	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
 	CreatedAt        *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt        *time.Time             `json:"updated_at,omitempty"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes a business unit, keeping unmodelled fields in Extra
+func (bu *BusinessUnit) UnmarshalJSON(data []byte) error {
+	type alias BusinessUnit
+	extra, err := unmarshalWithExtra(data, (*alias)(bu))
+	if err != nil {
+		return err
+	}
+	bu.Extra = extra
+	bu.raw = append(json.RawMessage(nil), data...)
+	return nil
 }
 
+// Raw returns the JSON the BusinessUnit was decoded from, or nil if it was not
+// decoded from a response
+func (bu *BusinessUnit) Raw() json.RawMessage { return bu.raw }
+
+func (bu *BusinessUnit) unknownFields() map[string]json.RawMessage { return bu.Extra }
+
 type BusinessUnitRequest struct {
 	Name             string                 `json:"name"`
 	Description      string                 `json:"description,omitempty"`
@@ -35,22 +60,43 @@ type BusinessUnitRequest struct {
 	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
 }
 
+// BusinessUnitPatchRequest describes a partial update. Only non-nil fields are
+// sent, so unspecified fields keep their current values.
+type BusinessUnitPatchRequest struct {
+	Name             *string                `json:"name,omitempty"`
+	Description      *string                `json:"description,omitempty"`
+	ParentID         *string                `json:"parent_id,omitempty"`
+	IsActive         *bool                  `json:"is_active,omitempty"`
+	Tags             *[]string              `json:"tags,omitempty"`
+	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+}
+
 type BusinessUnitAdmin struct {
-	ID        string     `json:"id,omitempty"`
-	Email     string     `json:"email,omitempty"`
-	FirstName string     `json:"first_name,omitempty"`
-	LastName  string     `json:"last_name,omitempty"`
-	Role      string     `json:"role,omitempty"`
-	IsActive  bool       `json:"is_active,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ID          string                   `json:"id,omitempty"`
+	Email       string                   `json:"email,omitempty"`
+	FirstName   string                   `json:"first_name,omitempty"`
+	LastName    string                   `json:"last_name,omitempty"`
+	Role        BusinessUnitRole         `json:"role,omitempty"`
+	Permissions []BusinessUnitPermission `json:"permissions,omitempty"`
+	IsActive    bool                     `json:"is_active,omitempty"`
+	CreatedAt   *time.Time               `json:"created_at,omitempty"`
 }
 
 type BusinessUnitAdminRequest struct {
-	Email       string   `json:"email"`
-	FirstName   string   `json:"first_name"`
-	LastName    string   `json:"last_name"`
-	Role        string   `json:"role"`
-	Permissions []string `json:"permissions,omitempty"`
+	Email       string                   `json:"email"`
+	FirstName   string                   `json:"first_name"`
+	LastName    string                   `json:"last_name"`
+	Role        BusinessUnitRole         `json:"role"`
+	Permissions []BusinessUnitPermission `json:"permissions,omitempty"`
+}
+
+// BusinessUnitAdminUpdateRequest changes an administrator in place, keeping
+// its pending invitation. Only non-nil fields are sent; Permissions replaces
+// the explicit grants, so an empty slice removes them all.
+type BusinessUnitAdminUpdateRequest struct {
+	Role        *BusinessUnitRole         `json:"role,omitempty"`
+	Permissions *[]BusinessUnitPermission `json:"permissions,omitempty"`
+	IsActive    *bool                     `json:"is_active,omitempty"`
 }
 
 type LicensedSeats struct {
@@ -67,6 +113,21 @@ type SeatTypeAllocation struct {
 	Available int    `json:"available"`
 }
 
+// ErrInsufficientSeats is returned by AllocateSeats when the source pool does
+// not have enough available seats to satisfy the request.
+var ErrInsufficientSeats = errors.New("digicert: insufficient available seats")
+
+type SeatAllocationRequest struct {
+	// SourceBusinessUnitID is the business unit the seats are taken from.
+	SourceBusinessUnitID string `json:"source_business_unit_id"`
+	// SourceSeatType is the seat type released in the source business unit.
+	SourceSeatType string `json:"source_seat_type"`
+	// SeatType is the seat type allocated in the target business unit.
+	// Defaults to SourceSeatType when empty.
+	SeatType string `json:"seat_type,omitempty"`
+	Quantity int    `json:"quantity"`
+}
+
 type BusinessUnitListOptions struct {
 	PaginationParams
 	Name      string `url:"name,omitempty"`
@@ -83,6 +144,9 @@ type BusinessUnitListResponse struct {
 
 // Create creates a new business unit
 func (s *BusinessUnitsService) Create(ctx context.Context, req *BusinessUnitRequest) (*BusinessUnit, *Response, error) {
+	if err := s.client.requireCapability("BusinessUnits.Create", CapabilityManageBusinessUnits); err != nil {
+		return nil, nil, err
+	}
 	u := "business-unit"
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
@@ -119,6 +183,9 @@ func (s *BusinessUnitsService) Get(ctx context.Context, buID string) (*BusinessU
 
 // Update updates a business unit
 func (s *BusinessUnitsService) Update(ctx context.Context, buID string, req *BusinessUnitRequest) (*BusinessUnit, *Response, error) {
+	if err := s.client.requireCapability("BusinessUnits.Update", CapabilityManageBusinessUnits); err != nil {
+		return nil, nil, err
+	}
 	u := fmt.Sprintf("business-unit/%s", buID)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPut, u, req)
@@ -135,8 +202,32 @@ func (s *BusinessUnitsService) Update(ctx context.Context, buID string, req *Bus
 	return &bu, resp, nil
 }
 
+// Patch partially updates a business unit, sending only the fields set in req
+func (s *BusinessUnitsService) Patch(ctx context.Context, buID string, req *BusinessUnitPatchRequest) (*BusinessUnit, *Response, error) {
+	if err := s.client.requireCapability("BusinessUnits.Patch", CapabilityManageBusinessUnits); err != nil {
+		return nil, nil, err
+	}
+	u := fmt.Sprintf("business-unit/%s", buID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPatch, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bu BusinessUnit
+	resp, err := s.client.Do(ctx, httpReq, &bu)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &bu, resp, nil
+}
+
 // Delete deletes a business unit
 func (s *BusinessUnitsService) Delete(ctx context.Context, buID string) (*Response, error) {
+	if err := s.client.requireCapability("BusinessUnits.Delete", CapabilityManageBusinessUnits); err != nil {
+		return nil, err
+	}
 	u := fmt.Sprintf("business-unit/%s", buID)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
@@ -209,8 +300,16 @@ func (s *BusinessUnitsService) GetLicensedSeats(ctx context.Context, buID string
 	return &seats, resp, nil
 }
 
-// AddAdmin adds an administrator to a business unit
+// AddAdmin adds an administrator to a business unit. The role and
+// permissions are validated first, as the API ignores unknown ones.
 func (s *BusinessUnitsService) AddAdmin(ctx context.Context, buID string, req *BusinessUnitAdminRequest) (*BusinessUnitAdmin, *Response, error) {
+	if req == nil {
+		return nil, nil, fmt.Errorf("business unit admin request cannot be nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	u := fmt.Sprintf("business-unit/%s/admin", buID)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
@@ -227,6 +326,33 @@ func (s *BusinessUnitsService) AddAdmin(ctx context.Context, buID string, req *B
 	return &admin, resp, nil
 }
 
+// UpdateAdmin changes the role, permissions or active state of a business
+// unit administrator. Unlike RemoveAdmin followed by AddAdmin, it does not
+// reset the administrator's invitation.
+func (s *BusinessUnitsService) UpdateAdmin(ctx context.Context, buID, adminID string, req *BusinessUnitAdminUpdateRequest) (*BusinessUnitAdmin, *Response, error) {
+	if req == nil {
+		return nil, nil, fmt.Errorf("business unit admin update request cannot be nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	u := fmt.Sprintf("business-unit/%s/admin/%s", buID, adminID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPatch, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var admin BusinessUnitAdmin
+	resp, err := s.client.Do(ctx, httpReq, &admin)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &admin, resp, nil
+}
+
 // RemoveAdmin removes an administrator from a business unit
 func (s *BusinessUnitsService) RemoveAdmin(ctx context.Context, buID, adminID string) (*Response, error) {
 	u := fmt.Sprintf("business-unit/%s/admin/%s", buID, adminID)
@@ -257,3 +383,50 @@ func (s *BusinessUnitsService) ListAdmins(ctx context.Context, buID string) ([]B
 
 	return admins, resp, nil
 }
+
+// AllocateSeats moves licensed seats from a source business unit into the given
+// business unit. The source pool is checked for availability before the
+// allocation is submitted; ErrInsufficientSeats is returned if it falls short
+func (s *BusinessUnitsService) AllocateSeats(ctx context.Context, buID string, req *SeatAllocationRequest) (*LicensedSeats, *Response, error) {
+	if req == nil || req.SourceBusinessUnitID == "" || req.SourceSeatType == "" {
+		return nil, nil, fmt.Errorf("source business unit and seat type are required")
+	}
+	if req.Quantity <= 0 {
+		return nil, nil, fmt.Errorf("quantity must be positive")
+	}
+
+	source, resp, err := s.GetLicensedSeats(ctx, req.SourceBusinessUnitID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	available := -1
+	for _, st := range source.SeatTypes {
+		if st.Type == req.SourceSeatType {
+			available = st.Available
+			break
+		}
+	}
+	if available < req.Quantity {
+		if available < 0 {
+			available = 0
+		}
+		return nil, resp, fmt.Errorf("%w: business unit %s has %d %s seats available, %d requested",
+			ErrInsufficientSeats, req.SourceBusinessUnitID, available, req.SourceSeatType, req.Quantity)
+	}
+
+	u := fmt.Sprintf("business-unit/%s/licensed-seats/allocate", buID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var seats LicensedSeats
+	resp, err = s.client.Do(ctx, httpReq, &seats)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &seats, resp, nil
+}
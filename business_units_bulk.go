@@ -0,0 +1,293 @@
+package digicert
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jonhadfield/go-digicert-tlm/report"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// businessUnitCSVHeader is the column layout used by ExportAll and ImportBulk.
+// ImportBulk reads CSV only, so XLSX exports are for people rather than
+// round trips.
+var businessUnitCSVHeader = []string{"name", "description", "parent_name", "tags"}
+
+// BusinessUnitRecord is the portable representation of a business unit used
+// for import and export. Parents are referenced by name rather than ID so
+// records can be moved between accounts.
+type BusinessUnitRecord struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	ParentName  string   `json:"parent_name,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+type BusinessUnitImportResult struct {
+	Name    string `json:"name"`
+	ID      string `json:"id,omitempty"`
+	Created bool   `json:"created"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BusinessUnitImportReport struct {
+	Results []BusinessUnitImportResult `json:"results"`
+	Created int                        `json:"created"`
+	Skipped int                        `json:"skipped"`
+	Failed  int                        `json:"failed"`
+}
+
+// ExportAll writes every business unit in the account to w in the given format
+func (s *BusinessUnitsService) ExportAll(ctx context.Context, w io.Writer, format ExportFormat) error {
+	units, err := s.listAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(units))
+	for _, bu := range units {
+		names[bu.ID] = bu.Name
+	}
+
+	records := make([]BusinessUnitRecord, 0, len(units))
+	for _, bu := range units {
+		records = append(records, BusinessUnitRecord{
+			Name:        bu.Name,
+			Description: bu.Description,
+			ParentName:  names[bu.ParentID],
+			Tags:        bu.Tags,
+		})
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case ExportFormatCSV, ExportFormatXLSX:
+		rows := make([]report.Record, 0, len(records))
+		for _, r := range records {
+			rows = append(rows, report.Record{
+				"name":        r.Name,
+				"description": r.Description,
+				"parent_name": r.ParentName,
+				"tags":        strings.Join(r.Tags, ";"),
+			})
+		}
+		return report.Write(w, report.Format(format), businessUnitCSVHeader, rows, nil)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// ImportBulk creates the business units described by r, which may hold either
+// a JSON array of BusinessUnitRecord or CSV in the ExportAll layout. Parents are
+// created before their children regardless of input order, and units whose name
+// already exists in the account are skipped. A failure on one record does not
+// stop the import; per-record outcomes are reported in the returned report.
+func (s *BusinessUnitsService) ImportBulk(ctx context.Context, r io.Reader) (*BusinessUnitImportReport, error) {
+	records, err := decodeBusinessUnitRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.listAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string, len(existing)+len(records))
+	for _, bu := range existing {
+		ids[bu.Name] = bu.ID
+	}
+
+	ordered, err := orderBusinessUnitRecords(records, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &BusinessUnitImportReport{}
+	for _, rec := range ordered {
+		result := BusinessUnitImportResult{Name: rec.Name}
+
+		if id, ok := ids[rec.Name]; ok {
+			result.ID = id
+			result.Skipped = true
+			summary.Skipped++
+			summary.Results = append(summary.Results, result)
+			continue
+		}
+
+		req := &BusinessUnitRequest{
+			Name:        rec.Name,
+			Description: rec.Description,
+			Tags:        rec.Tags,
+		}
+		if rec.ParentName != "" {
+			parentID, ok := ids[rec.ParentName]
+			if !ok {
+				result.Error = fmt.Sprintf("parent %q was not created", rec.ParentName)
+				summary.Failed++
+				summary.Results = append(summary.Results, result)
+				continue
+			}
+			req.ParentID = parentID
+		}
+
+		bu, _, err := s.Create(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return summary, ctx.Err()
+			}
+			result.Error = err.Error()
+			summary.Failed++
+		} else {
+			ids[rec.Name] = bu.ID
+			result.ID = bu.ID
+			result.Created = true
+			summary.Created++
+		}
+		summary.Results = append(summary.Results, result)
+	}
+
+	return summary, nil
+}
+
+// listAll pages through List until every business unit has been collected.
+func (s *BusinessUnitsService) listAll(ctx context.Context) ([]BusinessUnit, error) {
+	const pageSize = 100
+
+	var all []BusinessUnit
+	opts := &BusinessUnitListOptions{}
+	opts.Limit = pageSize
+	for {
+		page, _, err := s.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.BusinessUnits...)
+		if len(page.BusinessUnits) == 0 || len(all) >= page.Total {
+			return all, nil
+		}
+		opts.Offset = len(all)
+	}
+}
+
+func decodeBusinessUnitRecords(r io.Reader) ([]BusinessUnitRecord, error) {
+	br := bufio.NewReader(r)
+
+	// Sniff the start of the input to choose between JSON and CSV.
+	head, _ := br.Peek(512)
+	head = bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(head) > 0 && head[0] == '[' {
+		var records []BusinessUnitRecord
+		if err := json.NewDecoder(br).Decode(&records); err != nil {
+			return nil, fmt.Errorf("failed to decode business units: %w", err)
+		}
+		return records, nil
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode business units: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, h := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("business unit CSV is missing the name column")
+	}
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	records := make([]BusinessUnitRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		rec := BusinessUnitRecord{
+			Name:        field(row, "name"),
+			Description: field(row, "description"),
+			ParentName:  field(row, "parent_name"),
+		}
+		if rec.Name == "" {
+			continue
+		}
+		if tags := field(row, "tags"); tags != "" {
+			rec.Tags = strings.Split(tags, ";")
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// orderBusinessUnitRecords sorts records so that every parent precedes its
+// children. Parents may also refer to business units that already exist.
+func orderBusinessUnitRecords(records []BusinessUnitRecord, existing map[string]string) ([]BusinessUnitRecord, error) {
+	byName := make(map[string]BusinessUnitRecord, len(records))
+	for _, rec := range records {
+		if _, dup := byName[rec.Name]; dup {
+			return nil, fmt.Errorf("duplicate business unit %q in import", rec.Name)
+		}
+		byName[rec.Name] = rec
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(records))
+	ordered := make([]BusinessUnitRecord, 0, len(records))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("business unit %q is part of a parent cycle", name)
+		}
+		state[name] = visiting
+		rec := byName[name]
+		if _, inImport := byName[rec.ParentName]; inImport {
+			if err := visit(rec.ParentName); err != nil {
+				return err
+			}
+		} else if rec.ParentName != "" {
+			if _, ok := existing[rec.ParentName]; !ok {
+				return fmt.Errorf("business unit %q references unknown parent %q", name, rec.ParentName)
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, rec)
+		return nil
+	}
+
+	for _, rec := range records {
+		if err := visit(rec.Name); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,147 @@
+package digicert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBusinessUnitsService_ExportAll(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/business-unit" {
+			t.Errorf("Expected path /mpki/api/v1/business-unit, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(BusinessUnitListResponse{
+			ListResponse: ListResponse{Total: 2},
+			BusinessUnits: []BusinessUnit{
+				{ID: "bu-1", Name: "Engineering", Tags: []string{"tech", "core"}},
+				{ID: "bu-2", Name: "Platform", ParentID: "bu-1", Description: "Platform team"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	t.Run("CSV export", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.BusinessUnits.ExportAll(ctx, &buf, ExportFormatCSV); err != nil {
+			t.Fatalf("ExportAll() error = %v", err)
+		}
+
+		want := "name,description,parent_name,tags\nEngineering,,,tech;core\nPlatform,Platform team,Engineering,\n"
+		if buf.String() != want {
+			t.Errorf("ExportAll() = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("JSON export", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.BusinessUnits.ExportAll(ctx, &buf, ExportFormatJSON); err != nil {
+			t.Fatalf("ExportAll() error = %v", err)
+		}
+
+		var records []BusinessUnitRecord
+		if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+			t.Fatalf("Failed to decode export: %v", err)
+		}
+		if len(records) != 2 || records[1].ParentName != "Engineering" {
+			t.Errorf("records = %+v, want Platform parented by Engineering", records)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if err := client.BusinessUnits.ExportAll(ctx, &bytes.Buffer{}, "xml"); err == nil {
+			t.Error("Expected error for unsupported format")
+		}
+	})
+}
+
+func TestBusinessUnitsService_ImportBulk(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	newServer := func(t *testing.T, created *[]BusinessUnitRequest) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodGet {
+				json.NewEncoder(w).Encode(BusinessUnitListResponse{
+					ListResponse:  ListResponse{Total: 1},
+					BusinessUnits: []BusinessUnit{{ID: "bu-root", Name: "Root"}},
+				})
+				return
+			}
+
+			var req BusinessUnitRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			*created = append(*created, req)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(BusinessUnit{ID: "id-" + req.Name, Name: req.Name})
+		}))
+	}
+
+	t.Run("CSV import orders parents first", func(t *testing.T) {
+		var created []BusinessUnitRequest
+		server := newServer(t, &created)
+		defer server.Close()
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		input := "name,description,parent_name,tags\nChild,,Parent,a;b\nParent,,Root,\nRoot,,,\n"
+		report, err := client.BusinessUnits.ImportBulk(ctx, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ImportBulk() error = %v", err)
+		}
+
+		if report.Created != 2 || report.Skipped != 1 {
+			t.Errorf("Created = %v, Skipped = %v, want 2 and 1", report.Created, report.Skipped)
+		}
+		if len(created) != 2 || created[0].Name != "Parent" || created[0].ParentID != "bu-root" {
+			t.Fatalf("created = %+v, want Parent under bu-root first", created)
+		}
+		if created[1].ParentID != "id-Parent" || len(created[1].Tags) != 2 {
+			t.Errorf("Child request = %+v, want parent id-Parent and two tags", created[1])
+		}
+	})
+
+	t.Run("JSON import", func(t *testing.T) {
+		var created []BusinessUnitRequest
+		server := newServer(t, &created)
+		defer server.Close()
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		input := `[{"name": "Sales", "parent_name": "Root"}]`
+		report, err := client.BusinessUnits.ImportBulk(ctx, strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ImportBulk() error = %v", err)
+		}
+		if report.Created != 1 || report.Results[0].ID != "id-Sales" {
+			t.Errorf("report = %+v, want Sales created", report)
+		}
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		var created []BusinessUnitRequest
+		server := newServer(t, &created)
+		defer server.Close()
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		input := "name,parent_name\nOrphan,Missing\n"
+		if _, err := client.BusinessUnits.ImportBulk(ctx, strings.NewReader(input)); err == nil {
+			t.Error("Expected error for unknown parent")
+		}
+		if len(created) != 0 {
+			t.Errorf("created = %v, want none", created)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package digicert
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -297,6 +298,50 @@ func TestBusinessUnitsService_Update(t *testing.T) {
 	})
 }
 
+func TestBusinessUnitsService_Patch(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	t.Run("only set fields are sent", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/business-unit/bu-123" {
+				t.Errorf("Expected path /mpki/api/v1/business-unit/bu-123, got %s", r.URL.Path)
+			}
+			if r.Method != http.MethodPatch {
+				t.Errorf("Expected PATCH request, got %s", r.Method)
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if len(body) != 2 {
+				t.Errorf("body = %v, want only is_active and tags", body)
+			}
+			if body["is_active"] != false {
+				t.Errorf("is_active = %v, want false", body["is_active"])
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(BusinessUnit{ID: "bu-123", Name: "Unchanged", Tags: []string{"archived"}})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		result, _, err := client.BusinessUnits.Patch(ctx, "bu-123", &BusinessUnitPatchRequest{
+			IsActive: Bool(false),
+			Tags:     &[]string{"archived"},
+		})
+		if err != nil {
+			t.Fatalf("Patch() error = %v", err)
+		}
+		if result.Name != "Unchanged" {
+			t.Errorf("Name = %v, want %v", result.Name, "Unchanged")
+		}
+	})
+}
+
 func TestBusinessUnitsService_Delete(t *testing.T) {
 	client, _ := NewClient("test-key")
 	ctx := context.Background()
@@ -568,6 +613,88 @@ func TestBusinessUnitsService_GetLicensedSeats(t *testing.T) {
 	})
 }
 
+func TestBusinessUnitsService_AllocateSeats(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	sourceSeats := &LicensedSeats{
+		SeatTypes: []SeatTypeAllocation{
+			{Type: "MANAGEMENT_SEAT", Total: 100, Used: 80, Available: 20},
+		},
+	}
+
+	t.Run("successful allocation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/mpki/api/v1/business-unit/bu-source/licensed-seats":
+				json.NewEncoder(w).Encode(sourceSeats)
+			case "/mpki/api/v1/business-unit/bu-target/licensed-seats/allocate":
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				var req SeatAllocationRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("Failed to decode request body: %v", err)
+				}
+				if req.Quantity != 15 {
+					t.Errorf("Quantity = %v, want %v", req.Quantity, 15)
+				}
+				json.NewEncoder(w).Encode(LicensedSeats{TotalSeats: 65, AvailableSeats: 15})
+			default:
+				t.Errorf("Unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		result, _, err := client.BusinessUnits.AllocateSeats(ctx, "bu-target", &SeatAllocationRequest{
+			SourceBusinessUnitID: "bu-source",
+			SourceSeatType:       "MANAGEMENT_SEAT",
+			Quantity:             15,
+		})
+		if err != nil {
+			t.Fatalf("AllocateSeats() error = %v", err)
+		}
+		if result.TotalSeats != 65 {
+			t.Errorf("TotalSeats = %v, want %v", result.TotalSeats, 65)
+		}
+	})
+
+	t.Run("insufficient seats in source pool", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/business-unit/bu-source/licensed-seats" {
+				t.Errorf("Allocation should not be submitted, got request to %s", r.URL.Path)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(sourceSeats)
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.BusinessUnits.AllocateSeats(ctx, "bu-target", &SeatAllocationRequest{
+			SourceBusinessUnitID: "bu-source",
+			SourceSeatType:       "MANAGEMENT_SEAT",
+			Quantity:             50,
+		})
+		if !errors.Is(err, ErrInsufficientSeats) {
+			t.Errorf("AllocateSeats() error = %v, want ErrInsufficientSeats", err)
+		}
+	})
+
+	t.Run("invalid quantity", func(t *testing.T) {
+		_, _, err := client.BusinessUnits.AllocateSeats(ctx, "bu-target", &SeatAllocationRequest{
+			SourceBusinessUnitID: "bu-source",
+			SourceSeatType:       "MANAGEMENT_SEAT",
+		})
+		if err == nil {
+			t.Error("Expected error for zero quantity")
+		}
+	})
+}
+
 func TestBusinessUnitsService_AdminManagement(t *testing.T) {
 	client, _ := NewClient("test-key")
 	ctx := context.Background()
@@ -578,8 +705,8 @@ func TestBusinessUnitsService_AdminManagement(t *testing.T) {
 			Email:       "admin@example.com",
 			FirstName:   "Jane",
 			LastName:    "Admin",
-			Role:        "Administrator",
-			Permissions: []string{"read", "write", "admin"},
+			Role:        BusinessUnitRoleAdministrator,
+			Permissions: []BusinessUnitPermission{BusinessUnitPermissionRead, BusinessUnitPermissionWrite, BusinessUnitPermissionAdmin},
 		}
 
 		createdAt := time.Now()
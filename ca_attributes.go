@@ -0,0 +1,185 @@
+package digicert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CA vendors with built-in attribute schemas, matching Certificate.CAVendor
+// case-insensitively.
+const (
+	CAVendorMicrosoft    = "microsoft"
+	CAVendorEJBCA        = "ejbca"
+	CAVendorAWSPrivateCA = "aws_private_ca"
+)
+
+// CAAttributeSchema describes the ca_attributes a CA vendor accepts.
+type CAAttributeSchema struct {
+	Vendor string
+	// Required attributes must be present and non-empty.
+	Required []string
+	// Optional attributes may be present. Attributes in neither list are
+	// rejected.
+	Optional []string
+}
+
+// Validate checks attrs against the schema.
+func (s CAAttributeSchema) Validate(attrs CAAttributes) error {
+	var missing []string
+	for _, name := range s.Required {
+		if v, ok := attrs[name]; !ok || v == nil || v == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("digicert: %s CA attributes missing %s", s.Vendor, strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for name := range attrs {
+		if !containsString(s.Required, name) && !containsString(s.Optional, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("digicert: %s CA does not accept attributes %s", s.Vendor, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+var caAttributeSchemas = struct {
+	sync.RWMutex
+	m map[string]CAAttributeSchema
+}{m: map[string]CAAttributeSchema{
+	CAVendorMicrosoft: {
+		Vendor:   CAVendorMicrosoft,
+		Required: []string{"template_name"},
+		Optional: []string{"template_oid"},
+	},
+	CAVendorEJBCA: {
+		Vendor:   CAVendorEJBCA,
+		Required: []string{"end_entity_profile", "certificate_profile"},
+		Optional: []string{"ca_name"},
+	},
+	CAVendorAWSPrivateCA: {
+		Vendor:   CAVendorAWSPrivateCA,
+		Optional: []string{"template_arn", "signing_algorithm"},
+	},
+}}
+
+// RegisterCAAttributeSchema adds or replaces the schema for schema.Vendor,
+// for CAs without a built-in schema.
+func RegisterCAAttributeSchema(schema CAAttributeSchema) error {
+	if schema.Vendor == "" {
+		return fmt.Errorf("CA attribute schema vendor cannot be empty")
+	}
+	caAttributeSchemas.Lock()
+	caAttributeSchemas.m[strings.ToLower(schema.Vendor)] = schema
+	caAttributeSchemas.Unlock()
+	return nil
+}
+
+// LookupCAAttributeSchema returns the schema registered for vendor.
+func LookupCAAttributeSchema(vendor string) (CAAttributeSchema, bool) {
+	caAttributeSchemas.RLock()
+	defer caAttributeSchemas.RUnlock()
+	schema, ok := caAttributeSchemas.m[strings.ToLower(vendor)]
+	return schema, ok
+}
+
+// Validate checks the attributes against the schema of w.Vendor. Attributes
+// without a vendor, or for a vendor with no registered schema, are not
+// checked.
+func (w *CAAttributesWrapper) Validate() error {
+	if w == nil || w.Vendor == "" {
+		return nil
+	}
+	schema, ok := LookupCAAttributeSchema(w.Vendor)
+	if !ok {
+		return nil
+	}
+	return schema.Validate(w.Schema)
+}
+
+// CAAttributeSet is a typed set of attributes for one CA vendor.
+type CAAttributeSet interface {
+	CAVendor() string
+	Attributes() CAAttributes
+}
+
+// NewCAAttributes builds validated ca_attributes for a CertificateRequest
+// from a typed set such as MicrosoftCAAttributes.
+func NewCAAttributes(set CAAttributeSet) (*CAAttributesWrapper, error) {
+	if set == nil {
+		return nil, fmt.Errorf("CA attribute set cannot be nil")
+	}
+	w := &CAAttributesWrapper{Vendor: set.CAVendor(), Schema: set.Attributes()}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// MicrosoftCAAttributes selects the certificate template on a Microsoft CA.
+type MicrosoftCAAttributes struct {
+	TemplateName string
+	// TemplateOID identifies the template when names are ambiguous.
+	TemplateOID string
+}
+
+func (MicrosoftCAAttributes) CAVendor() string { return CAVendorMicrosoft }
+
+func (a MicrosoftCAAttributes) Attributes() CAAttributes {
+	return compactCAAttributes(map[string]string{
+		"template_name": a.TemplateName,
+		"template_oid":  a.TemplateOID,
+	})
+}
+
+// EJBCAAttributes selects the end entity and certificate profiles on EJBCA.
+type EJBCAAttributes struct {
+	EndEntityProfile   string
+	CertificateProfile string
+	CAName             string
+}
+
+func (EJBCAAttributes) CAVendor() string { return CAVendorEJBCA }
+
+func (a EJBCAAttributes) Attributes() CAAttributes {
+	return compactCAAttributes(map[string]string{
+		"end_entity_profile":  a.EndEntityProfile,
+		"certificate_profile": a.CertificateProfile,
+		"ca_name":             a.CAName,
+	})
+}
+
+// AWSPrivateCAAttributes sets the issuance template and signing algorithm
+// on AWS Private CA.
+type AWSPrivateCAAttributes struct {
+	TemplateARN      string
+	SigningAlgorithm string
+}
+
+func (AWSPrivateCAAttributes) CAVendor() string { return CAVendorAWSPrivateCA }
+
+func (a AWSPrivateCAAttributes) Attributes() CAAttributes {
+	return compactCAAttributes(map[string]string{
+		"template_arn":      a.TemplateARN,
+		"signing_algorithm": a.SigningAlgorithm,
+	})
+}
+
+// compactCAAttributes drops empty values, so unset optional fields are not
+// sent.
+func compactCAAttributes(values map[string]string) CAAttributes {
+	attrs := make(CAAttributes, len(values))
+	for name, v := range values {
+		if v != "" {
+			attrs[name] = v
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,119 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewCAAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     CAAttributeSet
+		want    CAAttributes
+		wantErr string
+	}{
+		{
+			name: "microsoft template",
+			set:  MicrosoftCAAttributes{TemplateName: "WebServer"},
+			want: CAAttributes{"template_name": "WebServer"},
+		},
+		{
+			name:    "microsoft without template",
+			set:     MicrosoftCAAttributes{TemplateOID: "1.3.6.1.4.1.311.21.8.1"},
+			wantErr: "missing template_name",
+		},
+		{
+			name: "ejbca profiles",
+			set:  EJBCAAttributes{EndEntityProfile: "TLS", CertificateProfile: "SERVER", CAName: "IssuingCA"},
+			want: CAAttributes{"end_entity_profile": "TLS", "certificate_profile": "SERVER", "ca_name": "IssuingCA"},
+		},
+		{
+			name:    "ejbca without certificate profile",
+			set:     EJBCAAttributes{EndEntityProfile: "TLS"},
+			wantErr: "missing certificate_profile",
+		},
+		{
+			name: "aws private ca with nothing set",
+			set:  AWSPrivateCAAttributes{},
+			want: CAAttributes{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewCAAttributes(tt.set)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewCAAttributes() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewCAAttributes() error = %v", err)
+			}
+			if len(w.Schema) != len(tt.want) {
+				t.Errorf("Schema = %v, want %v", w.Schema, tt.want)
+			}
+			for k, v := range tt.want {
+				if w.Schema[k] != v {
+					t.Errorf("Schema[%s] = %v, want %v", k, w.Schema[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestCAAttributeSchema_Registry(t *testing.T) {
+	if err := RegisterCAAttributeSchema(CAAttributeSchema{Vendor: "Venafi-Test", Required: []string{"zone"}}); err != nil {
+		t.Fatalf("RegisterCAAttributeSchema() error = %v", err)
+	}
+	if _, ok := LookupCAAttributeSchema("venafi-test"); !ok {
+		t.Fatal("LookupCAAttributeSchema() did not find the registered schema")
+	}
+
+	w := &CAAttributesWrapper{Vendor: "VENAFI-TEST", Schema: CAAttributes{"zone": "tls", "colour": "blue"}}
+	if err := w.Validate(); err == nil || !strings.Contains(err.Error(), "colour") {
+		t.Errorf("Validate() error = %v, want unknown attribute colour", err)
+	}
+	unchecked := &CAAttributesWrapper{Schema: CAAttributes{"anything": 1}}
+	if err := unchecked.Validate(); err != nil {
+		t.Errorf("Validate() without vendor error = %v, want nil", err)
+	}
+	if err := RegisterCAAttributeSchema(CAAttributeSchema{}); err == nil {
+		t.Error("RegisterCAAttributeSchema() without vendor error = nil, want error")
+	}
+}
+
+func TestCertificatesService_IssueValidatesCAAttributes(t *testing.T) {
+	var requests int
+	var body map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"request_id": "req-1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	bad := &CAAttributesWrapper{Vendor: CAVendorMicrosoft, Schema: CAAttributes{"template": "WebServer"}}
+	if _, _, err := client.Certificates.Issue(ctx, &CertificateRequest{CAAttributes: bad}); err == nil {
+		t.Error("Issue() with invalid CA attributes error = nil, want error")
+	}
+	if requests != 0 {
+		t.Errorf("requests = %d, want the invalid request rejected locally", requests)
+	}
+
+	good, _ := NewCAAttributes(MicrosoftCAAttributes{TemplateName: "WebServer"})
+	if _, _, err := client.Certificates.Issue(ctx, &CertificateRequest{CAAttributes: good}); err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if got := string(body["ca_attributes"]); got != `{"schema":{"template_name":"WebServer"}}` {
+		t.Errorf("ca_attributes = %s, want the template under schema", got)
+	}
+}
@@ -0,0 +1,89 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Capability is a permission an API key needs for a group of operations.
+// Values match the permission names returned by WhoAmI.
+type Capability string
+
+const (
+	CapabilityViewCertificates    Capability = "VIEW_CERTIFICATE"
+	CapabilityIssueCertificates   Capability = "ISSUE_CERTIFICATE"
+	CapabilityRevokeCertificates  Capability = "REVOKE_CERTIFICATE"
+	CapabilityManageEnrollments   Capability = "MANAGE_ENROLLMENT"
+	CapabilityManageBusinessUnits Capability = "MANAGE_BUSINESS_UNIT"
+)
+
+// ErrInsufficientPermissions is matched by the *PermissionError returned,
+// without contacting the API, for an operation the key is known not to be
+// allowed to perform.
+var ErrInsufficientPermissions = errors.New("digicert: insufficient permissions")
+
+// PermissionError reports an operation refused because the API key lacks a
+// capability. It matches ErrInsufficientPermissions and IsForbidden.
+type PermissionError struct {
+	Operation  string
+	Capability Capability
+	// Identity is the name or ID of the key's account, if known.
+	Identity string
+}
+
+func (e *PermissionError) Error() string {
+	who := "API key"
+	if e.Identity != "" {
+		who = fmt.Sprintf("API key %q", e.Identity)
+	}
+	return fmt.Sprintf("digicert: %s lacks the %s permission required by %s", who, e.Capability, e.Operation)
+}
+
+func (e *PermissionError) Unwrap() error { return ErrInsufficientPermissions }
+
+// Capabilities lists what the client's API key is allowed to do.
+type Capabilities struct {
+	Identity *Identity
+}
+
+// Can reports whether the key holds capability
+func (c *Capabilities) Can(capability Capability) bool {
+	return c != nil && c.Identity.HasPermission(string(capability))
+}
+
+// Capabilities fetches the permissions of the API key from WhoAmI and
+// remembers them, so that later calls needing a missing capability fail
+// early with a *PermissionError instead of a 403 from the API. Call it again
+// to refresh; SetAPIKey clears them. Keys rotated through an APIKeyProvider
+// are not detected, so refresh after a rotation that changes permissions.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	identity, _, err := c.WhoAmI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	caps := &Capabilities{Identity: identity}
+
+	c.mu.Lock()
+	c.capabilities = caps
+	c.mu.Unlock()
+	return caps, nil
+}
+
+// requireCapability returns a *PermissionError if capabilities have been
+// fetched and do not include capability. Without them every call is allowed
+// through to the API.
+func (c *Client) requireCapability(operation string, capability Capability) error {
+	c.mu.RLock()
+	caps := c.capabilities
+	c.mu.RUnlock()
+	if caps == nil || caps.Can(capability) {
+		return nil
+	}
+
+	identity := caps.Identity.Name
+	if identity == "" {
+		identity = caps.Identity.ID
+	}
+	return &PermissionError{Operation: operation, Capability: capability, Identity: identity}
+}
@@ -0,0 +1,67 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Capabilities(t *testing.T) {
+	var revokes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mpki/api/v1/user/me":
+			w.Write([]byte(`{"id": "sa-1", "name": "issuer-bot", "permissions": ["VIEW_CERTIFICATE", "ISSUE_CERTIFICATE"]}`))
+		case "/mpki/api/v1/certificate/0A/revoke":
+			revokes++
+			w.WriteHeader(http.StatusNoContent)
+		case "/mpki/api/v1/certificate":
+			w.Write([]byte(`{"serial_number": "0B"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	// Without fetched capabilities calls go straight to the API.
+	if _, err := client.Certificates.Revoke(ctx, "0A", &RevokeRequest{Reason: RevocationReasonSuperseded}); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	caps, err := client.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("Capabilities() error = %v", err)
+	}
+	if !caps.Can(CapabilityIssueCertificates) || caps.Can(CapabilityRevokeCertificates) {
+		t.Errorf("Capabilities = %v, want issue but not revoke", caps.Identity.Permissions)
+	}
+
+	_, err = client.Certificates.Revoke(ctx, "0A", &RevokeRequest{Reason: RevocationReasonSuperseded})
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("Revoke() error = %v, want *PermissionError", err)
+	}
+	if permErr.Capability != CapabilityRevokeCertificates || permErr.Identity != "issuer-bot" {
+		t.Errorf("PermissionError = %+v, want revoke capability for issuer-bot", permErr)
+	}
+	if !errors.Is(err, ErrInsufficientPermissions) || !IsForbidden(err) || RetryableError(err) {
+		t.Errorf("error %v should match ErrInsufficientPermissions and IsForbidden and not be retryable", err)
+	}
+	if revokes != 1 {
+		t.Errorf("revoke requests = %d, want 1", revokes)
+	}
+
+	if _, _, err := client.Certificates.Issue(ctx, &CertificateRequest{}); err != nil {
+		t.Errorf("Issue() error = %v, want the granted capability allowed", err)
+	}
+
+	client.SetAPIKey("other-key")
+	if _, err := client.Certificates.Revoke(ctx, "0A", nil); err != nil {
+		t.Errorf("Revoke() after SetAPIKey error = %v, want capabilities cleared", err)
+	}
+}
@@ -2,6 +2,8 @@ package digicert
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,8 +25,31 @@ type CertificateOwner struct {
 	IsActive         bool       `json:"is_active,omitempty"`
 	CreatedAt        *time.Time `json:"created_at,omitempty"`
 	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	raw json.RawMessage
 }
 
+// UnmarshalJSON decodes a certificate owner, keeping unmodelled fields in Extra
+func (o *CertificateOwner) UnmarshalJSON(data []byte) error {
+	type alias CertificateOwner
+	extra, err := unmarshalWithExtra(data, (*alias)(o))
+	if err != nil {
+		return err
+	}
+	o.Extra = extra
+	o.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Raw returns the JSON the CertificateOwner was decoded from, or nil if it was
+// not decoded from a response
+func (o *CertificateOwner) Raw() json.RawMessage { return o.raw }
+
+func (o *CertificateOwner) unknownFields() map[string]json.RawMessage { return o.Extra }
+
 type CertificateOwnerRequest struct {
 	Email       string `json:"email"`
 	FirstName   string `json:"first_name"`
@@ -50,6 +75,51 @@ type CertificateOwnerListResponse struct {
 	Owners []CertificateOwner `json:"certificate_owners"`
 }
 
+// ErrorCodeHasActiveCertificates is returned by the API when an owner that
+// still owns active certificates is deactivated.
+const ErrorCodeHasActiveCertificates = "HAS_ACTIVE_CERTIFICATES"
+
+type DeactivateOptions struct {
+	// ReassignTo is the owner that receives the certificates of the owner being
+	// deactivated. When empty, deactivating an owner with active certificates fails.
+	ReassignTo string
+}
+
+type OwnerCertificatesOptions struct {
+	PaginationParams
+	Status    string `url:"status,omitempty"`
+	ProfileID string `url:"profile_id,omitempty"`
+	SortBy    string `url:"sort_by,omitempty"`
+	SortOrder string `url:"sort_order,omitempty"`
+}
+
+type OwnerAssignmentFailure struct {
+	CertificateID string `json:"certificate_id"`
+	Code          string `json:"code,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+type BulkAssignResponse struct {
+	Assigned []string                 `json:"assigned"`
+	Failed   []OwnerAssignmentFailure `json:"failed,omitempty"`
+}
+
+// OwnershipTransferFilter restricts which certificates are moved by
+// TransferOwnership. An empty filter transfers every certificate.
+type OwnershipTransferFilter struct {
+	CertificateIDs []string `json:"certificate_ids,omitempty"`
+	ProfileID      string   `json:"profile_id,omitempty"`
+	BusinessUnitID string   `json:"business_unit_id,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+type OwnershipTransferResponse struct {
+	Transferred    int                      `json:"transferred"`
+	CertificateIDs []string                 `json:"certificate_ids,omitempty"`
+	Failed         []OwnerAssignmentFailure `json:"failed,omitempty"`
+}
+
 // Create creates a new certificate owner
 func (s *CertificateOwnersService) Create(ctx context.Context, req *CertificateOwnerRequest) (*CertificateOwner, *Response, error) {
 	u := "certificate-owners"
@@ -193,4 +263,173 @@ func (s *CertificateOwnersService) RemoveFromCertificate(ctx context.Context, ce
 
 	resp, err := s.client.Do(ctx, httpReq, nil)
 	return resp, err
-}
\ No newline at end of file
+}
+
+// BulkAssign adds an owner to many certificates in a single request
+func (s *CertificateOwnersService) BulkAssign(ctx context.Context, ownerID string, certificateIDs []string) (*BulkAssignResponse, *Response, error) {
+	u := fmt.Sprintf("certificate-owners/%s/certificates", ownerID)
+
+	req := struct {
+		CertificateIDs []string `json:"certificate_ids"`
+	}{
+		CertificateIDs: certificateIDs,
+	}
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPut, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result BulkAssignResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// TransferOwnership moves the certificates matching filter from one owner to another
+func (s *CertificateOwnersService) TransferOwnership(ctx context.Context, fromOwnerID, toOwnerID string, filter *OwnershipTransferFilter) (*OwnershipTransferResponse, *Response, error) {
+	if fromOwnerID == toOwnerID {
+		return nil, nil, fmt.Errorf("source and target owner must differ")
+	}
+
+	u := fmt.Sprintf("certificate-owners/%s/transfer", fromOwnerID)
+
+	req := struct {
+		ToOwnerID string                   `json:"to_owner_id"`
+		Filter    *OwnershipTransferFilter `json:"filter,omitempty"`
+	}{
+		ToOwnerID: toOwnerID,
+		Filter:    filter,
+	}
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result OwnershipTransferResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// ListCertificates lists the certificates linked to a certificate owner
+func (s *CertificateOwnersService) ListCertificates(ctx context.Context, ownerID string, opts *OwnerCertificatesOptions) (*CertificateSearchResponse, *Response, error) {
+	u := fmt.Sprintf("certificate-owners/%s/certificates", ownerID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Status != "" {
+			q.Add("status", opts.Status)
+		}
+		if opts.ProfileID != "" {
+			q.Add("profile_id", opts.ProfileID)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.SortBy != "" {
+			q.Add("sort_by", opts.SortBy)
+		}
+		if opts.SortOrder != "" {
+			q.Add("sort_order", opts.SortOrder)
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result CertificateSearchResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// Deactivate deactivates a certificate owner. If the owner still has active
+// certificates and opts.ReassignTo is set, the certificates are transferred to
+// that owner first; should deactivation still fail, the transfer is reversed
+func (s *CertificateOwnersService) Deactivate(ctx context.Context, ownerID string, opts *DeactivateOptions) (*CertificateOwner, *Response, error) {
+	owner, resp, err := s.deactivate(ctx, ownerID)
+	if err == nil {
+		return owner, resp, nil
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeHasActiveCertificates || opts == nil || opts.ReassignTo == "" {
+		return nil, resp, err
+	}
+
+	certificateIDs, err := s.listAllCertificateIDs(ctx, ownerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list certificates for reassignment: %w", err)
+	}
+
+	transfer, resp, err := s.TransferOwnership(ctx, ownerID, opts.ReassignTo, &OwnershipTransferFilter{CertificateIDs: certificateIDs})
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to reassign certificates: %w", err)
+	}
+
+	owner, resp, err = s.deactivate(ctx, ownerID)
+	if err != nil {
+		moved := transfer.CertificateIDs
+		if len(moved) == 0 {
+			moved = certificateIDs
+		}
+		if _, _, rollbackErr := s.TransferOwnership(ctx, opts.ReassignTo, ownerID, &OwnershipTransferFilter{CertificateIDs: moved}); rollbackErr != nil {
+			return nil, resp, errors.Join(err, fmt.Errorf("failed to roll back certificate reassignment: %w", rollbackErr))
+		}
+		return nil, resp, err
+	}
+
+	return owner, resp, nil
+}
+
+func (s *CertificateOwnersService) deactivate(ctx context.Context, ownerID string) (*CertificateOwner, *Response, error) {
+	u := fmt.Sprintf("certificate-owners/%s/deactivate", ownerID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var owner CertificateOwner
+	resp, err := s.client.Do(ctx, httpReq, &owner)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &owner, resp, nil
+}
+
+func (s *CertificateOwnersService) listAllCertificateIDs(ctx context.Context, ownerID string) ([]string, error) {
+	var ids []string
+	opts := &OwnerCertificatesOptions{}
+	opts.Limit = 100
+	for {
+		page, _, err := s.ListCertificates(ctx, ownerID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range page.Items {
+			ids = append(ids, cert.ID)
+		}
+		if len(page.Items) == 0 || len(ids) >= page.Total {
+			return ids, nil
+		}
+		opts.Offset = len(ids)
+	}
+}
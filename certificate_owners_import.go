@@ -0,0 +1,224 @@
+package digicert
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type OwnerImportOptions struct {
+	// Upsert updates owners whose email already exists in the account with
+	// the CSV values. Otherwise those rows are skipped.
+	Upsert bool
+}
+
+type OwnerImportResult struct {
+	// Row is the CSV line number, counting the header as line 1.
+	Row     int    `json:"row"`
+	Email   string `json:"email"`
+	ID      string `json:"id,omitempty"`
+	Created bool   `json:"created,omitempty"`
+	Updated bool   `json:"updated,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type OwnerImportReport struct {
+	Results []OwnerImportResult `json:"results"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+}
+
+// ImportCSV creates certificate owners from a CSV export with a header row.
+// The email column is required; names come from first_name and last_name
+// columns or from a single name column split at its last space. department,
+// job_title, phone_number and company are read when present and other
+// columns are ignored. Owners are matched to existing ones by email, case
+// insensitively. A failure on one row does not stop the import; per-row
+// outcomes are reported in the returned report.
+func (s *CertificateOwnersService) ImportCSV(ctx context.Context, r io.Reader, opts OwnerImportOptions) (*OwnerImportReport, error) {
+	rows, err := decodeOwnerCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.listAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owners := make(map[string]CertificateOwner, len(existing))
+	for _, o := range existing {
+		owners[strings.ToLower(o.Email)] = o
+	}
+
+	report := &OwnerImportReport{}
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		result := OwnerImportResult{Row: row.line, Email: row.req.Email}
+		key := strings.ToLower(row.req.Email)
+
+		switch first, dup := seen[key]; {
+		case row.err != nil:
+			result.Error = row.err.Error()
+		case dup:
+			result.Error = fmt.Sprintf("duplicate of row %d", first)
+		}
+		if result.Error != "" {
+			report.Failed++
+			report.Results = append(report.Results, result)
+			continue
+		}
+		seen[key] = row.line
+
+		current, exists := owners[key]
+		if exists && !opts.Upsert {
+			result.ID = current.ID
+			result.Skipped = true
+			report.Skipped++
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		var owner *CertificateOwner
+		if exists {
+			owner, _, err = s.Update(ctx, current.ID, mergeOwnerRequest(current, row.req))
+		} else {
+			owner, _, err = s.Create(ctx, &row.req)
+		}
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			result.Error = err.Error()
+			report.Failed++
+		case exists:
+			result.ID = owner.ID
+			result.Updated = true
+			report.Updated++
+		default:
+			result.ID = owner.ID
+			result.Created = true
+			report.Created++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// mergeOwnerRequest builds a full update for current, as Update replaces the
+// owner, keeping the fields the CSV row left empty.
+func mergeOwnerRequest(current CertificateOwner, row CertificateOwnerRequest) *CertificateOwnerRequest {
+	pick := func(csv, existing string) string {
+		if csv != "" {
+			return csv
+		}
+		return existing
+	}
+	return &CertificateOwnerRequest{
+		Email:       current.Email,
+		FirstName:   pick(row.FirstName, current.FirstName),
+		LastName:    pick(row.LastName, current.LastName),
+		PhoneNumber: pick(row.PhoneNumber, current.PhoneNumber),
+		JobTitle:    pick(row.JobTitle, current.JobTitle),
+		Company:     pick(row.Company, current.Company),
+		Department:  pick(row.Department, current.Department),
+	}
+}
+
+// listAll pages through List until every certificate owner has been collected.
+func (s *CertificateOwnersService) listAll(ctx context.Context) ([]CertificateOwner, error) {
+	const pageSize = 100
+
+	var all []CertificateOwner
+	opts := &CertificateOwnerListOptions{}
+	opts.Limit = pageSize
+	for {
+		page, _, err := s.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Owners...)
+		if len(page.Owners) == 0 || len(all) >= page.Total {
+			return all, nil
+		}
+		opts.Offset = len(all)
+	}
+}
+
+type ownerCSVRow struct {
+	line int
+	req  CertificateOwnerRequest
+	err  error
+}
+
+func decodeOwnerCSV(r io.Reader) ([]ownerCSVRow, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); string(bom) == "\xef\xbb\xbf" {
+		br.Discard(3)
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate owners: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, h := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("certificate owner CSV is missing the email column")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	rows := make([]ownerCSVRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		row := ownerCSVRow{line: i + 2, req: CertificateOwnerRequest{
+			Email:       field(record, "email"),
+			FirstName:   field(record, "first_name"),
+			LastName:    field(record, "last_name"),
+			PhoneNumber: field(record, "phone_number"),
+			JobTitle:    field(record, "job_title"),
+			Company:     field(record, "company"),
+			Department:  field(record, "department"),
+		}}
+		if name := field(record, "name"); name != "" && row.req.FirstName == "" && row.req.LastName == "" {
+			row.req.FirstName, row.req.LastName = splitFullName(name)
+		}
+		if row.req.Email == "" || !strings.Contains(row.req.Email, "@") {
+			row.err = fmt.Errorf("invalid email %q", row.req.Email)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// splitFullName splits name at its last space, so "Mary Jane Smith" becomes
+// "Mary Jane" and "Smith". A single word is used as the first name.
+func splitFullName(name string) (first, last string) {
+	name = strings.Join(strings.Fields(name), " ")
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
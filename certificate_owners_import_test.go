@@ -0,0 +1,84 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCertificateOwnersService_ImportCSV(t *testing.T) {
+	var created, updated []CertificateOwnerRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var req CertificateOwnerRequest
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/mpki/api/v1/certificate-owners":
+			json.NewEncoder(w).Encode(CertificateOwnerListResponse{
+				ListResponse: ListResponse{Total: 1},
+				Owners:       []CertificateOwner{{ID: "own-1", Email: "Ana@example.com", FirstName: "Ana", PhoneNumber: "555"}},
+			})
+		case r.Method == http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Email == "fail@example.com" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":"invalid_owner"}`))
+				return
+			}
+			created = append(created, req)
+			json.NewEncoder(w).Encode(CertificateOwner{ID: "new-" + req.FirstName, Email: req.Email})
+		case r.Method == http.MethodPut && r.URL.Path == "/mpki/api/v1/certificate-owners/own-1":
+			json.NewDecoder(r.Body).Decode(&req)
+			updated = append(updated, req)
+			json.NewEncoder(w).Encode(CertificateOwner{ID: "own-1", Email: req.Email})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	input := "\xef\xbb\xbfEmail,Name,Department\n" +
+		"ana@example.com,Ana Lopez,Security\n" +
+		"bo@example.com,Bo Mary Chen,IT\n" +
+		"not-an-email,Nobody,IT\n" +
+		"fail@example.com,Fay,IT\n" +
+		"BO@example.com,Bo Chen,IT\n"
+
+	t.Run("upsert", func(t *testing.T) {
+		created, updated = nil, nil
+		report, err := client.CertificateOwners.ImportCSV(context.Background(), strings.NewReader(input), OwnerImportOptions{Upsert: true})
+		if err != nil {
+			t.Fatalf("ImportCSV() error = %v", err)
+		}
+		if report.Created != 1 || report.Updated != 1 || report.Failed != 3 || len(report.Results) != 5 {
+			t.Errorf("report = %+v, want 1 created, 1 updated, 3 failed", report)
+		}
+		if len(created) != 1 || created[0].FirstName != "Bo Mary" || created[0].LastName != "Chen" || created[0].Department != "IT" {
+			t.Errorf("created = %+v", created)
+		}
+		if len(updated) != 1 || updated[0].LastName != "Lopez" || updated[0].PhoneNumber != "555" || updated[0].Department != "Security" {
+			t.Errorf("updated = %+v, want CSV values merged over the existing owner", updated)
+		}
+		if r := report.Results[4]; r.Row != 6 || !strings.Contains(r.Error, "row 3") {
+			t.Errorf("Results[4] = %+v, want a duplicate of row 3", r)
+		}
+	})
+
+	t.Run("skip existing", func(t *testing.T) {
+		created, updated = nil, nil
+		report, err := client.CertificateOwners.ImportCSV(context.Background(), strings.NewReader(input), OwnerImportOptions{})
+		if err != nil {
+			t.Fatalf("ImportCSV() error = %v", err)
+		}
+		if report.Skipped != 1 || report.Results[0].ID != "own-1" || len(updated) != 0 {
+			t.Errorf("report = %+v, want the existing owner skipped", report)
+		}
+	})
+
+	if _, err := client.CertificateOwners.ImportCSV(context.Background(), strings.NewReader("name\nAna\n"), OwnerImportOptions{}); err == nil {
+		t.Error("ImportCSV() expected error without an email column")
+	}
+}
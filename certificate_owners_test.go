@@ -511,4 +511,234 @@ func TestCertificateOwnersService_List(t *testing.T) {
 			t.Fatalf("List() error = %v", err)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestCertificateOwnersService_BulkAssign(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/certificate-owners/owner-123/certificates" {
+			t.Errorf("Expected path /mpki/api/v1/certificate-owners/owner-123/certificates, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+
+		var body struct {
+			CertificateIDs []string `json:"certificate_ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if len(body.CertificateIDs) != 3 {
+			t.Errorf("CertificateIDs length = %v, want %v", len(body.CertificateIDs), 3)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(BulkAssignResponse{
+			Assigned: []string{"cert-1", "cert-2"},
+			Failed:   []OwnerAssignmentFailure{{CertificateID: "cert-3", Code: "NOT_FOUND"}},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	result, _, err := client.CertificateOwners.BulkAssign(ctx, "owner-123", []string{"cert-1", "cert-2", "cert-3"})
+	if err != nil {
+		t.Fatalf("BulkAssign() error = %v", err)
+	}
+	if len(result.Assigned) != 2 || len(result.Failed) != 1 {
+		t.Errorf("result = %+v, want 2 assigned and 1 failed", result)
+	}
+}
+
+func TestCertificateOwnersService_TransferOwnership(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	t.Run("successful transfer", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/certificate-owners/owner-old/transfer" {
+				t.Errorf("Expected path /mpki/api/v1/certificate-owners/owner-old/transfer, got %s", r.URL.Path)
+			}
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST request, got %s", r.Method)
+			}
+
+			var body struct {
+				ToOwnerID string                   `json:"to_owner_id"`
+				Filter    *OwnershipTransferFilter `json:"filter"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if body.ToOwnerID != "owner-new" {
+				t.Errorf("ToOwnerID = %v, want %v", body.ToOwnerID, "owner-new")
+			}
+			if body.Filter == nil || body.Filter.ProfileID != "profile-1" {
+				t.Errorf("Filter = %+v, want profile-1", body.Filter)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(OwnershipTransferResponse{Transferred: 12})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		result, _, err := client.CertificateOwners.TransferOwnership(ctx, "owner-old", "owner-new", &OwnershipTransferFilter{ProfileID: "profile-1"})
+		if err != nil {
+			t.Fatalf("TransferOwnership() error = %v", err)
+		}
+		if result.Transferred != 12 {
+			t.Errorf("Transferred = %v, want %v", result.Transferred, 12)
+		}
+	})
+
+	t.Run("same owner rejected", func(t *testing.T) {
+		if _, _, err := client.CertificateOwners.TransferOwnership(ctx, "owner-1", "owner-1", nil); err == nil {
+			t.Error("Expected error when transferring to the same owner")
+		}
+	})
+}
+
+func TestCertificateOwnersService_ListCertificates(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/certificate-owners/owner-123/certificates" {
+			t.Errorf("Expected path /mpki/api/v1/certificate-owners/owner-123/certificates, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+
+		query := r.URL.Query()
+		if query.Get("status") != "issued" {
+			t.Errorf("status = %v, want %v", query.Get("status"), "issued")
+		}
+		if query.Get("offset") != "20" || query.Get("limit") != "10" {
+			t.Errorf("offset/limit = %v/%v, want 20/10", query.Get("offset"), query.Get("limit"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificateSearchResponse{
+			ListResponse: ListResponse{Total: 21, Offset: 20, Limit: 10},
+			Items:        []Certificate{{ID: "cert-21", CommonName: "owned.example.com"}},
+		})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	opts := &OwnerCertificatesOptions{Status: "issued"}
+	opts.Offset = 20
+	opts.Limit = 10
+
+	result, _, err := client.CertificateOwners.ListCertificates(ctx, "owner-123", opts)
+	if err != nil {
+		t.Fatalf("ListCertificates() error = %v", err)
+	}
+	if result.Total != 21 || len(result.Items) != 1 {
+		t.Errorf("result = %+v, want 1 of 21 items", result)
+	}
+}
+
+func TestCertificateOwnersService_Deactivate(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	conflict := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(APIError{Code: ErrorCodeHasActiveCertificates, Message: "Owner has active certificates"})
+	}
+
+	t.Run("reassigns then deactivates", func(t *testing.T) {
+		deactivations := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/mpki/api/v1/certificate-owners/owner-old/deactivate":
+				deactivations++
+				if deactivations == 1 {
+					conflict(w)
+					return
+				}
+				json.NewEncoder(w).Encode(CertificateOwner{ID: "owner-old", IsActive: false})
+			case "/mpki/api/v1/certificate-owners/owner-old/certificates":
+				json.NewEncoder(w).Encode(CertificateSearchResponse{
+					ListResponse: ListResponse{Total: 2},
+					Items:        []Certificate{{ID: "cert-1"}, {ID: "cert-2"}},
+				})
+			case "/mpki/api/v1/certificate-owners/owner-old/transfer":
+				json.NewEncoder(w).Encode(OwnershipTransferResponse{Transferred: 2, CertificateIDs: []string{"cert-1", "cert-2"}})
+			default:
+				t.Errorf("Unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		owner, _, err := client.CertificateOwners.Deactivate(ctx, "owner-old", &DeactivateOptions{ReassignTo: "owner-new"})
+		if err != nil {
+			t.Fatalf("Deactivate() error = %v", err)
+		}
+		if owner.ID != "owner-old" || deactivations != 2 {
+			t.Errorf("owner = %+v after %d deactivations, want owner-old after 2", owner, deactivations)
+		}
+	})
+
+	t.Run("conflict without reassignment", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conflict(w)
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.CertificateOwners.Deactivate(ctx, "owner-old", nil)
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			t.Fatalf("Error type = %T, want *APIError", err)
+		}
+		if apiErr.Code != ErrorCodeHasActiveCertificates {
+			t.Errorf("Error Code = %v, want %v", apiErr.Code, ErrorCodeHasActiveCertificates)
+		}
+	})
+
+	t.Run("rolls back reassignment on failure", func(t *testing.T) {
+		var transfers []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/mpki/api/v1/certificate-owners/owner-old/deactivate":
+				conflict(w)
+			case "/mpki/api/v1/certificate-owners/owner-old/certificates":
+				json.NewEncoder(w).Encode(CertificateSearchResponse{
+					ListResponse: ListResponse{Total: 1},
+					Items:        []Certificate{{ID: "cert-1"}},
+				})
+			default:
+				transfers = append(transfers, r.URL.Path)
+				json.NewEncoder(w).Encode(OwnershipTransferResponse{Transferred: 1})
+			}
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.CertificateOwners.Deactivate(ctx, "owner-old", &DeactivateOptions{ReassignTo: "owner-new"})
+		if err == nil {
+			t.Fatal("Expected error when deactivation keeps failing")
+		}
+		want := []string{
+			"/mpki/api/v1/certificate-owners/owner-old/transfer",
+			"/mpki/api/v1/certificate-owners/owner-new/transfer",
+		}
+		if len(transfers) != 2 || transfers[0] != want[0] || transfers[1] != want[1] {
+			t.Errorf("transfers = %v, want %v", transfers, want)
+		}
+	})
+}
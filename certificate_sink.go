@@ -0,0 +1,20 @@
+package digicert
+
+import "context"
+
+// CertificateSink receives issued certificate material for storage outside the
+// client, such as PEM files, Kubernetes secrets or a secrets manager. The sink
+// subpackage provides implementations.
+//
+// cert and key are PEM encoded. chain holds the PEM encoded intermediates (and
+// root, if returned) in issuer order and may be empty.
+type CertificateSink interface {
+	Store(ctx context.Context, name string, cert, key, chain []byte) error
+}
+
+// CertificateSinkFunc adapts a function to the CertificateSink interface.
+type CertificateSinkFunc func(ctx context.Context, name string, cert, key, chain []byte) error
+
+func (f CertificateSinkFunc) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	return f(ctx, name, cert, key, chain)
+}
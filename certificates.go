@@ -2,8 +2,12 @@ package digicert
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type CertificatesService struct {
@@ -24,7 +28,9 @@ type Account struct {
 }
 
 type ICA struct {
-	ID string `json:"id,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	ValidTo string `json:"valid_to,omitempty"`
 }
 
 type Subject struct {
@@ -37,11 +43,12 @@ type Subject struct {
 
 type Certificate struct {
 	ID                 string                 `json:"id,omitempty"`
-	Profile            ProfileReference       `json:"profile,omitempty"`
+	Profile            ProfileRef             `json:"profile,omitempty"`
 	Seat               *Seat                  `json:"seat,omitempty"`
 	SeatType           *SeatType              `json:"seat_type,omitempty"`
-	BusinessUnit       *BusinessUnit          `json:"business_unit,omitempty"`
+	BusinessUnit       *BusinessUnitRef       `json:"business_unit,omitempty"`
 	Account            *Account               `json:"account,omitempty"`
+	OrderID            string                 `json:"order_id,omitempty"`
 	Certificate        string                 `json:"certificate,omitempty"`
 	ICA                *ICA                   `json:"ica,omitempty"`
 	CommonName         string                 `json:"common_name,omitempty"`
@@ -50,23 +57,49 @@ type Certificate struct {
 	Thumbprint         string                 `json:"thumbprint,omitempty"`
 	ValidFrom          string                 `json:"valid_from,omitempty"`
 	ValidTo            string                 `json:"valid_to,omitempty"`
+	UpdatedAt          string                 `json:"updated_at,omitempty"`
 	IssuingCAName      string                 `json:"issuing_ca_name,omitempty"`
-	KeySize            string                 `json:"key_size,omitempty"`
+	KeySize            KeySpec                `json:"key_size,omitempty"`
 	SignatureAlgorithm string                 `json:"signature_algorithm,omitempty"`
 	Subject            *Subject               `json:"subject,omitempty"`
 	CAVendor           string                 `json:"ca_vendor,omitempty"`
 	Connector          string                 `json:"connector,omitempty"`
 	Source             string                 `json:"source,omitempty"`
-	ExpiresInDays      int                    `json:"expires_in_days,omitempty"`
+	ExpiresInDays      FlexibleInt            `json:"expires_in_days,omitempty"`
 	PQCVulnerable      bool                   `json:"pqc_vulnerable,omitempty"`
 	ExtendedKeyUsage   string                 `json:"extended_key_usage,omitempty"`
 	Escrow             bool                   `json:"escrow,omitempty"`
+	AutoRenew          *bool                  `json:"auto_renew,omitempty"`
 	Attributes         string                 `json:"attributes,omitempty"`
 	CustomAttributes   map[string]interface{} `json:"custom_attributes,omitempty"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes a certificate, keeping unmodelled fields in Extra
+func (c *Certificate) UnmarshalJSON(data []byte) error {
+	type alias Certificate
+	extra, err := unmarshalWithExtra(data, (*alias)(c))
+	if err != nil {
+		return err
+	}
+	c.Extra = extra
+	c.raw = append(json.RawMessage(nil), data...)
+	return nil
 }
 
+// Raw returns the JSON the Certificate was decoded from, or nil if it was not
+// decoded from a response
+func (c *Certificate) Raw() json.RawMessage { return c.raw }
+
+func (c *Certificate) unknownFields() map[string]json.RawMessage { return c.Extra }
+
 type CertificateRequest struct {
 	Profile          ProfileReference       `json:"profile"`
+	BusinessUnitID   string                 `json:"business_unit_id,omitempty"`
 	Seat             *SeatReference         `json:"seat,omitempty"`
 	CSR              string                 `json:"csr,omitempty"`
 	Validity         *Validity              `json:"validity,omitempty"`
@@ -95,7 +128,12 @@ type Validity struct {
 }
 
 type DeliveryFormat struct {
-	Format string `json:"format,omitempty"`
+	Format DeliveryFormatType `json:"format,omitempty"`
+	// Password protects the private key in PKCS12 and JKS keystores, and is
+	// required for them.
+	Password string `json:"password,omitempty"`
+	// Alias names the key entry in a PKCS12 or JKS keystore.
+	Alias string `json:"alias,omitempty"`
 }
 
 type CertificateAttributes struct {
@@ -117,8 +155,15 @@ type SubjectAltNames struct {
 	OtherNames  []string `json:"other_names,omitempty"`
 }
 
+// CAAttributesWrapper carries CA-specific issuance attributes, which the
+// API expects under a "schema" key. Build it with NewCAAttributes to have
+// the attributes checked against the vendor's schema before the request is
+// sent.
 type CAAttributesWrapper struct {
 	Schema CAAttributes `json:"schema,omitempty"`
+	// Vendor selects the schema Validate checks Schema against. It is not
+	// sent to the API.
+	Vendor string `json:"-"`
 }
 
 type CAAttributes map[string]interface{}
@@ -132,18 +177,61 @@ type CertificateResponse struct {
 	Certificate *Certificate `json:"certificate,omitempty"`
 	RequestID   string       `json:"request_id,omitempty"`
 	Chain       []string     `json:"chain,omitempty"`
-	PrivateKey  string       `json:"private_key,omitempty"`
+	PrivateKey  *KeyMaterial `json:"private_key,omitempty"`
+
+	// Operation is set when issuance was accepted for asynchronous
+	// processing. Wait on it and Decode the result into a CertificateResponse.
+	Operation *AsyncOperation `json:"-"`
 }
 
 type CertificateSearchOptions struct {
 	PaginationParams
-	CommonName   string   `url:"common_name,omitempty"`
-	SerialNumber string   `url:"serial_number,omitempty"`
-	Status       string   `url:"status,omitempty"`
-	ProfileID    string   `url:"profile_id,omitempty"`
-	Tags         []string `url:"tags,omitempty"`
-	SortBy       string   `url:"sort_by,omitempty"`
-	SortOrder    string   `url:"sort_order,omitempty"`
+	CommonName     string   `url:"common_name,omitempty"`
+	SerialNumber   string   `url:"serial_number,omitempty"`
+	Thumbprint     string   `url:"thumbprint,omitempty"`
+	Status         string   `url:"status,omitempty"`
+	ProfileID      string   `url:"profile_id,omitempty"`
+	BusinessUnitID string   `url:"business_unit_id,omitempty"`
+	Tags           []string `url:"tags,omitempty"`
+	SortBy         string   `url:"sort_by,omitempty"`
+	SortOrder      string   `url:"sort_order,omitempty"`
+	// Fields limits each returned certificate to the named JSON fields, e.g.
+	// []string{"id", "common_name", "valid_to"}. All fields are returned
+	// when empty.
+	Fields []string `url:"fields,omitempty"`
+	// DNSName matches certificates with this DNS subject alternative name.
+	// SearchByDNSName also matches wildcards and filters client side on
+	// servers without this filter.
+	DNSName string `url:"dns_name,omitempty"`
+	// Expand asks for related resources, such as ExpandProfile, to be
+	// returned inline with each certificate.
+	Expand []string `url:"expand,omitempty"`
+	// UpdatedAfter matches certificates changed at or after this time.
+	UpdatedAfter time.Time `url:"updated_after,omitempty"`
+	// CreatedAfter matches certificates created at or after this time.
+	// Together with UpdatedAfter it lets a cache sync incrementally rather
+	// than re-fetching every certificate.
+	CreatedAfter time.Time `url:"created_after,omitempty"`
+	// Prefetch makes All fetch up to this many pages concurrently while
+	// still yielding certificates in order. It is ignored by Search.
+	Prefetch int `url:"-"`
+	// OnDrift chooses how All handles pages that repeat certificates it has
+	// already yielded. It is ignored by Search.
+	OnDrift DriftStrategy `url:"-"`
+}
+
+// Related resources that can be returned inline with certificates, for
+// CertificateGetOptions.Expand and CertificateSearchOptions.Expand. The
+// expanded objects are available from ProfileRef.Expanded and
+// BusinessUnitRef.Expanded.
+const (
+	ExpandProfile      = "profile"
+	ExpandBusinessUnit = "business_unit"
+)
+
+type CertificateGetOptions struct {
+	// Expand names related resources to return inline, such as ExpandProfile.
+	Expand []string
 }
 
 type CertificateSearchResponse struct {
@@ -151,9 +239,38 @@ type CertificateSearchResponse struct {
 	Items []Certificate `json:"items"`
 }
 
+// certificateSearchV2Request is the body of a v2 certificate search, which
+// takes its filters as JSON rather than query parameters.
+type certificateSearchV2Request struct {
+	CommonName     string     `json:"common_name,omitempty"`
+	SerialNumber   string     `json:"serial_number,omitempty"`
+	Thumbprint     string     `json:"thumbprint,omitempty"`
+	DNSName        string     `json:"dns_name,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	ProfileID      string     `json:"profile_id,omitempty"`
+	BusinessUnitID string     `json:"business_unit_id,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	SortBy         string     `json:"sort_by,omitempty"`
+	SortOrder      string     `json:"sort_order,omitempty"`
+	Fields         []string   `json:"fields,omitempty"`
+	Expand         []string   `json:"expand,omitempty"`
+	UpdatedAfter   *time.Time `json:"updated_after,omitempty"`
+	CreatedAfter   *time.Time `json:"created_after,omitempty"`
+	Offset         int        `json:"offset,omitempty"`
+	Limit          int        `json:"limit,omitempty"`
+}
+
 type RevokeRequest struct {
 	Reason  string `json:"reason"`
 	Comment string `json:"comment,omitempty"`
+	// EffectiveDate schedules the revocation for a later time, such as the
+	// start of a change window. Nil revokes immediately.
+	EffectiveDate *time.Time `json:"effective_date,omitempty"`
+}
+
+type UnrevokeRequest struct {
+	Reason  string `json:"reason,omitempty"`
+	Comment string `json:"comment,omitempty"`
 }
 
 type RenewRequest struct {
@@ -166,36 +283,125 @@ type RenewRequest struct {
 	CustomAttributes []CustomAttribute      `json:"custom_attributes,omitempty"`
 }
 
+type CertificateImportItem struct {
+	Certificate  string   `json:"certificate"`
+	Chain        []string `json:"chain,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	CertOwnerIDs []string `json:"cert_owner_ids,omitempty"`
+}
+
+type CertificateImportRequest struct {
+	BusinessUnitID string                  `json:"business_unit_id,omitempty"`
+	Certificates   []CertificateImportItem `json:"certificates"`
+}
+
+type CertificateImportFailure struct {
+	Index   int    `json:"index"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type CertificateImportResponse struct {
+	Imported       int                        `json:"imported"`
+	CertificateIDs []string                   `json:"certificate_ids,omitempty"`
+	Failed         []CertificateImportFailure `json:"failed,omitempty"`
+
+	// Operation is set when the import was accepted for asynchronous
+	// processing. Wait on it and Decode the result into a
+	// CertificateImportResponse.
+	Operation *AsyncOperation `json:"-"`
+}
+
 type AdditionalFormatsResponse struct {
 	Formats map[string]string `json:"formats"`
 }
 
 // Issue creates a new certificate
 func (s *CertificatesService) Issue(ctx context.Context, req *CertificateRequest) (*CertificateResponse, *Response, error) {
+	if err := s.client.requireCapability("Issue", CapabilityIssueCertificates); err != nil {
+		return nil, nil, err
+	}
+	if bu := s.client.businessUnitID; bu != "" && req != nil && req.BusinessUnitID == "" {
+		scoped := *req
+		scoped.BusinessUnitID = bu
+		req = &scoped
+	}
+	if req != nil {
+		if err := req.DeliveryFormat.Validate(); err != nil {
+			return nil, nil, err
+		}
+		if err := req.CAAttributes.Validate(); err != nil {
+			return nil, nil, err
+		}
+		attrs, err := s.client.normalizeCertificateAttributes(req.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if attrs != req.Attributes {
+			normalized := *req
+			normalized.Attributes = attrs
+			req = &normalized
+		}
+	}
+
 	u := "certificate"
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := s.client.setIdempotencyKey(ctx, httpReq); err != nil {
+		return nil, nil, err
+	}
 
 	var cert CertificateResponse
 	resp, err := s.client.Do(ctx, httpReq, &cert)
 	if err != nil {
 		return nil, resp, err
 	}
+	cert.Operation = resp.Operation
 
 	return &cert, resp, nil
 }
 
+// BulkImport adds externally issued certificates to the inventory
+func (s *CertificatesService) BulkImport(ctx context.Context, req *CertificateImportRequest) (*CertificateImportResponse, *Response, error) {
+	u := "certificate/import"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result CertificateImportResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	result.Operation = resp.Operation
+
+	return &result, resp, nil
+}
+
 // Get retrieves a certificate by serial number
 func (s *CertificatesService) Get(ctx context.Context, serialNumber string) (*Certificate, *Response, error) {
+	return s.GetWithOptions(ctx, serialNumber, nil)
+}
+
+// GetWithOptions retrieves a certificate by serial number, with the related
+// resources named in opts returned inline
+func (s *CertificatesService) GetWithOptions(ctx context.Context, serialNumber string, opts *CertificateGetOptions) (*Certificate, *Response, error) {
 	u := fmt.Sprintf("certificate/%s", serialNumber)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	if opts != nil && len(opts.Expand) > 0 {
+		q := httpReq.URL.Query()
+		q.Set("expand", strings.Join(opts.Expand, ","))
+		httpReq.URL.RawQuery = q.Encode()
+	}
 
 	var cert Certificate
 	resp, err := s.client.Do(ctx, httpReq, &cert)
@@ -226,6 +432,19 @@ func (s *CertificatesService) GetCertificate(ctx context.Context, certificateID
 
 // Search searches for certificates
 func (s *CertificatesService) Search(ctx context.Context, opts *CertificateSearchOptions) (*CertificateSearchResponse, *Response, error) {
+	if bu := s.client.businessUnitID; bu != "" && (opts == nil || opts.BusinessUnitID == "") {
+		scoped := CertificateSearchOptions{}
+		if opts != nil {
+			scoped = *opts
+		}
+		scoped.BusinessUnitID = bu
+		opts = &scoped
+	}
+
+	if s.client.APIVersionFor(ServiceCertificates) == APIVersionV2 {
+		return s.searchV2(ctx, opts)
+	}
+
 	u := "certificate-search"
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
@@ -242,15 +461,36 @@ func (s *CertificatesService) Search(ctx context.Context, opts *CertificateSearc
 		if opts.SerialNumber != "" {
 			q.Add("serial_number", opts.SerialNumber)
 		}
+		if opts.Thumbprint != "" {
+			q.Add("thumbprint", opts.Thumbprint)
+		}
+		if opts.DNSName != "" {
+			q.Add("dns_name", opts.DNSName)
+		}
 		if opts.Status != "" {
 			q.Add("status", opts.Status)
 		}
 		if opts.ProfileID != "" {
 			q.Add("profile_id", opts.ProfileID)
 		}
+		if opts.BusinessUnitID != "" {
+			q.Add("business_unit_id", opts.BusinessUnitID)
+		}
 		for _, tag := range opts.Tags {
 			q.Add("tags", tag)
 		}
+		if len(opts.Fields) > 0 {
+			q.Add("fields", strings.Join(opts.Fields, ","))
+		}
+		if len(opts.Expand) > 0 {
+			q.Add("expand", strings.Join(opts.Expand, ","))
+		}
+		if !opts.UpdatedAfter.IsZero() {
+			q.Add("updated_after", opts.UpdatedAfter.UTC().Format(time.RFC3339Nano))
+		}
+		if !opts.CreatedAfter.IsZero() {
+			q.Add("created_after", opts.CreatedAfter.UTC().Format(time.RFC3339Nano))
+		}
 		if opts.Offset > 0 {
 			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
 		}
@@ -260,17 +500,112 @@ func (s *CertificatesService) Search(ctx context.Context, opts *CertificateSearc
 		httpReq.URL.RawQuery = q.Encode()
 	}
 
-	var result CertificateSearchResponse
-	resp, err := s.client.Do(ctx, httpReq, &result)
+	result := newCertificateSearchResponse(opts)
+	resp, err := s.client.Do(ctx, httpReq, result)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return &result, resp, nil
+	return result, resp, nil
+}
+
+// searchV2 searches certificates using the v2 search endpoint
+func (s *CertificatesService) searchV2(ctx context.Context, opts *CertificateSearchOptions) (*CertificateSearchResponse, *Response, error) {
+	u := "certificate-search"
+
+	body := newCertificateSearchV2Request(opts)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := newCertificateSearchResponse(opts)
+	resp, err := s.client.Do(ctx, httpReq, result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+func newCertificateSearchV2Request(opts *CertificateSearchOptions) *certificateSearchV2Request {
+	if opts == nil {
+		return &certificateSearchV2Request{}
+	}
+	r := &certificateSearchV2Request{
+		CommonName:     opts.CommonName,
+		SerialNumber:   opts.SerialNumber,
+		Thumbprint:     opts.Thumbprint,
+		DNSName:        opts.DNSName,
+		Status:         opts.Status,
+		ProfileID:      opts.ProfileID,
+		BusinessUnitID: opts.BusinessUnitID,
+		Tags:           opts.Tags,
+		SortBy:         opts.SortBy,
+		SortOrder:      opts.SortOrder,
+		Fields:         opts.Fields,
+		Expand:         opts.Expand,
+		Offset:         opts.Offset,
+		Limit:          opts.Limit,
+	}
+	if !opts.UpdatedAfter.IsZero() {
+		updatedAfter := opts.UpdatedAfter.UTC()
+		r.UpdatedAfter = &updatedAfter
+	}
+	if !opts.CreatedAfter.IsZero() {
+		createdAfter := opts.CreatedAfter.UTC()
+		r.CreatedAfter = &createdAfter
+	}
+	return r
+}
+
+// options converts r back into search options.
+func (r *certificateSearchV2Request) options() CertificateSearchOptions {
+	opts := CertificateSearchOptions{
+		PaginationParams: PaginationParams{Offset: r.Offset, Limit: r.Limit},
+		CommonName:       r.CommonName,
+		SerialNumber:     r.SerialNumber,
+		Thumbprint:       r.Thumbprint,
+		DNSName:          r.DNSName,
+		Status:           r.Status,
+		ProfileID:        r.ProfileID,
+		BusinessUnitID:   r.BusinessUnitID,
+		Tags:             r.Tags,
+		SortBy:           r.SortBy,
+		SortOrder:        r.SortOrder,
+		Fields:           r.Fields,
+		Expand:           r.Expand,
+	}
+	if r.UpdatedAfter != nil {
+		opts.UpdatedAfter = *r.UpdatedAfter
+	}
+	if r.CreatedAfter != nil {
+		opts.CreatedAfter = *r.CreatedAfter
+	}
+	return opts
 }
 
-// Revoke revokes a certificate
+// maxSearchSizeHint caps the number of items preallocated for a search page.
+const maxSearchSizeHint = 1000
+
+// newCertificateSearchResponse returns a response whose Items has room for
+// the requested page, so decoding a large page does not repeatedly grow and
+// copy the slice.
+func newCertificateSearchResponse(opts *CertificateSearchOptions) *CertificateSearchResponse {
+	result := &CertificateSearchResponse{}
+	if opts != nil && opts.Limit > 0 {
+		result.Items = make([]Certificate, 0, min(opts.Limit, maxSearchSizeHint))
+	}
+	return result
+}
+
+// Revoke revokes a certificate. If the server processes the revocation
+// asynchronously, the returned Response carries the Operation to wait on
 func (s *CertificatesService) Revoke(ctx context.Context, serialNumber string, req *RevokeRequest) (*Response, error) {
+	if err := s.client.requireCapability("Revoke", CapabilityRevokeCertificates); err != nil {
+		return nil, err
+	}
 	u := fmt.Sprintf("certificate/%s/revoke", serialNumber)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPut, u, req)
@@ -284,9 +619,23 @@ func (s *CertificatesService) Revoke(ctx context.Context, serialNumber string, r
 
 // Unrevoke unrevokes a certificate
 func (s *CertificatesService) Unrevoke(ctx context.Context, serialNumber string) (*Response, error) {
+	return s.UnrevokeWithRequest(ctx, serialNumber, nil)
+}
+
+// UnrevokeWithRequest unrevokes a certificate, sending req as the
+// justification recorded in the audit log. A nil req sends no body, as
+// Unrevoke does.
+func (s *CertificatesService) UnrevokeWithRequest(ctx context.Context, serialNumber string, req *UnrevokeRequest) (*Response, error) {
+	if err := s.client.requireCapability("Unrevoke", CapabilityRevokeCertificates); err != nil {
+		return nil, err
+	}
 	u := fmt.Sprintf("certificate/%s/revoke", serialNumber)
 
-	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
+	var body interface{}
+	if req != nil {
+		body = req
+	}
+	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, body)
 	if err != nil {
 		return nil, err
 	}
@@ -297,12 +646,33 @@ func (s *CertificatesService) Unrevoke(ctx context.Context, serialNumber string)
 
 // Renew renews a certificate
 func (s *CertificatesService) Renew(ctx context.Context, serialNumber string, req *RenewRequest) (*CertificateResponse, *Response, error) {
+	if err := s.client.requireCapability("Renew", CapabilityIssueCertificates); err != nil {
+		return nil, nil, err
+	}
+	if req != nil {
+		if err := req.DeliveryFormat.Validate(); err != nil {
+			return nil, nil, err
+		}
+		attrs, err := s.client.normalizeCertificateAttributes(req.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if attrs != req.Attributes {
+			normalized := *req
+			normalized.Attributes = attrs
+			req = &normalized
+		}
+	}
+
 	u := fmt.Sprintf("certificate/%s/renew", serialNumber)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := s.client.setIdempotencyKey(ctx, httpReq); err != nil {
+		return nil, nil, err
+	}
 
 	var cert CertificateResponse
 	resp, err := s.client.Do(ctx, httpReq, &cert)
@@ -348,3 +718,73 @@ func (s *CertificatesService) Pickup(ctx context.Context, requestID string) (*Ce
 
 	return &cert, resp, nil
 }
+
+// All iterates over every certificate matching opts, fetching further pages
+// as the loop advances. opts may be nil; its Offset is the starting point and
+// its Limit the page size, defaulting to 100. Set Prefetch to fetch several
+// pages concurrently. Certificates repeated by later pages are yielded only
+// once; set OnDrift to restart or fail instead. Iteration stops after
+// yielding the first error.
+//
+//	for cert, err := range client.Certificates.All(ctx, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (s *CertificatesService) All(ctx context.Context, opts *CertificateSearchOptions) iter.Seq2[Certificate, error] {
+	return func(yield func(Certificate, error) bool) {
+		page := CertificateSearchOptions{}
+		if opts != nil {
+			page = *opts
+		}
+		if page.Limit == 0 {
+			page.Limit = 100
+		}
+		start := page.Offset
+		drift := newDriftDetector(page.OnDrift)
+		if page.Prefetch > 1 {
+			offset, more := s.allPrefetched(ctx, page, drift, yield)
+			if !more {
+				return
+			}
+			page.Offset = offset
+		}
+		for {
+			result, _, err := s.Search(ctx, &page)
+			if err != nil {
+				yield(Certificate{}, err)
+				return
+			}
+			items, restart, err := drift.page(result.Items, page.Offset)
+			if err != nil {
+				yield(Certificate{}, err)
+				return
+			}
+			for _, c := range items {
+				if !yield(c, nil) {
+					return
+				}
+			}
+			if restart {
+				page.Offset = start
+				continue
+			}
+			page.Offset += len(result.Items)
+			if len(result.Items) == 0 || page.Offset >= result.Total {
+				return
+			}
+		}
+	}
+}
+
+// searchAll calls fn for every certificate matching opts
+func (s *CertificatesService) searchAll(ctx context.Context, opts *CertificateSearchOptions, fn func(Certificate)) error {
+	for c, err := range s.All(ctx, opts) {
+		if err != nil {
+			return err
+		}
+		fn(c)
+	}
+	return nil
+}
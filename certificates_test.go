@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestCertificatesService_Issue(t *testing.T) {
@@ -150,12 +151,12 @@ func TestCertificatesService_Search(t *testing.T) {
 			if q.Get("status") != "issued" {
 				t.Errorf("Expected status=issued, got %s", q.Get("status"))
 			}
-			// Neither offset nor limit should be present when offset is 0 (consistent pagination logic)
+			// A zero offset is omitted, but the first page still sends its limit
 			if q.Has("offset") {
 				t.Errorf("offset parameter should not be present when value is 0, but got %s", q.Get("offset"))
 			}
-			if q.Has("limit") {
-				t.Errorf("limit parameter should not be present when offset is 0")
+			if q.Get("limit") != "20" {
+				t.Errorf("Expected limit=20 when offset is 0, got %q", q.Get("limit"))
 			}
 
 			w.Header().Set("Content-Type", "application/json")
@@ -246,6 +247,31 @@ func TestCertificatesService_Search(t *testing.T) {
 			t.Fatalf("Search() error = %v", err)
 		}
 	})
+
+	t.Run("search with field projection", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("fields"); got != "id,common_name,valid_to" {
+				t.Errorf("Expected fields=id,common_name,valid_to, got %s", got)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"total": 1, "items": [{"id": "cert-1", "common_name": "example.com", "valid_to": "2025-01-01T00:00:00Z"}]}`))
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		result, _, err := client.Certificates.Search(ctx, &CertificateSearchOptions{
+			Fields: []string{"id", "common_name", "valid_to"},
+		})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if result.Items[0].ValidTo != "2025-01-01T00:00:00Z" {
+			t.Errorf("ValidTo = %v, want %v", result.Items[0].ValidTo, "2025-01-01T00:00:00Z")
+		}
+	})
 }
 
 func TestCertificatesService_Revoke(t *testing.T) {
@@ -863,4 +889,58 @@ func TestCertificateResponseFormat(t *testing.T) {
 	if cert.ExpiresInDays != 128 {
 		t.Errorf("ExpiresInDays = %v, want %v", cert.ExpiresInDays, 128)
 	}
-}
\ No newline at end of file
+}
+func TestCertificatesService_Search_SyncFilters(t *testing.T) {
+	ctx := context.Background()
+	updated := time.Date(2024, 3, 1, 10, 0, 0, 500000000, time.FixedZone("CET", 3600))
+	created := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	opts := &CertificateSearchOptions{UpdatedAfter: updated, CreatedAfter: created}
+
+	t.Run("v1 query parameters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if got, want := q.Get("updated_after"), "2024-03-01T09:00:00.5Z"; got != want {
+				t.Errorf("updated_after = %v, want %v", got, want)
+			}
+			if got, want := q.Get("created_after"), "2024-02-01T00:00:00Z"; got != want {
+				t.Errorf("created_after = %v, want %v", got, want)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CertificateSearchResponse{})
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		if _, _, err := client.Certificates.Search(ctx, opts); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	})
+
+	t.Run("v2 request body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if got, want := body["updated_after"], "2024-03-01T09:00:00.5Z"; got != want {
+				t.Errorf("updated_after = %v, want %v", got, want)
+			}
+			if got, want := body["created_after"], "2024-02-01T00:00:00Z"; got != want {
+				t.Errorf("created_after = %v, want %v", got, want)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(CertificateSearchResponse{})
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithServiceAPIVersion(ServiceCertificates, APIVersionV2))
+		if _, _, err := client.Certificates.Search(ctx, opts); err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+	})
+
+	t.Run("v2 round trip", func(t *testing.T) {
+		got := newCertificateSearchV2Request(opts).options()
+		if !got.UpdatedAfter.Equal(updated) || !got.CreatedAfter.Equal(created) {
+			t.Errorf("options() = %v, %v, want %v, %v", got.UpdatedAfter, got.CreatedAfter, updated, created)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+// Package certmanager adapts the DigiCert Trust Lifecycle Manager client to the
+// signer contract used by cert-manager external issuers.
+//
+// An external issuer controller hands the adapter the PEM encoded CSR from a
+// CertificateRequest and receives the signed chain back, ready to be written to
+// the request status:
+//
+//	signer := certmanager.NewSigner(client, "profile-id")
+//	bundle, err := signer.Sign(ctx, certmanager.SignRequest{CSR: csrPEM, Duration: 90 * 24 * time.Hour})
+//	if certmanager.IsPending(err) {
+//		// requeue the CertificateRequest
+//	}
+package certmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+const (
+	DefaultPollInterval = 5 * time.Second
+	DefaultPollTimeout  = 2 * time.Minute
+)
+
+// Signer is the contract expected by cert-manager external issuer controllers.
+type Signer interface {
+	Sign(ctx context.Context, req SignRequest) (*PEMBundle, error)
+}
+
+// SignRequest carries the fields of a cert-manager CertificateRequest that are
+// relevant to issuance.
+type SignRequest struct {
+	CSR      []byte
+	Duration time.Duration
+}
+
+// PEMBundle is the signed result in the shape cert-manager stores on a
+// CertificateRequest: the leaf followed by intermediates, and the CA separately.
+type PEMBundle struct {
+	ChainPEM []byte
+	CAPEM    []byte
+}
+
+// IssuerSigner issues certificates against a single TLM profile.
+type IssuerSigner struct {
+	client       *digicert.Client
+	profileID    string
+	seatID       string
+	pollInterval time.Duration
+	pollTimeout  time.Duration
+}
+
+type SignerOption func(*IssuerSigner)
+
+// WithSeatID sets the seat used for issued certificates.
+func WithSeatID(seatID string) SignerOption {
+	return func(s *IssuerSigner) {
+		s.seatID = seatID
+	}
+}
+
+// WithPickupPolling configures how long the signer waits for certificates from
+// CAs that issue asynchronously (such as Microsoft CA).
+func WithPickupPolling(interval, timeout time.Duration) SignerOption {
+	return func(s *IssuerSigner) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+		if timeout > 0 {
+			s.pollTimeout = timeout
+		}
+	}
+}
+
+func NewSigner(client *digicert.Client, profileID string, opts ...SignerOption) *IssuerSigner {
+	s := &IssuerSigner{
+		client:       client,
+		profileID:    profileID,
+		pollInterval: DefaultPollInterval,
+		pollTimeout:  DefaultPollTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Sign submits the CSR to TLM and returns the signed chain. When the CA issues
+// asynchronously the signer polls the pickup endpoint until the certificate is
+// available or the poll timeout elapses, in which case a PendingError is returned.
+func (s *IssuerSigner) Sign(ctx context.Context, req SignRequest) (*PEMBundle, error) {
+	if len(req.CSR) == 0 {
+		return nil, &PermanentError{Reason: ReasonFailed, Err: fmt.Errorf("CSR is required")}
+	}
+
+	certReq := &digicert.CertificateRequest{
+		Profile:        digicert.ProfileReference{ID: s.profileID},
+		CSR:            string(req.CSR),
+		IncludeCAChain: true,
+	}
+	if s.seatID != "" {
+		certReq.Seat = &digicert.SeatReference{SeatID: s.seatID}
+	}
+	if req.Duration > 0 {
+		days := int(req.Duration.Hours() / 24)
+		if days < 1 {
+			days = 1
+		}
+		certReq.Validity = &digicert.Validity{Days: days}
+	}
+
+	result, _, err := s.client.Certificates.Issue(ctx, certReq)
+	if err != nil {
+		return nil, classifyError(err)
+	}
+
+	if !hasCertificate(result) {
+		if result.RequestID == "" {
+			return nil, &PermanentError{Reason: ReasonFailed, Err: fmt.Errorf("issuance returned neither a certificate nor a request ID")}
+		}
+		result, err = s.waitForPickup(ctx, result.RequestID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reason := MapStatus(result.Certificate.Status); reason == ReasonFailed || reason == ReasonDenied {
+		return nil, &PermanentError{Reason: reason, Err: fmt.Errorf("certificate status %q", result.Certificate.Status)}
+	}
+
+	return bundleFromResponse(result), nil
+}
+
+func (s *IssuerSigner) waitForPickup(parent context.Context, requestID string) (*digicert.CertificateResponse, error) {
+	ctx, cancel := context.WithTimeout(parent, s.pollTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		result, _, err := s.client.Certificates.Pickup(ctx, requestID)
+		if err != nil && ctx.Err() != nil && parent.Err() == nil {
+			return nil, &PendingError{RequestID: requestID}
+		}
+		if err != nil && !digicert.IsNotFound(err) {
+			return nil, classifyError(err)
+		}
+		if err == nil && hasCertificate(result) {
+			return result, nil
+		}
+		if err == nil && result.Certificate != nil {
+			if reason := MapStatus(result.Certificate.Status); reason == ReasonFailed || reason == ReasonDenied {
+				return nil, &PermanentError{Reason: reason, Err: fmt.Errorf("certificate status %q", result.Certificate.Status)}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := parent.Err(); err != nil {
+				return nil, err
+			}
+			return nil, &PendingError{RequestID: requestID}
+		case <-ticker.C:
+		}
+	}
+}
+
+func hasCertificate(resp *digicert.CertificateResponse) bool {
+	return resp != nil && resp.Certificate != nil && resp.Certificate.Certificate != ""
+}
+
+// bundleFromResponse orders the leaf before the intermediates and reports the
+// last element of the TLM chain as the CA, which is where cert-manager expects
+// it. The CA is never repeated in ChainPEM, whatever the chain length.
+func bundleFromResponse(resp *digicert.CertificateResponse) *PEMBundle {
+	var chain strings.Builder
+	chain.WriteString(ensureTrailingNewline(resp.Certificate.Certificate))
+
+	bundle := &PEMBundle{}
+	intermediates := resp.Chain
+	if n := len(intermediates); n > 0 {
+		bundle.CAPEM = []byte(ensureTrailingNewline(intermediates[n-1]))
+		intermediates = intermediates[:n-1]
+	}
+	for _, c := range intermediates {
+		chain.WriteString(ensureTrailingNewline(c))
+	}
+
+	bundle.ChainPEM = []byte(chain.String())
+	return bundle
+}
+
+func ensureTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
+
+// Reason is the cert-manager condition reason a TLM state maps to.
+type Reason string
+
+const (
+	ReasonIssued  Reason = "Issued"
+	ReasonPending Reason = "Pending"
+	ReasonFailed  Reason = "Failed"
+	ReasonDenied  Reason = "Denied"
+)
+
+// MapStatus converts a TLM certificate status into a cert-manager condition reason.
+func MapStatus(status string) Reason {
+	switch strings.ToLower(status) {
+	case "issued", "active", "valid":
+		return ReasonIssued
+	case "pending", "pending_issuance", "pending_approval", "processing", "":
+		return ReasonPending
+	case "rejected", "denied":
+		return ReasonDenied
+	default:
+		return ReasonFailed
+	}
+}
+
+// PendingError indicates the certificate has not been issued yet and the
+// CertificateRequest should be requeued.
+type PendingError struct {
+	RequestID string
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("certmanager: certificate request %s is still pending", e.RequestID)
+}
+
+// PermanentError indicates issuance failed in a way that retrying will not fix.
+type PermanentError struct {
+	Reason Reason
+	Err    error
+}
+
+func (e *PermanentError) Error() string {
+	return fmt.Sprintf("certmanager: %s: %v", e.Reason, e.Err)
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPending reports whether err indicates the request should be retried later.
+func IsPending(err error) bool {
+	var pending *PendingError
+	return errors.As(err, &pending)
+}
+
+// IsPermanent reports whether err indicates the request has failed for good.
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+// classifyError marks client errors (4xx other than 429) as permanent so the
+// controller does not hammer the API with requests that cannot succeed.
+func classifyError(err error) error {
+	status := digicert.StatusCode(err)
+	if status >= 400 && status < 500 && !digicert.IsRateLimited(err) {
+		reason := ReasonFailed
+		if digicert.IsForbidden(err) {
+			reason = ReasonDenied
+		}
+		return &PermanentError{Reason: reason, Err: err}
+	}
+	return err
+}
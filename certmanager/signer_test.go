@@ -0,0 +1,165 @@
+package certmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+const (
+	leafPEM         = "-----BEGIN CERTIFICATE-----\nLEAF\n-----END CERTIFICATE-----\n"
+	intermediatePEM = "-----BEGIN CERTIFICATE-----\nINTERMEDIATE\n-----END CERTIFICATE-----\n"
+	rootPEM         = "-----BEGIN CERTIFICATE-----\nROOT\n-----END CERTIFICATE-----\n"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *digicert.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := digicert.NewClient("test-key", digicert.WithBaseURL(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestIssuerSigner_Sign(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("synchronous issuance", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			var req digicert.CertificateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if req.Profile.ID != "profile-123" {
+				t.Errorf("Profile ID = %v, want %v", req.Profile.ID, "profile-123")
+			}
+			if req.Validity == nil || req.Validity.Days != 90 {
+				t.Errorf("Validity = %+v, want 90 days", req.Validity)
+			}
+
+			json.NewEncoder(w).Encode(digicert.CertificateResponse{
+				Certificate: &digicert.Certificate{Certificate: leafPEM, Status: "issued"},
+				Chain:       []string{intermediatePEM, rootPEM},
+			})
+		})
+
+		bundle, err := NewSigner(client, "profile-123").Sign(ctx, SignRequest{
+			CSR:      []byte("csr"),
+			Duration: 90 * 24 * time.Hour,
+		})
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+
+		if string(bundle.ChainPEM) != leafPEM+intermediatePEM {
+			t.Errorf("ChainPEM = %q, want leaf followed by intermediate", bundle.ChainPEM)
+		}
+		if string(bundle.CAPEM) != rootPEM {
+			t.Errorf("CAPEM = %q, want root", bundle.CAPEM)
+		}
+	})
+
+	t.Run("asynchronous issuance polls pickup", func(t *testing.T) {
+		var pickups int32
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/certificate") {
+				json.NewEncoder(w).Encode(digicert.CertificateResponse{RequestID: "req-1"})
+				return
+			}
+			if atomic.AddInt32(&pickups, 1) < 2 {
+				json.NewEncoder(w).Encode(digicert.CertificateResponse{RequestID: "req-1"})
+				return
+			}
+			json.NewEncoder(w).Encode(digicert.CertificateResponse{
+				Certificate: &digicert.Certificate{Certificate: leafPEM, Status: "issued"},
+			})
+		})
+
+		signer := NewSigner(client, "profile-123", WithPickupPolling(10*time.Millisecond, time.Second))
+		bundle, err := signer.Sign(ctx, SignRequest{CSR: []byte("csr")})
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		if string(bundle.ChainPEM) != leafPEM {
+			t.Errorf("ChainPEM = %q, want %q", bundle.ChainPEM, leafPEM)
+		}
+		if atomic.LoadInt32(&pickups) != 2 {
+			t.Errorf("pickup calls = %d, want 2", pickups)
+		}
+	})
+
+	t.Run("pickup timeout is pending", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(digicert.CertificateResponse{RequestID: "req-1"})
+		})
+
+		signer := NewSigner(client, "profile-123", WithPickupPolling(10*time.Millisecond, 30*time.Millisecond))
+		_, err := signer.Sign(ctx, SignRequest{CSR: []byte("csr")})
+		if !IsPending(err) {
+			t.Errorf("Sign() error = %v, want PendingError", err)
+		}
+	})
+
+	t.Run("bad request is permanent", func(t *testing.T) {
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(digicert.APIError{Code: "INVALID_PROFILE", Message: "bad profile"})
+		})
+
+		_, err := NewSigner(client, "profile-123").Sign(ctx, SignRequest{CSR: []byte("csr")})
+		if !IsPermanent(err) {
+			t.Errorf("Sign() error = %v, want PermanentError", err)
+		}
+	})
+}
+
+func TestBundleFromResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		chain     []string
+		wantChain string
+		wantCA    string
+	}{
+		{"no chain", nil, leafPEM, ""},
+		{"one element", []string{rootPEM}, leafPEM, rootPEM},
+		{"two elements", []string{intermediatePEM, rootPEM}, leafPEM + intermediatePEM, rootPEM},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := bundleFromResponse(&digicert.CertificateResponse{
+				Certificate: &digicert.Certificate{Certificate: leafPEM},
+				Chain:       tt.chain,
+			})
+			if string(bundle.ChainPEM) != tt.wantChain {
+				t.Errorf("ChainPEM = %q, want %q", bundle.ChainPEM, tt.wantChain)
+			}
+			if string(bundle.CAPEM) != tt.wantCA {
+				t.Errorf("CAPEM = %q, want %q", bundle.CAPEM, tt.wantCA)
+			}
+		})
+	}
+}
+
+func TestMapStatus(t *testing.T) {
+	tests := map[string]Reason{
+		"issued":           ReasonIssued,
+		"PENDING_ISSUANCE": ReasonPending,
+		"rejected":         ReasonDenied,
+		"revoked":          ReasonFailed,
+	}
+	for status, want := range tests {
+		if got := MapStatus(status); got != want {
+			t.Errorf("MapStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while the circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("digicert: circuit breaker is open")
+
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitOpenTimeout      = 30 * time.Second
+)
+
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every request with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial request through to decide whether
+	// to close the circuit again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreakerSettings struct {
+	// FailureThreshold is the number of consecutive 5xx responses or timeouts
+	// that opens the circuit. Defaults to DefaultCircuitFailureThreshold.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a trial request is
+	// allowed. Defaults to DefaultCircuitOpenTimeout.
+	OpenTimeout time.Duration
+	// OnStateChange, if set, is called whenever the circuit changes state. It
+	// runs while the breaker is locked and must not block.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen after repeated
+// server errors or timeouts, until the API has had time to recover
+func WithCircuitBreaker(settings CircuitBreakerSettings) ClientOption {
+	return func(c *Client) error {
+		if settings.FailureThreshold <= 0 {
+			settings.FailureThreshold = DefaultCircuitFailureThreshold
+		}
+		if settings.OpenTimeout <= 0 {
+			settings.OpenTimeout = DefaultCircuitOpenTimeout
+		}
+		c.breaker = &circuitBreaker{settings: settings, now: time.Now}
+		return nil
+	}
+}
+
+// CircuitState returns the current state of the circuit breaker. Clients
+// without a breaker always report CircuitClosed.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	return c.breaker.currentState()
+}
+
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	// circuitIgnored is used when the caller abandoned the request, which
+	// says nothing about the health of the API.
+	circuitIgnored
+)
+
+type circuitBreaker struct {
+	mu       sync.Mutex
+	settings CircuitBreakerSettings
+	now      func() time.Time
+
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	// trial is set while the half-open trial request is in flight.
+	trial bool
+}
+
+// currentState moves an open circuit to half-open once the open timeout has
+// elapsed. The caller must hold mu.
+func (b *circuitBreaker) currentState() CircuitState {
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.setState(CircuitHalfOpen)
+	}
+	return b.state
+}
+
+// allow reports whether a request may be sent.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request allowed by allow.
+func (b *circuitBreaker) record(outcome circuitOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasTrial := b.state == CircuitHalfOpen
+	b.trial = false
+
+	switch outcome {
+	case circuitSuccess:
+		b.failures = 0
+		if wasTrial {
+			b.setState(CircuitClosed)
+		}
+	case circuitFailure:
+		b.failures++
+		if wasTrial || b.failures >= b.settings.FailureThreshold {
+			b.openedAt = b.now()
+			b.setState(CircuitOpen)
+		}
+	}
+}
+
+func (b *circuitBreaker) setState(to CircuitState) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	if to != CircuitOpen {
+		b.failures = 0
+	}
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(from, to)
+	}
+}
+
+// classifyCircuitOutcome decides whether a round trip counts against the
+// breaker. Server errors and timeouts do; client errors do not.
+func classifyCircuitOutcome(ctx context.Context, resp *http.Response, err error) circuitOutcome {
+	if err != nil {
+		if ctx.Err() != nil {
+			return circuitIgnored
+		}
+		// Timeouts, refused connections and DNS failures all mean the API
+		// could not be reached.
+		return circuitFailure
+	}
+	if resp.StatusCode >= 500 {
+		return circuitFailure
+	}
+	return circuitSuccess
+}
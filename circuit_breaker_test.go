@@ -0,0 +1,103 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	var status int32 = http.StatusServiceUnavailable
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+
+	var transitions []string
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithCircuitBreaker(CircuitBreakerSettings{
+		FailureThreshold: 3,
+		OpenTimeout:      time.Minute,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	}))
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Profiles.Get(ctx, "p-1"); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d: circuit opened early", i)
+		}
+	}
+	if client.CircuitState() != CircuitOpen {
+		t.Fatalf("CircuitState() = %v, want %v", client.CircuitState(), CircuitOpen)
+	}
+
+	_, _, err := client.Profiles.Get(ctx, "p-1")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Get() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %v, want %v", calls, 3)
+	}
+
+	// After the open timeout a failing trial re-opens the circuit.
+	now = now.Add(time.Minute)
+	client.Profiles.Get(ctx, "p-1")
+	if client.CircuitState() != CircuitOpen {
+		t.Errorf("CircuitState() = %v, want %v", client.CircuitState(), CircuitOpen)
+	}
+
+	// A successful trial closes it again.
+	now = now.Add(time.Minute)
+	atomic.StoreInt32(&status, http.StatusOK)
+	if _, _, err := client.Profiles.Get(ctx, "p-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if client.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState() = %v, want %v", client.CircuitState(), CircuitClosed)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions[%d] = %v, want %v", i, transitions[i], want[i])
+		}
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1}))
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Profiles.Get(ctx, "missing"); !IsNotFound(err) {
+			t.Fatalf("Get() error = %v, want not found", err)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client.Profiles.Get(cancelled, "p-1")
+
+	if client.CircuitState() != CircuitClosed {
+		t.Errorf("CircuitState() = %v, want %v", client.CircuitState(), CircuitClosed)
+	}
+}
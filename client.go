@@ -10,9 +10,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -29,6 +31,32 @@ type Client struct {
 	BaseURL   *url.URL
 	UserAgent string
 	apiKey    string
+	signer    RequestSigner
+
+	apiKeyProvider APIKeyProvider
+
+	idempotencyKeys bool
+	breaker         *circuitBreaker
+	hedging         *hedging
+	retry           *RetryPolicy
+	coalesce        *flightGroup
+	cache           *DiskCache
+	logger          *slog.Logger
+	redaction       *RedactionPolicy
+	headers         http.Header
+	capabilities    *Capabilities
+	strictDecoding  bool
+	codec           Codec
+	businessUnitID  string
+	disableIDNA     bool
+
+	mu                 sync.RWMutex
+	apiVersion         string
+	serviceVersions    map[APIService]string
+	negotiatedVersions map[APIService]string
+	// noBulkGet is set once the server has shown it has no bulk certificate
+	// fetch endpoint, so GetMany stops trying it.
+	noBulkGet bool
 
 	// Services
 	Certificates      *CertificatesService
@@ -42,6 +70,10 @@ type Client struct {
 	Profiles          *ProfilesService
 	CustomFields      *CustomFieldsService
 	ACME              *ACMEService
+	Templates         *TemplatesService
+	Tags              *TagsService
+	Admins            *AdminsService
+	Invitations       *InvitationsService
 }
 
 type service struct {
@@ -51,20 +83,17 @@ type service struct {
 type ClientOption func(*Client) error
 
 func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
-	if apiKey == "" {
-		return nil, fmt.Errorf("API key is required")
-	}
-
 	baseURL, err := url.Parse(DefaultBaseURL)
 	if err != nil {
 		return nil, err
 	}
 
 	c := &Client{
-		client:    &http.Client{Timeout: 30 * time.Second},
+		client:    &http.Client{Timeout: 30 * time.Second, Transport: NewTransport()},
 		BaseURL:   baseURL,
 		UserAgent: UserAgent,
 		apiKey:    apiKey,
+		codec:     JSONCodec{},
 	}
 
 	for _, opt := range opts {
@@ -73,6 +102,10 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 		}
 	}
 
+	if c.apiKey == "" && c.apiKeyProvider == nil {
+		return nil, fmt.Errorf("API key is required")
+	}
+
 	// Initialize services
 	c.Certificates = &CertificatesService{client: c}
 	c.Orders = &OrdersService{client: c}
@@ -85,6 +118,10 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 	c.Profiles = &ProfilesService{client: c}
 	c.CustomFields = &CustomFieldsService{client: c}
 	c.ACME = &ACMEService{client: c}
+	c.Templates = &TemplatesService{client: c}
+	c.Tags = &TagsService{client: c}
+	c.Admins = &AdminsService{client: c}
+	c.Invitations = &InvitationsService{client: c}
 
 	return c, nil
 }
@@ -122,7 +159,7 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 		c.BaseURL.Path += "/"
 	}
 
-	rel, err := url.Parse(fmt.Sprintf("mpki/api/%s/%s", APIVersion, strings.TrimPrefix(urlStr, "/")))
+	rel, err := url.Parse(fmt.Sprintf("mpki/api/%s/%s", c.APIVersionFor(serviceForPath(urlStr)), strings.TrimPrefix(urlStr, "/")))
 	if err != nil {
 		return nil, err
 	}
@@ -131,12 +168,11 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 
 	var buf io.ReadWriter
 	if body != nil {
-		buf = &bytes.Buffer{}
-		enc := json.NewEncoder(buf)
-		enc.SetEscapeHTML(false)
-		if err := enc.Encode(body); err != nil {
+		data, err := c.codec.Marshal(body)
+		if err != nil {
 			return nil, err
 		}
+		buf = bytes.NewBuffer(data)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
@@ -149,34 +185,118 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-API-Key", c.apiKey)
+	c.applyHeaders(ctx, req)
+	applyConditions(ctx, req)
+
+	apiKey, err := c.resolveAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", apiKey)
 
 	return req, nil
 }
 
+// newAbsoluteRequest creates an authenticated request for a URL outside the
+// versioned API path, such as an operation status URL returned by the server.
+func (c *Client) newAbsoluteRequest(ctx context.Context, method, urlStr string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	c.applyHeaders(ctx, req)
+	applyConditions(ctx, req)
+
+	apiKey, err := c.resolveAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", apiKey)
+
+	return req, nil
+}
+
+// Do sends req and decodes a successful response into v, serving it from
+// the cache set by WithDiskCache when fresh, retrying failures as
+// configured by WithRetry and sharing identical GETs as configured by
+// WithRequestCoalescing.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+	if c.cache != nil && c.cache.covers(endpoint(req)) {
+		return c.doCached(ctx, req, v)
+	}
+	return c.doUncached(ctx, req, v)
+}
+
+func (c *Client) doUncached(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if c.coalesce != nil && coalescable(req) {
+		return c.doCoalesced(ctx, req, v)
+	}
+	return c.doWithRetry(ctx, req, v)
+}
+
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if c.retry != nil {
+		return c.retry.do(ctx, req, func(req *http.Request) (*Response, error) {
+			return c.do(ctx, req, v)
+		})
+	}
+	return c.do(ctx, req, v)
+}
+
+// do performs a single attempt of Do.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if c.signer != nil {
+		if err := c.signRequest(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.breaker != nil {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.send(req)
+	if c.breaker != nil {
+		c.breaker.record(classifyCircuitOutcome(ctx, resp, err))
+	}
 	if err != nil {
+		c.logRequest(ctx, req, nil, nil, time.Since(start), err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	response := &Response{Response: resp}
+	response.setValidators()
 
-	data, err := io.ReadAll(resp.Body)
+	data, err := readBody(resp)
+	c.logRequest(ctx, req, resp, data, time.Since(start), err)
 	if err != nil {
 		return response, err
 	}
 
 	response.Body = data
 
+	if resp.StatusCode == http.StatusAccepted {
+		response.Operation = c.newAsyncOperation(resp, data)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		return response, ErrNotModified
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		err = c.checkError(resp, data)
 		return response, err
 	}
 
 	if v != nil && len(data) > 0 {
-		if err := json.Unmarshal(data, v); err != nil {
+		if err := c.decode(data, v); err != nil {
 			return response, fmt.Errorf("failed to decode response: %w", err)
 		}
 	}
@@ -184,22 +304,83 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 	return response, nil
 }
 
+// maxPreallocatedBody caps the buffer sized from Content-Length up front, so
+// a bogus header cannot force a huge allocation.
+const maxPreallocatedBody = 256 << 20
+
+// readBody reads a response body into a buffer sized from Content-Length,
+// avoiding repeated growth and copying when reading large search pages.
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.ContentLength <= 0 || resp.ContentLength > maxPreallocatedBody {
+		return io.ReadAll(resp.Body)
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, resp.ContentLength+bytes.MinRead))
+	_, err := buf.ReadFrom(resp.Body)
+	return buf.Bytes(), err
+}
+
 func (c *Client) checkError(resp *http.Response, data []byte) error {
+	var method, u string
+	if resp.Request != nil {
+		method = resp.Request.Method
+		u = sanitizeURL(resp.Request.URL)
+	}
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	body := c.redactionPolicy().RedactJSON(data)
+	if len(body) > MaxErrorBodySize {
+		body = body[:MaxErrorBodySize]
+	}
+	requestID := resp.Header.Get("X-Request-Id")
+
 	var apiError APIError
 	if err := json.Unmarshal(data, &apiError); err != nil {
 		return &HTTPError{
 			StatusCode: resp.StatusCode,
 			Message:    string(data),
+			Method:     method,
+			URL:        u,
+			RequestID:  requestID,
+			Header:     header,
+			RetryAfter: retryAfter(resp),
+			Body:       body,
 		}
 	}
 
 	apiError.StatusCode = resp.StatusCode
+	if apiError.RequestID == "" {
+		apiError.RequestID = requestID
+	}
+	apiError.Method = method
+	apiError.URL = u
+	apiError.Header = header
+	apiError.RetryAfter = retryAfter(resp)
+	apiError.Body = body
+	apiError.Language = resp.Header.Get("Content-Language")
 	return &apiError
 }
 
 type Response struct {
 	*http.Response
 	Body []byte
+
+	// Operation is set when the server accepted the request for asynchronous
+	// processing and returned a status URL to poll.
+	Operation *AsyncOperation
+
+	// FromCache is set when the response was served by WithDiskCache
+	// without contacting the API.
+	FromCache bool
+
+	// Coalesced is set when WithRequestCoalescing shared the response
+	// between identical requests in flight at the same time.
+	Coalesced bool
+
+	// ETag and LastModified are the validators the server returned, if any.
+	// Pass them to ContextWithIfNoneMatch or ContextWithIfModifiedSince to
+	// fetch the resource again only if it changed.
+	ETag         string
+	LastModified time.Time
 }
 
 type PaginationParams struct {
@@ -212,3 +393,12 @@ type ListResponse struct {
 	Offset int `json:"offset"`
 	Limit  int `json:"limit"`
 }
+
+// String returns a pointer to v, for use in optional request fields.
+func String(v string) *string { return &v }
+
+// Bool returns a pointer to v, for use in optional request fields.
+func Bool(v bool) *bool { return &v }
+
+// Int returns a pointer to v, for use in optional request fields.
+func Int(v int) *int { return &v }
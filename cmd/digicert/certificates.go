@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+func runSearch(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "search")
+	opts := &digicert.CertificateSearchOptions{}
+	fs.StringVar(&opts.CommonName, "cn", "", "common name")
+	fs.StringVar(&opts.SerialNumber, "serial", "", "serial number")
+	fs.StringVar(&opts.Status, "status", "", "certificate status")
+	fs.StringVar(&opts.ProfileID, "profile", "", "profile ID")
+	tags := fs.String("tags", "", "comma separated tags")
+	dns := fs.String("dns", "", "only certificates covering this host name, including wildcards (all pages)")
+	fs.IntVar(&opts.Limit, "limit", 50, "maximum number of results")
+	fs.IntVar(&opts.Offset, "offset", 0, "result offset")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts.Tags = splitList(*tags)
+
+	var result *digicert.CertificateSearchResponse
+	if *dns != "" {
+		opts.Offset = 0
+		certs, err := e.client.Certificates.SearchByDNSName(ctx, *dns, opts)
+		if err != nil {
+			return err
+		}
+		result = &digicert.CertificateSearchResponse{ListResponse: digicert.ListResponse{Total: len(certs)}, Items: certs}
+	} else {
+		var err error
+		result, _, err = e.client.Certificates.Search(ctx, opts)
+		if err != nil {
+			return err
+		}
+	}
+	if e.json {
+		return e.printJSON(result)
+	}
+
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSERIAL\tCOMMON NAME\tSTATUS\tVALID TO")
+	for _, c := range result.Items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.ID, c.SerialNumber, c.CommonName, c.Status, c.ValidTo)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "%d of %d certificates\n", len(result.Items), result.Total)
+	return nil
+}
+
+func runIssue(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "issue")
+	profile := fs.String("profile", "", "profile ID (required)")
+	seat := fs.String("seat", "", "seat ID")
+	csrFile := fs.String("csr", "", "path to a PEM encoded CSR (required)")
+	cn := fs.String("cn", "", "common name")
+	dns := fs.String("dns", "", "comma separated DNS subject alternative names")
+	days := fs.Int("days", 0, "validity in days (profile default if unset)")
+	tags := fs.String("tags", "", "comma separated tags")
+	out := fs.String("out", "", "write the certificate chain to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := required(fs, "profile", "csr"); err != nil {
+		return err
+	}
+
+	csr, err := os.ReadFile(*csrFile)
+	if err != nil {
+		return err
+	}
+
+	req := &digicert.CertificateRequest{
+		Profile:        digicert.ProfileReference{ID: *profile},
+		CSR:            string(csr),
+		IncludeCAChain: true,
+		Tags:           splitList(*tags),
+	}
+	if *seat != "" {
+		req.Seat = &digicert.SeatReference{SeatID: *seat}
+	}
+	if *days > 0 {
+		req.Validity = &digicert.Validity{Days: *days}
+	}
+	if *cn != "" || *dns != "" {
+		req.Attributes = &digicert.CertificateAttributes{CommonName: *cn}
+		if names := splitList(*dns); len(names) > 0 {
+			req.Attributes.SANs = &digicert.SubjectAltNames{DNSNames: names}
+		}
+	}
+
+	result, _, err := e.client.Certificates.Issue(ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.writeIssued(result, *out)
+}
+
+func runRenew(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "renew")
+	serial := fs.String("serial", "", "serial number of the certificate to renew (required)")
+	csrFile := fs.String("csr", "", "path to a PEM encoded CSR for the renewed certificate")
+	days := fs.Int("days", 0, "validity in days (profile default if unset)")
+	out := fs.String("out", "", "write the certificate chain to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := required(fs, "serial"); err != nil {
+		return err
+	}
+
+	req := &digicert.RenewRequest{IncludeCAChain: true}
+	if *csrFile != "" {
+		csr, err := os.ReadFile(*csrFile)
+		if err != nil {
+			return err
+		}
+		req.CSR = string(csr)
+	}
+	if *days > 0 {
+		req.Validity = &digicert.Validity{Days: *days}
+	}
+
+	result, _, err := e.client.Certificates.Renew(ctx, *serial, req)
+	if err != nil {
+		return err
+	}
+	return e.writeIssued(result, *out)
+}
+
+func runRevoke(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "revoke")
+	serial := fs.String("serial", "", "serial number of the certificate to revoke (required unless -id is set)")
+	id := fs.String("id", "", "certificate ID of the certificate to revoke, instead of -serial")
+	reason := fs.String("reason", "unspecified", "revocation reason; certificate_hold can later be lifted with -release")
+	comment := fs.String("comment", "", "revocation comment, or the justification for -release")
+	at := fs.String("at", "", "schedule the revocation for this RFC 3339 time instead of revoking now")
+	release := fs.Bool("release", false, "lift a certificate hold instead of revoking")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		if err := required(fs, "serial"); err != nil {
+			return err
+		}
+	}
+
+	target := *serial
+	if *id != "" {
+		target = *id
+	}
+	if *release {
+		var err error
+		if *id != "" {
+			var req *digicert.UnrevokeRequest
+			if *comment != "" {
+				req = &digicert.UnrevokeRequest{Comment: *comment}
+			}
+			_, err = e.client.Certificates.UnrevokeByID(ctx, *id, req)
+		} else {
+			_, err = e.client.Certificates.Release(ctx, *serial, *comment)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stderr, "released %s\n", target)
+		return nil
+	}
+
+	req := &digicert.RevokeRequest{Reason: *reason, Comment: *comment}
+	if *at != "" {
+		effective, err := time.Parse(time.RFC3339, *at)
+		if err != nil {
+			return fmt.Errorf("invalid -at: %w", err)
+		}
+		req.EffectiveDate = &effective
+	}
+	var err error
+	if *id != "" {
+		_, err = e.client.Certificates.RevokeByID(ctx, *id, req)
+	} else {
+		_, err = e.client.Certificates.Revoke(ctx, *serial, req)
+	}
+	if err != nil {
+		return err
+	}
+	if req.EffectiveDate != nil {
+		fmt.Fprintf(e.stderr, "scheduled revocation of %s at %s\n", target, req.EffectiveDate.Format(time.RFC3339))
+		return nil
+	}
+	fmt.Fprintf(e.stderr, "revoked %s\n", target)
+	return nil
+}
+
+// writeIssued prints the outcome of an issue or renew call. Certificates that
+// are still pending approval only have a request ID to report.
+func (e *env) writeIssued(result *digicert.CertificateResponse, out string) error {
+	if e.json {
+		return e.printJSON(result)
+	}
+	if result.Certificate == nil || result.Certificate.Certificate == "" {
+		fmt.Fprintf(e.stdout, "request %s is pending; collect it later with the pickup API\n", result.RequestID)
+		return nil
+	}
+
+	pem := strings.TrimSpace(result.Certificate.Certificate) + "\n"
+	for _, c := range result.Chain {
+		pem += strings.TrimSpace(c) + "\n"
+	}
+	if out == "" {
+		_, err := fmt.Fprint(e.stdout, pem)
+		return err
+	}
+	if err := os.WriteFile(out, []byte(pem), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "wrote certificate %s to %s\n", result.Certificate.SerialNumber, out)
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+var inventoryHeader = []string{
+	"id", "serial_number", "common_name", "status", "profile_id",
+	"valid_from", "valid_to", "issuing_ca_name", "key_size", "signature_algorithm",
+}
+
+func runExport(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "export")
+	opts := &digicert.CertificateSearchOptions{}
+	fs.StringVar(&opts.Status, "status", "", "only export certificates with this status")
+	fs.StringVar(&opts.ProfileID, "profile", "", "only export certificates issued from this profile")
+	out := fs.String("out", "", "write the CSV to this file instead of stdout")
+	pageSize := fs.Int("page-size", 100, "certificates requested per API call")
+	fs.IntVar(&opts.Prefetch, "parallel", 1, "pages fetched concurrently; rows stay in order")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var w io.Writer = e.stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(inventoryHeader); err != nil {
+		return err
+	}
+
+	count := 0
+	opts.Limit = *pageSize
+	for c, err := range e.client.Certificates.All(ctx, opts) {
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(inventoryRow(c)); err != nil {
+			return err
+		}
+		count++
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	if *out != "" {
+		fmt.Fprintf(e.stderr, "exported %d certificates to %s\n", count, *out)
+	}
+	return nil
+}
+
+func inventoryRow(c digicert.Certificate) []string {
+	return []string{
+		c.ID,
+		c.SerialNumber,
+		c.CommonName,
+		c.Status,
+		c.Profile.ID,
+		c.ValidFrom,
+		c.ValidTo,
+		c.IssuingCAName,
+		strings.TrimSpace(string(c.KeySize)),
+		c.SignatureAlgorithm,
+	}
+}
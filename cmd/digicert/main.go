@@ -0,0 +1,181 @@
+// Command digicert is a command-line client for DigiCert Trust Lifecycle
+// Manager built on the go-digicert-tlm library.
+//
+// Usage:
+//
+//	digicert [global flags] <command> [command flags]
+//
+// The API key is read from -api-key or the DIGICERT_API_KEY environment
+// variable. Run "digicert help" for the list of commands.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+// env carries the state shared by every command.
+type env struct {
+	client *digicert.Client
+	stdout io.Writer
+	stderr io.Writer
+	json   bool
+}
+
+// printJSON writes v to stdout as indented JSON.
+func (e *env) printJSON(v interface{}) error {
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type command struct {
+	summary string
+	run     func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = map[string]command{
+	"search":         {"Search certificates", runSearch},
+	"issue":          {"Issue a certificate from a CSR", runIssue},
+	"renew":          {"Renew a certificate", runRenew},
+	"revoke":         {"Revoke a certificate", runRevoke},
+	"profiles":       {"List certificate profiles", runProfiles},
+	"business-units": {"List business units", runBusinessUnits},
+	"export":         {"Export the certificate inventory as CSV", runExport},
+	"approve":        {"Approve a pending enrollment", runApprove},
+	"reject":         {"Reject a pending enrollment", runReject},
+	"sync":           {"Converge certificates on a desired inventory", runSync},
+}
+
+// errUsage signals that usage has already been printed.
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("digicert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	apiKey := fs.String("api-key", "", "API key (default $DIGICERT_API_KEY)")
+	baseURL := fs.String("base-url", envOr("DIGICERT_BASE_URL", digicert.DefaultBaseURL), "API base URL (default $DIGICERT_BASE_URL)")
+	jsonOut := fs.Bool("json", false, "print results as JSON")
+	cacheDir := fs.String("cache-dir", os.Getenv("DIGICERT_CACHE_DIR"), "cache profiles and templates in this directory (default $DIGICERT_CACHE_DIR)")
+	cacheTTL := fs.Duration("cache-ttl", time.Hour, "how long cached profiles and templates stay fresh")
+	fs.Usage = func() { usage(stderr, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	// The key is read from the environment after parsing so it never
+	// appears as a flag default in usage output.
+	if *apiKey == "" {
+		*apiKey = os.Getenv("DIGICERT_API_KEY")
+	}
+	if fs.NArg() == 0 || fs.Arg(0) == "help" {
+		usage(stderr, fs)
+		return 2
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "digicert: unknown command %q\n", fs.Arg(0))
+		usage(stderr, fs)
+		return 2
+	}
+
+	opts := []digicert.ClientOption{
+		digicert.WithBaseURL(strings.TrimSuffix(*baseURL, "/") + "/"),
+		digicert.WithUserAgent("digicert-cli/1.0"),
+	}
+	if *cacheDir != "" {
+		opts = append(opts, digicert.WithDiskCache(digicert.NewDiskCache(*cacheDir, *cacheTTL)))
+	}
+	client, err := digicert.NewClient(*apiKey, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "digicert: %v\n", err)
+		return 1
+	}
+
+	e := &env{client: client, stdout: stdout, stderr: stderr, json: *jsonOut}
+	if err := cmd.run(ctx, e, fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintf(stderr, "digicert %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: digicert [global flags] <command> [command flags]")
+	fmt.Fprintln(w, "\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].summary)
+	}
+
+	fmt.Fprintln(w, "\nGlobal flags:")
+	fs.PrintDefaults()
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors to stderr.
+func newFlagSet(e *env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("digicert "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// required returns errUsage after printing which flags are missing.
+func required(fs *flag.FlagSet, names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if f := fs.Lookup(name); f != nil && f.Value.String() == "" {
+			missing = append(missing, "-"+name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	fmt.Fprintf(fs.Output(), "missing required flag(s): %s\n", strings.Join(missing, ", "))
+	fs.Usage()
+	return errUsage
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// splitList splits a comma separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+func runCLI(t *testing.T, handler http.HandlerFunc, args ...string) (int, string, string) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-api-key", "test-key", "-base-url", server.URL}, args...)
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	t.Setenv("DIGICERT_API_KEY", "secret-key")
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), nil, &stdout, &stderr); code != 2 {
+		t.Errorf("run() = %v, want %v", code, 2)
+	}
+	if !strings.Contains(stderr.String(), "business-units") {
+		t.Errorf("usage = %q, want command list", stderr.String())
+	}
+	if strings.Contains(stderr.String(), "secret-key") {
+		t.Errorf("usage = %q, leaks $DIGICERT_API_KEY", stderr.String())
+	}
+
+	stderr.Reset()
+	if code := run(context.Background(), []string{"-api-key", "k", "bogus"}, &stdout, &stderr); code != 2 {
+		t.Errorf("run(bogus) = %v, want %v", code, 2)
+	}
+}
+
+func TestRun_Search(t *testing.T) {
+	code, stdout, _ := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/certificate-search" {
+			t.Errorf("Expected path /mpki/api/v1/certificate-search, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("common_name") != "example.com" {
+			t.Errorf("common_name = %v, want %v", r.URL.Query().Get("common_name"), "example.com")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(digicert.CertificateSearchResponse{
+			ListResponse: digicert.ListResponse{Total: 1},
+			Items:        []digicert.Certificate{{ID: "cert-1", SerialNumber: "0A1B", CommonName: "example.com", Status: "issued"}},
+		})
+	}, "search", "-cn", "example.com")
+
+	if code != 0 {
+		t.Fatalf("run() = %v, want 0", code)
+	}
+	if !strings.Contains(stdout, "0A1B") || !strings.Contains(stdout, "COMMON NAME") {
+		t.Errorf("stdout = %q, want table with serial 0A1B", stdout)
+	}
+}
+
+func TestRun_Export(t *testing.T) {
+	code, stdout, _ := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		items := []digicert.Certificate{{ID: "cert-1", SerialNumber: "01"}}
+		if offset == "1" {
+			items = []digicert.Certificate{{ID: "cert-2", SerialNumber: "02"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(digicert.CertificateSearchResponse{
+			ListResponse: digicert.ListResponse{Total: 2},
+			Items:        items,
+		})
+	}, "export", "-page-size", "1")
+
+	if code != 0 {
+		t.Fatalf("run() = %v, want 0", code)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "id,serial_number") || !strings.HasPrefix(lines[2], "cert-2,02") {
+		t.Errorf("export = %q, want header and two rows", stdout)
+	}
+}
+
+func TestRun_Approve(t *testing.T) {
+	code, stdout, _ := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/manual-enrollment/enr-1/approve" {
+			t.Errorf("Expected path /mpki/api/v1/manual-enrollment/enr-1/approve, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(digicert.EnrollmentResponse{EnrollmentID: "enr-1", Status: digicert.EnrollmentStatusCompleted})
+	}, "approve", "-id", "enr-1")
+
+	if code != 0 {
+		t.Fatalf("run() = %v, want 0", code)
+	}
+	if !strings.Contains(stdout, "completed") {
+		t.Errorf("stdout = %q, want completed status", stdout)
+	}
+}
+
+func TestRun_MissingRequiredFlag(t *testing.T) {
+	code, _, stderr := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected API call")
+	}, "revoke")
+
+	if code != 2 {
+		t.Errorf("run() = %v, want %v", code, 2)
+	}
+	if !strings.Contains(stderr, "-serial") {
+		t.Errorf("stderr = %q, want missing -serial", stderr)
+	}
+}
+
+func TestRun_APIError(t *testing.T) {
+	code, _, stderr := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"errors": []map[string]string{{"code": "not_found", "message": "no such certificate"}}})
+	}, "revoke", "-serial", "FF")
+
+	if code != 1 {
+		t.Errorf("run() = %v, want %v", code, 1)
+	}
+	if !strings.HasPrefix(stderr, "digicert revoke:") {
+		t.Errorf("stderr = %q, want prefixed error", stderr)
+	}
+}
+
+func TestRun_SyncDryRun(t *testing.T) {
+	inventory := filepath.Join(t.TempDir(), "inventory.json")
+	os.WriteFile(inventory, []byte(`[{"common_name": "new.example.com", "profile_id": "p1"}]`), 0o600)
+
+	code, stdout, _ := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/certificate-search" {
+			t.Errorf("unexpected request %s %s in dry run", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(digicert.CertificateSearchResponse{
+			ListResponse: digicert.ListResponse{Total: 1},
+			Items:        []digicert.Certificate{{SerialNumber: "0A", CommonName: "old.example.com", Status: "issued"}},
+		})
+	}, "sync", "-f", inventory, "-prune", "-dry-run")
+
+	if code != 0 {
+		t.Fatalf("run() = %v, want 0", code)
+	}
+	want := "+ issue new.example.com: missing\n- revoke old.example.com (0A): no longer desired\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestRun_RevokeScheduledByID(t *testing.T) {
+	var got digicert.RevokeRequest
+	code, _, stderr := runCLI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/mpki/api/v1/certificate-by-id/cert-1/revoke" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}, "revoke", "-id", "cert-1", "-reason", "superseded", "-at", "2026-10-17T22:00:00Z")
+
+	if code != 0 {
+		t.Fatalf("run() = %v, want 0 (stderr %q)", code, stderr)
+	}
+	if got.Reason != "superseded" || got.EffectiveDate == nil || got.EffectiveDate.Hour() != 22 {
+		t.Errorf("request = %+v, want superseded effective at 22:00", got)
+	}
+	if !strings.Contains(stderr, "scheduled revocation of cert-1") {
+		t.Errorf("stderr = %q, want scheduled message", stderr)
+	}
+}
+
+func TestRun_ProfilesCached(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total": 1, "profiles": [{"id": "p1", "name": "Web"}]}`))
+	}))
+	defer server.Close()
+
+	args := []string{"-api-key", "test-key", "-base-url", server.URL, "-cache-dir", t.TempDir(), "profiles"}
+	for range 2 {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != 0 {
+			t.Fatalf("run() = %v, stderr %q", code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "p1") {
+			t.Errorf("stdout = %q, want profile p1", stdout.String())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("upstream calls = %v, want 1", calls)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+func runProfiles(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "profiles")
+	opts := &digicert.ProfileListOptions{}
+	fs.StringVar(&opts.Name, "name", "", "profile name")
+	fs.StringVar(&opts.Status, "status", "", "profile status")
+	fs.StringVar(&opts.EnrollmentMethod, "enrollment-method", "", "enrollment method")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, _, err := e.client.Profiles.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if e.json {
+		return e.printJSON(result)
+	}
+
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tTYPE\tSTATUS\tENROLLMENT")
+	for _, p := range result.Profiles {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", p.ID, p.Name, p.Type, p.Status, p.EnrollmentMethod)
+	}
+	return tw.Flush()
+}
+
+func runBusinessUnits(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "business-units")
+	opts := &digicert.BusinessUnitListOptions{}
+	fs.StringVar(&opts.Name, "name", "", "business unit name")
+	fs.StringVar(&opts.ParentID, "parent", "", "parent business unit ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, _, err := e.client.BusinessUnits.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if e.json {
+		return e.printJSON(result)
+	}
+
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPARENT\tSEATS USED/LICENSED")
+	for _, bu := range result.BusinessUnits {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d/%d\n", bu.ID, bu.Name, bu.ParentID, bu.UsedSeats, bu.LicensedSeats)
+	}
+	return tw.Flush()
+}
+
+func runApprove(ctx context.Context, e *env, args []string) error {
+	return decideEnrollment(ctx, e, "approve", args, e.client.Enrollments.Approve)
+}
+
+func runReject(ctx context.Context, e *env, args []string) error {
+	return decideEnrollment(ctx, e, "reject", args, e.client.Enrollments.Reject)
+}
+
+type enrollmentDecision func(ctx context.Context, enrollmentID string, req *digicert.EnrollmentApprovalRequest) (*digicert.EnrollmentResponse, *digicert.Response, error)
+
+func decideEnrollment(ctx context.Context, e *env, name string, args []string, decide enrollmentDecision) error {
+	fs := newFlagSet(e, name)
+	id := fs.String("id", "", "enrollment ID (required)")
+	comment := fs.String("comment", "", "comment recorded with the decision")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+
+	result, _, err := decide(ctx, *id, &digicert.EnrollmentApprovalRequest{Comment: *comment})
+	if err != nil {
+		return err
+	}
+	if e.json {
+		return e.printJSON(result)
+	}
+	fmt.Fprintf(e.stdout, "enrollment %s: %s\n", *id, result.Status)
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+func runSync(ctx context.Context, e *env, args []string) error {
+	fs := newFlagSet(e, "sync")
+	file := fs.String("f", "", "JSON file listing the desired certificates (required)")
+	tag := fs.String("tag", "managed-by-digicert-sync", "tag marking the certificates sync owns")
+	renewDays := fs.Int("renew-days", 30, "renew certificates expiring within this many days")
+	prune := fs.Bool("prune", false, "revoke managed certificates that are no longer desired")
+	dryRun := fs.Bool("dry-run", false, "print the plan without changing anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := required(fs, "f"); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var desired []digicert.DesiredCertificate
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+
+	report, err := e.client.Certificates.Reconcile(ctx, desired, &digicert.ReconcileOptions{
+		ManagedTag:  *tag,
+		RenewBefore: time.Duration(*renewDays) * 24 * time.Hour,
+		Prune:       *prune,
+		DryRun:      *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if e.json {
+		if err := e.printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(e.stdout, report.Diff())
+	}
+	fmt.Fprintf(e.stderr, "%d changes, %d unchanged\n", len(report.Actions), len(report.Unchanged))
+	return report.Err()
+}
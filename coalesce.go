@@ -0,0 +1,122 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WithRequestCoalescing shares one upstream call between identical GET
+// requests that are in flight at the same time, so a burst of callers
+// asking for the same profile or certificate costs a single request.
+// Requests are identical when their URL and headers match, which keeps
+// callers using different API keys or context headers apart.
+//
+// The shared call runs detached from any one caller's context: a caller
+// whose context ends stops waiting and gets its context error, while the
+// others still get the response. Every caller decodes the response into
+// its own value, but the Response, including Body, is shared and must not
+// be modified.
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) error {
+		c.coalesce = &flightGroup{}
+		return nil
+	}
+}
+
+// flightGroup is a minimal singleflight keyed on the request.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	waiters int
+	resp    *Response
+	err     error
+}
+
+// do runs fn once for all callers of key that arrive while it is running,
+// and waits for its result or the end of ctx. shared reports whether more
+// than one caller waited on the call.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*Response, error)) (resp *Response, err error, shared bool) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		if g.calls == nil {
+			g.calls = make(map[string]*flightCall)
+		}
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go func() {
+			call.resp, call.err = fn()
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		// The call left the group before done was closed, so waiters no
+		// longer changes.
+		return call.resp, call.err, call.waiters > 1
+	case <-ctx.Done():
+		return nil, ctx.Err(), false
+	}
+}
+
+// coalescable reports whether req may share a call with identical requests.
+func coalescable(req *http.Request) bool {
+	return req.Method == http.MethodGet && (req.Body == nil || req.Body == http.NoBody)
+}
+
+// coalesceKey identifies req by its URL and headers.
+func coalesceKey(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(req.URL.String())
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteByte('\n')
+		b.WriteString(name)
+		for _, value := range req.Header[name] {
+			b.WriteByte('\x00')
+			b.WriteString(value)
+		}
+	}
+	return b.String()
+}
+
+// doCoalesced performs req through the flight group and decodes the shared
+// response into v.
+func (c *Client) doCoalesced(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	resp, err, shared := c.coalesce.do(ctx, coalesceKey(req), func() (*Response, error) {
+		detached := context.WithoutCancel(ctx)
+		return c.doWithRetry(detached, req.Clone(context.WithoutCancel(req.Context())), nil)
+	})
+	if resp == nil {
+		return nil, err
+	}
+	response := *resp
+	response.Coalesced = shared
+	if err != nil {
+		return &response, err
+	}
+	if v != nil && len(response.Body) > 0 {
+		if err := c.decode(response.Body, v); err != nil {
+			return &response, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return &response, nil
+}
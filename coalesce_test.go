@@ -0,0 +1,159 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RequestCoalescing(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`{"id": "p1", "name": "Web"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithRequestCoalescing())
+	ctx := context.Background()
+
+	const callers = 10
+	var wg sync.WaitGroup
+	profiles := make([]*Profile, callers)
+	resps := make([]*Response, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profiles[i], resps[i], errs[i] = client.Profiles.Get(ctx, "p1")
+		}()
+	}
+	for client.coalesceWaiters() < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %v, want 1", got)
+	}
+	for i := range callers {
+		if errs[i] != nil {
+			t.Fatalf("Get() error = %v", errs[i])
+		}
+		if profiles[i].Name != "Web" {
+			t.Errorf("Name = %v, want Web", profiles[i].Name)
+		}
+		if !resps[i].Coalesced {
+			t.Error("Coalesced = false, want true for a shared response")
+		}
+	}
+	if profiles[0] == profiles[1] {
+		t.Error("callers share a decoded value, want one each")
+	}
+
+	// Once the flight has landed, the next request goes upstream again.
+	_, resp, err := client.Profiles.Get(ctx, "p1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp.Coalesced {
+		t.Error("Coalesced = true, want false for a lone request")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("upstream calls = %v, want 2", got)
+	}
+}
+
+func TestClient_RequestCoalescingKeys(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithRequestCoalescing())
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	send := func(ctx context.Context, method, path string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest(ctx, method, path, nil)
+			client.Do(ctx, req, nil)
+		}()
+	}
+	send(ctx, http.MethodGet, "profile/p1")
+	send(ctx, http.MethodGet, "profile/p2")
+	send(ContextWithHeader(ctx, "X-Tenant-ID", "b"), http.MethodGet, "profile/p1")
+	send(ctx, http.MethodPost, "profile/p1")
+	for calls.Load() < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 4 {
+		t.Errorf("upstream calls = %v, want 4", got)
+	}
+}
+
+func TestClient_RequestCoalescingCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"id": "p1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithRequestCoalescing())
+
+	// The first caller gives up, but the call it started still serves the
+	// second.
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, _, err := client.Profiles.Get(first, "p1")
+		firstErr <- err
+	}()
+	for client.coalesceWaiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan error, 1)
+	go func() {
+		_, _, err := client.Profiles.Get(context.Background(), "p1")
+		second <- err
+	}()
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first Get() error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("second Get() error = %v", err)
+	}
+}
+
+// coalesceWaiters returns the number of callers sharing calls in flight.
+func (c *Client) coalesceWaiters() int {
+	c.coalesce.mu.Lock()
+	defer c.coalesce.mu.Unlock()
+	n := 0
+	for _, call := range c.coalesce.calls {
+		n += call.waiters
+	}
+	return n
+}
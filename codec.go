@@ -0,0 +1,51 @@
+package digicert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Codec encodes request bodies and decodes response bodies. The default,
+// JSONCodec, uses encoding/json; callers handling very large responses,
+// such as full inventory searches, can plug in a faster implementation
+// like jsoniter or sonic with WithCodec.
+//
+// Types that keep unmodelled fields in Extra, such as Certificate and
+// Profile, implement json.Unmarshaler and so still decode themselves with
+// encoding/json whatever the codec.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec is the default Codec, backed by encoding/json. It does not
+// escape HTML characters when encoding.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// WithCodec replaces the codec used for request and response bodies.
+// WithStrictDecoding takes precedence for responses, as it relies on
+// encoding/json to reject unknown fields.
+func WithCodec(codec Codec) ClientOption {
+	return func(c *Client) error {
+		if codec == nil {
+			return fmt.Errorf("codec cannot be nil")
+		}
+		c.codec = codec
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type countingCodec struct {
+	JSONCodec
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return c.JSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return c.JSONCodec.Unmarshal(data, v)
+}
+
+func TestWithCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total": 1, "items": [{"id": "1", "common_name": "a.example.com"}]}`))
+	}))
+	defer server.Close()
+
+	codec := &countingCodec{}
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithCodec(codec))
+	result, _, err := client.Certificates.Search(context.Background(), &CertificateSearchOptions{CommonName: "a.example.com"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].CommonName != "a.example.com" {
+		t.Errorf("Items = %+v, want one certificate", result.Items)
+	}
+	if codec.unmarshals != 1 {
+		t.Errorf("codec unmarshals = %v, want 1", codec.unmarshals)
+	}
+
+	if _, err := client.Certificates.Revoke(context.Background(), "0A", &RevokeRequest{Reason: "superseded"}); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if codec.marshals != 1 {
+		t.Errorf("codec marshals = %v, want 1", codec.marshals)
+	}
+
+	if _, err := NewClient("test-key", WithCodec(nil)); err == nil {
+		t.Error("Expected error for nil codec")
+	}
+}
+
+func TestJSONCodec_NoHTMLEscaping(t *testing.T) {
+	data, err := JSONCodec{}.Marshal(map[string]string{"comment": "<a&b>"})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), "<a&b>") {
+		t.Errorf("Marshal() = %s, want HTML characters unescaped", data)
+	}
+}
+
+// searchPayload returns a search response of n certificates, roughly 1KB
+// each.
+func searchPayload(n int) []byte {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"id": "cert-%d", "common_name": "host%d.example.com", "serial_number": "%040X", "status": "issued",
+			"thumbprint": "%064X", "valid_from": "2026-01-01T00:00:00Z", "valid_to": "2027-01-01T00:00:00Z",
+			"key_size": "2048", "signature_algorithm": "sha256WithRSAEncryption", "tags": ["prod", "web"],
+			"business_unit": {"id": "bu-1", "name": "Platform"}, "profile": {"id": "profile-1", "name": "TLS"},
+			"sans": {"dns_names": ["host%d.example.com", "www.host%d.example.com"]}}`, i, i, i, i, i, i)
+	}
+	return []byte(fmt.Sprintf(`{"total": %d, "offset": 0, "limit": %d, "items": [%s]}`, n, n, strings.Join(items, ",")))
+}
+
+// BenchmarkCodec_SearchResponse decodes a 10,000 certificate search export
+// through each codec. The module has no third-party dependencies, so only
+// the encoding/json baseline is included; add a case for jsoniter or sonic
+// locally to measure it before adopting it with WithCodec.
+func BenchmarkCodec_SearchResponse(b *testing.B) {
+	payload := searchPayload(10000)
+	codecs := []struct {
+		name  string
+		codec Codec
+	}{
+		{"encoding/json", JSONCodec{}},
+	}
+	for _, bc := range codecs {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var resp CertificateSearchResponse
+				if err := bc.codec.Unmarshal(payload, &resp); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
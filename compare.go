@@ -0,0 +1,172 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// InventorySource is one side of an inventory comparison.
+type InventorySource struct {
+	Client *Client
+	// Search filters the certificates compared. BusinessUnitID defaults to
+	// the client's business unit scope, so two business units of one account
+	// can be compared with a single client.
+	Search CertificateSearchOptions
+}
+
+// Fields compared by CompareInventories, named after their JSON fields.
+const (
+	CompareFieldCommonName         = "common_name"
+	CompareFieldStatus             = "status"
+	CompareFieldProfileID          = "profile_id"
+	CompareFieldValidFrom          = "valid_from"
+	CompareFieldValidTo            = "valid_to"
+	CompareFieldKeySize            = "key_size"
+	CompareFieldSignatureAlgorithm = "signature_algorithm"
+	CompareFieldIssuingCAName      = "issuing_ca_name"
+)
+
+var compareFields = map[string]func(Certificate) string{
+	CompareFieldCommonName:         func(c Certificate) string { return c.CommonName },
+	CompareFieldStatus:             func(c Certificate) string { return c.Status },
+	CompareFieldProfileID:          func(c Certificate) string { return c.Profile.ID },
+	CompareFieldValidFrom:          func(c Certificate) string { return c.ValidFrom },
+	CompareFieldValidTo:            func(c Certificate) string { return c.ValidTo },
+	CompareFieldKeySize:            func(c Certificate) string { return strings.TrimSpace(string(c.KeySize)) },
+	CompareFieldSignatureAlgorithm: func(c Certificate) string { return c.SignatureAlgorithm },
+	CompareFieldIssuingCAName:      func(c Certificate) string { return c.IssuingCAName },
+}
+
+type CompareOptions struct {
+	// Key identifies the same certificate in both inventories. Defaults to
+	// CertificateIdentity.
+	Key func(Certificate) string
+	// IgnoreFields lists the CompareField* values not to compare, e.g.
+	// CompareFieldProfileID when profiles were recreated in the target
+	// account.
+	IgnoreFields []string
+}
+
+type AttributeDifference struct {
+	Field string `json:"field"`
+	A     string `json:"a"`
+	B     string `json:"b"`
+}
+
+type CertificateDifference struct {
+	Key         string                `json:"key"`
+	A           Certificate           `json:"a"`
+	B           Certificate           `json:"b"`
+	Differences []AttributeDifference `json:"differences"`
+}
+
+// InventoryDiff is the result of CompareInventories. Each list is sorted by
+// key.
+type InventoryDiff struct {
+	OnlyInA   []Certificate           `json:"only_in_a"`
+	OnlyInB   []Certificate           `json:"only_in_b"`
+	Differing []CertificateDifference `json:"differing"`
+	// Matching counts the certificates present and identical in both.
+	Matching int `json:"matching"`
+}
+
+// Empty reports whether the inventories matched.
+func (d *InventoryDiff) Empty() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Differing) == 0
+}
+
+// CertificateIdentity keys a certificate by its thumbprint, or by serial
+// number when the thumbprint is not returned. Both survive an import into
+// another account, unlike the certificate ID.
+func CertificateIdentity(c Certificate) string {
+	if c.Thumbprint != "" {
+		return "sha:" + NormalizeThumbprint(c.Thumbprint)
+	}
+	return "serial:" + strings.ToUpper(strings.ReplaceAll(c.SerialNumber, ":", ""))
+}
+
+// CompareInventories fetches the certificate inventories of a and b
+// concurrently and reports the certificates found in only one of them and
+// those whose attributes differ, to check an account or business unit
+// migration.
+func CompareInventories(ctx context.Context, a, b InventorySource, opts *CompareOptions) (*InventoryDiff, error) {
+	if a.Client == nil || b.Client == nil {
+		return nil, fmt.Errorf("compare: both inventory sources need a client")
+	}
+	key := CertificateIdentity
+	var ignore []string
+	if opts != nil {
+		if opts.Key != nil {
+			key = opts.Key
+		}
+		ignore = opts.IgnoreFields
+	}
+	for _, f := range ignore {
+		if _, ok := compareFields[f]; !ok {
+			return nil, fmt.Errorf("compare: unknown field %q", f)
+		}
+	}
+
+	var (
+		wg         sync.WaitGroup
+		invA, invB map[string]Certificate
+		errA, errB error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		invA, errA = a.inventory(ctx, key)
+	}()
+	go func() {
+		defer wg.Done()
+		invB, errB = b.inventory(ctx, key)
+	}()
+	wg.Wait()
+	if errA != nil {
+		return nil, fmt.Errorf("compare: inventory A: %w", errA)
+	}
+	if errB != nil {
+		return nil, fmt.Errorf("compare: inventory B: %w", errB)
+	}
+
+	diff := &InventoryDiff{}
+	for _, k := range sortedKeys(invA) {
+		certA := invA[k]
+		certB, ok := invB[k]
+		if !ok {
+			diff.OnlyInA = append(diff.OnlyInA, certA)
+			continue
+		}
+		var differences []AttributeDifference
+		for _, field := range sortedKeys(compareFields) {
+			if containsString(ignore, field) {
+				continue
+			}
+			get := compareFields[field]
+			if va, vb := get(certA), get(certB); va != vb {
+				differences = append(differences, AttributeDifference{Field: field, A: va, B: vb})
+			}
+		}
+		if len(differences) > 0 {
+			diff.Differing = append(diff.Differing, CertificateDifference{Key: k, A: certA, B: certB, Differences: differences})
+		} else {
+			diff.Matching++
+		}
+	}
+	for _, k := range sortedKeys(invB) {
+		if _, ok := invA[k]; !ok {
+			diff.OnlyInB = append(diff.OnlyInB, invB[k])
+		}
+	}
+	return diff, nil
+}
+
+func (src InventorySource) inventory(ctx context.Context, key func(Certificate) string) (map[string]Certificate, error) {
+	inv := make(map[string]Certificate)
+	err := src.Client.Certificates.searchAll(ctx, &src.Search, func(c Certificate) {
+		inv[key(c)] = c
+	})
+	return inv, err
+}
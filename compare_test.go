@@ -0,0 +1,76 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareInventories(t *testing.T) {
+	inventories := map[string][]Certificate{
+		"bu-old": {
+			{ID: "a1", Thumbprint: "AA:01", CommonName: "same.example.com", Status: "issued", Profile: ProfileRef{ID: "p1"}},
+			{ID: "a2", Thumbprint: "AA:02", CommonName: "moved.example.com", Status: "issued", Profile: ProfileRef{ID: "p1"}},
+			{ID: "a3", SerialNumber: "0a:03", CommonName: "gone.example.com", Status: "issued"},
+		},
+		"bu-new": {
+			{ID: "b1", Thumbprint: "aa01", CommonName: "same.example.com", Status: "issued", Profile: ProfileRef{ID: "p2"}},
+			{ID: "b2", Thumbprint: "aa02", CommonName: "moved.example.com", Status: "revoked", Profile: ProfileRef{ID: "p2"}},
+			{ID: "b4", SerialNumber: "0B04", CommonName: "extra.example.com", Status: "issued"},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		items := inventories[r.URL.Query().Get("business_unit_id")]
+		// Serve one certificate per page to exercise pagination.
+		if r.URL.Query().Get("offset") != "" {
+			items = items[len(items)-1:]
+		} else {
+			items = items[:len(items)-1]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: 3}, Items: items})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	a := InventorySource{Client: client, Search: CertificateSearchOptions{BusinessUnitID: "bu-old"}}
+	b := InventorySource{Client: client, Search: CertificateSearchOptions{BusinessUnitID: "bu-new"}}
+
+	diff, err := CompareInventories(context.Background(), a, b, &CompareOptions{IgnoreFields: []string{CompareFieldProfileID}})
+	if err != nil {
+		t.Fatalf("CompareInventories() error = %v", err)
+	}
+	if diff.Empty() {
+		t.Fatal("Empty() = true, want differences")
+	}
+	if diff.Matching != 1 {
+		t.Errorf("Matching = %v, want 1", diff.Matching)
+	}
+	if len(diff.OnlyInA) != 1 || diff.OnlyInA[0].ID != "a3" {
+		t.Errorf("OnlyInA = %+v, want a3", diff.OnlyInA)
+	}
+	if len(diff.OnlyInB) != 1 || diff.OnlyInB[0].ID != "b4" {
+		t.Errorf("OnlyInB = %+v, want b4", diff.OnlyInB)
+	}
+	if len(diff.Differing) != 1 {
+		t.Fatalf("Differing = %+v, want one certificate", diff.Differing)
+	}
+	want := AttributeDifference{Field: CompareFieldStatus, A: "issued", B: "revoked"}
+	if d := diff.Differing[0]; d.Key != "sha:aa02" || len(d.Differences) != 1 || d.Differences[0] != want {
+		t.Errorf("Differing[0] = %+v, want status difference for sha:aa02", d)
+	}
+
+	diff, err = CompareInventories(context.Background(), a, b, nil)
+	if err != nil {
+		t.Fatalf("CompareInventories() error = %v", err)
+	}
+	if len(diff.Differing) != 2 || diff.Matching != 0 {
+		t.Errorf("Differing = %d, Matching = %d, want profile differences counted", len(diff.Differing), diff.Matching)
+	}
+
+	if _, err := CompareInventories(context.Background(), a, b, &CompareOptions{IgnoreFields: []string{"bogus"}}); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+}
@@ -0,0 +1,94 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNotModified is returned by a conditional GET, made with a context from
+// ContextWithIfNoneMatch or ContextWithIfModifiedSince, when the resource
+// has not changed. The Response is returned alongside it, with no body to
+// decode.
+var ErrNotModified = errors.New("digicert: not modified")
+
+// IsNotModified reports whether a conditional GET found the resource
+// unchanged since the caller last fetched it.
+func IsNotModified(err error) bool {
+	return errors.Is(err, ErrNotModified)
+}
+
+type conditionsContextKey struct{}
+
+// conditions are the validators sent on a conditional GET.
+type conditions struct {
+	etag  string
+	since time.Time
+}
+
+// ContextWithIfNoneMatch returns a context that makes GET requests
+// conditional on the resource no longer matching etag, usually the ETag of
+// an earlier Response. If it still matches, the request fails with
+// ErrNotModified instead of transferring the resource again.
+func ContextWithIfNoneMatch(ctx context.Context, etag string) context.Context {
+	c, _ := ctx.Value(conditionsContextKey{}).(conditions)
+	c.etag = etag
+	return context.WithValue(ctx, conditionsContextKey{}, c)
+}
+
+// ContextWithIfModifiedSince returns a context that makes GET requests
+// conditional on the resource changing after t, usually the LastModified of
+// an earlier Response. If it has not, the request fails with
+// ErrNotModified.
+func ContextWithIfModifiedSince(ctx context.Context, t time.Time) context.Context {
+	c, _ := ctx.Value(conditionsContextKey{}).(conditions)
+	c.since = t
+	return context.WithValue(ctx, conditionsContextKey{}, c)
+}
+
+// applyConditions sets the If-None-Match and If-Modified-Since headers from
+// ctx on GET requests.
+func applyConditions(ctx context.Context, req *http.Request) {
+	if req.Method != http.MethodGet {
+		return
+	}
+	c, ok := ctx.Value(conditionsContextKey{}).(conditions)
+	if !ok {
+		return
+	}
+	if c.etag != "" {
+		req.Header.Set("If-None-Match", quoteETag(c.etag))
+	}
+	if !c.since.IsZero() {
+		req.Header.Set("If-Modified-Since", c.since.UTC().Format(http.TimeFormat))
+	}
+}
+
+// conditional reports whether req only wants the resource if it changed.
+func conditional(req *http.Request) bool {
+	return req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != ""
+}
+
+// quoteETag quotes a bare entity tag, leaving quoted and weak tags and the
+// "*" wildcard as they are.
+func quoteETag(etag string) string {
+	if etag == "*" || strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// setValidators copies the ETag and Last-Modified headers onto r.
+func (r *Response) setValidators() {
+	if r.Response == nil {
+		return
+	}
+	r.ETag = r.Header.Get("ETag")
+	if lm := r.Header.Get("Last-Modified"); lm != "" {
+		if t, err := http.ParseTime(lm); err == nil {
+			r.LastModified = t
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConditionalGet(t *testing.T) {
+	ctx := context.Background()
+	modified := time.Date(2024, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/certificate-by-id/cert-1" {
+			t.Errorf("path = %v, want certificate-by-id/cert-1", r.URL.Path)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Certificate{ID: "cert-1"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+
+	cert, resp, err := client.Certificates.GetCertificate(ctx, "cert-1")
+	if err != nil {
+		t.Fatalf("GetCertificate() error = %v", err)
+	}
+	if cert.ID != "cert-1" {
+		t.Errorf("ID = %v, want %v", cert.ID, "cert-1")
+	}
+	if resp.ETag != `"v1"` || !resp.LastModified.Equal(modified) {
+		t.Errorf("validators = %q, %v, want %q, %v", resp.ETag, resp.LastModified, `"v1"`, modified)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{"matching etag", ContextWithIfNoneMatch(ctx, resp.ETag), true},
+		{"bare etag", ContextWithIfNoneMatch(ctx, "v1"), true},
+		{"stale etag", ContextWithIfNoneMatch(ctx, `"v0"`), false},
+		{"not modified since", ContextWithIfModifiedSince(ctx, resp.LastModified), true},
+		{"modified since", ContextWithIfModifiedSince(ctx, modified.Add(-time.Hour)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, resp, err := client.Certificates.GetCertificate(tt.ctx, "cert-1")
+			if got := IsNotModified(err); got != tt.want {
+				t.Fatalf("IsNotModified(%v) = %v, want %v", err, got, tt.want)
+			}
+			if tt.want {
+				if cert != nil || resp == nil || resp.StatusCode != http.StatusNotModified {
+					t.Errorf("GetCertificate() = %v, %v, want no certificate and a 304 response", cert, resp)
+				}
+				if RetryableError(err) {
+					t.Error("RetryableError(ErrNotModified) = true, want false")
+				}
+			} else if err != nil {
+				t.Errorf("GetCertificate() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConditionalGet_BypassesDiskCache(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Profile{ID: "p1"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithDiskCache(NewDiskCache(t.TempDir(), time.Hour)))
+
+	if _, _, err := client.Profiles.Get(ctx, "p1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_, resp, err := client.Profiles.Get(ctx, "p1")
+	if err != nil || !resp.FromCache || resp.ETag != `"v1"` {
+		t.Fatalf("Get() = %+v, %v, want a cached response with its ETag", resp, err)
+	}
+
+	if _, _, err := client.Profiles.Get(ContextWithIfNoneMatch(ctx, resp.ETag), "p1"); !IsNotModified(err) {
+		t.Errorf("Get() error = %v, want %v", err, ErrNotModified)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %v, want %v", requests, 2)
+	}
+}
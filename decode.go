@@ -0,0 +1,259 @@
+package digicert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// WithStrictDecoding makes the client reject responses containing fields the
+// library does not model, including fields that would otherwise be captured
+// in Extra. It is intended for tests that pin the SDK to the API schema.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) error {
+		c.strictDecoding = true
+		return nil
+	}
+}
+
+// decode unmarshals a response body into v with the client's codec,
+// honouring WithStrictDecoding.
+func (c *Client) decode(data []byte, v interface{}) error {
+	if !c.strictDecoding {
+		return c.codec.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	return findUnknownFields(reflect.ValueOf(v))
+}
+
+// unknownFieldCarrier is implemented by types that capture unmodelled fields.
+type unknownFieldCarrier interface {
+	unknownFields() map[string]json.RawMessage
+}
+
+// findUnknownFields walks v and reports the first value that captured fields
+// during decoding. Types with their own UnmarshalJSON are not covered by
+// DisallowUnknownFields, so strict mode checks them here instead.
+func findUnknownFields(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return findUnknownFields(v.Elem())
+	case reflect.Struct:
+		if v.CanAddr() {
+			if c, ok := v.Addr().Interface().(unknownFieldCarrier); ok {
+				if extra := c.unknownFields(); len(extra) > 0 {
+					names := make([]string, 0, len(extra))
+					for name := range extra {
+						names = append(names, name)
+					}
+					sort.Strings(names)
+					return fmt.Errorf("json: unknown field(s) %s in %s", strings.Join(names, ", "), v.Type().Name())
+				}
+			}
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := findUnknownFields(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := findUnknownFields(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable, so check a copy.
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			if err := findUnknownFields(elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalWithExtra decodes data into v, which must be a pointer to a struct
+// without its own UnmarshalJSON, and returns the object members v has no
+// field for.
+func unmarshalWithExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	// Most objects only carry modelled fields, so check the member names
+	// without decoding them into a map first.
+	unknown := false
+	scanned := scanObjectKeys(data, func(key []byte) {
+		if !known[string(key)] && !known[strings.ToLower(string(key))] {
+			unknown = true
+		}
+	})
+	if scanned && !unknown {
+		return nil, nil
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	for name := range all {
+		// encoding/json matches member names case-insensitively.
+		if known[strings.ToLower(name)] {
+			delete(all, name)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// scanObjectKeys calls fn with each member name of the JSON object in data,
+// which must already be known to be valid JSON. It reports false, leaving the
+// caller to decode the object, if data is not an object or a name contains
+// escapes or non-ASCII characters that need decoding to compare.
+func scanObjectKeys(data []byte, fn func(key []byte)) bool {
+	i := skipJSONSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return false
+	}
+	for i++; ; {
+		i = skipJSONSpace(data, i)
+		if i >= len(data) {
+			return false
+		}
+		switch data[i] {
+		case '}':
+			return true
+		case ',':
+			i++
+			continue
+		case '"':
+		default:
+			return false
+		}
+
+		end := i + 1
+		for ; end < len(data) && data[end] != '"'; end++ {
+			if data[end] == '\\' || data[end] >= utf8.RuneSelf {
+				return false
+			}
+		}
+		if end >= len(data) {
+			return false
+		}
+		fn(data[i+1 : end])
+
+		i = skipJSONSpace(data, end+1)
+		if i >= len(data) || data[i] != ':' {
+			return false
+		}
+		i = skipJSONValue(data, i+1)
+	}
+}
+
+// skipJSONValue returns the offset just past the value starting at or after
+// data[i].
+func skipJSONValue(data []byte, i int) int {
+	depth := 0
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if depth == 0 {
+				return i + 1
+			}
+		case '{', '[':
+			depth++
+		case '}', ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case ',':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(data)
+}
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+var jsonFieldNameCache sync.Map // map[reflect.Type]map[string]bool
+
+// jsonFieldNames returns the lower-cased JSON member names decoded into t.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if cached, ok := jsonFieldNameCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for embedded := range jsonFieldNames(ft) {
+					names[embedded] = true
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+
+	jsonFieldNameCache.Store(t, names)
+	return names
+}
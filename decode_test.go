@@ -0,0 +1,190 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUnknownFieldCapture(t *testing.T) {
+	t.Run("certificate", func(t *testing.T) {
+		var cert Certificate
+		data := `{"id": "cert-1", "Common_Name": "example.com", "key_usage": ["digitalSignature"], "lifecycle": {"stage": "active"}}`
+		if err := json.Unmarshal([]byte(data), &cert); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if cert.ID != "cert-1" || cert.CommonName != "example.com" {
+			t.Errorf("Certificate = %+v, want modelled fields decoded", cert)
+		}
+		if len(cert.Extra) != 2 || string(cert.Extra["lifecycle"]) != `{"stage": "active"}` {
+			t.Errorf("Extra = %v, want key_usage and lifecycle", cert.Extra)
+		}
+	})
+
+	t.Run("nested and embedded", func(t *testing.T) {
+		var result CertificateSearchResponse
+		data := `{"total": 1, "items": [{"id": "cert-1", "business_unit": {"id": "bu-1", "region": "eu"}}]}`
+		if err := json.Unmarshal([]byte(data), &result); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if result.Items[0].Extra != nil {
+			t.Errorf("Certificate.Extra = %v, want nil", result.Items[0].Extra)
+		}
+		if string(result.Items[0].BusinessUnit.Extra["region"]) != `"eu"` {
+			t.Errorf("BusinessUnit.Extra = %v, want region", result.Items[0].BusinessUnit.Extra)
+		}
+	})
+
+	t.Run("no unknown fields", func(t *testing.T) {
+		var enrollment Enrollment
+		if err := json.Unmarshal([]byte(`{"id": "enr-1", "status": "pending"}`), &enrollment); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if enrollment.Extra != nil {
+			t.Errorf("Extra = %v, want nil", enrollment.Extra)
+		}
+	})
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		body    string
+		strict  bool
+		wantErr string
+	}{
+		{name: "lenient", body: `{"total": 1, "cursor": "x", "items": [{"id": "c", "new_field": 1}]}`},
+		{name: "strict top-level", body: `{"total": 1, "cursor": "x", "items": []}`, strict: true, wantErr: `unknown field "cursor"`},
+		{name: "strict nested", body: `{"total": 1, "items": [{"id": "c", "new_field": 1}]}`, strict: true, wantErr: "new_field in Certificate"},
+		{name: "strict clean", body: `{"total": 1, "items": [{"id": "c"}]}`, strict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			opts := []ClientOption{WithBaseURL(server.URL + "/")}
+			if tt.strict {
+				opts = append(opts, WithStrictDecoding())
+			}
+			client, _ := NewClient("test-key", opts...)
+
+			_, _, err := client.Certificates.Search(ctx, nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Search() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Search() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRaw(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	item := `{"id":"owner-1","email":"a@example.com","preferences":{"digest":"weekly"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(item))
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	owner, _, err := client.CertificateOwners.Get(ctx, "owner-1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(owner.Raw()) != item {
+		t.Errorf("Raw() = %s, want %s", owner.Raw(), item)
+	}
+
+	var fields struct {
+		Preferences struct {
+			Digest string `json:"digest"`
+		} `json:"preferences"`
+	}
+	if err := json.Unmarshal(owner.Raw(), &fields); err != nil || fields.Preferences.Digest != "weekly" {
+		t.Errorf("Raw() preferences = %+v, %v, want weekly digest", fields, err)
+	}
+
+	if (&Certificate{}).Raw() != nil {
+		t.Error("Raw() on a constructed Certificate should be nil")
+	}
+}
+
+func TestScanObjectKeys(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+		ok   bool
+	}{
+		{`{}`, "", true},
+		{` { "a" : 1 , "b":"x\"}y", "c": {"d": [1, {"e": "]"}]}, "f": null } `, "a,b,c,f", true},
+		{`{"a": [], "b": {}, "c": true, "d": -1.5e3}`, "a,b,c,d", true},
+		{`{"a\u0062": 1}`, "", false},
+		{`{"é": 1}`, "", false},
+		{`[1, 2]`, "", false},
+		{`null`, "", false},
+	}
+	for _, tt := range tests {
+		var keys []string
+		ok := scanObjectKeys([]byte(tt.data), func(key []byte) { keys = append(keys, string(key)) })
+		if ok != tt.ok || (ok && strings.Join(keys, ",") != tt.want) {
+			t.Errorf("scanObjectKeys(%s) = %v, %v, want %v, %v", tt.data, keys, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func BenchmarkUnmarshalCertificate(b *testing.B) {
+	data := []byte(`{"id": "cert-1", "common_name": "host.example.com", "serial_number": "0A1B2C", "status": "issued",
+		"business_unit": {"id": "bu-1", "name": "Platform"}, "profile": {"id": "profile-1", "name": "TLS"},
+		"valid_from": "2026-01-01T00:00:00Z", "valid_to": "2027-01-01T00:00:00Z", "key_size": "2048"}`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var c Certificate
+		if err := json.Unmarshal(data, &c); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCertificatesService_SearchPage measures a full search round trip
+// for pages of increasing size, from reading the body to decoded items.
+func BenchmarkCertificatesService_SearchPage(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		payload := searchPayload(n)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(payload)
+		}))
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		opts := &CertificateSearchOptions{PaginationParams: PaginationParams{Offset: 1, Limit: n}}
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := client.Certificates.Search(context.Background(), opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		server.Close()
+	}
+}
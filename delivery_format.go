@@ -0,0 +1,55 @@
+package digicert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeliveryFormatType is the encoding a certificate is delivered in.
+type DeliveryFormatType string
+
+const (
+	DeliveryFormatPEM    DeliveryFormatType = "pem"
+	DeliveryFormatDER    DeliveryFormatType = "der"
+	DeliveryFormatPKCS7  DeliveryFormatType = "pkcs7"
+	DeliveryFormatPKCS12 DeliveryFormatType = "pkcs12"
+	DeliveryFormatJKS    DeliveryFormatType = "jks"
+)
+
+// minKeystorePasswordLength is the shortest password keytool accepts for a
+// JKS keystore.
+const minKeystorePasswordLength = 6
+
+// Is reports whether t equals other, ignoring case.
+func (t DeliveryFormatType) Is(other DeliveryFormatType) bool {
+	return strings.EqualFold(string(t), string(other))
+}
+
+// IsKeystore reports whether the format bundles the private key, and so
+// takes a Password and Alias.
+func (t DeliveryFormatType) IsKeystore() bool {
+	return t.Is(DeliveryFormatPKCS12) || t.Is(DeliveryFormatJKS)
+}
+
+// Validate checks that keystore formats carry a password and that other
+// formats do not set keystore fields. Formats this package does not know
+// are passed to the API as they are.
+func (f *DeliveryFormat) Validate() error {
+	if f == nil {
+		return nil
+	}
+	if !f.Format.IsKeystore() {
+		known := f.Format.Is(DeliveryFormatPEM) || f.Format.Is(DeliveryFormatDER) || f.Format.Is(DeliveryFormatPKCS7)
+		if known && (f.Password != "" || f.Alias != "") {
+			return fmt.Errorf("digicert: delivery format %q does not take a password or alias", f.Format)
+		}
+		return nil
+	}
+	if f.Password == "" {
+		return fmt.Errorf("digicert: delivery format %q requires a password", f.Format)
+	}
+	if f.Format.Is(DeliveryFormatJKS) && len(f.Password) < minKeystorePasswordLength {
+		return fmt.Errorf("digicert: JKS keystore password must be at least %d characters", minKeystorePasswordLength)
+	}
+	return nil
+}
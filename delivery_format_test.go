@@ -0,0 +1,48 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeliveryFormat_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  *DeliveryFormat
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"pem", &DeliveryFormat{Format: DeliveryFormatPEM}, false},
+		{"pem with password", &DeliveryFormat{Format: DeliveryFormatPEM, Password: "secret"}, true},
+		{"pkcs12 with password", &DeliveryFormat{Format: DeliveryFormatPKCS12, Password: "secret", Alias: "server"}, false},
+		{"pkcs12 without password", &DeliveryFormat{Format: DeliveryFormatPKCS12}, true},
+		{"jks upper case", &DeliveryFormat{Format: "JKS", Password: "changeit"}, false},
+		{"jks short password", &DeliveryFormat{Format: DeliveryFormatJKS, Password: "12345"}, true},
+		{"unknown format", &DeliveryFormat{Format: "bks", Password: "secret"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.format.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCertificatesService_Issue_InvalidDeliveryFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected API call")
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	_, _, err := client.Certificates.Issue(context.Background(), &CertificateRequest{
+		Profile:        ProfileReference{ID: "profile-1"},
+		DeliveryFormat: &DeliveryFormat{Format: DeliveryFormatJKS},
+	})
+	if err == nil {
+		t.Error("Expected error for JKS delivery without a password")
+	}
+}
@@ -0,0 +1,167 @@
+package digicert
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewTransport returns the transport NewClient uses by default: a copy of
+// http.DefaultTransport that takes its proxy from HTTPS_PROXY, HTTP_PROXY
+// and NO_PROXY and negotiates HTTP/2 where the server supports it. Start
+// from it when customising TLS settings for WithHTTPClient, as a transport
+// with its own TLSClientConfig otherwise falls back to HTTP/1.1.
+func NewTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+	t.ForceAttemptHTTP2 = true
+	return t
+}
+
+// DiagnosticsResult reports how a request to the API host was carried, for
+// troubleshooting connectivity.
+type DiagnosticsResult struct {
+	URL string
+	// Proxy is the proxy the request went through, empty when direct or
+	// when the client's transport is not an *http.Transport.
+	Proxy      string
+	RemoteAddr string
+	// Protocol is the negotiated HTTP version, such as "HTTP/2.0".
+	Protocol    string
+	TLSVersion  string
+	CipherSuite string
+	ServerName  string
+
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// FirstByte is the time from sending the request to the first byte of
+	// the response.
+	FirstByte time.Duration
+	Total     time.Duration
+
+	StatusCode int
+	RequestID  string
+}
+
+// String formats the result for pasting into a support ticket.
+func (d *DiagnosticsResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "url: %s\n", d.URL)
+	if d.Proxy != "" {
+		fmt.Fprintf(&b, "proxy: %s\n", d.Proxy)
+	}
+	fmt.Fprintf(&b, "remote: %s\n", d.RemoteAddr)
+	fmt.Fprintf(&b, "protocol: %s\n", d.Protocol)
+	if d.TLSVersion != "" {
+		fmt.Fprintf(&b, "tls: %s %s (server name %s)\n", d.TLSVersion, d.CipherSuite, d.ServerName)
+	}
+	fmt.Fprintf(&b, "dns: %s, connect: %s, tls handshake: %s, first byte: %s, total: %s\n",
+		d.DNSLookup, d.Connect, d.TLSHandshake, d.FirstByte, d.Total)
+	fmt.Fprintf(&b, "status: %d", d.StatusCode)
+	if d.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", d.RequestID)
+	}
+	return b.String()
+}
+
+// Diagnostics makes the same small request as Ping over a new connection,
+// bypassing retries, hedging and the circuit breaker, and reports the
+// negotiated protocol and TLS version and where the time went. Any HTTP
+// status counts as a completed request, so an invalid key still yields a
+// full result. If the request fails, the result holds the timings gathered
+// so far along with the error.
+//
+// A new connection is only guaranteed when the client's transport is an
+// *http.Transport; other transports may reuse a pooled connection, leaving
+// the DNS, connect and handshake timings at zero.
+func (c *Client) Diagnostics(ctx context.Context) (*DiagnosticsResult, error) {
+	httpReq, err := c.NewRequest(ctx, http.MethodGet, "business-unit?limit=1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := c.client
+	result := &DiagnosticsResult{URL: sanitizeURL(httpReq.URL)}
+	if t, ok := client.Transport.(*http.Transport); ok || client.Transport == nil {
+		if !ok {
+			t = NewTransport()
+		} else {
+			t = t.Clone()
+		}
+		defer t.CloseIdleConnections()
+		if t.Proxy != nil {
+			if proxy, err := t.Proxy(httpReq); err == nil && proxy != nil {
+				result.Proxy = sanitizeURL(proxy)
+			}
+		}
+		client = &http.Client{Transport: t, Timeout: c.client.Timeout, CheckRedirect: c.client.CheckRedirect}
+	}
+
+	// The transport calls these from its own goroutines, so they share a
+	// lock.
+	var mu sync.Mutex
+	var dnsStart, connectStart, tlsStart, wroteRequest time.Time
+	record := func(f func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		f()
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { record(func() { dnsStart = time.Now() }) },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			record(func() { result.DNSLookup = time.Since(dnsStart) })
+		},
+		ConnectStart: func(string, string) { record(func() { connectStart = time.Now() }) },
+		ConnectDone: func(string, string, error) {
+			record(func() { result.Connect = time.Since(connectStart) })
+		},
+		TLSHandshakeStart: func() { record(func() { tlsStart = time.Now() }) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			record(func() { result.TLSHandshake = time.Since(tlsStart) })
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			record(func() { result.RemoteAddr = info.Conn.RemoteAddr().String() })
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) { record(func() { wroteRequest = time.Now() }) },
+		GotFirstResponseByte: func() {
+			record(func() { result.FirstByte = time.Since(wroteRequest) })
+		},
+	}
+	httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), trace))
+
+	if c.signer != nil {
+		if err := c.signRequest(httpReq); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := client.Do(httpReq)
+	if err == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	result.Total = time.Since(start)
+	if resp == nil {
+		return result, err
+	}
+
+	result.Protocol = resp.Proto
+	result.StatusCode = resp.StatusCode
+	result.RequestID = resp.Header.Get("X-Request-Id")
+	if resp.TLS != nil {
+		result.TLSVersion = tls.VersionName(resp.TLS.Version)
+		result.CipherSuite = tls.CipherSuiteName(resp.TLS.CipherSuite)
+		result.ServerName = resp.TLS.ServerName
+	}
+	return result, err
+}
@@ -0,0 +1,100 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewTransport(t *testing.T) {
+	tr := NewTransport()
+	if !tr.ForceAttemptHTTP2 || tr.Proxy == nil {
+		t.Errorf("NewTransport() = HTTP/2 %v, proxy set %v, want both", tr.ForceAttemptHTTP2, tr.Proxy != nil)
+	}
+	if tr == http.DefaultTransport {
+		t.Error("NewTransport() returned http.DefaultTransport, want a copy")
+	}
+
+	client, _ := NewClient("test-key")
+	if _, ok := client.client.Transport.(*http.Transport); !ok {
+		t.Errorf("default transport = %T, want *http.Transport", client.client.Transport)
+	}
+}
+
+func TestClient_Diagnostics(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/business-unit" || r.Header.Get("X-API-Key") != "test-key" {
+			t.Errorf("request = %s, want an authenticated business unit list", r.URL)
+		}
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithHTTPClient(server.Client()))
+	// Warm the pool; Diagnostics must still open a new connection.
+	client.Ping(context.Background())
+
+	result, err := client.Diagnostics(context.Background())
+	if err != nil {
+		t.Fatalf("Diagnostics() error = %v", err)
+	}
+	if result.Protocol != "HTTP/2.0" || result.TLSVersion != "TLS 1.3" || result.CipherSuite == "" {
+		t.Errorf("protocol = %v, TLS = %v %v, want HTTP/2.0 over TLS 1.3", result.Protocol, result.TLSVersion, result.CipherSuite)
+	}
+	if result.StatusCode != http.StatusUnauthorized || result.RequestID != "req-1" {
+		t.Errorf("status = %v, request ID = %v, want 401 and req-1", result.StatusCode, result.RequestID)
+	}
+	if result.Connect <= 0 || result.TLSHandshake <= 0 || result.FirstByte <= 0 || result.Total < result.FirstByte {
+		t.Errorf("timings = %+v, want a new connection", result)
+	}
+	if result.RemoteAddr != server.Listener.Addr().String() || result.Proxy != "" {
+		t.Errorf("remote = %v, proxy = %v, want a direct connection to the server", result.RemoteAddr, result.Proxy)
+	}
+	if s := result.String(); !strings.Contains(s, "protocol: HTTP/2.0") || !strings.Contains(s, "status: 401 (request ID req-1)") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestClient_DiagnosticsProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	transport := NewTransport()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	client, _ := NewClient("test-key",
+		WithBaseURL("http://digicert.invalid/"),
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	result, err := client.Diagnostics(context.Background())
+	if err != nil {
+		t.Fatalf("Diagnostics() error = %v", err)
+	}
+	if result.Proxy != proxy.URL || !strings.HasPrefix(proxied, "http://digicert.invalid/mpki/api/v1/business-unit") {
+		t.Errorf("proxy = %v, proxied %q, want the request sent through %v", result.Proxy, proxied, proxy.URL)
+	}
+	if result.Protocol != "HTTP/1.1" || result.TLSVersion != "" {
+		t.Errorf("protocol = %v, TLS = %v, want plain HTTP/1.1", result.Protocol, result.TLSVersion)
+	}
+}
+
+func TestClient_DiagnosticsUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, err := client.Diagnostics(context.Background())
+	if err == nil || result == nil || result.StatusCode != 0 {
+		t.Errorf("Diagnostics() = %v, %v, want a partial result and an error", result, err)
+	}
+}
@@ -0,0 +1,250 @@
+package digicerttest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+// decodeFixture decodes the named fixture into v, which must match it.
+func decodeFixture(name string, v interface{}) {
+	if err := json.Unmarshal(Fixture(name), v); err != nil {
+		panic(fmt.Sprintf("digicerttest: fixture %q: %v", name, err))
+	}
+}
+
+// roundTrip returns v re-decoded from its JSON, so the Raw of the value
+// matches its fields rather than the fixture it started from.
+func roundTrip[T any](v *T) *T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("digicerttest: encode %T: %v", v, err))
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(fmt.Sprintf("digicerttest: decode %T: %v", v, err))
+	}
+	return &out
+}
+
+type certificateBuilder struct {
+	cert     digicert.Certificate
+	dnsNames []string
+	pem      bool
+}
+
+// CertificateOption adjusts a certificate built by NewCertificate.
+type CertificateOption func(*certificateBuilder)
+
+// NewCertificate returns an issued certificate with the metadata of
+// FixtureCertificate, adjusted by opts. The PEM is omitted unless WithPEM
+// is given, as the fixture's would not match the adjusted fields.
+func NewCertificate(opts ...CertificateOption) *digicert.Certificate {
+	b := &certificateBuilder{dnsNames: []string{"www.example.com", "example.com"}}
+	decodeFixture(FixtureCertificate, &b.cert)
+	b.cert.Certificate = ""
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.pem {
+		b.generatePEM()
+	}
+	return roundTrip(&b.cert)
+}
+
+// WithID sets the certificate ID.
+func WithID(id string) CertificateOption {
+	return func(b *certificateBuilder) { b.cert.ID = id }
+}
+
+// WithCommonName sets the common name, subject and seat, and makes it the
+// first DNS name of a generated PEM.
+func WithCommonName(cn string) CertificateOption {
+	return func(b *certificateBuilder) {
+		b.cert.CommonName = cn
+		subject := digicert.Subject{}
+		if b.cert.Subject != nil {
+			subject = *b.cert.Subject
+		}
+		subject.CommonName = cn
+		b.cert.Subject = &subject
+		b.cert.Seat = &digicert.Seat{SeatID: cn}
+		b.dnsNames = []string{cn}
+	}
+}
+
+// WithDNSNames sets the DNS names of a generated PEM.
+func WithDNSNames(names ...string) CertificateOption {
+	return func(b *certificateBuilder) { b.dnsNames = names }
+}
+
+// WithSerialNumber sets the serial number, in the upper case hex TLM uses.
+func WithSerialNumber(serial string) CertificateOption {
+	return func(b *certificateBuilder) { b.cert.SerialNumber = serial }
+}
+
+// WithStatus sets the status, such as "issued", "revoked" or "expired".
+func WithStatus(status string) CertificateOption {
+	return func(b *certificateBuilder) { b.cert.Status = status }
+}
+
+// WithValidity sets the validity period and the days left until notAfter,
+// counted from now.
+func WithValidity(notBefore, notAfter time.Time) CertificateOption {
+	return func(b *certificateBuilder) {
+		b.cert.ValidFrom = notBefore.UTC().Format(time.RFC3339)
+		b.cert.ValidTo = notAfter.UTC().Format(time.RFC3339)
+		b.cert.ExpiresInDays = digicert.FlexibleInt(max(0, int(math.Ceil(time.Until(notAfter).Hours()/24))))
+	}
+}
+
+// WithExpiresIn sets a validity period that started a year before now and
+// ends d from now.
+func WithExpiresIn(d time.Duration) CertificateOption {
+	now := time.Now().Truncate(time.Second)
+	return WithValidity(now.AddDate(-1, 0, 0), now.Add(d))
+}
+
+// WithProfile sets the profile the certificate was issued from.
+func WithProfile(id, name string) CertificateOption {
+	return func(b *certificateBuilder) { b.cert.Profile = digicert.ProfileRef{ID: id, Name: name} }
+}
+
+// WithBusinessUnit sets the owning business unit.
+func WithBusinessUnit(id, name string) CertificateOption {
+	return func(b *certificateBuilder) { b.cert.BusinessUnit = &digicert.BusinessUnitRef{ID: id, Name: name} }
+}
+
+// WithKeySize sets the key size as TLM reports it, such as "RSA 2048".
+func WithKeySize(keySize string) CertificateOption {
+	return func(b *certificateBuilder) { b.cert.KeySize = digicert.KeySpec(keySize) }
+}
+
+// WithPEM generates a self-signed ECDSA P-256 certificate matching the
+// common name, DNS names, serial number and validity, and sets the PEM,
+// thumbprint, key size and signature algorithm to match it. It may appear
+// anywhere among the options.
+func WithPEM() CertificateOption {
+	return func(b *certificateBuilder) { b.pem = true }
+}
+
+func (b *certificateBuilder) generatePEM() {
+	c := &b.cert
+	serial, ok := new(big.Int).SetString(c.SerialNumber, 16)
+	if !ok {
+		panic(fmt.Sprintf("digicerttest: serial number %q is not hex", c.SerialNumber))
+	}
+	notBefore, err := time.Parse(time.RFC3339, c.ValidFrom)
+	if err != nil {
+		panic(fmt.Sprintf("digicerttest: valid_from: %v", err))
+	}
+	notAfter, err := time.Parse(time.RFC3339, c.ValidTo)
+	if err != nil {
+		panic(fmt.Sprintf("digicerttest: valid_to: %v", err))
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(fmt.Sprintf("digicerttest: generate key: %v", err))
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: c.CommonName},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		DNSNames:     b.dnsNames,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(fmt.Sprintf("digicerttest: create certificate: %v", err))
+	}
+
+	sum := sha1.Sum(der)
+	c.Certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	c.Thumbprint = hex.EncodeToString(sum[:])
+	c.KeySize = "EC P-256"
+	c.SignatureAlgorithm = "ecdsa-with-SHA256"
+}
+
+// NewCertificateSearch returns a single page of search results holding
+// certs.
+func NewCertificateSearch(certs ...*digicert.Certificate) *digicert.CertificateSearchResponse {
+	resp := &digicert.CertificateSearchResponse{
+		ListResponse: digicert.ListResponse{Total: len(certs), Limit: max(len(certs), 100)},
+		Items:        make([]digicert.Certificate, 0, len(certs)),
+	}
+	for _, c := range certs {
+		resp.Items = append(resp.Items, *c)
+	}
+	return resp
+}
+
+// NewProfile returns the profile of FixtureProfile with opts applied.
+func NewProfile(opts ...func(*digicert.Profile)) *digicert.Profile {
+	var p digicert.Profile
+	decodeFixture(FixtureProfile, &p)
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return roundTrip(&p)
+}
+
+// NewProfileList returns a single page of profiles.
+func NewProfileList(profiles ...*digicert.Profile) *digicert.ProfileListResponse {
+	resp := &digicert.ProfileListResponse{
+		ListResponse: digicert.ListResponse{Total: len(profiles), Limit: max(len(profiles), 100)},
+		Profiles:     make([]digicert.Profile, 0, len(profiles)),
+	}
+	for _, p := range profiles {
+		resp.Profiles = append(resp.Profiles, *p)
+	}
+	return resp
+}
+
+// NewBusinessUnit returns the business unit of FixtureBusinessUnit with
+// opts applied.
+func NewBusinessUnit(opts ...func(*digicert.BusinessUnit)) *digicert.BusinessUnit {
+	var bu digicert.BusinessUnit
+	decodeFixture(FixtureBusinessUnit, &bu)
+	for _, opt := range opts {
+		opt(&bu)
+	}
+	return roundTrip(&bu)
+}
+
+// NewBusinessUnitList returns a single page of business units.
+func NewBusinessUnitList(units ...*digicert.BusinessUnit) *digicert.BusinessUnitListResponse {
+	resp := &digicert.BusinessUnitListResponse{
+		ListResponse:  digicert.ListResponse{Total: len(units), Limit: max(len(units), 100)},
+		BusinessUnits: make([]digicert.BusinessUnit, 0, len(units)),
+	}
+	for _, bu := range units {
+		resp.BusinessUnits = append(resp.BusinessUnits, *bu)
+	}
+	return resp
+}
+
+// NewEnrollment returns the pending enrollment of FixtureEnrollment with
+// opts applied.
+func NewEnrollment(opts ...func(*digicert.Enrollment)) *digicert.Enrollment {
+	var e digicert.Enrollment
+	decodeFixture(FixtureEnrollment, &e)
+	for _, opt := range opts {
+		opt(&e)
+	}
+	return roundTrip(&e)
+}
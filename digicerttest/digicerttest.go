@@ -0,0 +1,74 @@
+// Package digicerttest provides fixtures, builders and a fake API server for
+// testing code that uses go-digicert-tlm.
+//
+// Fixtures are canned API responses for each resource type, usable with any
+// HTTP mocking library:
+//
+//	httpmock.RegisterResponder(http.MethodGet,
+//		digicert.DefaultBaseURL+digicerttest.APIPath("profiles/p1"),
+//		httpmock.NewBytesResponder(http.StatusOK, digicerttest.Fixture(digicerttest.FixtureProfile)))
+//
+// Builders return realistic values that tests adjust only where it matters:
+//
+//	cert := digicerttest.NewCertificate(
+//		digicerttest.WithCommonName("api.example.com"),
+//		digicerttest.WithValidity(now.Add(-time.Hour), now.Add(10*24*time.Hour)),
+//		digicerttest.WithPEM())
+//
+// Server routes requests by method and API path and hands out clients
+// pointed at it:
+//
+//	srv := digicerttest.NewServer(t)
+//	srv.Handle("GET certificate/{serial}", digicerttest.JSON(http.StatusOK, cert))
+//	client := srv.Client(t)
+package digicerttest
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Fixture names, one per canned response.
+const (
+	FixtureCertificate        = "certificate"
+	FixtureCertificateSearch  = "certificate_search"
+	FixtureProfile            = "profile"
+	FixtureProfileList        = "profile_list"
+	FixtureBusinessUnit       = "business_unit"
+	FixtureBusinessUnitList   = "business_unit_list"
+	FixtureEnrollment         = "enrollment"
+	FixtureEnrollmentResponse = "enrollment_response"
+	FixtureEnrollmentStatus   = "enrollment_status"
+	FixtureError              = "error"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns a fresh copy of the named canned response body. It panics
+// on an unknown name, as that is a mistake in the test itself.
+func Fixture(name string) []byte {
+	data, err := fixtures.ReadFile(path.Join("fixtures", name+".json"))
+	if err != nil {
+		panic(fmt.Sprintf("digicerttest: unknown fixture %q", name))
+	}
+	return data
+}
+
+// Fixtures lists the names of the available fixtures.
+func Fixtures() []string {
+	entries, _ := fixtures.ReadDir("fixtures")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	return names
+}
+
+// APIPath returns the URL path the client sends a request for endpoint to,
+// such as "/mpki/api/v1/certificate/0A" for "certificate/0A".
+func APIPath(endpoint string) string {
+	return "/mpki/api/v1/" + endpoint
+}
@@ -0,0 +1,172 @@
+package digicerttest
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+// TestFixtures decodes every fixture through the client with strict
+// decoding, so fixtures cannot drift from the library's types.
+func TestFixtures(t *testing.T) {
+	srv := NewServer(t)
+	srv.Handle("GET certificate/{serial}", JSON(http.StatusOK, Fixture(FixtureCertificate)))
+	srv.Handle("GET certificate-search", JSON(http.StatusOK, Fixture(FixtureCertificateSearch)))
+	srv.Handle("GET profiles/{id}", JSON(http.StatusOK, Fixture(FixtureProfile)))
+	srv.Handle("GET profiles", JSON(http.StatusOK, Fixture(FixtureProfileList)))
+	srv.Handle("GET business-unit/{id}", JSON(http.StatusOK, Fixture(FixtureBusinessUnit)))
+	srv.Handle("GET business-unit", JSON(http.StatusOK, Fixture(FixtureBusinessUnitList)))
+	srv.Handle("GET enrollment/{code}", JSON(http.StatusOK, Fixture(FixtureEnrollment)))
+	srv.Handle("POST enrollment", JSON(http.StatusCreated, Fixture(FixtureEnrollmentResponse)))
+	srv.Handle("GET enrollment/{id}/status", JSON(http.StatusOK, Fixture(FixtureEnrollmentStatus)))
+	srv.Handle("PUT certificate/{serial}/revoke", JSON(http.StatusBadRequest, Fixture(FixtureError)))
+	client := srv.Client(t, digicert.WithStrictDecoding())
+	ctx := context.Background()
+
+	cert, _, err := client.Certificates.Get(ctx, "3A7F2C19E4B05D8816C2F0A9D3E47B51")
+	if err != nil {
+		t.Fatalf("Certificates.Get() error = %v", err)
+	}
+	thumbprint, err := digicert.ThumbprintSHA1([]byte(cert.Certificate))
+	if err != nil || thumbprint != cert.Thumbprint {
+		t.Errorf("PEM thumbprint = %v, %v, want %v", thumbprint, err, cert.Thumbprint)
+	}
+
+	checks := map[string]func() error{
+		FixtureCertificateSearch: func() error {
+			_, _, err := client.Certificates.Search(ctx, nil)
+			return err
+		},
+		FixtureProfile: func() error {
+			_, _, err := client.Profiles.Get(ctx, "p1")
+			return err
+		},
+		FixtureProfileList: func() error {
+			_, _, err := client.Profiles.List(ctx, nil)
+			return err
+		},
+		FixtureBusinessUnit: func() error {
+			_, _, err := client.BusinessUnits.Get(ctx, "bu1")
+			return err
+		},
+		FixtureBusinessUnitList: func() error {
+			_, _, err := client.BusinessUnits.List(ctx, nil)
+			return err
+		},
+		FixtureEnrollment: func() error {
+			_, _, err := client.Enrollments.Get(ctx, "X7K2-M9PQ-4RTV")
+			return err
+		},
+		FixtureEnrollmentResponse: func() error {
+			_, _, err := client.Enrollments.Create(ctx, &digicert.EnrollmentRequest{})
+			return err
+		},
+		FixtureEnrollmentStatus: func() error {
+			_, _, err := client.Enrollments.GetStatus(ctx, "e1")
+			return err
+		},
+	}
+	for name, check := range checks {
+		if err := check(); err != nil {
+			t.Errorf("%s: error = %v", name, err)
+		}
+	}
+
+	_, err = client.Certificates.Revoke(ctx, "0A", &digicert.RevokeRequest{})
+	var apiErr *digicert.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "invalid_input" || len(apiErr.Details) != 1 {
+		t.Errorf("error fixture = %v, want an invalid_input APIError with details", err)
+	}
+
+	if got := len(Fixtures()); got != len(checks)+2 {
+		t.Errorf("Fixtures() = %d names, want every fixture covered", got)
+	}
+}
+
+func TestNewCertificate(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cert := NewCertificate(
+		WithPEM(),
+		WithCommonName("api.example.com"),
+		WithSerialNumber("0C"),
+		WithValidity(now.Add(-time.Hour), now.Add(10*24*time.Hour)),
+		WithStatus("issued"),
+	)
+
+	if cert.CommonName != "api.example.com" || cert.Subject.CommonName != "api.example.com" || cert.Seat.SeatID != "api.example.com" {
+		t.Errorf("common name = %v, subject %v, seat %v, want api.example.com throughout", cert.CommonName, cert.Subject.CommonName, cert.Seat.SeatID)
+	}
+	if cert.ExpiresInDays != 10 {
+		t.Errorf("ExpiresInDays = %v, want 10", cert.ExpiresInDays)
+	}
+	if cert.Profile.ID == "" || cert.BusinessUnit == nil || cert.ICA == nil {
+		t.Errorf("certificate = %+v, want the fixture's profile, business unit and ICA", cert)
+	}
+
+	block, _ := pem.Decode([]byte(cert.Certificate))
+	if block == nil {
+		t.Fatal("Certificate is not PEM")
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	if parsed.Subject.CommonName != "api.example.com" || parsed.SerialNumber.Int64() != 12 || !parsed.NotAfter.Equal(now.Add(10*24*time.Hour)) {
+		t.Errorf("PEM = %v serial %v until %v, want it to match the metadata", parsed.Subject, parsed.SerialNumber, parsed.NotAfter)
+	}
+	if thumbprint, _ := digicert.ThumbprintSHA1([]byte(cert.Certificate)); thumbprint != cert.Thumbprint {
+		t.Errorf("Thumbprint = %v, want %v", cert.Thumbprint, thumbprint)
+	}
+	if !strings.Contains(string(cert.Raw()), "api.example.com") {
+		t.Errorf("Raw() = %s, want the built certificate", cert.Raw())
+	}
+
+	if plain := NewCertificate(); plain.Certificate != "" || plain.CommonName != "www.example.com" {
+		t.Errorf("NewCertificate() = %+v, want fixture metadata without PEM", plain)
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	profile := NewProfile(func(p *digicert.Profile) { p.ID, p.RequireApproval = "p2", true })
+	if profile.ID != "p2" || !profile.RequireApproval || profile.Name == "" {
+		t.Errorf("NewProfile() = %+v, want the fixture with the option applied", profile)
+	}
+
+	srv := NewServer(t)
+	srv.Handle("GET profiles", JSON(http.StatusOK, NewProfileList(NewProfile(), profile)))
+	srv.Handle("GET business-unit", JSON(http.StatusOK, NewBusinessUnitList(NewBusinessUnit())))
+	srv.Handle("GET certificate-search", JSON(http.StatusOK, NewCertificateSearch(NewCertificate(), NewCertificate(WithID("c2")))))
+	srv.Handle("GET enrollment/{code}", JSON(http.StatusOK, NewEnrollment(func(e *digicert.Enrollment) {
+		e.Status = digicert.EnrollmentStatusCompleted
+	})))
+	srv.Handle("GET business-unit/{id}", Error(http.StatusNotFound, "not_found", "no such business unit"))
+	client := srv.Client(t, digicert.WithStrictDecoding())
+	ctx := context.Background()
+
+	profiles, _, err := client.Profiles.List(ctx, nil)
+	if err != nil || profiles.Total != 2 || profiles.Profiles[1].ID != "p2" {
+		t.Errorf("Profiles.List() = %+v, %v, want both profiles", profiles, err)
+	}
+	units, _, err := client.BusinessUnits.List(ctx, nil)
+	if err != nil || len(units.BusinessUnits) != 1 {
+		t.Errorf("BusinessUnits.List() = %+v, %v, want one business unit", units, err)
+	}
+	certs, _, err := client.Certificates.Search(ctx, nil)
+	if err != nil || len(certs.Items) != 2 || certs.Items[1].ID != "c2" {
+		t.Errorf("Certificates.Search() = %+v, %v, want both certificates", certs, err)
+	}
+	enrollment, _, err := client.Enrollments.Get(ctx, "X7K2")
+	if err != nil || !enrollment.Status.IsSuccessful() {
+		t.Errorf("Enrollments.Get() = %+v, %v, want a completed enrollment", enrollment, err)
+	}
+	if _, _, err := client.BusinessUnits.Get(ctx, "missing"); !digicert.IsNotFound(err) {
+		t.Errorf("BusinessUnits.Get() error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,91 @@
+package digicerttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+// Server is a fake TLM API. Requests that match no route fail the test and
+// get a 404 API error.
+type Server struct {
+	*httptest.Server
+	mux *http.ServeMux
+}
+
+// NewServer starts a Server that is closed when the test ends.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+	s := &Server{mux: http.NewServeMux()}
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		tb.Errorf("digicerttest: unexpected request %s %s", r.Method, r.URL)
+		Error(http.StatusNotFound, "not_found", "no route for "+r.Method+" "+r.URL.Path)(w, r)
+	})
+	s.Server = httptest.NewServer(s.mux)
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Handle routes requests for pattern to handler. Patterns take the
+// net/http.ServeMux form, relative to the API version, such as
+// "GET certificate/{serial}" or "profiles".
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	method, endpoint, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, endpoint = "", pattern
+	}
+	route := APIPath(strings.TrimPrefix(strings.TrimSpace(endpoint), "/"))
+	if method != "" {
+		route = method + " " + route
+	}
+	s.mux.Handle(route, handler)
+}
+
+// HandleFunc routes requests for pattern to handler.
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.Handle(pattern, http.HandlerFunc(handler))
+}
+
+// Client returns a client for the server, with opts applied after the base
+// URL.
+func (s *Server) Client(tb testing.TB, opts ...digicert.ClientOption) *digicert.Client {
+	tb.Helper()
+	opts = append([]digicert.ClientOption{digicert.WithBaseURL(s.URL + "/")}, opts...)
+	client, err := digicert.NewClient("test-key", opts...)
+	if err != nil {
+		tb.Fatalf("digicerttest: NewClient() error = %v", err)
+	}
+	return client
+}
+
+// JSON responds with status and body. A []byte or string body, such as a
+// Fixture, is written as is; anything else is encoded as JSON.
+func JSON(status int, body interface{}) http.HandlerFunc {
+	var data []byte
+	switch b := body.(type) {
+	case []byte:
+		data = b
+	case string:
+		data = []byte(b)
+	default:
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			panic("digicerttest: encode response: " + err.Error())
+		}
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(data)
+	}
+}
+
+// Error responds with status and an API error body carrying code and
+// message.
+func Error(status int, code, message string) http.HandlerFunc {
+	return JSON(status, &digicert.APIError{Code: code, Message: message})
+}
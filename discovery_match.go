@@ -0,0 +1,167 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DiscoveryMatchedBy is how a discovered certificate was linked to the
+// inventory.
+type DiscoveryMatchedBy string
+
+const (
+	// MatchedByThumbprint matched the SHA-1 or SHA-256 thumbprint.
+	MatchedByThumbprint DiscoveryMatchedBy = "thumbprint"
+	// MatchedBySerial matched the serial number only. Serial numbers are
+	// unique per CA rather than globally, so treat these as weaker matches.
+	MatchedBySerial DiscoveryMatchedBy = "serial"
+)
+
+// DiscoveryMatch links an endpoint seen during a scan to its inventory
+// entry.
+type DiscoveryMatch struct {
+	Finding     ScanFinding        `json:"finding"`
+	Certificate Certificate        `json:"certificate"`
+	MatchedBy   DiscoveryMatchedBy `json:"matched_by"`
+}
+
+// DiscoveryReport sorts scan findings by whether the certificate served is
+// managed in the inventory.
+type DiscoveryReport struct {
+	Managed []DiscoveryMatch `json:"managed"`
+	// Unmanaged lists endpoints serving a certificate the inventory does
+	// not know about.
+	Unmanaged []ScanFinding `json:"unmanaged"`
+	// Unidentified lists findings without a thumbprint, serial number or
+	// certificate to match on, typically failed connections.
+	Unidentified []ScanFinding `json:"unidentified"`
+}
+
+// DiscoveryMatcher links discovered endpoint certificates to inventory
+// entries by thumbprint, falling back to serial number.
+type DiscoveryMatcher struct {
+	byThumbprint map[string]Certificate
+	bySerial     map[string]Certificate
+}
+
+// NewDiscoveryMatcher indexes inventory for matching.
+func NewDiscoveryMatcher(inventory []Certificate) *DiscoveryMatcher {
+	m := &DiscoveryMatcher{
+		byThumbprint: make(map[string]Certificate, len(inventory)),
+		bySerial:     make(map[string]Certificate, len(inventory)),
+	}
+	for _, c := range inventory {
+		m.Add(c)
+	}
+	return m
+}
+
+// Add indexes one inventory certificate. Certificates with a PEM are indexed
+// by both thumbprints, so findings reporting either match.
+func (m *DiscoveryMatcher) Add(c Certificate) {
+	if t := NormalizeThumbprint(c.Thumbprint); t != "" {
+		m.byThumbprint[t] = c
+	}
+	if c.Certificate != "" {
+		for _, t := range pemThumbprints(c.Certificate) {
+			m.byThumbprint[t] = c
+		}
+	}
+	if s := normalizeSerial(c.SerialNumber); s != "" {
+		m.bySerial[s] = c
+	}
+}
+
+// Match returns the inventory entry for a finding. ok is false for
+// unmanaged and unidentified findings.
+func (m *DiscoveryMatcher) Match(f ScanFinding) (cert Certificate, by DiscoveryMatchedBy, ok bool) {
+	thumbprints := pemThumbprints(f.Certificate)
+	if t := NormalizeThumbprint(f.Thumbprint); t != "" {
+		thumbprints = append(thumbprints, t)
+	}
+	for _, t := range thumbprints {
+		if cert, ok := m.byThumbprint[t]; ok {
+			return cert, MatchedByThumbprint, true
+		}
+	}
+	if s := normalizeSerial(f.SerialNumber); s != "" {
+		if cert, ok := m.bySerial[s]; ok {
+			return cert, MatchedBySerial, true
+		}
+	}
+	return Certificate{}, "", false
+}
+
+// MatchAll sorts findings into managed, unmanaged and unidentified, keeping
+// their order.
+func (m *DiscoveryMatcher) MatchAll(findings []ScanFinding) *DiscoveryReport {
+	report := &DiscoveryReport{}
+	for _, f := range findings {
+		if f.Thumbprint == "" && f.SerialNumber == "" && f.Certificate == "" {
+			report.Unidentified = append(report.Unidentified, f)
+			continue
+		}
+		if cert, by, ok := m.Match(f); ok {
+			report.Managed = append(report.Managed, DiscoveryMatch{Finding: f, Certificate: cert, MatchedBy: by})
+			continue
+		}
+		report.Unmanaged = append(report.Unmanaged, f)
+	}
+	return report
+}
+
+// MatchScan fetches every finding of a scan and matches it against the
+// certificates selected by search, or the whole visible inventory when
+// search is nil.
+func (s *AgentsService) MatchScan(ctx context.Context, scanID string, search *CertificateSearchOptions) (*DiscoveryReport, error) {
+	var findings []ScanFinding
+	opts := &ScanResultsOptions{PaginationParams: PaginationParams{Limit: 100}}
+	for {
+		page, _, err := s.GetScanResults(ctx, scanID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("discovery: scan %s results: %w", scanID, err)
+		}
+		findings = append(findings, page.Results...)
+		opts.Offset += len(page.Results)
+		if len(page.Results) == 0 || opts.Offset >= page.Total {
+			break
+		}
+	}
+
+	matcher := NewDiscoveryMatcher(nil)
+	for cert, err := range s.client.Certificates.All(ctx, search) {
+		if err != nil {
+			return nil, fmt.Errorf("discovery: inventory: %w", err)
+		}
+		matcher.Add(cert)
+	}
+	return matcher.MatchAll(findings), nil
+}
+
+// pemThumbprints returns the SHA-1 and SHA-256 thumbprints of a PEM
+// certificate, or nil if it cannot be parsed.
+func pemThumbprints(pemData string) []string {
+	if pemData == "" {
+		return nil
+	}
+	sha1, err := ThumbprintSHA1([]byte(pemData))
+	if err != nil {
+		return nil
+	}
+	sha256, _ := ThumbprintSHA256([]byte(pemData))
+	return []string{sha1, sha256}
+}
+
+// normalizeSerial returns a serial number as upper case hex without
+// separators or leading zeros.
+func normalizeSerial(serial string) string {
+	s := strings.ToUpper(compactHex(strings.TrimSpace(serial)))
+	if s == "" {
+		return ""
+	}
+	if s = strings.TrimLeft(s, "0"); s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package digicert
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDiscoveryMatcher_MatchAll(t *testing.T) {
+	now := time.Now()
+	served := newTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "www.example.com"}, NotBefore: now, NotAfter: now.Add(time.Hour)}, nil)
+	sha256, _ := ThumbprintSHA256([]byte(served.pem))
+
+	matcher := NewDiscoveryMatcher([]Certificate{
+		{ID: "pem", Certificate: served.pem},
+		{ID: "sha1", Thumbprint: "AB:CD:EF:01"},
+		{ID: "serial", SerialNumber: "00:0A:1B"},
+	})
+
+	findings := []ScanFinding{
+		{Host: "a", Port: 443, Thumbprint: FormatThumbprint(sha256)},
+		{Host: "b", Port: 443, Thumbprint: "abcdef01"},
+		{Host: "c", Port: 8443, SerialNumber: "a1b"},
+		{Host: "d", Port: 443, Certificate: served.pem},
+		{Host: "e", Port: 443, Thumbprint: "ffff", SerialNumber: "99"},
+		{Host: "f", Port: 443, Error: "connection refused"},
+	}
+	report := matcher.MatchAll(findings)
+
+	want := []struct {
+		host string
+		id   string
+		by   DiscoveryMatchedBy
+	}{
+		{"a", "pem", MatchedByThumbprint},
+		{"b", "sha1", MatchedByThumbprint},
+		{"c", "serial", MatchedBySerial},
+		{"d", "pem", MatchedByThumbprint},
+	}
+	if len(report.Managed) != len(want) {
+		t.Fatalf("Managed = %d findings, want %d", len(report.Managed), len(want))
+	}
+	for i, w := range want {
+		m := report.Managed[i]
+		if m.Finding.Host != w.host || m.Certificate.ID != w.id || m.MatchedBy != w.by {
+			t.Errorf("Managed[%d] = %s -> %s by %s, want %s -> %s by %s", i, m.Finding.Host, m.Certificate.ID, m.MatchedBy, w.host, w.id, w.by)
+		}
+	}
+	if len(report.Unmanaged) != 1 || report.Unmanaged[0].Host != "e" {
+		t.Errorf("Unmanaged = %+v, want host e", report.Unmanaged)
+	}
+	if len(report.Unidentified) != 1 || report.Unidentified[0].Host != "f" {
+		t.Errorf("Unidentified = %+v, want host f", report.Unidentified)
+	}
+}
+
+func TestAgentsService_MatchScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mpki/api/v1/agents/scans/scan-1/results":
+			offset := r.URL.Query().Get("offset")
+			var results []ScanFinding
+			if offset == "" {
+				for i := 0; i < 100; i++ {
+					results = append(results, ScanFinding{Host: fmt.Sprintf("h%d", i), Port: 443, SerialNumber: "01"})
+				}
+			} else if offset == "100" {
+				results = []ScanFinding{{Host: "rogue", Port: 443, SerialNumber: "02"}}
+			}
+			json.NewEncoder(w).Encode(ScanResultsResponse{ListResponse: ListResponse{Total: 101}, Results: results})
+		case "/mpki/api/v1/certificate-search":
+			w.Write([]byte(`{"total": 1, "items": [{"id": "cert-1", "serial_number": "01"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	report, err := client.Agents.MatchScan(context.Background(), "scan-1", nil)
+	if err != nil {
+		t.Fatalf("MatchScan() error = %v", err)
+	}
+	if len(report.Managed) != 100 || len(report.Unmanaged) != 1 || report.Unmanaged[0].Host != "rogue" {
+		t.Errorf("report = %d managed, unmanaged %+v, want 100 and the rogue endpoint", len(report.Managed), report.Unmanaged)
+	}
+}
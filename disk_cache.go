@@ -0,0 +1,254 @@
+package digicert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultCachedPaths lists the endpoints a DiskCache stores by default:
+// profiles and notification templates, which change rarely but are read on
+// almost every run of a CLI or job.
+var DefaultCachedPaths = []string{"profiles", "notification-template"}
+
+// DiskCache stores successful GET responses for slow-changing resources in
+// a directory, so short-lived processes such as CLI invocations do not
+// fetch the same metadata on every run. Entries are keyed on the full URL
+// and request headers, with the API key hashed rather than stored, and
+// expire after TTL. A successful write to a cached endpoint drops the
+// entries for that endpoint.
+//
+// Files are written with 0600 permissions in a 0700 directory. Several
+// processes may share a directory; entries are replaced atomically.
+type DiskCache struct {
+	Dir string
+	TTL time.Duration
+	// Paths lists the endpoint prefixes to cache, such as "profiles" or
+	// "profiles/123/scep", relative to the API version. Defaults to
+	// DefaultCachedPaths. Add the issuing CA endpoints your account uses
+	// here to cache them too.
+	Paths []string
+
+	now func() time.Time
+}
+
+// NewDiskCache returns a cache of DefaultCachedPaths in dir whose entries
+// live for ttl.
+func NewDiskCache(dir string, ttl time.Duration) *DiskCache {
+	return &DiskCache{Dir: dir, TTL: ttl}
+}
+
+// WithDiskCache serves GET requests for the cache's paths from disk while
+// the stored response is fresh. Use ContextWithoutCache to force a fetch.
+func WithDiskCache(cache *DiskCache) ClientOption {
+	return func(c *Client) error {
+		if cache == nil {
+			return fmt.Errorf("disk cache cannot be nil")
+		}
+		if cache.Dir == "" {
+			return fmt.Errorf("disk cache directory is required")
+		}
+		if cache.TTL <= 0 {
+			return fmt.Errorf("disk cache TTL must be positive")
+		}
+		c.cache = cache
+		return nil
+	}
+}
+
+type skipCacheContextKey struct{}
+
+// ContextWithoutCache returns a context whose requests bypass the disk
+// cache when reading. The fresh responses are still stored.
+func ContextWithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheContextKey{}, true)
+}
+
+// Clear removes every entry from the cache.
+func (d *DiskCache) Clear() error {
+	return d.removeMatching("*.json")
+}
+
+// cacheEntry is the on-disk form of a cached response.
+type cacheEntry struct {
+	URL      string      `json:"url"`
+	StoredAt time.Time   `json:"stored_at"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+}
+
+// endpoint returns the request path relative to the API version.
+func endpoint(req *http.Request) string {
+	_, rest, ok := strings.Cut(req.URL.Path, "/mpki/api/")
+	if !ok {
+		return ""
+	}
+	_, rest, _ = strings.Cut(rest, "/")
+	return rest
+}
+
+func (d *DiskCache) paths() []string {
+	if d.Paths == nil {
+		return DefaultCachedPaths
+	}
+	return d.Paths
+}
+
+// covers reports whether the cache stores responses for path.
+func (d *DiskCache) covers(path string) bool {
+	for _, p := range d.paths() {
+		p = strings.Trim(p, "/")
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// file returns the entry path for req. Entries are named after the first
+// path segment so writes can drop them without reading each one.
+func (d *DiskCache) file(req *http.Request) string {
+	sum := sha256.Sum256([]byte(coalesceKey(req)))
+	return filepath.Join(d.Dir, cacheFilePrefix(endpoint(req))+hex.EncodeToString(sum[:])+".json")
+}
+
+func cacheFilePrefix(path string) string {
+	segment, _, _ := strings.Cut(path, "/")
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, segment) + "-"
+}
+
+func (d *DiskCache) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+	return time.Now()
+}
+
+// get returns the fresh entry for req. Unreadable or corrupt entries count
+// as misses.
+func (d *DiskCache) get(req *http.Request) (*cacheEntry, bool) {
+	data, err := os.ReadFile(d.file(req))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != req.URL.String() {
+		return nil, false
+	}
+	if d.clock().Sub(entry.StoredAt) >= d.TTL {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (d *DiskCache) put(req *http.Request, resp *Response) error {
+	header := resp.Header.Clone()
+	for _, name := range []string{"Set-Cookie", "Date", "Content-Length"} {
+		header.Del(name)
+	}
+	data, err := json.Marshal(&cacheEntry{
+		URL:      req.URL.String(),
+		StoredAt: d.clock(),
+		Header:   header,
+		Body:     resp.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(d.Dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.file(req))
+}
+
+// invalidate drops the entries sharing req's first path segment.
+func (d *DiskCache) invalidate(req *http.Request) error {
+	return d.removeMatching(cacheFilePrefix(endpoint(req)) + "*.json")
+}
+
+func (d *DiskCache) removeMatching(pattern string) error {
+	files, err := filepath.Glob(filepath.Join(d.Dir, pattern))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// doCached serves req from the disk cache when it holds a fresh response,
+// and otherwise sends it and stores a successful result. Failing to store
+// an entry does not fail the request.
+func (c *Client) doCached(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := c.doUncached(ctx, req, v)
+		if err == nil {
+			c.cache.invalidate(req)
+		}
+		return resp, err
+	}
+
+	// Conditional requests go to the API so the caller learns whether the
+	// resource changed, and their 304 responses are not cached.
+	if skip, _ := ctx.Value(skipCacheContextKey{}).(bool); !skip && !conditional(req) {
+		if entry, ok := c.cache.get(req); ok {
+			resp := &Response{
+				Response: &http.Response{
+					Status:     "200 OK",
+					StatusCode: http.StatusOK,
+					Proto:      "HTTP/1.1",
+					ProtoMajor: 1,
+					ProtoMinor: 1,
+					Header:     entry.Header,
+					Body:       http.NoBody,
+					Request:    req,
+				},
+				Body:      entry.Body,
+				FromCache: true,
+			}
+			resp.setValidators()
+			if v != nil && len(entry.Body) > 0 {
+				if err := c.decode(entry.Body, v); err != nil {
+					return resp, fmt.Errorf("failed to decode response: %w", err)
+				}
+			}
+			return resp, nil
+		}
+	}
+
+	resp, err := c.doUncached(ctx, req, v)
+	if err == nil && resp.StatusCode == http.StatusOK {
+		c.cache.put(req, resp)
+	}
+	return resp, err
+}
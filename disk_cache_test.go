@@ -0,0 +1,153 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch {
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{}`))
+		case strings.HasPrefix(r.URL.Path, "/mpki/api/v1/profiles/"):
+			w.Header().Set("X-Request-Id", "req-1")
+			w.Write([]byte(`{"id": "p1", "name": "Web"}`))
+		default:
+			w.Write([]byte(`{"id": "bu1", "name": "Eng"}`))
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	cache := NewDiskCache(filepath.Join(t.TempDir(), "cache"), time.Hour)
+	cache.now = func() time.Time { return now }
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithDiskCache(cache))
+	ctx := context.Background()
+
+	get := func(ctx context.Context) *Response {
+		t.Helper()
+		profile, resp, err := client.Profiles.Get(ctx, "p1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if profile.Name != "Web" {
+			t.Errorf("Name = %v, want Web", profile.Name)
+		}
+		return resp
+	}
+	expectCalls := func(want int32) {
+		t.Helper()
+		if got := calls.Load(); got != want {
+			t.Errorf("upstream calls = %v, want %v", got, want)
+		}
+	}
+
+	if resp := get(ctx); resp.FromCache {
+		t.Error("FromCache = true on first fetch")
+	}
+	resp := get(ctx)
+	expectCalls(1)
+	if !resp.FromCache || resp.StatusCode != http.StatusOK || resp.Header.Get("X-Request-Id") != "req-1" {
+		t.Errorf("cached response = %+v, want a 200 with the stored headers", resp.Response)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(cache.Dir, "*"))
+	if len(files) != 1 || !strings.HasPrefix(filepath.Base(files[0]), "profiles-") {
+		t.Fatalf("cache files = %v, want one profiles entry", files)
+	}
+	if info, _ := os.Stat(files[0]); info.Mode().Perm() != 0600 {
+		t.Errorf("entry mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "test-key") {
+		t.Error("cache entry contains the API key")
+	}
+
+	// Another key must not read this key's entries.
+	other, _ := NewClient("other-key", WithBaseURL(server.URL+"/"), WithDiskCache(cache))
+	other.Profiles.Get(ctx, "p1")
+	expectCalls(2)
+
+	get(ContextWithoutCache(ctx))
+	expectCalls(3)
+
+	now = now.Add(time.Hour)
+	get(ctx)
+	expectCalls(4)
+	get(ctx)
+	expectCalls(4)
+
+	// Uncovered endpoints are not cached.
+	client.BusinessUnits.Get(ctx, "bu1")
+	client.BusinessUnits.Get(ctx, "bu1")
+	expectCalls(6)
+
+	// A write to profiles drops the profile entries.
+	if _, _, err := client.Profiles.RotateSCEPChallenge(ctx, "p1", &SCEPChallengeRequest{}); err != nil {
+		t.Fatalf("RotateSCEPChallenge() error = %v", err)
+	}
+	get(ctx)
+	expectCalls(8)
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	get(ctx)
+	expectCalls(9)
+}
+
+func TestDiskCache_CorruptEntry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"id": "t1"}`))
+	}))
+	defer server.Close()
+
+	cache := NewDiskCache(t.TempDir(), time.Hour)
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithDiskCache(cache))
+	ctx := context.Background()
+
+	client.Templates.Get(ctx, "t1")
+	files, _ := filepath.Glob(filepath.Join(cache.Dir, "notification-template-*.json"))
+	if len(files) != 1 {
+		t.Fatalf("cache files = %v, want one template entry", files)
+	}
+	os.WriteFile(files[0], []byte("{"), 0600)
+
+	template, _, err := client.Templates.Get(ctx, "t1")
+	if err != nil || template.ID != "t1" {
+		t.Fatalf("Get() = %v, %v, want the template refetched", template, err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("upstream calls = %v, want 2", got)
+	}
+}
+
+func TestWithDiskCache_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		cache *DiskCache
+	}{
+		{"nil cache", nil},
+		{"no directory", NewDiskCache("", time.Hour)},
+		{"no TTL", NewDiskCache(t.TempDir(), 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient("test-key", WithDiskCache(tt.cache)); err == nil {
+				t.Error("NewClient() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -42,14 +42,19 @@ The client provides access to various API services:
 
   - Certificates: Issue, search, get, revoke, and renew certificates
   - Enrollments: Create and manage certificate enrollments
-  - BusinessUnits: Manage organizational units and seat allocations
+  - BusinessUnits: Manage organizational units, seat allocations and seat usage reports
   - CertificateOwners: Manage certificate ownership
   - Profiles: List and retrieve certificate profiles
-  - Agents: Certificate discovery agents (placeholder)
-  - Automation: Certificate lifecycle automation (placeholder)
-  - AuditLog: Audit log retrieval (placeholder)
+  - Agents: Certificate discovery agent provisioning
+  - Automation: Automation target registration, verification and profile binding
+  - AuditLog: Audit log search by category and severity, typed event details and cursor-checkpointed streaming
   - CustomFields: Custom field management (placeholder)
-  - ACME: ACME protocol operations (placeholder)
+  - ACME: ACME directory lookup and account/order auditing
+  - Templates: Notification email template listing, editing and preview
+  - Tags: Tag usage counts, renaming and clean-up across resources
+  - Admins: Account administrator invitation, role changes and deactivation
+  - Invitations: Pending admin and owner invitations, resending and revocation
+  - Orders: Order lookup, including the order a certificate was issued from
 
 # Configuration
 
@@ -0,0 +1,188 @@
+package digicert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// DuplicateKind is the reason certificates were grouped as duplicates.
+type DuplicateKind string
+
+const (
+	// DuplicatePublicKey groups certificates issued for the same key pair.
+	DuplicatePublicKey DuplicateKind = "public_key"
+	// DuplicateNames groups certificates with the same common name and DNS
+	// SANs.
+	DuplicateNames DuplicateKind = "names"
+	// DuplicateOverlap groups certificates with different name sets that
+	// share a DNS name.
+	DuplicateOverlap DuplicateKind = "overlap"
+)
+
+// DuplicateEntry summarises a certificate in a DuplicateGroup. Only these
+// fields are kept, so large inventories can be analysed in bounded memory.
+type DuplicateEntry struct {
+	ID             string `json:"id"`
+	SerialNumber   string `json:"serial_number"`
+	CommonName     string `json:"common_name"`
+	Status         string `json:"status"`
+	ValidTo        string `json:"valid_to"`
+	BusinessUnitID string `json:"business_unit_id,omitempty"`
+}
+
+type DuplicateGroup struct {
+	Kind DuplicateKind `json:"kind"`
+	// Key is the SHA-256 public key hash, the comma separated name set or
+	// the shared DNS name, depending on Kind.
+	Key          string           `json:"key"`
+	Certificates []DuplicateEntry `json:"certificates"`
+}
+
+// BusinessUnits returns the distinct business units in the group.
+func (g *DuplicateGroup) BusinessUnits() []string {
+	var units []string
+	for _, c := range g.Certificates {
+		if c.BusinessUnitID != "" && !containsString(units, c.BusinessUnitID) {
+			units = append(units, c.BusinessUnitID)
+		}
+	}
+	sort.Strings(units)
+	return units
+}
+
+// CrossBusinessUnit reports whether the group spans business units.
+func (g *DuplicateGroup) CrossBusinessUnit() bool {
+	return len(g.BusinessUnits()) > 1
+}
+
+// DuplicateReport is the result of FindDuplicates. Groups are ordered by
+// kind, as the DuplicateKind constants are listed, then by key.
+type DuplicateReport struct {
+	Groups []DuplicateGroup `json:"groups"`
+	// Scanned counts the certificates analysed.
+	Scanned int `json:"scanned"`
+	// WithoutKey counts scanned certificates whose PEM was not returned, so
+	// they could not be grouped by public key.
+	WithoutKey int `json:"without_key"`
+}
+
+type DuplicateOptions struct {
+	// Search selects the certificates analysed, e.g. a business unit or
+	// profile. All business units visible to the client are analysed by
+	// default.
+	Search CertificateSearchOptions
+	// IncludeInactive also analyses revoked and expired certificates.
+	IncludeInactive bool
+}
+
+// FindDuplicates streams the certificate inventory and groups certificates
+// that share a public key, have identical names, or overlap on a DNS name,
+// to find consolidation candidates within and across business units.
+func (s *CertificatesService) FindDuplicates(ctx context.Context, opts *DuplicateOptions) (*DuplicateReport, error) {
+	if opts == nil {
+		opts = &DuplicateOptions{}
+	}
+
+	var (
+		entries []DuplicateEntry
+		byKey   = make(map[string][]int)
+		byNames = make(map[string][]int)
+		byName  = make(map[string][]int)
+		nameSet []string
+	)
+	report := &DuplicateReport{}
+	for c, err := range s.All(ctx, &opts.Search) {
+		if err != nil {
+			return nil, err
+		}
+		if !opts.IncludeInactive {
+			switch strings.ToLower(c.Status) {
+			case "revoked", "expired":
+				continue
+			}
+		}
+		report.Scanned++
+
+		i := len(entries)
+		entry := DuplicateEntry{ID: c.ID, SerialNumber: c.SerialNumber, CommonName: c.CommonName, Status: c.Status, ValidTo: c.ValidTo}
+		if c.BusinessUnit != nil {
+			entry.BusinessUnitID = c.BusinessUnit.ID
+		}
+		entries = append(entries, entry)
+
+		if hash := publicKeyHash(c); hash != "" {
+			byKey[hash] = append(byKey[hash], i)
+		} else {
+			report.WithoutKey++
+		}
+
+		names := certificateNameSet(c)
+		key := strings.Join(names, ",")
+		byNames[key] = append(byNames[key], i)
+		nameSet = append(nameSet, key)
+		for _, name := range names {
+			if looksLikeHostname(name) {
+				byName[name] = append(byName[name], i)
+			}
+		}
+	}
+
+	group := func(kind DuplicateKind, index map[string][]int, keep func([]int) bool) {
+		for _, key := range sortedKeys(index) {
+			members := index[key]
+			if len(members) < 2 || !keep(members) {
+				continue
+			}
+			g := DuplicateGroup{Kind: kind, Key: key}
+			for _, i := range members {
+				g.Certificates = append(g.Certificates, entries[i])
+			}
+			report.Groups = append(report.Groups, g)
+		}
+	}
+	all := func([]int) bool { return true }
+	group(DuplicatePublicKey, byKey, all)
+	group(DuplicateNames, byNames, func(members []int) bool { return nameSet[members[0]] != "" })
+	// A shared name is only an overlap if the name sets differ; identical
+	// sets are already reported as DuplicateNames.
+	group(DuplicateOverlap, byName, func(members []int) bool {
+		for _, i := range members[1:] {
+			if nameSet[i] != nameSet[members[0]] {
+				return true
+			}
+		}
+		return false
+	})
+	return report, nil
+}
+
+// publicKeyHash returns the hex SHA-256 of the certificate's
+// SubjectPublicKeyInfo, or "" if its PEM is unavailable.
+func publicKeyHash(c Certificate) string {
+	if c.Certificate == "" {
+		return ""
+	}
+	cert, err := parseCertificatePEM(c.Certificate)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(sum[:])
+}
+
+// certificateNameSet returns the sorted, lower-cased, de-duplicated common
+// name and DNS SANs of c.
+func certificateNameSet(c Certificate) []string {
+	var names []string
+	for _, n := range append([]string{c.CommonName}, certificateDNSNames(c)...) {
+		n = strings.ToLower(strings.TrimSuffix(n, "."))
+		if n != "" && !containsString(names, n) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
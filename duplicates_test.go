@@ -0,0 +1,98 @@
+package digicert
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCertificatesService_FindDuplicates(t *testing.T) {
+	// Two certificates for the same key pair.
+	first := newTestCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "a.example.com"},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+	}, nil)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "b.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, first.cert, &first.key.PublicKey, first.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	second := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	inventory := []Certificate{
+		{ID: "1", CommonName: "a.example.com", Certificate: first.pem, BusinessUnit: &BusinessUnitRef{ID: "bu-1"}},
+		{ID: "2", CommonName: "b.example.com", Certificate: second, BusinessUnit: &BusinessUnitRef{ID: "bu-1"}},
+		{ID: "3", CommonName: "WWW.example.com", BusinessUnit: &BusinessUnitRef{ID: "bu-1"}},
+		{ID: "4", CommonName: "www.example.com", BusinessUnit: &BusinessUnitRef{ID: "bu-2"}},
+		{ID: "5", CommonName: "www.example.com", Status: "revoked"},
+		{ID: "6", CommonName: "shop.example.com"},
+	}
+	// Certificate 6 also covers www.example.com through a SAN.
+	sans := `{"id": "6", "common_name": "shop.example.com", "sans": {"dns_names": ["shop.example.com", "www.example.com"]}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := min(offset+2, len(inventory))
+		var items []json.RawMessage
+		for _, c := range inventory[offset:end] {
+			data, _ := json.Marshal(c)
+			if c.ID == "6" {
+				data = []byte(sans)
+			}
+			items = append(items, data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"total": len(inventory), "items": items})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	report, err := client.Certificates.FindDuplicates(context.Background(), &DuplicateOptions{Search: CertificateSearchOptions{PaginationParams: PaginationParams{Limit: 2}}})
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if report.Scanned != 5 || report.WithoutKey != 3 {
+		t.Errorf("Scanned = %d, WithoutKey = %d, want 5 and 3", report.Scanned, report.WithoutKey)
+	}
+
+	type summary struct {
+		kind  DuplicateKind
+		ids   string
+		cross bool
+	}
+	var got []summary
+	for _, g := range report.Groups {
+		ids := ""
+		for _, c := range g.Certificates {
+			ids += c.ID
+		}
+		got = append(got, summary{g.Kind, ids, g.CrossBusinessUnit()})
+	}
+	want := []summary{
+		{DuplicatePublicKey, "12", false},
+		{DuplicateNames, "34", true},
+		{DuplicateOverlap, "346", true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Groups = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Groups[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package digicert
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// EnrollmentPortalPath is the path of the end-user enrollment portal on a
+// Trust Lifecycle Manager host.
+const EnrollmentPortalPath = "/mpki/enroll"
+
+// QREncoder renders content as a size x size pixel QR code PNG. This package
+// has no QR encoder of its own; wrap a library such as
+// github.com/skip2/go-qrcode:
+//
+//	func(content string, size int) ([]byte, error) {
+//		return qrcode.Encode(content, qrcode.Medium, size)
+//	}
+type QREncoder func(content string, size int) ([]byte, error)
+
+// PortalURL returns the link an end user follows to redeem the enrollment,
+// for example in an onboarding email. host is the account's Trust Lifecycle
+// Manager hostname, such as "one.digicert.com" or client.BaseURL.Host; a
+// full https URL is also accepted. The link carries the enrollment code, so
+// treat it as a secret.
+func (e *EnrollmentResponse) PortalURL(host string) (string, error) {
+	if e == nil || e.EnrollmentCode == "" {
+		return "", errors.New("digicert: enrollment has no enrollment code")
+	}
+
+	base, err := portalBaseURL(host)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("code", e.EnrollmentCode)
+	if e.EnrollmentID != "" {
+		q.Set("enrollment_id", e.EnrollmentID)
+	}
+	base.Path = EnrollmentPortalPath
+	base.RawQuery = q.Encode()
+	return base.String(), nil
+}
+
+// PortalQRCode renders the PortalURL as a QR code PNG of size pixels square
+// using encode.
+func (e *EnrollmentResponse) PortalQRCode(host string, size int, encode QREncoder) ([]byte, error) {
+	if encode == nil {
+		return nil, fmt.Errorf("QR encoder cannot be nil")
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("QR code size must be positive")
+	}
+	link, err := e.PortalURL(host)
+	if err != nil {
+		return nil, err
+	}
+	return encode(link, size)
+}
+
+func portalBaseURL(host string) (*url.URL, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return nil, errors.New("digicert: enrollment portal host is required")
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("digicert: invalid enrollment portal host %q", host)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("digicert: enrollment portal host %q must use https", host)
+	}
+	return &url.URL{Scheme: u.Scheme, Host: u.Host}, nil
+}
@@ -0,0 +1,66 @@
+package digicert
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEnrollmentResponse_PortalURL(t *testing.T) {
+	enrollment := &EnrollmentResponse{EnrollmentID: "enr-1", EnrollmentCode: "a b&c"}
+	want := "https://one.digicert.com/mpki/enroll?code=a+b%26c&enrollment_id=enr-1"
+
+	tests := []struct {
+		name    string
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{"hostname", "one.digicert.com", want, false},
+		{"https URL with path", "https://one.digicert.com/account/", want, false},
+		{"hostname with port", "tlm.example.com:8443", "https://tlm.example.com:8443/mpki/enroll?code=a+b%26c&enrollment_id=enr-1", false},
+		{"plain http", "http://one.digicert.com", "", true},
+		{"empty", " ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := enrollment.PortalURL(tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PortalURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PortalURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&EnrollmentResponse{EnrollmentID: "enr-1"}).PortalURL("one.digicert.com"); err == nil {
+		t.Error("PortalURL() without a code error = nil, want error")
+	}
+}
+
+func TestEnrollmentResponse_PortalQRCode(t *testing.T) {
+	enrollment := &EnrollmentResponse{EnrollmentCode: "code-1"}
+
+	var gotContent string
+	var gotSize int
+	png, err := enrollment.PortalQRCode("one.digicert.com", 256, func(content string, size int) ([]byte, error) {
+		gotContent, gotSize = content, size
+		return []byte("\x89PNG"), nil
+	})
+	if err != nil {
+		t.Fatalf("PortalQRCode() error = %v", err)
+	}
+	if !bytes.Equal(png, []byte("\x89PNG")) || gotSize != 256 || gotContent != "https://one.digicert.com/mpki/enroll?code=code-1" {
+		t.Errorf("encoder called with %q at %d, returned %q", gotContent, gotSize, png)
+	}
+
+	failing := errors.New("encode failed")
+	if _, err := enrollment.PortalQRCode("one.digicert.com", 256, func(string, int) ([]byte, error) { return nil, failing }); !errors.Is(err, failing) {
+		t.Errorf("PortalQRCode() error = %v, want %v", err, failing)
+	}
+	if _, err := enrollment.PortalQRCode("one.digicert.com", 256, nil); err == nil {
+		t.Error("PortalQRCode(nil encoder) error = nil, want error")
+	}
+}
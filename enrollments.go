@@ -2,8 +2,10 @@ package digicert
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -11,10 +13,38 @@ type EnrollmentsService struct {
 	client *Client
 }
 
+// EnrollmentStatus is the lifecycle state of an enrollment.
+type EnrollmentStatus string
+
+const (
+	EnrollmentStatusPending   EnrollmentStatus = "pending"
+	EnrollmentStatusRedeemed  EnrollmentStatus = "redeemed"
+	EnrollmentStatusExpired   EnrollmentStatus = "expired"
+	EnrollmentStatusRejected  EnrollmentStatus = "rejected"
+	EnrollmentStatusCompleted EnrollmentStatus = "completed"
+)
+
+// Is reports whether s equals other, ignoring case as the API is not
+// consistent about status casing.
+func (s EnrollmentStatus) Is(other EnrollmentStatus) bool {
+	return strings.EqualFold(string(s), string(other))
+}
+
+// IsTerminal reports whether the enrollment can no longer change state.
+func (s EnrollmentStatus) IsTerminal() bool {
+	return s.Is(EnrollmentStatusCompleted) || s.Is(EnrollmentStatusRedeemed) ||
+		s.Is(EnrollmentStatusExpired) || s.Is(EnrollmentStatusRejected)
+}
+
+// IsSuccessful reports whether the enrollment resulted in a certificate.
+func (s EnrollmentStatus) IsSuccessful() bool {
+	return s.Is(EnrollmentStatusCompleted) || s.Is(EnrollmentStatusRedeemed)
+}
+
 type Enrollment struct {
 	ID               string                 `json:"id,omitempty"`
 	EnrollmentCode   string                 `json:"enrollment_code,omitempty"`
-	Status           string                 `json:"status,omitempty"`
+	Status           EnrollmentStatus       `json:"status,omitempty"`
 	ProfileID        string                 `json:"profile_id,omitempty"`
 	ProfileName      string                 `json:"profile_name,omitempty"`
 	SeatID           string                 `json:"seat_id,omitempty"`
@@ -27,10 +57,58 @@ type Enrollment struct {
 	UpdatedAt        *time.Time             `json:"updated_at,omitempty"`
 	Tags             []string               `json:"tags,omitempty"`
 	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON decodes a enrollment, keeping unmodelled fields in Extra
+func (e *Enrollment) UnmarshalJSON(data []byte) error {
+	type alias Enrollment
+	extra, err := unmarshalWithExtra(data, (*alias)(e))
+	if err != nil {
+		return err
+	}
+	e.Extra = extra
+	e.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Raw returns the JSON the Enrollment was decoded from, or nil if it was not
+// decoded from a response
+func (e *Enrollment) Raw() json.RawMessage { return e.raw }
+
+func (e *Enrollment) unknownFields() map[string]json.RawMessage { return e.Extra }
+
+// IsActionable reports whether the enrollment is still waiting on the end user
+// or an approver and has not passed its expiration date.
+func (e *Enrollment) IsActionable() bool {
+	if !e.Status.Is(EnrollmentStatusPending) {
+		return false
+	}
+	return e.ExpirationDate == nil || time.Now().Before(*e.ExpirationDate)
+}
+
+// EnrollmentError is returned by WaitForCompletion when an enrollment reaches
+// a terminal state without producing a certificate.
+type EnrollmentError struct {
+	EnrollmentID string
+	Status       EnrollmentStatus
+	Message      string
+}
+
+func (e *EnrollmentError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("digicert: enrollment %s ended with status %s: %s", e.EnrollmentID, e.Status, e.Message)
+	}
+	return fmt.Sprintf("digicert: enrollment %s ended with status %s", e.EnrollmentID, e.Status)
 }
 
 type EnrollmentRequest struct {
 	Profile            ProfileReference      `json:"profile"`
+	BusinessUnitID     string                `json:"business_unit_id,omitempty"`
 	Seat               *SeatReference        `json:"seat,omitempty"`
 	Validity           *Validity             `json:"validity,omitempty"`
 	Email              string                `json:"email,omitempty"`
@@ -54,17 +132,17 @@ type EnrollmentAttributes struct {
 }
 
 type EnrollmentResponse struct {
-	EnrollmentID   string `json:"enrollment_id,omitempty"`
-	EnrollmentCode string `json:"enrollment_code,omitempty"`
-	Status         string `json:"status,omitempty"`
-	Message        string `json:"message,omitempty"`
+	EnrollmentID   string           `json:"enrollment_id,omitempty"`
+	EnrollmentCode string           `json:"enrollment_code,omitempty"`
+	Status         EnrollmentStatus `json:"status,omitempty"`
+	Message        string           `json:"message,omitempty"`
 }
 
 type EnrollmentStatusResponse struct {
-	Status        string     `json:"status"`
-	CertificateID string     `json:"certificate_id,omitempty"`
-	Message       string     `json:"message,omitempty"`
-	LastUpdated   *time.Time `json:"last_updated,omitempty"`
+	Status        EnrollmentStatus `json:"status"`
+	CertificateID string           `json:"certificate_id,omitempty"`
+	Message       string           `json:"message,omitempty"`
+	LastUpdated   *time.Time       `json:"last_updated,omitempty"`
 }
 
 type RedeemEnrollmentRequest struct {
@@ -74,6 +152,7 @@ type RedeemEnrollmentRequest struct {
 
 type ManualEnrollmentRequest struct {
 	Profile          ProfileReference       `json:"profile"`
+	BusinessUnitID   string                 `json:"business_unit_id,omitempty"`
 	Seat             *SeatReference         `json:"seat,omitempty"`
 	CSR              string                 `json:"csr"`
 	Validity         *Validity              `json:"validity,omitempty"`
@@ -87,12 +166,23 @@ type ManualEnrollmentRequest struct {
 	Comments         string                 `json:"comments,omitempty"`
 }
 
+type EnrollmentApprovalRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
 type EnrollmentDetailsOptions struct {
 	PaginationParams
-	Status    string `url:"status,omitempty"`
-	ProfileID string `url:"profile_id,omitempty"`
-	SortBy    string `url:"sort_by,omitempty"`
-	SortOrder string `url:"sort_order,omitempty"`
+	Status         EnrollmentStatus `url:"status,omitempty"`
+	ProfileID      string           `url:"profile_id,omitempty"`
+	SeatID         string           `url:"seat_id,omitempty"`
+	Email          string           `url:"email,omitempty"`
+	CommonName     string           `url:"common_name,omitempty"`
+	BusinessUnitID string           `url:"business_unit_id,omitempty"`
+	CreatedAfter   time.Time        `url:"created_after,omitempty"`
+	CreatedBefore  time.Time        `url:"created_before,omitempty"`
+	UpdatedAfter   time.Time        `url:"updated_after,omitempty"`
+	SortBy         string           `url:"sort_by,omitempty"`
+	SortOrder      string           `url:"sort_order,omitempty"`
 }
 
 type EnrollmentDetailsResponse struct {
@@ -102,12 +192,40 @@ type EnrollmentDetailsResponse struct {
 
 // Create creates a new enrollment
 func (s *EnrollmentsService) Create(ctx context.Context, req *EnrollmentRequest) (*EnrollmentResponse, *Response, error) {
+	if err := s.client.requireCapability("Enrollments.Create", CapabilityManageEnrollments); err != nil {
+		return nil, nil, err
+	}
+	if bu := s.client.businessUnitID; bu != "" && req != nil && req.BusinessUnitID == "" {
+		scoped := *req
+		scoped.BusinessUnitID = bu
+		req = &scoped
+	}
+	if req != nil {
+		cn, _, err := s.client.normalizeNames(req.CommonName, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		attrs, err := s.client.normalizeEnrollmentAttributes(req.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cn != req.CommonName || attrs != req.Attributes {
+			normalized := *req
+			normalized.CommonName = cn
+			normalized.Attributes = attrs
+			req = &normalized
+		}
+	}
+
 	u := "enrollment"
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := s.client.setIdempotencyKey(ctx, httpReq); err != nil {
+		return nil, nil, err
+	}
 
 	var enrollment EnrollmentResponse
 	resp, err := s.client.Do(ctx, httpReq, &enrollment)
@@ -174,6 +292,29 @@ func (s *EnrollmentsService) Redeem(ctx context.Context, req *RedeemEnrollmentRe
 
 // CreateManualEnrollment creates a manual enrollment (requires approval)
 func (s *EnrollmentsService) CreateManualEnrollment(ctx context.Context, req *ManualEnrollmentRequest) (*EnrollmentResponse, *Response, error) {
+	if err := s.client.requireCapability("CreateManualEnrollment", CapabilityManageEnrollments); err != nil {
+		return nil, nil, err
+	}
+	if bu := s.client.businessUnitID; bu != "" && req != nil && req.BusinessUnitID == "" {
+		scoped := *req
+		scoped.BusinessUnitID = bu
+		req = &scoped
+	}
+	if req != nil {
+		if err := req.DeliveryFormat.Validate(); err != nil {
+			return nil, nil, err
+		}
+		attrs, err := s.client.normalizeCertificateAttributes(req.Attributes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if attrs != req.Attributes {
+			normalized := *req
+			normalized.Attributes = attrs
+			req = &normalized
+		}
+	}
+
 	u := "manual-enrollment"
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
@@ -192,6 +333,9 @@ func (s *EnrollmentsService) CreateManualEnrollment(ctx context.Context, req *Ma
 
 // RenewManualEnrollment renews a certificate through manual enrollment
 func (s *EnrollmentsService) RenewManualEnrollment(ctx context.Context, certificateID string, req *ManualEnrollmentRequest) (*EnrollmentResponse, *Response, error) {
+	if err := s.client.requireCapability("RenewManualEnrollment", CapabilityManageEnrollments); err != nil {
+		return nil, nil, err
+	}
 	u := fmt.Sprintf("manual-enrollment/renew/%s", certificateID)
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
@@ -208,8 +352,53 @@ func (s *EnrollmentsService) RenewManualEnrollment(ctx context.Context, certific
 	return &enrollment, resp, nil
 }
 
+// Approve approves a pending manual enrollment
+func (s *EnrollmentsService) Approve(ctx context.Context, enrollmentID string, req *EnrollmentApprovalRequest) (*EnrollmentResponse, *Response, error) {
+	u := fmt.Sprintf("manual-enrollment/%s/approve", enrollmentID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var enrollment EnrollmentResponse
+	resp, err := s.client.Do(ctx, httpReq, &enrollment)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &enrollment, resp, nil
+}
+
+// Reject rejects a pending manual enrollment
+func (s *EnrollmentsService) Reject(ctx context.Context, enrollmentID string, req *EnrollmentApprovalRequest) (*EnrollmentResponse, *Response, error) {
+	u := fmt.Sprintf("manual-enrollment/%s/reject", enrollmentID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var enrollment EnrollmentResponse
+	resp, err := s.client.Do(ctx, httpReq, &enrollment)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &enrollment, resp, nil
+}
+
 // ListDetails lists enrollment details
 func (s *EnrollmentsService) ListDetails(ctx context.Context, opts *EnrollmentDetailsOptions) (*EnrollmentDetailsResponse, *Response, error) {
+	if bu := s.client.businessUnitID; bu != "" && (opts == nil || opts.BusinessUnitID == "") {
+		scoped := EnrollmentDetailsOptions{}
+		if opts != nil {
+			scoped = *opts
+		}
+		scoped.BusinessUnitID = bu
+		opts = &scoped
+	}
+
 	u := "enrollment-details"
 
 	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
@@ -221,13 +410,36 @@ func (s *EnrollmentsService) ListDetails(ctx context.Context, opts *EnrollmentDe
 	if opts != nil {
 		q := httpReq.URL.Query()
 		if opts.Status != "" {
-			q.Add("status", opts.Status)
+			q.Add("status", string(opts.Status))
 		}
 		if opts.ProfileID != "" {
 			q.Add("profile_id", opts.ProfileID)
 		}
-		if opts.Offset > 0 && opts.Limit > 0 {
+		if opts.SeatID != "" {
+			q.Add("seat_id", opts.SeatID)
+		}
+		if opts.Email != "" {
+			q.Add("email", opts.Email)
+		}
+		if opts.CommonName != "" {
+			q.Add("common_name", opts.CommonName)
+		}
+		if opts.BusinessUnitID != "" {
+			q.Add("business_unit_id", opts.BusinessUnitID)
+		}
+		if !opts.CreatedAfter.IsZero() {
+			q.Add("created_after", opts.CreatedAfter.UTC().Format(time.RFC3339Nano))
+		}
+		if !opts.CreatedBefore.IsZero() {
+			q.Add("created_before", opts.CreatedBefore.UTC().Format(time.RFC3339Nano))
+		}
+		if !opts.UpdatedAfter.IsZero() {
+			q.Add("updated_after", opts.UpdatedAfter.UTC().Format(time.RFC3339Nano))
+		}
+		if opts.Offset > 0 {
 			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+		}
+		if opts.Limit > 0 {
 			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
 		}
 		if opts.SortBy != "" {
@@ -283,3 +495,35 @@ func (s *EnrollmentsService) GetByCertificate(ctx context.Context, certificateID
 
 	return &enrollment, resp, nil
 }
+
+// WaitForCompletion polls the status of an enrollment until it reaches a
+// terminal state. On success the issued certificate is fetched and returned
+// along with the final status; otherwise an *EnrollmentError is returned
+func (s *EnrollmentsService) WaitForCompletion(ctx context.Context, enrollmentID string, opts *PollOptions) (*Certificate, *EnrollmentStatusResponse, error) {
+	var status *EnrollmentStatusResponse
+	err := poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		var err error
+		status, _, err = s.GetStatus(ctx, enrollmentID)
+		if err != nil {
+			return false, err
+		}
+		return status.Status.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, status, err
+	}
+
+	if !status.Status.IsSuccessful() {
+		return nil, status, &EnrollmentError{EnrollmentID: enrollmentID, Status: status.Status, Message: status.Message}
+	}
+	if status.CertificateID == "" {
+		return nil, status, &EnrollmentError{EnrollmentID: enrollmentID, Status: status.Status, Message: "no certificate ID reported"}
+	}
+
+	cert, _, err := s.client.Certificates.GetCertificate(ctx, status.CertificateID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	return cert, status, nil
+}
@@ -206,6 +206,48 @@ func TestEnrollmentsService_ListDetails(t *testing.T) {
 		}
 	})
 
+	t.Run("list with triage filters", func(t *testing.T) {
+		after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2024, 2, 1, 12, 30, 0, 750000000, time.FixedZone("CET", 3600))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			expected := map[string]string{
+				"seat_id":          "seat-1",
+				"email":            "user@example.com",
+				"common_name":      "host.example.com",
+				"business_unit_id": "bu-1",
+				"created_after":    "2024-01-01T00:00:00Z",
+				"created_before":   "2024-02-01T11:30:00.75Z",
+				"updated_after":    "2024-01-15T08:00:00.25Z",
+			}
+			for key, want := range expected {
+				if got := q.Get(key); got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(EnrollmentDetailsResponse{})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.Enrollments.ListDetails(ctx, &EnrollmentDetailsOptions{
+			SeatID:         "seat-1",
+			Email:          "user@example.com",
+			CommonName:     "host.example.com",
+			BusinessUnitID: "bu-1",
+			CreatedAfter:   after,
+			CreatedBefore:  before,
+			UpdatedAfter:   time.Date(2024, 1, 15, 8, 0, 0, 250000000, time.UTC),
+		})
+		if err != nil {
+			t.Fatalf("ListDetails() error = %v", err)
+		}
+	})
+
 	t.Run("list without pagination parameters", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			q := r.URL.Query()
@@ -247,4 +289,177 @@ func TestEnrollmentsService_ListDetails(t *testing.T) {
 			t.Fatalf("ListDetails() error = %v", err)
 		}
 	})
-}
\ No newline at end of file
+
+	t.Run("limit without offset", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Has("offset") {
+				t.Errorf("offset parameter should not be present when value is 0")
+			}
+			if got := q.Get("limit"); got != "25" {
+				t.Errorf("limit = %v, want %v", got, "25")
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(EnrollmentDetailsResponse{})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.Enrollments.ListDetails(ctx, &EnrollmentDetailsOptions{
+			PaginationParams: PaginationParams{Limit: 25},
+		})
+		if err != nil {
+			t.Fatalf("ListDetails() error = %v", err)
+		}
+	})
+}
+func TestEnrollmentStatus(t *testing.T) {
+	t.Run("terminal states", func(t *testing.T) {
+		tests := map[EnrollmentStatus]bool{
+			EnrollmentStatusPending:   false,
+			EnrollmentStatusRedeemed:  true,
+			EnrollmentStatusExpired:   true,
+			EnrollmentStatusRejected:  true,
+			EnrollmentStatusCompleted: true,
+			"COMPLETED":               true,
+		}
+		for status, want := range tests {
+			if got := status.IsTerminal(); got != want {
+				t.Errorf("%s.IsTerminal() = %v, want %v", status, got, want)
+			}
+		}
+	})
+
+	t.Run("actionable enrollments", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		future := time.Now().Add(time.Hour)
+
+		tests := []struct {
+			name       string
+			enrollment Enrollment
+			want       bool
+		}{
+			{"pending without expiry", Enrollment{Status: EnrollmentStatusPending}, true},
+			{"pending before expiry", Enrollment{Status: "PENDING", ExpirationDate: &future}, true},
+			{"pending after expiry", Enrollment{Status: EnrollmentStatusPending, ExpirationDate: &past}, false},
+			{"completed", Enrollment{Status: EnrollmentStatusCompleted}, false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got := tt.enrollment.IsActionable(); got != tt.want {
+					t.Errorf("IsActionable() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+}
+
+func TestEnrollmentsService_WaitForCompletion(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+	opts := &PollOptions{Interval: 5 * time.Millisecond, Timeout: time.Second}
+
+	t.Run("returns issued certificate", func(t *testing.T) {
+		polls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/mpki/api/v1/enrollment/enrollment-1/status":
+				polls++
+				if polls < 3 {
+					json.NewEncoder(w).Encode(EnrollmentStatusResponse{Status: EnrollmentStatusPending})
+					return
+				}
+				json.NewEncoder(w).Encode(EnrollmentStatusResponse{Status: EnrollmentStatusCompleted, CertificateID: "cert-1"})
+			case "/mpki/api/v1/certificate-by-id/cert-1":
+				json.NewEncoder(w).Encode(Certificate{ID: "cert-1", CommonName: "device.example.com"})
+			default:
+				t.Errorf("Unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		cert, status, err := client.Enrollments.WaitForCompletion(ctx, "enrollment-1", opts)
+		if err != nil {
+			t.Fatalf("WaitForCompletion() error = %v", err)
+		}
+		if cert.ID != "cert-1" || polls != 3 {
+			t.Errorf("cert = %v after %d polls, want cert-1 after 3", cert.ID, polls)
+		}
+		if status.Status != EnrollmentStatusCompleted {
+			t.Errorf("Status = %v, want %v", status.Status, EnrollmentStatusCompleted)
+		}
+	})
+
+	t.Run("rejected enrollment", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(EnrollmentStatusResponse{Status: EnrollmentStatusRejected, Message: "Denied by approver"})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.Enrollments.WaitForCompletion(ctx, "enrollment-1", opts)
+		enrollmentErr, ok := err.(*EnrollmentError)
+		if !ok {
+			t.Fatalf("Error type = %T, want *EnrollmentError", err)
+		}
+		if enrollmentErr.Status != EnrollmentStatusRejected {
+			t.Errorf("Status = %v, want %v", enrollmentErr.Status, EnrollmentStatusRejected)
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(EnrollmentStatusResponse{Status: EnrollmentStatusPending})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		_, _, err := client.Enrollments.WaitForCompletion(ctx, "enrollment-1", &PollOptions{Interval: 5 * time.Millisecond, Timeout: 30 * time.Millisecond})
+		if err == nil {
+			t.Fatal("Expected timeout error")
+		}
+	})
+}
+
+func TestEnrollmentsService_Approve(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/manual-enrollment/enrollment-1/approve" {
+			t.Errorf("Expected path /mpki/api/v1/manual-enrollment/enrollment-1/approve, got %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+
+		var req EnrollmentApprovalRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Comment != "looks good" {
+			t.Errorf("Comment = %v, want %v", req.Comment, "looks good")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(EnrollmentResponse{EnrollmentID: "enrollment-1", Status: EnrollmentStatusCompleted})
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	result, _, err := client.Enrollments.Approve(ctx, "enrollment-1", &EnrollmentApprovalRequest{Comment: "looks good"})
+	if err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if result.Status != EnrollmentStatusCompleted {
+		t.Errorf("Status = %v, want %v", result.Status, EnrollmentStatusCompleted)
+	}
+}
@@ -1,6 +1,20 @@
 package digicert
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxErrorBodySize is the number of response body bytes kept on APIError
+// and HTTPError.
+const MaxErrorBodySize = 1024
 
 type APIError struct {
 	StatusCode int      `json:"-"`
@@ -8,50 +22,161 @@ type APIError struct {
 	Message    string   `json:"message"`
 	Details    []string `json:"details,omitempty"`
 	RequestID  string   `json:"request_id,omitempty"`
+
+	// Method and URL identify the failed request. Query values that look
+	// like credentials are redacted from URL.
+	Method string `json:"-"`
+	URL    string `json:"-"`
+	// Header holds the response headers, without cookies.
+	Header http.Header `json:"-"`
+	// RetryAfter is the delay requested by a Retry-After header, if any.
+	RetryAfter time.Duration `json:"-"`
+	// Body holds up to MaxErrorBodySize bytes of the response body.
+	Body []byte `json:"-"`
+	// Language is the Content-Language of Message, if the server sent one.
+	Language string `json:"-"`
 }
 
 func (e *APIError) Error() string {
 	if e.Code != "" {
-		return fmt.Sprintf("digicert: %s (code: %s, status: %d)", e.Message, e.Code, e.StatusCode)
+		return fmt.Sprintf("digicert: %s (code: %s, status: %d)%s", e.Message, e.Code, e.StatusCode, requestContext(e.Method, e.URL, e.RequestID))
 	}
-	return fmt.Sprintf("digicert: %s (status: %d)", e.Message, e.StatusCode)
+	return fmt.Sprintf("digicert: %s (status: %d)%s", e.Message, e.StatusCode, requestContext(e.Method, e.URL, e.RequestID))
 }
 
 type HTTPError struct {
 	StatusCode int
 	Message    string
+
+	// Method, URL, RequestID, Header, RetryAfter and Body are as on
+	// APIError.
+	Method     string
+	URL        string
+	RequestID  string
+	Header     http.Header
+	RetryAfter time.Duration
+	Body       []byte
 }
 
 func (e *HTTPError) Error() string {
-	return fmt.Sprintf("digicert: HTTP %d: %s", e.StatusCode, e.Message)
+	return fmt.Sprintf("digicert: HTTP %d: %s%s", e.StatusCode, e.Message, requestContext(e.Method, e.URL, e.RequestID))
 }
 
-func IsNotFound(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.StatusCode == 404
+// requestContext formats the request details appended to error messages.
+func requestContext(method, url, requestID string) string {
+	var parts []string
+	if method != "" || url != "" {
+		parts = append(parts, strings.TrimSpace(method+" "+url))
+	}
+	if requestID != "" {
+		parts = append(parts, "request_id: "+requestID)
 	}
-	if httpErr, ok := err.(*HTTPError); ok {
-		return httpErr.StatusCode == 404
+	if len(parts) == 0 {
+		return ""
 	}
-	return false
+	return " [" + strings.Join(parts, ", ") + "]"
 }
 
-func IsUnauthorized(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.StatusCode == 401
+// sensitiveQueryParams are redacted from the URLs recorded on errors.
+var sensitiveQueryParams = []string{"key", "token", "secret", "password", "signature", "credential"}
+
+// sanitizeURL drops user info and redacts credential-like query values.
+func sanitizeURL(u *url.URL) string {
+	if u == nil {
+		return ""
 	}
-	if httpErr, ok := err.(*HTTPError); ok {
-		return httpErr.StatusCode == 401
+	clean := *u
+	clean.User = nil
+	if clean.RawQuery != "" {
+		query := clean.Query()
+		for name := range query {
+			lower := strings.ToLower(name)
+			for _, s := range sensitiveQueryParams {
+				if strings.Contains(lower, s) {
+					query[name] = []string{"REDACTED"}
+					break
+				}
+			}
+		}
+		clean.RawQuery = query.Encode()
 	}
-	return false
+	return clean.String()
+}
+
+// StatusCode returns the HTTP status of an *APIError or *HTTPError in err's
+// chain, or 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
+
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
 }
 
 func IsForbidden(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.StatusCode == 403
+	return StatusCode(err) == http.StatusForbidden || errors.Is(err, ErrInsufficientPermissions)
+}
+
+func IsBadRequest(err error) bool {
+	return StatusCode(err) == http.StatusBadRequest
+}
+
+// IsConflict reports a 409, returned when the request clashes with the
+// current state, e.g. revoking an already revoked certificate.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+func IsServerError(err error) bool {
+	return StatusCode(err) >= 500
+}
+
+// IsTimeout reports whether err is a timeout: a 408 or 504 from the API, an
+// expired context deadline or a network timeout, however deeply wrapped.
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch StatusCode(err) {
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// RetryableError reports whether err is worth retrying: rate limits, server
+// errors, timeouts and transport failures are; other API errors, cancelled
+// contexts and an open circuit breaker are not.
+func RetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
 	}
-	if httpErr, ok := err.(*HTTPError); ok {
-		return httpErr.StatusCode == 403
+	if status := StatusCode(err); status != 0 {
+		return status == http.StatusTooManyRequests || status == http.StatusRequestTimeout || status >= 500
 	}
-	return false
-}
\ No newline at end of file
+	if IsTimeout(err) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
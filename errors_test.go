@@ -0,0 +1,114 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestStatusErrorHelpers(t *testing.T) {
+	wrapped := func(err error) error { return fmt.Errorf("issue certificate: %w", err) }
+	refused := &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	tests := []struct {
+		name     string
+		err      error
+		checkFn  func(error) bool
+		expected bool
+	}{
+		{"IsNotFound with wrapped APIError", wrapped(&APIError{StatusCode: 404}), IsNotFound, true},
+		{"IsBadRequest with HTTPError 400", &HTTPError{StatusCode: 400}, IsBadRequest, true},
+		{"IsConflict with wrapped APIError 409", wrapped(&APIError{StatusCode: 409}), IsConflict, true},
+		{"IsConflict with APIError 400", &APIError{StatusCode: 400}, IsConflict, false},
+		{"IsRateLimited with APIError 429", &APIError{StatusCode: 429}, IsRateLimited, true},
+		{"IsServerError with HTTPError 502", &HTTPError{StatusCode: 502}, IsServerError, true},
+		{"IsServerError with APIError 404", &APIError{StatusCode: 404}, IsServerError, false},
+		{"IsTimeout with HTTPError 504", &HTTPError{StatusCode: 504}, IsTimeout, true},
+		{"IsTimeout with deadline", wrapped(context.DeadlineExceeded), IsTimeout, true},
+		{"IsTimeout with wrapped net error", wrapped(&url.Error{Op: "Get", URL: "https://example.com", Err: timeoutError{}}), IsTimeout, true},
+		{"IsTimeout with connection refused", refused, IsTimeout, false},
+		{"IsTimeout with nil", nil, IsTimeout, false},
+		{"RetryableError with APIError 429", &APIError{StatusCode: 429}, RetryableError, true},
+		{"RetryableError with APIError 503", wrapped(&APIError{StatusCode: 503}), RetryableError, true},
+		{"RetryableError with APIError 409", &APIError{StatusCode: 409}, RetryableError, false},
+		{"RetryableError with connection refused", refused, RetryableError, true},
+		{"RetryableError with cancelled context", wrapped(context.Canceled), RetryableError, false},
+		{"RetryableError with open circuit", ErrCircuitOpen, RetryableError, false},
+		{"RetryableError with validation error", errors.New("digicert: ReKey requires a CSR"), RetryableError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.checkFn(tt.err); got != tt.expected {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+
+	if got := StatusCode(wrapped(&HTTPError{StatusCode: 418})); got != 418 {
+		t.Errorf("StatusCode() = %v, want 418", got)
+	}
+}
+
+func TestClient_ErrorContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusTooManyRequests)
+		if r.URL.Path == "/mpki/api/v1/html" {
+			w.Write([]byte("<html>" + strings.Repeat("x", 2*MaxErrorBodySize) + "</html>"))
+			return
+		}
+		w.Write([]byte(`{"code": "rate_limited", "message": "slow down"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "certificate/0A?access_token=abc&limit=5", nil)
+	_, err := client.Do(ctx, req, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Do() error = %v, want *APIError", err)
+	}
+	if apiErr.Method != http.MethodGet || !strings.Contains(apiErr.URL, "/mpki/api/v1/certificate/0A?") {
+		t.Errorf("request = %s %s, want GET of the certificate", apiErr.Method, apiErr.URL)
+	}
+	if strings.Contains(apiErr.URL, "abc") || !strings.Contains(apiErr.URL, "limit=5") {
+		t.Errorf("URL = %v, want the token redacted and other parameters kept", apiErr.URL)
+	}
+	if apiErr.RequestID != "req-123" || apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("RequestID = %v, RetryAfter = %v, want req-123 and 30s", apiErr.RequestID, apiErr.RetryAfter)
+	}
+	if apiErr.Header.Get("Set-Cookie") != "" {
+		t.Error("Header kept Set-Cookie")
+	}
+	if !strings.Contains(apiErr.Error(), "GET "+server.URL) || !strings.Contains(apiErr.Error(), "request_id: req-123") {
+		t.Errorf("Error() = %q, want request context", apiErr.Error())
+	}
+
+	req, _ = client.NewRequest(ctx, http.MethodPost, "html", nil)
+	_, err = client.Do(ctx, req, nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Do() error = %v, want *HTTPError", err)
+	}
+	if len(httpErr.Body) != MaxErrorBodySize || httpErr.RequestID != "req-123" || httpErr.Method != http.MethodPost {
+		t.Errorf("HTTPError = %d body bytes, request ID %q, method %q", len(httpErr.Body), httpErr.RequestID, httpErr.Method)
+	}
+}
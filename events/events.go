@@ -0,0 +1,210 @@
+// Package events delivers certificate changes as a stream of events.
+//
+// This library has no server-sent event or long-poll endpoint to stream
+// from, so Subscribe emulates one by polling the certificate search for
+// certificates updated since the last poll. Each event carries an opaque
+// cursor; passing the last one back, or a digicert.Checkpointer, resumes
+// the stream after a restart without missing or repeating changes:
+//
+//	err := events.Subscribe(ctx, client, &events.Options{
+//		Filter:       digicert.CertificateSearchOptions{ProfileID: "p1"},
+//		Checkpointer: digicert.FileCheckpointer("/var/lib/tlm/cert-events.cursor"),
+//	}, func(ctx context.Context, e events.Event) error {
+//		log.Printf("%s is now %s", e.Certificate.SerialNumber, e.Certificate.Status)
+//		return nil
+//	})
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+// DefaultInterval is the time between polls once the stream has caught up.
+const DefaultInterval = 30 * time.Second
+
+// Event reports that a certificate was issued or changed, such as being
+// revoked or having its owner or tags updated. Certificate holds its state
+// after the change.
+type Event struct {
+	Certificate digicert.Certificate
+	// UpdatedAt is when the change was made.
+	UpdatedAt time.Time
+	// Cursor resumes a subscription after this event.
+	Cursor string
+}
+
+// Handler processes an event. Returning an error stops the subscription
+// without checkpointing the events of the current poll, so they are
+// delivered again when it is resumed.
+type Handler func(ctx context.Context, event Event) error
+
+type Options struct {
+	// Filter restricts the certificates watched. Pagination, sorting and
+	// UpdatedAfter are managed by Subscribe and ignored.
+	Filter digicert.CertificateSearchOptions
+	// Cursor resumes a previous subscription from the Cursor of its last
+	// handled event.
+	Cursor string
+	// Since is where a subscription without a cursor starts. Defaults to the
+	// time Subscribe is called, delivering only later changes.
+	Since time.Time
+	// Checkpointer, if set, supplies the cursor when Cursor is empty and is
+	// updated after every poll whose events were all handled.
+	Checkpointer digicert.Checkpointer
+	// Interval between polls. Defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// cursor is the position of a subscription: the update time of the last
+// event and the IDs of the certificates already delivered at that time,
+// which a poll from that time returns again.
+type cursor struct {
+	Time time.Time `json:"t"`
+	IDs  []string  `json:"ids,omitempty"`
+}
+
+func (c cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("events: invalid cursor %q", s)
+	}
+	return c, nil
+}
+
+// advance records a delivered certificate updated at t.
+func (c *cursor) advance(id string, t time.Time) {
+	if t.After(c.Time) {
+		c.Time = t
+		c.IDs = c.IDs[:0]
+	}
+	c.IDs = append(c.IDs, id)
+}
+
+// seen reports whether the certificate with id, updated at t, has already
+// been delivered.
+func (c *cursor) seen(id string, t time.Time) bool {
+	return t.Before(c.Time) || t.Equal(c.Time) && slices.Contains(c.IDs, id)
+}
+
+// Subscribe calls handler for every certificate change matching opts, in
+// update order, until ctx is cancelled or the handler or a checkpoint fails.
+// Certificates are delivered at least once per change. Those without an
+// update time cannot be ordered and are skipped. Transient API errors are
+// retried at the next poll; others, such as a 401, stop the subscription.
+func Subscribe(ctx context.Context, client *digicert.Client, opts *Options, handler Handler) error {
+	if handler == nil {
+		return fmt.Errorf("handler is required")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	token := opts.Cursor
+	if token == "" && opts.Checkpointer != nil {
+		saved, err := opts.Checkpointer.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("events: load checkpoint: %w", err)
+		}
+		token = saved
+	}
+	pos := cursor{Time: opts.Since}
+	if token != "" {
+		var err error
+		if pos, err = parseCursor(token); err != nil {
+			return err
+		}
+	} else if pos.Time.IsZero() {
+		pos.Time = time.Now()
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		changes, err := fetch(ctx, client, opts, pos)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !digicert.RetryableError(err) {
+				return fmt.Errorf("events: search certificates: %w", err)
+			}
+			// Transient API failures are retried on the next tick.
+			timer.Reset(interval)
+			continue
+		}
+
+		for _, ch := range changes {
+			pos.advance(ch.id, ch.updated)
+			if err := handler(ctx, Event{Certificate: ch.cert, UpdatedAt: ch.updated, Cursor: pos.String()}); err != nil {
+				return err
+			}
+		}
+		if len(changes) > 0 && opts.Checkpointer != nil {
+			if err := opts.Checkpointer.Save(ctx, pos.String()); err != nil {
+				return fmt.Errorf("events: save checkpoint: %w", err)
+			}
+		}
+		timer.Reset(interval)
+	}
+}
+
+type change struct {
+	id      string
+	updated time.Time
+	cert    digicert.Certificate
+}
+
+// fetch returns the certificates updated since pos, oldest change first.
+// They are sorted here rather than by the server so that a server ignoring
+// the sort order cannot make the cursor skip changes.
+func fetch(ctx context.Context, client *digicert.Client, opts *Options, pos cursor) ([]change, error) {
+	search := opts.Filter
+	search.Offset = 0
+	search.UpdatedAfter = pos.Time
+	search.SortBy = "updated_at"
+	search.SortOrder = "asc"
+
+	var changes []change
+	for c, err := range client.Certificates.All(ctx, &search) {
+		if err != nil {
+			return nil, err
+		}
+		updated, err := time.Parse(time.RFC3339Nano, c.UpdatedAt)
+		if err != nil || pos.seen(c.ID, updated) {
+			// Servers that ignore the filter return certificates already
+			// delivered.
+			continue
+		}
+		changes = append(changes, change{id: c.ID, updated: updated, cert: c})
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].updated.Before(changes[j].updated) })
+	return changes, nil
+}
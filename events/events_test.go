@@ -0,0 +1,135 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+	"github.com/jonhadfield/go-digicert-tlm/digicerttest"
+)
+
+type memoryCheckpointer struct {
+	mu     sync.Mutex
+	cursor string
+}
+
+func (m *memoryCheckpointer) Load(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursor, nil
+}
+
+func (m *memoryCheckpointer) Save(ctx context.Context, cursor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursor = cursor
+	return nil
+}
+
+func TestSubscribe(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cert := func(id string, updated time.Duration) *digicert.Certificate {
+		c := digicerttest.NewCertificate(digicerttest.WithID(id))
+		c.UpdatedAt = start.Add(updated).Format(time.RFC3339)
+		return c
+	}
+
+	var (
+		mu       sync.Mutex
+		polls    int
+		inFilter []string
+	)
+	srv := digicerttest.NewServer(t)
+	srv.HandleFunc("GET certificate-search", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls++
+		inFilter = append(inFilter, r.URL.Query().Get("updated_after"))
+		n := polls
+		mu.Unlock()
+
+		// The server ignores the sort order, and a failed poll and a
+		// repeated certificate are retried and skipped.
+		var page *digicert.CertificateSearchResponse
+		switch n {
+		case 1:
+			page = digicerttest.NewCertificateSearch(cert("c2", 2*time.Minute), cert("c1", time.Minute))
+		case 2:
+			digicerttest.Error(http.StatusServiceUnavailable, "unavailable", "try later")(w, r)
+			return
+		case 3:
+			page = digicerttest.NewCertificateSearch(cert("c2", 2*time.Minute), cert("c3", 2*time.Minute))
+		default:
+			page = digicerttest.NewCertificateSearch(cert("c3", 2*time.Minute), cert("c4", 3*time.Minute))
+		}
+		digicerttest.JSON(http.StatusOK, page)(w, r)
+	})
+	client := srv.Client(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cp := &memoryCheckpointer{}
+	var seen []string
+	err := Subscribe(ctx, client, &Options{Since: start, Checkpointer: cp, Interval: time.Millisecond},
+		func(ctx context.Context, e Event) error {
+			seen = append(seen, e.Certificate.ID)
+			if e.Certificate.ID == "c3" {
+				cancel()
+			}
+			return nil
+		})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe() error = %v, want %v", err, context.Canceled)
+	}
+	if got, want := len(seen), 3; got != want || seen[0] != "c1" || seen[1] != "c2" || seen[2] != "c3" {
+		t.Errorf("seen = %v, want [c1 c2 c3]", seen)
+	}
+	if inFilter[0] != "2024-05-01T12:00:00Z" || inFilter[2] != "2024-05-01T12:02:00Z" {
+		t.Errorf("updated_after = %v, want start then the time of c2", inFilter)
+	}
+
+	pos, err := parseCursor(cp.cursor)
+	if err != nil {
+		t.Fatalf("parseCursor() error = %v", err)
+	}
+	if !pos.Time.Equal(start.Add(2*time.Minute)) || len(pos.IDs) != 2 {
+		t.Errorf("checkpoint = %+v, want c2 and c3 at 12:02", pos)
+	}
+
+	// Resuming from the checkpoint delivers nothing already seen.
+	handlerErr := errors.New("downstream unavailable")
+	err = Subscribe(context.Background(), client, &Options{Checkpointer: cp, Interval: time.Millisecond},
+		func(ctx context.Context, e Event) error {
+			if e.Certificate.ID != "c4" {
+				t.Errorf("redelivered %s after resume", e.Certificate.ID)
+			}
+			return handlerErr
+		})
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("Subscribe() error = %v, want %v", err, handlerErr)
+	}
+}
+
+func TestSubscribe_NonRetryableError(t *testing.T) {
+	srv := digicerttest.NewServer(t)
+	srv.HandleFunc("GET certificate-search", digicerttest.Error(http.StatusForbidden, "forbidden", "no access"))
+	client := srv.Client(t)
+
+	err := Subscribe(context.Background(), client, &Options{Interval: time.Millisecond},
+		func(context.Context, Event) error { return nil })
+	if !digicert.IsForbidden(err) {
+		t.Errorf("Subscribe() error = %v, want 403", err)
+	}
+}
+
+func TestSubscribe_InvalidCursor(t *testing.T) {
+	client, _ := digicert.NewClient("test-key")
+	err := Subscribe(context.Background(), client, &Options{Cursor: "not a cursor"}, func(context.Context, Event) error { return nil })
+	if err == nil {
+		t.Error("Subscribe() error = nil, want invalid cursor")
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"log"
 	"os"
 
-	"github.com/jonhadfield/go-digicert"
+	"github.com/jonhadfield/go-digicert-tlm"
 )
 
 func main() {
@@ -81,7 +81,8 @@ func main() {
 	} else {
 		if newCert.RequestID != "" {
 			fmt.Printf("Certificate request submitted. Request ID: %s\n", newCert.RequestID)
-			// For Microsoft CA certificates, use Pickup method with request ID
+			// For Microsoft CA certificates, use Pickup with the request ID, or
+			// IssueAndWait to wait for the certificate in one call
 		} else if newCert.Certificate != nil {
 			fmt.Printf("Certificate issued: %s\n", newCert.Certificate.SerialNumber)
 		}
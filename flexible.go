@@ -0,0 +1,47 @@
+package digicert
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// FlexibleInt is an integer field that TLM sometimes sends as a string. It
+// decodes a JSON number, a string holding one such as "2048", or a string
+// qualifying one such as "RSA_2048" or "90 days". Values it cannot read,
+// including null, decode to zero rather than failing the whole response.
+type FlexibleInt int
+
+// digitRun matches a run of decimal digits.
+var digitRun = regexp.MustCompile(`\d+`)
+
+func (n *FlexibleInt) UnmarshalJSON(data []byte) error {
+	*n = 0
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+	switch data[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if digits := digitRun.FindAllString(s, -1); len(digits) == 1 {
+			if v, err := strconv.Atoi(digits[0]); err == nil {
+				*n = FlexibleInt(v)
+			}
+		}
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		f, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return err
+		}
+		if f >= math.MinInt && f <= math.MaxInt {
+			*n = FlexibleInt(f)
+		}
+	}
+	return nil
+}
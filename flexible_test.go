@@ -0,0 +1,59 @@
+package digicert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFlexibleInt_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want FlexibleInt
+	}{
+		{`2048`, 2048},
+		{`2048.0`, 2048},
+		{`"2048"`, 2048},
+		{`"RSA_2048"`, 2048},
+		{`"90 days"`, 90},
+		{`""`, 0},
+		{`null`, 0},
+		{`"P-256 SHA384"`, 0},
+		{`true`, 0},
+		{`{"bits": 2048}`, 0},
+	}
+	for _, tt := range tests {
+		var got FlexibleInt
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeChangedFieldTypes(t *testing.T) {
+	var cert Certificate
+	if err := json.Unmarshal([]byte(`{"id": "c1", "key_size": 2048, "expires_in_days": "30"}`), &cert); err != nil {
+		t.Fatalf("Unmarshal(Certificate) error = %v", err)
+	}
+	if cert.KeySize != "2048" || cert.ExpiresInDays != 30 {
+		t.Errorf("Certificate = %q, %v, want %q, %v", cert.KeySize, cert.ExpiresInDays, "2048", 30)
+	}
+
+	var profile Profile
+	if err := json.Unmarshal([]byte(`{"id": "p1", "key_size": "RSA_3072", "renewal_window_days": "60"}`), &profile); err != nil {
+		t.Fatalf("Unmarshal(Profile) error = %v", err)
+	}
+	if profile.KeySize != 3072 || profile.RenewalWindowDays != 60 {
+		t.Errorf("Profile = %v, %v, want %v, %v", profile.KeySize, profile.RenewalWindowDays, 3072, 60)
+	}
+
+	// Encoding keeps the documented types.
+	data, _ := json.Marshal(Profile{KeySize: 2048})
+	if !strings.Contains(string(data), `"key_size":2048`) {
+		t.Errorf("Marshal(Profile) = %s, want key_size as a number", data)
+	}
+}
@@ -0,0 +1,12 @@
+package digicert
+
+// Endpoints of the TLM OpenAPI document without a hand-written
+// implementation are generated into zz_generated_endpoints.go. Fetch the
+// document first:
+//
+//	curl -o openapi-public.json https://one.digicert.com/mpki/docs/openapi-public.json
+//	go generate
+//
+// New service types in the output must be added to Client by hand.
+
+//go:generate go run ./internal/openapigen -spec openapi-public.json -out zz_generated_endpoints.go
@@ -0,0 +1,155 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const (
+	DefaultGetManyConcurrency = 8
+	// getManyBatchSize is the most IDs sent in one bulk fetch.
+	getManyBatchSize = 100
+)
+
+type GetManyOptions struct {
+	// Concurrency is the number of GetCertificate calls made in parallel on
+	// servers without the bulk fetch endpoint. Defaults to
+	// DefaultGetManyConcurrency.
+	Concurrency int
+}
+
+// GetManyResult is the outcome of fetching one certificate with GetMany.
+// Exactly one of Certificate and Err is set.
+type GetManyResult struct {
+	Certificate *Certificate
+	Err         error
+}
+
+type bulkGetRequest struct {
+	IDs []string `json:"ids"`
+}
+
+type bulkGetResponse struct {
+	Items []Certificate `json:"items"`
+}
+
+// GetMany retrieves certificates by ID, returning a result for every distinct
+// ID in ids. It uses the bulk fetch endpoint, 100 IDs at a time, and on
+// servers without it calls GetCertificate in parallel instead. IDs the
+// server does not return have a not found APIError. The error is set when a
+// bulk fetch fails outright, or when ctx ends, alongside the results so far;
+// IDs not yet fetched then carry it as their Err.
+func (s *CertificatesService) GetMany(ctx context.Context, ids []string, opts *GetManyOptions) (map[string]GetManyResult, error) {
+	results := make(map[string]GetManyResult, len(ids))
+	var pending []string
+	for _, id := range ids {
+		if _, ok := results[id]; !ok {
+			results[id] = GetManyResult{}
+			pending = append(pending, id)
+		}
+	}
+
+	for len(pending) > 0 && s.bulkGetSupported() {
+		n := min(len(pending), getManyBatchSize)
+		found, err := s.bulkGet(ctx, pending[:n])
+		if isBulkGetUnsupported(err) {
+			s.client.mu.Lock()
+			s.client.noBulkGet = true
+			s.client.mu.Unlock()
+			break
+		}
+		if err != nil {
+			for _, id := range pending {
+				results[id] = GetManyResult{Err: err}
+			}
+			return results, err
+		}
+		for _, id := range pending[:n] {
+			if c, ok := found[id]; ok {
+				results[id] = GetManyResult{Certificate: c}
+				continue
+			}
+			results[id] = GetManyResult{Err: &APIError{
+				StatusCode: http.StatusNotFound,
+				Code:       "not_found",
+				Message:    fmt.Sprintf("no certificate with ID %s", id),
+			}}
+		}
+		pending = pending[n:]
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	concurrency := DefaultGetManyConcurrency
+	if opts != nil && opts.Concurrency > 0 {
+		concurrency = opts.Concurrency
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+	)
+	for _, id := range pending {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			results[id] = GetManyResult{Err: ctx.Err()}
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			cert, _, err := s.GetCertificate(ctx, id)
+			mu.Lock()
+			results[id] = GetManyResult{Certificate: cert, Err: err}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+func (s *CertificatesService) bulkGetSupported() bool {
+	s.client.mu.RLock()
+	defer s.client.mu.RUnlock()
+	return !s.client.noBulkGet
+}
+
+// bulkGet fetches the certificates with ids in one request, keyed by ID
+func (s *CertificatesService) bulkGet(ctx context.Context, ids []string) (map[string]*Certificate, error) {
+	u := "certificate-by-id/bulk"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, &bulkGetRequest{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	var result bulkGetResponse
+	if _, err := s.client.Do(ctx, httpReq, &result); err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*Certificate, len(result.Items))
+	for i := range result.Items {
+		found[result.Items[i].ID] = &result.Items[i]
+	}
+	return found, nil
+}
+
+// isBulkGetUnsupported reports whether err shows the server has no bulk
+// fetch endpoint.
+func isBulkGetUnsupported(err error) bool {
+	switch StatusCode(err) {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCertificatesService_GetMany_Bulk(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/mpki/api/v1/certificate-by-id/bulk" {
+			t.Errorf("request = %s %s, want POST /mpki/api/v1/certificate-by-id/bulk", r.Method, r.URL.Path)
+		}
+		var req bulkGetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, len(req.IDs))
+
+		var resp bulkGetResponse
+		for _, id := range req.IDs {
+			if id != "missing" {
+				resp.Items = append(resp.Items, Certificate{ID: id, CommonName: id + ".example.com"})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+
+	ids := []string{"missing", "missing"}
+	for i := 0; i < 149; i++ {
+		ids = append(ids, fmt.Sprintf("cert-%d", i))
+	}
+	results, err := client.Certificates.GetMany(context.Background(), ids, nil)
+	if err != nil {
+		t.Fatalf("GetMany() error = %v", err)
+	}
+
+	if len(results) != 150 {
+		t.Errorf("len(results) = %v, want %v", len(results), 150)
+	}
+	if len(batches) != 2 || batches[0] != 100 || batches[1] != 50 {
+		t.Errorf("batches = %v, want [100 50]", batches)
+	}
+	if c := results["cert-7"].Certificate; c == nil || c.CommonName != "cert-7.example.com" {
+		t.Errorf("results[cert-7] = %+v, want certificate", results["cert-7"])
+	}
+	if r := results["missing"]; r.Certificate != nil || !IsNotFound(r.Err) {
+		t.Errorf("results[missing] = %+v, want not found", r)
+	}
+}
+
+func TestCertificatesService_GetMany_BulkError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req bulkGetRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		if calls++; calls > 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(APIError{Code: "bad_request", Message: "batch rejected"})
+			return
+		}
+		var resp bulkGetResponse
+		for _, id := range req.IDs {
+			resp.Items = append(resp.Items, Certificate{ID: id})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+
+	var ids []string
+	for i := 0; i < 150; i++ {
+		ids = append(ids, fmt.Sprintf("cert-%d", i))
+	}
+	results, err := client.Certificates.GetMany(context.Background(), ids, nil)
+	if !IsBadRequest(err) {
+		t.Fatalf("GetMany() error = %v, want bad request", err)
+	}
+	if len(results) != 150 {
+		t.Fatalf("len(results) = %v, want %v", len(results), 150)
+	}
+	if r := results["cert-7"]; r.Certificate == nil || r.Err != nil {
+		t.Errorf("results[cert-7] = %+v, want certificate from first batch", r)
+	}
+	if r := results["cert-120"]; r.Certificate != nil || !IsBadRequest(r.Err) {
+		t.Errorf("results[cert-120] = %+v, want batch error", r)
+	}
+}
+
+func TestCertificatesService_GetMany_Fallback(t *testing.T) {
+	var (
+		mu                  sync.Mutex
+		bulkCalls, inFlight int
+		maxInFlight         int
+	)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mpki/api/v1/certificate-by-id/bulk" {
+			mu.Lock()
+			bulkCalls++
+			mu.Unlock()
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(APIError{Code: "not_found", Message: "no such endpoint"})
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		if inFlight == 2 {
+			close(release)
+		}
+		mu.Unlock()
+		<-release
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		id := strings.TrimPrefix(r.URL.Path, "/mpki/api/v1/certificate-by-id/")
+		w.Header().Set("Content-Type", "application/json")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(APIError{Code: "not_found", Message: "certificate not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(Certificate{ID: id})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	opts := &GetManyOptions{Concurrency: 2}
+
+	results, err := client.Certificates.GetMany(ctx, []string{"a", "b", "c", "missing"}, opts)
+	if err != nil {
+		t.Fatalf("GetMany() error = %v", err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if c := results[id].Certificate; c == nil || c.ID != id {
+			t.Errorf("results[%s] = %+v, want certificate", id, results[id])
+		}
+	}
+	if !IsNotFound(results["missing"].Err) {
+		t.Errorf("results[missing].Err = %v, want not found", results["missing"].Err)
+	}
+	if maxInFlight != 2 {
+		t.Errorf("max in flight = %v, want %v", maxInFlight, 2)
+	}
+
+	if _, err := client.Certificates.GetMany(ctx, []string{"d"}, opts); err != nil {
+		t.Fatalf("GetMany() error = %v", err)
+	}
+	if bulkCalls != 1 {
+		t.Errorf("bulk calls = %v, want %v", bulkCalls, 1)
+	}
+}
@@ -0,0 +1,69 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type headersContextKey struct{}
+
+// WithDefaultHeader adds a header, such as X-Tenant-ID or a gateway routing
+// header, to every request the client sends. It may be given more than once.
+// The X-API-Key header is managed by the client and cannot be set this way.
+func WithDefaultHeader(key, value string) ClientOption {
+	return func(c *Client) error {
+		if err := validateHeader(key, value); err != nil {
+			return err
+		}
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Set(key, value)
+		return nil
+	}
+}
+
+// ContextWithHeader returns a context that sets a header on requests made
+// with it, overriding any default header of the same name. An empty value
+// removes the header for those requests. Invalid headers are ignored.
+func ContextWithHeader(ctx context.Context, key, value string) context.Context {
+	if err := validateHeader(key, value); err != nil {
+		return ctx
+	}
+	h := make(http.Header)
+	if parent, ok := ctx.Value(headersContextKey{}).(http.Header); ok {
+		h = parent.Clone()
+	}
+	h.Set(key, value)
+	return context.WithValue(ctx, headersContextKey{}, h)
+}
+
+// applyHeaders sets the client's default headers and then those on ctx.
+func (c *Client) applyHeaders(ctx context.Context, req *http.Request) {
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	overrides, _ := ctx.Value(headersContextKey{}).(http.Header)
+	for key, values := range overrides {
+		if len(values) == 0 || values[0] == "" {
+			req.Header.Del(key)
+			continue
+		}
+		req.Header[key] = append([]string(nil), values...)
+	}
+}
+
+func validateHeader(key, value string) error {
+	if key == "" || strings.ContainsAny(key, " \t\r\n:") {
+		return fmt.Errorf("invalid header name %q", key)
+	}
+	if strings.EqualFold(key, "X-API-Key") {
+		return fmt.Errorf("the X-API-Key header cannot be overridden")
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("header %s value cannot contain line breaks", key)
+	}
+	return nil
+}
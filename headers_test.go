@@ -0,0 +1,59 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_DefaultHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL+"/"),
+		WithDefaultHeader("X-Tenant-ID", "tenant-a"),
+		WithDefaultHeader("X-Route", "blue"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx := context.Background()
+	req, _ := client.NewRequest(ctx, http.MethodGet, "certificate/0A", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.Get("X-Tenant-ID") != "tenant-a" || got.Get("X-Route") != "blue" {
+		t.Errorf("headers = %v, want the default headers", got)
+	}
+
+	callCtx := ContextWithHeader(ContextWithHeader(ctx, "X-Tenant-ID", "tenant-b"), "X-Route", "")
+	req, _ = client.NewRequest(callCtx, http.MethodGet, "certificate/0A", nil)
+	if _, err := client.Do(callCtx, req, nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.Get("X-Tenant-ID") != "tenant-b" || got.Get("X-Route") != "" {
+		t.Errorf("headers = %v, want the per-call overrides", got)
+	}
+	if got.Get("X-API-Key") != "test-key" {
+		t.Errorf("X-API-Key = %v, want test-key", got.Get("X-API-Key"))
+	}
+}
+
+func TestWithDefaultHeader_Invalid(t *testing.T) {
+	tests := []struct{ key, value string }{
+		{"", "x"},
+		{"X Tenant", "x"},
+		{"x-api-key", "other"},
+		{"X-Tenant-ID", "a\r\nInjected: 1"},
+	}
+	for _, tt := range tests {
+		if _, err := NewClient("test-key", WithDefaultHeader(tt.key, tt.value)); err == nil {
+			t.Errorf("WithDefaultHeader(%q, %q) error = nil, want error", tt.key, tt.value)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type hedging struct {
+	delay     time.Duration
+	maxHedges int
+}
+
+// WithHedging sends up to maxHedges extra copies of a GET or HEAD request
+// that has not answered within delay, each delay after the last, and uses
+// whichever response arrives first. Set delay near the endpoint's P99
+// latency to trim the long tail at the cost of a little extra load. Other
+// methods are never hedged.
+func WithHedging(delay time.Duration, maxHedges int) ClientOption {
+	return func(c *Client) error {
+		if delay <= 0 {
+			return fmt.Errorf("hedging delay must be positive")
+		}
+		if maxHedges < 1 {
+			return fmt.Errorf("hedging needs at least one hedged request")
+		}
+		c.hedging = &hedging{delay: delay, maxHedges: maxHedges}
+		return nil
+	}
+}
+
+// send performs req, hedging it when enabled and the request is safe to
+// repeat.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.hedging == nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) || req.Body != nil {
+		return c.client.Do(req)
+	}
+	return c.hedging.do(c.client, req)
+}
+
+type hedgeResult struct {
+	attempt int
+	resp    *http.Response
+	err     error
+}
+
+func (h *hedging) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	results := make(chan hedgeResult, h.maxHedges+1)
+	var cancels []context.CancelFunc
+	start := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		clone := req.Clone(ctx)
+		go func() {
+			resp, err := client.Do(clone)
+			results <- hedgeResult{attempt: attempt, resp: resp, err: err}
+		}()
+	}
+
+	start()
+	inFlight := 1
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if len(cancels) <= h.maxHedges {
+				start()
+				inFlight++
+				timer.Reset(h.delay)
+			}
+		case r := <-results:
+			inFlight--
+			if r.err == nil {
+				// Abandon the other attempts; the winner's context lives
+				// until its body is closed.
+				for i, cancel := range cancels {
+					if i != r.attempt {
+						cancel()
+					}
+				}
+				go drainHedges(results, inFlight)
+				r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: cancels[r.attempt]}
+				return r.resp, nil
+			}
+			cancels[r.attempt]()
+			if inFlight == 0 {
+				return nil, r.err
+			}
+		}
+	}
+}
+
+// drainHedges closes the responses of abandoned attempts that completed
+// before they noticed the cancellation.
+func drainHedges(results <-chan hedgeResult, n int) {
+	for ; n > 0; n-- {
+		if r := <-results; r.resp != nil {
+			r.resp.Body.Close()
+		}
+	}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,79 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithHedging(t *testing.T) {
+	var requests, cancelled int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// The first attempt stalls until the hedge wins.
+			select {
+			case <-r.Context().Done():
+				atomic.AddInt32(&cancelled, 1)
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Certificate{SerialNumber: "0A"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL+"/"), WithHedging(20*time.Millisecond, 2))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	start := time.Now()
+	cert, _, err := client.Certificates.Get(context.Background(), "0A")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if cert.SerialNumber != "0A" {
+		t.Errorf("SerialNumber = %v, want 0A", cert.SerialNumber)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Get() took %v, want the hedged response", elapsed)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&cancelled) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&cancelled) != 1 {
+		t.Error("stalled attempt was not cancelled")
+	}
+
+	// Requests with side effects are never hedged.
+	atomic.StoreInt32(&requests, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		client.Certificates.Revoke(ctx, "0A", &RevokeRequest{Reason: RevocationReasonSuperseded})
+	}()
+	<-done
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("requests after revoke = %d, want a single attempt", n-1)
+	}
+}
+
+func TestWithHedging_InvalidSettings(t *testing.T) {
+	if _, err := NewClient("test-key", WithHedging(0, 1)); err == nil {
+		t.Error("Expected error for zero delay")
+	}
+	if _, err := NewClient("test-key", WithHedging(time.Millisecond, 0)); err == nil {
+		t.Error("Expected error for no hedges")
+	}
+}
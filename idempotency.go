@@ -0,0 +1,60 @@
+package digicert
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is sent on issuance requests so the server can discard
+// duplicates of a request that was retried after a network failure.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKeys attaches a generated Idempotency-Key to every Issue,
+// Renew and enrollment Create request
+func WithIdempotencyKeys() ClientOption {
+	return func(c *Client) error {
+		c.idempotencyKeys = true
+		return nil
+	}
+}
+
+// ContextWithIdempotencyKey returns a context that makes the next idempotent
+// request use key instead of a generated one. Reuse the same key when
+// retrying a call yourself so the server recognises the retry.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// setIdempotencyKey adds the Idempotency-Key header to req if a key was set
+// on ctx or automatic keys are enabled.
+func (c *Client) setIdempotencyKey(ctx context.Context, req *http.Request) error {
+	if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+		return nil
+	}
+	if !c.idempotencyKeys {
+		return nil
+	}
+
+	key, err := newUUID()
+	if err != nil {
+		return err
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return nil
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
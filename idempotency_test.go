@@ -0,0 +1,68 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{})
+	}))
+	defer server.Close()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		keys = nil
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		client.Certificates.Issue(ctx, &CertificateRequest{})
+		if keys[0] != "" {
+			t.Errorf("%s = %v, want none", IdempotencyKeyHeader, keys[0])
+		}
+	})
+
+	t.Run("generated per request", func(t *testing.T) {
+		keys = nil
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithIdempotencyKeys())
+		client.Certificates.Issue(ctx, &CertificateRequest{})
+		client.Certificates.Renew(ctx, "0A", &RenewRequest{})
+		client.Enrollments.Create(ctx, &EnrollmentRequest{})
+		client.Certificates.Search(ctx, nil)
+
+		if len(keys) != 4 {
+			t.Fatalf("requests = %v, want 4", len(keys))
+		}
+		for i, key := range keys[:3] {
+			if !uuidPattern.MatchString(key) {
+				t.Errorf("request %d %s = %q, want UUID", i, IdempotencyKeyHeader, key)
+			}
+		}
+		if keys[0] == keys[1] || keys[1] == keys[2] {
+			t.Errorf("keys = %v, want unique keys", keys)
+		}
+		if keys[3] != "" {
+			t.Errorf("Search %s = %v, want none", IdempotencyKeyHeader, keys[3])
+		}
+	})
+
+	t.Run("per-call override", func(t *testing.T) {
+		keys = nil
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		callCtx := ContextWithIdempotencyKey(ctx, "order-42")
+		client.Certificates.Issue(callCtx, &CertificateRequest{})
+		client.Certificates.Issue(callCtx, &CertificateRequest{})
+		if keys[0] != "order-42" || keys[1] != "order-42" {
+			t.Errorf("keys = %v, want order-42 on both attempts", keys)
+		}
+	})
+}
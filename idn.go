@@ -0,0 +1,110 @@
+package digicert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonhadfield/go-digicert-tlm/internal/idna"
+)
+
+// WithoutIDNAConversion stops the client rewriting common names and DNS SANs
+// before issuance. By default internationalized domain names are converted to
+// their punycode form and host names are lower-cased and stripped of a
+// trailing dot.
+func WithoutIDNAConversion() ClientOption {
+	return func(c *Client) error {
+		c.disableIDNA = true
+		return nil
+	}
+}
+
+// normalizeHostname lower-cases name, removes a trailing dot and IDNA encodes
+// it if it contains non-ASCII characters.
+func normalizeHostname(name string) (string, error) {
+	name = strings.TrimSuffix(name, ".")
+	ascii, err := idna.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized domain name %q: %w", name, err)
+	}
+	return ascii, nil
+}
+
+// looksLikeHostname reports whether a common name is a domain name rather than,
+// say, a person's name on an S/MIME or client certificate.
+func looksLikeHostname(cn string) bool {
+	return strings.Contains(strings.TrimSuffix(cn, "."), ".") && !strings.ContainsAny(cn, " @/:")
+}
+
+// normalizeNames applies IDNA conversion to a common name and DNS SANs. The
+// SANs are copied if any of them change.
+func (c *Client) normalizeNames(cn string, sans *SubjectAltNames) (string, *SubjectAltNames, error) {
+	if c.disableIDNA {
+		return cn, sans, nil
+	}
+
+	if looksLikeHostname(cn) {
+		normalized, err := normalizeHostname(cn)
+		if err != nil {
+			return "", nil, err
+		}
+		cn = normalized
+	}
+
+	if sans == nil || len(sans.DNSNames) == 0 {
+		return cn, sans, nil
+	}
+	names := make([]string, len(sans.DNSNames))
+	changed := false
+	for i, name := range sans.DNSNames {
+		normalized, err := normalizeHostname(name)
+		if err != nil {
+			return "", nil, err
+		}
+		names[i] = normalized
+		changed = changed || normalized != name
+	}
+	if changed {
+		copied := *sans
+		copied.DNSNames = names
+		sans = &copied
+	}
+	return cn, sans, nil
+}
+
+// normalizeCertificateAttributes returns attrs with IDNA conversion applied,
+// copying rather than modifying the caller's value.
+func (c *Client) normalizeCertificateAttributes(attrs *CertificateAttributes) (*CertificateAttributes, error) {
+	if attrs == nil {
+		return nil, nil
+	}
+	cn, sans, err := c.normalizeNames(attrs.CommonName, attrs.SANs)
+	if err != nil {
+		return nil, err
+	}
+	if cn == attrs.CommonName && sans == attrs.SANs {
+		return attrs, nil
+	}
+	normalized := *attrs
+	normalized.CommonName = cn
+	normalized.SANs = sans
+	return &normalized, nil
+}
+
+// normalizeEnrollmentAttributes is normalizeCertificateAttributes for
+// enrollments.
+func (c *Client) normalizeEnrollmentAttributes(attrs *EnrollmentAttributes) (*EnrollmentAttributes, error) {
+	if attrs == nil {
+		return nil, nil
+	}
+	cn, sans, err := c.normalizeNames(attrs.CommonName, attrs.SANs)
+	if err != nil {
+		return nil, err
+	}
+	if cn == attrs.CommonName && sans == attrs.SANs {
+		return attrs, nil
+	}
+	normalized := *attrs
+	normalized.CommonName = cn
+	normalized.SANs = sans
+	return &normalized, nil
+}
@@ -0,0 +1,97 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestIDNAConversion(t *testing.T) {
+	ctx := context.Background()
+
+	var got CertificateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = CertificateRequest{}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	newReq := func() *CertificateRequest {
+		return &CertificateRequest{
+			Profile: ProfileReference{ID: "profile-123"},
+			Attributes: &CertificateAttributes{
+				CommonName: "Bücher.Example.",
+				SANs:       &SubjectAltNames{DNSNames: []string{"bücher.example", "*.WWW.example.com."}},
+			},
+		}
+	}
+
+	t.Run("default", func(t *testing.T) {
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		req := newReq()
+		if _, _, err := client.Certificates.Issue(ctx, req); err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		if got.Attributes.CommonName != "xn--bcher-kva.example" {
+			t.Errorf("CommonName = %v, want %v", got.Attributes.CommonName, "xn--bcher-kva.example")
+		}
+		want := []string{"xn--bcher-kva.example", "*.www.example.com"}
+		if !reflect.DeepEqual(got.Attributes.SANs.DNSNames, want) {
+			t.Errorf("DNSNames = %v, want %v", got.Attributes.SANs.DNSNames, want)
+		}
+		if req.Attributes.CommonName != "Bücher.Example." || req.Attributes.SANs.DNSNames[0] != "bücher.example" {
+			t.Errorf("Issue() modified the caller's request: %+v", req.Attributes)
+		}
+	})
+
+	t.Run("opt out", func(t *testing.T) {
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithoutIDNAConversion())
+		if _, _, err := client.Certificates.Issue(ctx, newReq()); err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		if got.Attributes.CommonName != "Bücher.Example." {
+			t.Errorf("CommonName = %v, want it unchanged", got.Attributes.CommonName)
+		}
+	})
+
+	t.Run("personal common name", func(t *testing.T) {
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		req := &CertificateRequest{Attributes: &CertificateAttributes{CommonName: "José García"}}
+		if _, _, err := client.Certificates.Issue(ctx, req); err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+		if got.Attributes.CommonName != "José García" {
+			t.Errorf("CommonName = %v, want it unchanged", got.Attributes.CommonName)
+		}
+	})
+}
+
+func TestIDNAConversion_Enrollment(t *testing.T) {
+	var got EnrollmentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	_, _, err := client.Enrollments.Create(context.Background(), &EnrollmentRequest{
+		CommonName: "münchen.de",
+		Attributes: &EnrollmentAttributes{SANs: &SubjectAltNames{DNSNames: []string{"www.münchen.de"}}},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got.CommonName != "xn--mnchen-3ya.de" {
+		t.Errorf("CommonName = %v, want %v", got.CommonName, "xn--mnchen-3ya.de")
+	}
+	if got.Attributes.SANs.DNSNames[0] != "www.xn--mnchen-3ya.de" {
+		t.Errorf("DNSNames = %v, want %v", got.Attributes.SANs.DNSNames, "www.xn--mnchen-3ya.de")
+	}
+}
@@ -0,0 +1,123 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Installation statuses.
+const (
+	InstallationStatusInstalled = "installed"
+	InstallationStatusRemoved   = "removed"
+	InstallationStatusFailed    = "failed"
+)
+
+// Installation records a certificate being deployed to an endpoint.
+type Installation struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	// Status is one of the InstallationStatus values. Recording an
+	// installation without a status marks it installed.
+	Status      string     `json:"status,omitempty"`
+	InstalledAt *time.Time `json:"installed_at,omitempty"`
+	// Source names what performed or observed the installation, e.g. an
+	// automation tool or "discovery".
+	Source  string `json:"source,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Endpoint returns the installation's host:port.
+func (i Installation) Endpoint() string {
+	return net.JoinHostPort(i.Host, strconv.Itoa(i.Port))
+}
+
+// IsInstalled reports whether the certificate is recorded as serving on the
+// endpoint.
+func (i Installation) IsInstalled() bool {
+	return i.Status == "" || strings.EqualFold(i.Status, InstallationStatusInstalled)
+}
+
+type installationListResponse struct {
+	Installations []Installation `json:"installations"`
+}
+
+// RecordInstallation records that a certificate has been installed on, or
+// removed from, an endpoint. InstalledAt defaults to now.
+func (s *CertificatesService) RecordInstallation(ctx context.Context, certificateID string, inst *Installation) (*Installation, *Response, error) {
+	if inst == nil || inst.Host == "" || inst.Port <= 0 {
+		return nil, nil, fmt.Errorf("installation host and port are required")
+	}
+	if inst.InstalledAt == nil {
+		now := time.Now().UTC()
+		withTime := *inst
+		withTime.InstalledAt = &now
+		inst = &withTime
+	}
+
+	u := fmt.Sprintf("certificate-by-id/%s/installations", certificateID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, inst)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var recorded Installation
+	resp, err := s.client.Do(ctx, httpReq, &recorded)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &recorded, resp, nil
+}
+
+// ListInstallations lists the endpoints a certificate has been recorded on
+func (s *CertificatesService) ListInstallations(ctx context.Context, certificateID string) ([]Installation, *Response, error) {
+	u := fmt.Sprintf("certificate-by-id/%s/installations", certificateID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result installationListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result.Installations, resp, nil
+}
+
+// StaleInstallations returns the endpoints where the previous certificate is
+// still recorded as installed and the current one, typically its renewal,
+// is not, so renewal automation knows which endpoints remain to be updated.
+func (s *CertificatesService) StaleInstallations(ctx context.Context, previousID, currentID string) ([]Installation, error) {
+	previous, _, err := s.ListInstallations(ctx, previousID)
+	if err != nil {
+		return nil, err
+	}
+	current, _, err := s.ListInstallations(ctx, currentID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make(map[string]bool, len(current))
+	for _, inst := range current {
+		if inst.IsInstalled() {
+			updated[strings.ToLower(inst.Endpoint())] = true
+		}
+	}
+
+	var stale []Installation
+	for _, inst := range previous {
+		if inst.IsInstalled() && !updated[strings.ToLower(inst.Endpoint())] {
+			stale = append(stale, inst)
+		}
+	}
+	return stale, nil
+}
@@ -0,0 +1,69 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCertificatesService_RecordInstallation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/mpki/api/v1/certificate-by-id/cert-1/installations" {
+			t.Errorf("request = %s %s, want POST of cert-1 installations", r.Method, r.URL.Path)
+		}
+		var inst Installation
+		json.NewDecoder(r.Body).Decode(&inst)
+		if inst.Host != "web-1.example.com" || inst.Port != 443 || inst.InstalledAt == nil {
+			t.Errorf("installation = %+v, want host, port and a timestamp", inst)
+		}
+		json.NewEncoder(w).Encode(inst)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	inst, _, err := client.Certificates.RecordInstallation(context.Background(), "cert-1", &Installation{Host: "web-1.example.com", Port: 443, Source: "ansible"})
+	if err != nil {
+		t.Fatalf("RecordInstallation() error = %v", err)
+	}
+	if inst.Endpoint() != "web-1.example.com:443" || !inst.IsInstalled() {
+		t.Errorf("Installation = %+v", inst)
+	}
+
+	if _, _, err := client.Certificates.RecordInstallation(context.Background(), "cert-1", &Installation{Host: "web-1.example.com"}); err == nil {
+		t.Error("RecordInstallation() without port error = nil, want error")
+	}
+}
+
+func TestCertificatesService_StaleInstallations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate-by-id/old/installations":
+			w.Write([]byte(`{"installations": [
+				{"host": "web-1", "port": 443, "status": "installed"},
+				{"host": "web-2", "port": 443, "status": "installed"},
+				{"host": "web-3", "port": 443, "status": "removed"},
+				{"host": "WEB-4", "port": 8443}
+			]}`))
+		case "/mpki/api/v1/certificate-by-id/new/installations":
+			w.Write([]byte(`{"installations": [
+				{"host": "web-1", "port": 443, "status": "installed"},
+				{"host": "web-2", "port": 443, "status": "failed"},
+				{"host": "web-4", "port": 8443}
+			]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	stale, err := client.Certificates.StaleInstallations(context.Background(), "old", "new")
+	if err != nil {
+		t.Fatalf("StaleInstallations() error = %v", err)
+	}
+	if len(stale) != 1 || stale[0].Host != "web-2" {
+		t.Errorf("StaleInstallations() = %+v, want only web-2", stale)
+	}
+}
@@ -0,0 +1,258 @@
+// Package idna converts internationalized domain names to and from their
+// ASCII (punycode) form as described in RFC 3490 and RFC 3492.
+//
+// It covers what certificate requests need without depending on
+// golang.org/x/net/idna: labels are lower-cased but not NFC normalized or
+// checked against the full UTS #46 mapping tables.
+package idna
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const acePrefix = "xn--"
+
+// ToASCII converts domain to its ASCII form, punycode encoding every label
+// that contains non-ASCII characters. Labels are lower-cased and the
+// alternative full stops U+3002, U+FF0E and U+FF61 are treated as separators.
+func ToASCII(domain string) (string, error) {
+	labels := splitLabels(domain)
+	for i, label := range labels {
+		if isASCII(label) {
+			label = strings.ToLower(label)
+			if strings.HasPrefix(label, acePrefix) {
+				if _, err := decode(label[len(acePrefix):]); err != nil {
+					return "", fmt.Errorf("idna: invalid label %q: %w", label, err)
+				}
+			}
+			labels[i] = label
+			continue
+		}
+		if !utf8.ValidString(label) {
+			return "", fmt.Errorf("idna: label %q is not valid UTF-8", label)
+		}
+		encoded, err := encode(strings.ToLower(label))
+		if err != nil {
+			return "", fmt.Errorf("idna: invalid label %q: %w", label, err)
+		}
+		labels[i] = acePrefix + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// ToUnicode converts domain to its Unicode form, decoding every punycode
+// label.
+func ToUnicode(domain string) (string, error) {
+	labels := splitLabels(domain)
+	for i, label := range labels {
+		if len(label) < len(acePrefix) || !strings.EqualFold(label[:len(acePrefix)], acePrefix) {
+			continue
+		}
+		decoded, err := decode(strings.ToLower(label[len(acePrefix):]))
+		if err != nil {
+			return "", fmt.Errorf("idna: invalid label %q: %w", label, err)
+		}
+		labels[i] = decoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// IsASCII reports whether s contains only ASCII characters.
+func IsASCII(s string) bool {
+	return isASCII(s)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func splitLabels(domain string) []string {
+	domain = strings.Map(func(r rune) rune {
+		switch r {
+		case '。', '．', '｡':
+			return '.'
+		}
+		return r
+	}, domain)
+	return strings.Split(domain, ".")
+}
+
+// Punycode parameters from RFC 3492 section 5.
+const (
+	base        = 36
+	tmin        = 1
+	tmax        = 26
+	skew        = 38
+	damp        = 700
+	initialBias = 72
+	initialN    = 128
+	maxInt      = int(^uint32(0) >> 1)
+)
+
+var errOverflow = errors.New("punycode overflow")
+
+func adapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= damp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > ((base-tmin)*tmax)/2 {
+		delta /= base - tmin
+		k += base
+	}
+	return k + (base-tmin+1)*delta/(delta+skew)
+}
+
+func threshold(k, bias int) int {
+	switch {
+	case k <= bias:
+		return tmin
+	case k >= bias+tmax:
+		return tmax
+	}
+	return k - bias
+}
+
+func encodeDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func decodeDigit(c byte) (int, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c-'0') + 26, true
+	case 'a' <= c && c <= 'z':
+		return int(c - 'a'), true
+	case 'A' <= c && c <= 'Z':
+		return int(c - 'A'), true
+	}
+	return 0, false
+}
+
+// encode returns the punycode encoding of s, without the ACE prefix.
+func encode(s string) (string, error) {
+	runes := []rune(s)
+	var out strings.Builder
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out.WriteByte(byte(r))
+		}
+	}
+	basic := out.Len()
+	handled := basic
+	if basic > 0 {
+		out.WriteByte('-')
+	}
+
+	n, delta, bias := initialN, 0, initialBias
+	for handled < len(runes) {
+		m := int(unicode.MaxRune) + 1
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		if m-n > (maxInt-delta)/(handled+1) {
+			return "", errOverflow
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+				if delta > maxInt {
+					return "", errOverflow
+				}
+			}
+			if int(r) != n {
+				continue
+			}
+			q := delta
+			for k := base; ; k += base {
+				t := threshold(k, bias)
+				if q < t {
+					break
+				}
+				out.WriteByte(encodeDigit(t + (q-t)%(base-t)))
+				q = (q - t) / (base - t)
+			}
+			out.WriteByte(encodeDigit(q))
+			bias = adapt(delta, handled+1, handled == basic)
+			delta = 0
+			handled++
+		}
+		delta++
+		n++
+	}
+	return out.String(), nil
+}
+
+// decode returns the Unicode string encoded by the punycode s, without the
+// ACE prefix.
+func decode(s string) (string, error) {
+	var output []rune
+	rest := s
+	if pos := strings.LastIndexByte(s, '-'); pos >= 0 {
+		for _, r := range s[:pos] {
+			if r >= utf8.RuneSelf {
+				return "", errors.New("non-ASCII basic code point")
+			}
+			output = append(output, r)
+		}
+		rest = s[pos+1:]
+	}
+
+	n, i, bias := initialN, 0, initialBias
+	for idx := 0; idx < len(rest); {
+		oldi, w := i, 1
+		for k := base; ; k += base {
+			if idx >= len(rest) {
+				return "", errors.New("truncated punycode")
+			}
+			d, ok := decodeDigit(rest[idx])
+			if !ok {
+				return "", fmt.Errorf("invalid punycode digit %q", rest[idx])
+			}
+			idx++
+			if d > (maxInt-i)/w {
+				return "", errOverflow
+			}
+			i += d * w
+			t := threshold(k, bias)
+			if d < t {
+				break
+			}
+			w *= base - t
+		}
+		bias = adapt(i-oldi, len(output)+1, oldi == 0)
+		if i/(len(output)+1) > maxInt-n {
+			return "", errOverflow
+		}
+		n += i / (len(output) + 1)
+		i %= len(output) + 1
+		if n > unicode.MaxRune || (n >= 0xd800 && n <= 0xdfff) {
+			return "", fmt.Errorf("invalid code point %#x", n)
+		}
+		output = append(output, 0)
+		copy(output[i+1:], output[i:])
+		output[i] = rune(n)
+		i++
+	}
+	return string(output), nil
+}
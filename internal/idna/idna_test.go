@@ -0,0 +1,72 @@
+package idna
+
+import "testing"
+
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"example.com", "example.com"},
+		{"Example.COM", "example.com"},
+		{"bücher.example", "xn--bcher-kva.example"},
+		{"BÜCHER.example", "xn--bcher-kva.example"},
+		{"münchen.de", "xn--mnchen-3ya.de"},
+		{"日本語.jp", "xn--wgv71a119e.jp"},
+		{"例え。テスト", "xn--r8jz45g.xn--zckzah"},
+		{"*.bücher.example", "*.xn--bcher-kva.example"},
+		{"xn--bcher-kva.example", "xn--bcher-kva.example"},
+	}
+
+	for _, tt := range tests {
+		got, err := ToASCII(tt.in)
+		if err != nil {
+			t.Errorf("ToASCII(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToASCII(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if _, err := ToASCII("xn--a!.example"); err == nil {
+		t.Error("ToASCII() with invalid punycode label should fail")
+	}
+}
+
+func TestToUnicode(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"example.com", "example.com"},
+		{"xn--bcher-kva.example", "bücher.example"},
+		{"XN--MNCHEN-3YA.de", "münchen.de"},
+		{"xn--r8jz45g.xn--zckzah", "例え.テスト"},
+	}
+
+	for _, tt := range tests {
+		got, err := ToUnicode(tt.in)
+		if err != nil {
+			t.Errorf("ToUnicode(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToUnicode(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, s := range []string{"ü", "日本語", "δοκιμή", "испытание", "abc-ü-déf", "😀"} {
+		encoded, err := encode(s)
+		if err != nil {
+			t.Fatalf("encode(%q) error = %v", s, err)
+		}
+		decoded, err := decode(encoded)
+		if err != nil {
+			t.Fatalf("decode(%q) error = %v", encoded, err)
+		}
+		if decoded != s {
+			t.Errorf("decode(encode(%q)) = %v", s, decoded)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package kube
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+		}
+		if r.URL.Path != "/api/v1/namespaces/default/secrets/www-tls" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"metadata": {"name": "www-tls"}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{APIServer: server.URL + "/", Token: "token", Namespace: "default"}
+	secrets := c.URL("/api/v1", "secrets")
+	if want := server.URL + "/api/v1/namespaces/default/secrets"; secrets != want {
+		t.Errorf("URL() = %v, want %v", secrets, want)
+	}
+
+	var out struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	status, err := c.Do(context.Background(), http.MethodGet, secrets+"/www-tls", "", nil, &out)
+	if err != nil || status != http.StatusOK || out.Metadata.Name != "www-tls" {
+		t.Errorf("Do() = %v, %v, name %q, want 200 and www-tls", status, err, out.Metadata.Name)
+	}
+
+	status, err = c.Do(context.Background(), http.MethodGet, secrets+"/missing", "", nil, &out)
+	if err != nil || status != http.StatusNotFound {
+		t.Errorf("Do() = %v, %v, want 404", status, err)
+	}
+}
@@ -0,0 +1,485 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// generator turns the endpoints of a spec that are not written by hand into
+// Go source for the package scanned into hw.
+type generator struct {
+	spec *spec
+	hw   *handwritten
+	pkg  string
+
+	// types holds the generated type declarations, by name.
+	types map[string]string
+	// services lists the service types the output declares.
+	services map[string]bool
+	// methods holds the generated methods, by receiver.
+	methods map[string]map[string]bool
+
+	usesFmt, usesTime bool
+	warnings          []string
+}
+
+func newGenerator(s *spec, hw *handwritten, pkg string) *generator {
+	return &generator{
+		spec:     s,
+		hw:       hw,
+		pkg:      pkg,
+		types:    make(map[string]string),
+		services: make(map[string]bool),
+		methods:  make(map[string]map[string]bool),
+	}
+}
+
+func (g *generator) warnf(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+// generate returns the formatted source for every uncovered endpoint, and
+// the number of methods it holds.
+func (g *generator) generate(endpoints []endpoint) ([]byte, int, error) {
+	var methods bytes.Buffer
+	n := 0
+	for _, e := range endpoints {
+		if g.hw.covered[e.key()] {
+			continue
+		}
+		if g.method(&methods, e) {
+			n++
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by openapigen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg)
+	if n > 0 {
+		out.WriteString("import (\n\t\"context\"\n")
+		if g.usesFmt {
+			out.WriteString("\t\"fmt\"\n")
+		}
+		out.WriteString("\t\"net/http\"\n")
+		if g.usesTime {
+			out.WriteString("\t\"time\"\n")
+		}
+		out.WriteString(")\n\n")
+	}
+	for _, name := range sortedNames(g.services) {
+		fmt.Fprintf(&out, "type %s struct {\n\tclient *Client\n}\n\n", name)
+		g.warnf("%s is new: add it to Client and NewClient", name)
+	}
+	for _, name := range sortedNames(g.types) {
+		out.WriteString(g.types[name])
+	}
+	out.Write(methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), n, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, n, nil
+}
+
+// serviceFor returns the service type for e, declaring a new one when no
+// hand-written service requests the path segment.
+func (g *generator) serviceFor(e endpoint) string {
+	if name, ok := g.hw.services[e.segment()]; ok {
+		return name
+	}
+	name := exportName(e.segment()) + "Service"
+	if !g.hw.types[name] {
+		g.services[name] = true
+	}
+	return name
+}
+
+// methodName returns the exported name of e's method, from its operation
+// ID or else its method and literal path segments.
+func methodName(e endpoint) string {
+	if e.Op.OperationID != "" {
+		return exportName(e.Op.OperationID)
+	}
+	var b strings.Builder
+	b.WriteString(exportName(strings.ToLower(e.Method)))
+	for _, part := range strings.Split(e.Path, "/") {
+		if !strings.HasPrefix(part, "{") {
+			b.WriteString(exportName(part))
+		}
+	}
+	return b.String()
+}
+
+type param struct {
+	name, goName, typ string
+}
+
+// method writes the method for e and reports whether it did.
+func (g *generator) method(w *bytes.Buffer, e endpoint) bool {
+	service := g.serviceFor(e)
+	name := methodName(e)
+	if g.hw.methods[service][name] || g.methods[service][name] {
+		g.warnf("skipping %s %s: %s.%s already exists", e.Method, e.Path, service, name)
+		return false
+	}
+	if g.methods[service] == nil {
+		g.methods[service] = make(map[string]bool)
+	}
+	g.methods[service][name] = true
+
+	// Path parameters come from the template, in order, so they match the
+	// format string even when the document lists them differently.
+	var pathParams, queryParams []param
+	for _, part := range strings.Split(e.Path, "/") {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			name := strings.Trim(part, "{}")
+			pathParams = append(pathParams, param{name: name, goName: unexportName(name), typ: "string"})
+		}
+	}
+	for _, p := range e.Op.Parameters {
+		if p.In != "query" {
+			continue
+		}
+		typ := "string"
+		if p.Schema != nil {
+			if typ = g.scalarType(p.Schema); typ == "time.Time" || typ == "interface{}" {
+				typ = "string"
+			}
+		}
+		queryParams = append(queryParams, param{name: p.Name, goName: exportName(p.Name), typ: typ})
+	}
+	paginated := hasParam(queryParams, "offset") && hasParam(queryParams, "limit")
+
+	args := []string{"ctx context.Context"}
+	for _, p := range pathParams {
+		args = append(args, p.goName+" string")
+	}
+	var optsType string
+	if len(queryParams) > 0 {
+		optsType = g.optionsType(name, queryParams, paginated)
+		args = append(args, "opts *"+optsType)
+	}
+	body := "nil"
+	if s := e.requestSchema(); s != nil {
+		reqType := g.namedType(s, name+"Request")
+		args = append(args, "req *"+reqType)
+		body = "req"
+	}
+
+	var result, resultDecl string
+	pointer := false
+	if s := e.responseSchema(); s != nil {
+		result = g.namedType(s, name+"Response")
+		pointer = !strings.HasPrefix(result, "[]") && !strings.HasPrefix(result, "map[") && result != "interface{}"
+		resultDecl = result
+		if pointer {
+			resultDecl = "*" + result
+		}
+	}
+
+	fmt.Fprintf(w, "// %s sends %s %s.\n", name, e.Method, e.Path)
+	if summary := firstSentence(e.Op.Summary); summary != "" {
+		fmt.Fprintf(w, "//\n// %s.\n", summary)
+	}
+	if result != "" {
+		fmt.Fprintf(w, "func (s *%s) %s(%s) (%s, *Response, error) {\n", service, name, strings.Join(args, ", "), resultDecl)
+	} else {
+		fmt.Fprintf(w, "func (s *%s) %s(%s) (*Response, error) {\n", service, name, strings.Join(args, ", "))
+	}
+
+	if len(pathParams) > 0 {
+		g.usesFmt = true
+		format := e.Path
+		values := make([]string, 0, len(pathParams))
+		for _, p := range pathParams {
+			format = strings.Replace(format, "{"+p.name+"}", "%s", 1)
+			values = append(values, p.goName)
+		}
+		fmt.Fprintf(w, "\tu := fmt.Sprintf(%q, %s)\n\n", format, strings.Join(values, ", "))
+	} else {
+		fmt.Fprintf(w, "\tu := %q\n\n", e.Path)
+	}
+
+	failNil := "nil, nil, err"
+	if result == "" {
+		failNil = "nil, err"
+	}
+	fmt.Fprintf(w, "\thttpReq, err := s.client.NewRequest(ctx, http.Method%s, u, %s)\n", exportName(strings.ToLower(e.Method)), body)
+	fmt.Fprintf(w, "\tif err != nil {\n\t\treturn %s\n\t}\n\n", failNil)
+
+	if optsType != "" {
+		w.WriteString("\t// Add query parameters\n\tif opts != nil {\n\t\tq := httpReq.URL.Query()\n")
+		for _, p := range queryParams {
+			if paginated && (p.name == "offset" || p.name == "limit") {
+				continue
+			}
+			g.writeQueryParam(w, p)
+		}
+		if paginated {
+			g.usesFmt = true
+			w.WriteString("\t\tif opts.Offset > 0 {\n")
+			w.WriteString("\t\t\tq.Add(\"offset\", fmt.Sprintf(\"%d\", opts.Offset))\n\t\t}\n")
+			w.WriteString("\t\tif opts.Limit > 0 {\n")
+			w.WriteString("\t\t\tq.Add(\"limit\", fmt.Sprintf(\"%d\", opts.Limit))\n\t\t}\n")
+		}
+		w.WriteString("\t\thttpReq.URL.RawQuery = q.Encode()\n\t}\n\n")
+	}
+
+	if result == "" {
+		w.WriteString("\treturn s.client.Do(ctx, httpReq, nil)\n}\n\n")
+		return true
+	}
+	fmt.Fprintf(w, "\tvar result %s\n", result)
+	w.WriteString("\tresp, err := s.client.Do(ctx, httpReq, &result)\n")
+	w.WriteString("\tif err != nil {\n\t\treturn nil, resp, err\n\t}\n\n")
+	if pointer {
+		w.WriteString("\treturn &result, resp, nil\n}\n\n")
+	} else {
+		w.WriteString("\treturn result, resp, nil\n}\n\n")
+	}
+	return true
+}
+
+func (g *generator) writeQueryParam(w *bytes.Buffer, p param) {
+	field := "opts." + p.goName
+	switch p.typ {
+	case "int", "int64":
+		g.usesFmt = true
+		fmt.Fprintf(w, "\t\tif %s != 0 {\n\t\t\tq.Add(%q, fmt.Sprintf(\"%%d\", %s))\n\t\t}\n", field, p.name, field)
+	case "float64":
+		g.usesFmt = true
+		fmt.Fprintf(w, "\t\tif %s != 0 {\n\t\t\tq.Add(%q, fmt.Sprintf(\"%%g\", %s))\n\t\t}\n", field, p.name, field)
+	case "bool":
+		fmt.Fprintf(w, "\t\tif %s {\n\t\t\tq.Add(%q, \"true\")\n\t\t}\n", field, p.name)
+	default:
+		fmt.Fprintf(w, "\t\tif %s != \"\" {\n\t\t\tq.Add(%q, %s)\n\t\t}\n", field, p.name, field)
+	}
+}
+
+func hasParam(params []param, name string) bool {
+	for _, p := range params {
+		if p.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// optionsType declares the query options struct of a method. Offset and
+// limit come from an embedded PaginationParams when both are present.
+func (g *generator) optionsType(method string, params []param, paginated bool) string {
+	name := g.uniqueTypeName(method + "Options")
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	if paginated {
+		b.WriteString("\tPaginationParams\n")
+	}
+	for _, p := range params {
+		if paginated && (p.name == "offset" || p.name == "limit") {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s %s `url:\"%s,omitempty\"`\n", p.goName, p.typ, p.name)
+	}
+	b.WriteString("}\n\n")
+	g.types[name] = b.String()
+	return name
+}
+
+// namedType returns the type for a request or response body, declaring it
+// as hint when the schema is an inline object.
+func (g *generator) namedType(s *schema, hint string) string {
+	typ := g.goType(s, hint)
+	return strings.TrimPrefix(typ, "*")
+}
+
+// goType returns the Go type of s as a struct field, declaring referenced
+// and inline object schemas as needed. Hand-written types of the same name
+// are reused rather than generated.
+func (g *generator) goType(s *schema, hint string) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if s.Ref != "" {
+		name := exportName(s.refName())
+		target := g.spec.Components.Schemas[s.refName()]
+		if target == nil {
+			g.warnf("unresolved reference %s", s.Ref)
+			return "interface{}"
+		}
+		if !isObject(target) {
+			return g.goType(target, name)
+		}
+		if !g.hw.types[name] {
+			if _, done := g.types[name]; !done {
+				g.types[name] = "" // reserve before recursing
+				g.types[name] = g.structType(name, target)
+			}
+		}
+		return "*" + name
+	}
+	switch s.Type {
+	case "array":
+		item := g.goType(s.Items, hint+"Item")
+		return "[]" + strings.TrimPrefix(item, "*")
+	case "object", "":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		name := g.uniqueTypeName(hint)
+		g.types[name] = ""
+		g.types[name] = g.structType(name, s)
+		return "*" + name
+	}
+	typ := g.scalarType(s)
+	if typ == "time.Time" {
+		g.usesTime = true
+		return "*time.Time"
+	}
+	return typ
+}
+
+func (g *generator) scalarType(s *schema) string {
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "interface{}"
+}
+
+func isObject(s *schema) bool {
+	return s.Type == "object" && len(s.Properties) > 0 || s.Type == "" && len(s.Properties) > 0
+}
+
+// structType declares name with a field for each property of s. Required
+// properties are sent even when empty.
+func (g *generator) structType(name string, s *schema) string {
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	var b strings.Builder
+	if d := firstSentence(s.Description); d != "" {
+		fmt.Fprintf(&b, "// %s: %s.\n", name, d)
+	}
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for _, prop := range sortedNames(s.Properties) {
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:%q`\n", exportName(prop), g.goType(s.Properties[prop], name+exportName(prop)), tag)
+	}
+	b.WriteString("}\n\n")
+	return b.String()
+}
+
+func (g *generator) uniqueTypeName(name string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		if _, generated := g.types[candidate]; !generated && !g.hw.types[candidate] {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{
+	"acme": true, "api": true, "ca": true, "cn": true, "csr": true, "dn": true,
+	"dns": true, "http": true, "https": true, "ica": true, "id": true, "ip": true,
+	"json": true, "ocsp": true, "pem": true, "san": true, "scep": true, "tls": true,
+	"ttl": true, "uri": true, "url": true, "utc": true, "uuid": true,
+}
+
+// nameWords splits snake_case, kebab-case and camelCase names into words.
+func nameWords(s string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+	if len(words) == 0 || unicode.IsDigit([]rune(words[0])[0]) {
+		words = append([]string{"x"}, words...)
+	}
+	return words
+}
+
+func exportWord(w string) string {
+	lower := strings.ToLower(w)
+	if initialisms[lower] {
+		return strings.ToUpper(lower)
+	}
+	r := []rune(lower)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// exportName converts a name to an exported Go identifier.
+func exportName(s string) string {
+	var b strings.Builder
+	for _, w := range nameWords(s) {
+		b.WriteString(exportWord(w))
+	}
+	return b.String()
+}
+
+// unexportName converts a name to an unexported Go identifier, for
+// parameters.
+func unexportName(s string) string {
+	words := nameWords(s)
+	var b strings.Builder
+	b.WriteString(strings.ToLower(words[0]))
+	for _, w := range words[1:] {
+		b.WriteString(exportWord(w))
+	}
+	return b.String()
+}
+
+func firstSentence(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\n", " "))
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSuffix(s, ".")
+}
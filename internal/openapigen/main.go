@@ -0,0 +1,76 @@
+// Command openapigen generates request and response types and basic service
+// methods for the endpoints of the TLM OpenAPI document that the digicert
+// package does not implement by hand.
+//
+// It scans the package for NewRequest calls to find the endpoints already
+// covered, then writes the rest to a single file. Methods join the
+// hand-written service that owns the path's first segment; segments with
+// no service get a new service type, which must be added to Client by
+// hand. Hand-written types and methods of the same name always win, so an
+// endpoint can be promoted to a curated implementation by writing it and
+// regenerating.
+//
+// Usage:
+//
+//	go run ./internal/openapigen -spec openapi-public.json [-dir .] [-out zz_generated_endpoints.go]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("openapigen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	specPath := fs.String("spec", "", "OpenAPI 3 document in JSON")
+	dir := fs.String("dir", ".", "directory of the package to extend")
+	out := fs.String("out", "zz_generated_endpoints.go", "output file, relative to -dir")
+	pkg := fs.String("pkg", "digicert", "package name of the output")
+	basePath := fs.String("base-path", "", "path prefix to strip from the document's paths, besides /mpki/api/<version>")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *specPath == "" {
+		fmt.Fprintln(stderr, "openapigen: -spec is required")
+		return 2
+	}
+
+	s, err := loadSpec(*specPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "openapigen: %v\n", err)
+		return 1
+	}
+	hw, err := scanPackage(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "openapigen: scan %s: %v\n", *dir, err)
+		return 1
+	}
+
+	g := newGenerator(s, hw, *pkg)
+	src, n, err := g.generate(s.endpoints(*basePath))
+	for _, w := range g.warnings {
+		fmt.Fprintf(stderr, "openapigen: %s\n", w)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "openapigen: %v\n", err)
+		return 1
+	}
+	path := *out
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(*dir, path)
+	}
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		fmt.Fprintf(stderr, "openapigen: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "openapigen: wrote %d methods to %s\n", n, *out)
+	return 0
+}
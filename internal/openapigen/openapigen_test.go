@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden file")
+
+// TestGenerate runs the generator against the digicert package and the
+// sample document, which mixes hand-written and new endpoints.
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	var stderr bytes.Buffer
+	code := run([]string{
+		"-spec", "testdata/openapi.json",
+		"-dir", "../..",
+		"-out", filepath.Join(dir, "generated.go"),
+	}, &stderr)
+	if code != 0 {
+		t.Fatalf("run() = %d, stderr %q", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "ReportService is new") || !strings.Contains(stderr.String(), "wrote 5 methods") {
+		t.Errorf("stderr = %q, want the new service reported and 5 methods", stderr.String())
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "generated.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated code differs from %s; run go test -update to accept:\n%s", golden, got)
+	}
+}
+
+func TestScanPackage(t *testing.T) {
+	hw, err := scanPackage("../..")
+	if err != nil {
+		t.Fatalf("scanPackage() error = %v", err)
+	}
+	for _, key := range []string{
+		"GET certificate/{}",
+		"PUT certificate/{}/revoke",
+		"GET profiles",
+		"POST tag/{}/rename",
+		"PATCH business-unit/{}/admin/{}",
+	} {
+		if !hw.covered[key] {
+			t.Errorf("covered[%q] = false, want true", key)
+		}
+	}
+	if hw.services["certificate"] != "CertificatesService" || hw.services["profiles"] != "ProfilesService" {
+		t.Errorf("services = %v, want certificate and profiles mapped to their services", hw.services)
+	}
+	if !hw.types["Certificate"] || !hw.methods["CertificatesService"]["Get"] {
+		t.Error("scan missed the Certificate type or CertificatesService.Get")
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		in, exported, unexported string
+	}{
+		{"serial_number", "SerialNumber", "serialNumber"},
+		{"getCertificateHistory", "GetCertificateHistory", "getCertificateHistory"},
+		{"business-unit", "BusinessUnit", "businessUnit"},
+		{"ica_id", "ICAID", "icaID"},
+		{"id", "ID", "id"},
+		{"csr_pem", "CSRPEM", "csrPEM"},
+		{"2fa_enabled", "X2faEnabled", "x2faEnabled"},
+	}
+	for _, tt := range tests {
+		if got := exportName(tt.in); got != tt.exported {
+			t.Errorf("exportName(%q) = %v, want %v", tt.in, got, tt.exported)
+		}
+		if got := unexportName(tt.in); got != tt.unexported {
+			t.Errorf("unexportName(%q) = %v, want %v", tt.in, got, tt.unexported)
+		}
+	}
+
+	if got := normalizePath("/certificate/{serial_number}/revoke"); got != "certificate/{}/revoke" {
+		t.Errorf("normalizePath() = %v", got)
+	}
+	if got := normalizePath("business-unit/%s/admin?limit=1"); got != "business-unit/{}/admin" {
+		t.Errorf("normalizePath() = %v", got)
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run(nil, &stderr); code != 2 || !strings.Contains(stderr.String(), "-spec is required") {
+		t.Errorf("run() = %d, %q, want usage error", code, stderr.String())
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// handwritten describes the package the generated code joins.
+type handwritten struct {
+	// types holds every declared type name.
+	types map[string]bool
+	// methods holds the method names of each receiver type.
+	methods map[string]map[string]bool
+	// covered holds the endpoints requested through NewRequest, keyed as
+	// endpoint.key.
+	covered map[string]bool
+	// services maps a path segment to the service type requesting it
+	// most often.
+	services map[string]string
+	// requests counts the NewRequest calls of each service type per path
+	// segment.
+	requests map[string]map[string]int
+}
+
+// scanPackage parses the non-test, non-generated Go files in dir and
+// records the endpoints already implemented by hand.
+func scanPackage(dir string) (*handwritten, error) {
+	h := &handwritten{
+		types:    make(map[string]bool),
+		methods:  make(map[string]map[string]bool),
+		covered:  make(map[string]bool),
+		services: make(map[string]string),
+		requests: make(map[string]map[string]int),
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if ast.IsGenerated(f) {
+			continue
+		}
+		h.scanFile(f)
+	}
+
+	for segment, calls := range h.requests {
+		best := ""
+		for service, n := range calls {
+			if n > calls[best] || n == calls[best] && service < best {
+				best = service
+			}
+		}
+		h.services[segment] = best
+	}
+	return h, nil
+}
+
+func (h *handwritten) scanFile(f *ast.File) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					h.types[ts.Name.Name] = true
+				}
+			}
+		case *ast.FuncDecl:
+			recv := receiverName(d)
+			if recv != "" {
+				if h.methods[recv] == nil {
+					h.methods[recv] = make(map[string]bool)
+				}
+				h.methods[recv][d.Name.Name] = true
+			}
+			if d.Body != nil {
+				h.scanBody(recv, d.Body)
+			}
+		}
+	}
+}
+
+func receiverName(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return ""
+	}
+	t := d.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// scanBody records the NewRequest calls in a function body. Paths held in
+// local variables are resolved from their most recent assignment.
+func (h *handwritten) scanBody(recv string, body *ast.BlockStmt) {
+	paths := make(map[string]string)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && i < len(n.Rhs) {
+					if p, ok := pathExpr(n.Rhs[i], paths); ok {
+						paths[id.Name] = p
+					}
+				}
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "NewRequest" || len(n.Args) < 3 {
+				return true
+			}
+			method, ok := methodExpr(n.Args[1])
+			if !ok {
+				return true
+			}
+			path, ok := pathExpr(n.Args[2], paths)
+			if !ok {
+				return true
+			}
+			h.covered[method+" "+normalizePath(path)] = true
+			if strings.HasSuffix(recv, "Service") {
+				segment, _, _ := strings.Cut(strings.Trim(path, "/"), "/")
+				segment, _, _ = strings.Cut(segment, "?")
+				if h.requests[segment] == nil {
+					h.requests[segment] = make(map[string]int)
+				}
+				h.requests[segment][recv]++
+			}
+		}
+		return true
+	})
+}
+
+// methodExpr resolves http.MethodX constants and string literals.
+func methodExpr(e ast.Expr) (string, bool) {
+	switch e := e.(type) {
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok && x.Name == "http" && strings.HasPrefix(e.Sel.Name, "Method") {
+			return strings.ToUpper(strings.TrimPrefix(e.Sel.Name, "Method")), true
+		}
+	case *ast.BasicLit:
+		if s, err := strconv.Unquote(e.Value); err == nil {
+			return strings.ToUpper(s), true
+		}
+	}
+	return "", false
+}
+
+// pathExpr resolves a request path built from string literals,
+// fmt.Sprintf with a literal format, concatenation and known variables.
+// Parts that are not literals become "%s".
+func pathExpr(e ast.Expr, vars map[string]string) (string, bool) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.Ident:
+		p, ok := vars[e.Name]
+		return p, ok
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || len(e.Args) == 0 {
+			return "", false
+		}
+		if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "fmt" || sel.Sel.Name != "Sprintf" {
+			return "", false
+		}
+		return pathExpr(e.Args[0], nil)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := pathExpr(e.X, vars)
+		if !ok {
+			left = "%s"
+		}
+		right, ok := pathExpr(e.Y, vars)
+		if !ok {
+			right = "%s"
+		}
+		return left + right, true
+	}
+	return "", false
+}
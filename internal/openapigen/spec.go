@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// spec is the subset of an OpenAPI 3 document the generator reads.
+type spec struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Tags        []string     `json:"tags"`
+	Parameters  []*parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Enum                 []interface{}      `json:"enum"`
+}
+
+// refName returns the component name a $ref points to.
+func (s *schema) refName() string {
+	return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+}
+
+func loadSpec(path string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// endpoint is one operation of the document, with its path relative to
+// the API version.
+type endpoint struct {
+	Method string
+	Path   string
+	Op     *operation
+}
+
+// key identifies the endpoint regardless of path parameter names, in the
+// form the scanner reports hand-written endpoints.
+func (e endpoint) key() string {
+	return e.Method + " " + normalizePath(e.Path)
+}
+
+// segment returns the first path segment, which selects the service.
+func (e endpoint) segment() string {
+	segment, _, _ := strings.Cut(e.Path, "/")
+	return segment
+}
+
+// requestSchema returns the JSON request body schema, or nil.
+func (e endpoint) requestSchema() *schema {
+	if e.Op.RequestBody == nil {
+		return nil
+	}
+	return e.Op.RequestBody.Content["application/json"].Schema
+}
+
+// responseSchema returns the JSON schema of the first 2xx response, or nil.
+func (e endpoint) responseSchema() *schema {
+	codes := make([]string, 0, len(e.Op.Responses))
+	for code := range e.Op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			return e.Op.Responses[code].Content["application/json"].Schema
+		}
+	}
+	return nil
+}
+
+var httpMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// endpoints returns the operations of s in a stable order, with basePath
+// and any "/mpki/api/<version>" prefix stripped from their paths.
+func (s *spec) endpoints(basePath string) []endpoint {
+	var out []endpoint
+	for path, ops := range s.Paths {
+		rel := strings.TrimPrefix(path, strings.TrimSuffix(basePath, "/"))
+		if _, rest, ok := strings.Cut(rel, "/mpki/api/"); ok {
+			_, rel, _ = strings.Cut(rest, "/")
+		}
+		rel = strings.Trim(rel, "/")
+		for _, method := range httpMethods {
+			if op := ops[method]; op != nil {
+				out = append(out, endpoint{Method: strings.ToUpper(method), Path: rel, Op: op})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+// normalizePath replaces path parameters, whether OpenAPI "{name}" or
+// fmt verbs such as "%s", with "{}" and drops any query string.
+func normalizePath(path string) string {
+	path, _, _ = strings.Cut(strings.Trim(path, "/"), "?")
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, "{") || strings.HasPrefix(p, "%") {
+			parts[i] = "{}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,275 @@
+package digicert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonhadfield/go-digicert-tlm/report"
+)
+
+// ExportFormatCycloneDX exports a CycloneDX bill of materials with a
+// cryptographic asset per certificate, for compliance tooling that ingests
+// SBOM-style attestations.
+const ExportFormatCycloneDX ExportFormat = "cyclonedx"
+
+// CycloneDXSpecVersion is the CycloneDX specification version written by
+// ExportInventory.
+const CycloneDXSpecVersion = "1.6"
+
+// InventoryColumns lists the columns ExportInventory can write to CSV and
+// XLSX, in their default order.
+var InventoryColumns = []string{
+	"serial_number", "common_name", "status", "valid_from", "valid_to", "key_size",
+	"signature_algorithm", "thumbprint", "issuing_ca", "profile_id", "business_unit_id",
+}
+
+type InventoryExportOptions struct {
+	// Search selects the certificates exported. Every certificate is
+	// exported when nil.
+	Search *CertificateSearchOptions
+	// Report picks, orders and renames the CSV and XLSX columns from
+	// InventoryColumns.
+	Report *report.Options
+}
+
+// ExportInventory writes certificates to w in the given format. JSON writes
+// the certificates as returned by the API, and CSV and XLSX one row per
+// certificate. For CycloneDX, subject, issuer, validity and algorithms are
+// read from the PEM when the search returns it and from the API fields
+// otherwise. Nothing is written if the inventory cannot be read.
+func (s *CertificatesService) ExportInventory(ctx context.Context, w io.Writer, format ExportFormat, opts *InventoryExportOptions) error {
+	switch format {
+	case ExportFormatJSON, ExportFormatCSV, ExportFormatXLSX, ExportFormatCycloneDX:
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+	if opts == nil {
+		opts = &InventoryExportOptions{}
+	}
+
+	var certs []Certificate
+	for c, err := range s.All(ctx, opts.Search) {
+		if err != nil {
+			return err
+		}
+		certs = append(certs, c)
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(certs)
+	case ExportFormatCycloneDX:
+		bom, err := newCycloneDXBOM(certs, time.Now())
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(bom)
+	}
+
+	records := make([]report.Record, 0, len(certs))
+	for _, c := range certs {
+		rec := report.Record{
+			"serial_number":       c.SerialNumber,
+			"common_name":         c.CommonName,
+			"status":              c.Status,
+			"valid_from":          c.ValidFrom,
+			"valid_to":            c.ValidTo,
+			"key_size":            string(c.KeySize),
+			"signature_algorithm": c.SignatureAlgorithm,
+			"thumbprint":          c.Thumbprint,
+			"issuing_ca":          c.IssuingCAName,
+			"profile_id":          c.Profile.ID,
+		}
+		if c.BusinessUnit != nil {
+			rec["business_unit_id"] = c.BusinessUnit.ID
+		}
+		records = append(records, rec)
+	}
+	return report.Write(w, report.Format(format), InventoryColumns, records, opts.Report)
+}
+
+type cdxBOM struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []cdxComponent `json:"components"`
+	} `json:"tools"`
+}
+
+type cdxComponent struct {
+	Type             string               `json:"type"`
+	BOMRef           string               `json:"bom-ref,omitempty"`
+	Name             string               `json:"name"`
+	Version          string               `json:"version,omitempty"`
+	CryptoProperties *cdxCryptoProperties `json:"cryptoProperties,omitempty"`
+	Properties       []cdxProperty        `json:"properties,omitempty"`
+}
+
+type cdxCryptoProperties struct {
+	AssetType             string                    `json:"assetType"`
+	AlgorithmProperties   *cdxAlgorithmProperties   `json:"algorithmProperties,omitempty"`
+	CertificateProperties *cdxCertificateProperties `json:"certificateProperties,omitempty"`
+}
+
+type cdxAlgorithmProperties struct {
+	Primitive string `json:"primitive"`
+}
+
+type cdxCertificateProperties struct {
+	SubjectName           string `json:"subjectName,omitempty"`
+	IssuerName            string `json:"issuerName,omitempty"`
+	NotValidBefore        string `json:"notValidBefore,omitempty"`
+	NotValidAfter         string `json:"notValidAfter,omitempty"`
+	SignatureAlgorithmRef string `json:"signatureAlgorithmRef,omitempty"`
+	CertificateFormat     string `json:"certificateFormat"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// newCycloneDXBOM describes certs as cryptographic assets, each referring to
+// a shared component for its signature algorithm.
+func newCycloneDXBOM(certs []Certificate, now time.Time) (*cdxBOM, error) {
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	bom := &cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  CycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + id,
+		Version:      1,
+		Components:   make([]cdxComponent, 0, len(certs)),
+	}
+	bom.Metadata.Timestamp = now.UTC().Format(time.RFC3339)
+	bom.Metadata.Tools.Components = []cdxComponent{{Type: "library", Name: "go-digicert-tlm", Version: strings.TrimPrefix(UserAgent, "go-digicert/")}}
+
+	algorithms := make(map[string]string)
+	for _, c := range certs {
+		props := &cdxCertificateProperties{
+			SubjectName:    subjectDN(c.Subject, c.CommonName),
+			IssuerName:     c.IssuingCAName,
+			NotValidBefore: c.ValidFrom,
+			NotValidAfter:  c.ValidTo,
+		}
+		sigAlg, keySize := c.SignatureAlgorithm, string(c.KeySize)
+		if leaf, err := parseCertificatePEM(c.Certificate); err == nil {
+			props.SubjectName = leaf.Subject.String()
+			props.IssuerName = leaf.Issuer.String()
+			props.NotValidBefore = leaf.NotBefore.UTC().Format(time.RFC3339)
+			props.NotValidAfter = leaf.NotAfter.UTC().Format(time.RFC3339)
+			sigAlg = leaf.SignatureAlgorithm.String()
+			if bits := publicKeyBits(leaf); bits > 0 {
+				keySize = strconv.Itoa(bits)
+			}
+		}
+		props.CertificateFormat = "X.509"
+		if sigAlg != "" {
+			ref := "crypto/algorithm/" + sigAlg
+			algorithms[sigAlg] = ref
+			props.SignatureAlgorithmRef = ref
+		}
+
+		name := c.CommonName
+		if name == "" {
+			name = c.SerialNumber
+		}
+		component := cdxComponent{
+			Type:             "cryptographic-asset",
+			BOMRef:           "crypto/certificate/" + c.SerialNumber,
+			Name:             name,
+			CryptoProperties: &cdxCryptoProperties{AssetType: "certificate", CertificateProperties: props},
+		}
+		for _, p := range [][2]string{
+			{"digicert:tlm:id", c.ID},
+			{"digicert:tlm:serial_number", c.SerialNumber},
+			{"digicert:tlm:status", c.Status},
+			{"digicert:tlm:key_size", keySize},
+			{"digicert:tlm:thumbprint", c.Thumbprint},
+			{"digicert:tlm:profile_id", c.Profile.ID},
+		} {
+			if p[1] != "" {
+				component.Properties = append(component.Properties, cdxProperty{Name: p[0], Value: p[1]})
+			}
+		}
+		if c.BusinessUnit != nil && c.BusinessUnit.ID != "" {
+			component.Properties = append(component.Properties, cdxProperty{Name: "digicert:tlm:business_unit_id", Value: c.BusinessUnit.ID})
+		}
+		bom.Components = append(bom.Components, component)
+	}
+
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bom.Components = append(bom.Components, cdxComponent{
+			Type:   "cryptographic-asset",
+			BOMRef: algorithms[name],
+			Name:   name,
+			CryptoProperties: &cdxCryptoProperties{
+				AssetType:           "algorithm",
+				AlgorithmProperties: &cdxAlgorithmProperties{Primitive: "signature"},
+			},
+		})
+	}
+	return bom, nil
+}
+
+// subjectDN renders the subject the API reported as a distinguished name.
+func subjectDN(s *Subject, commonName string) string {
+	name := pkix.Name{CommonName: commonName}
+	if s != nil {
+		if s.CommonName != "" {
+			name.CommonName = s.CommonName
+		}
+		if s.OrganizationName != "" {
+			name.Organization = []string{s.OrganizationName}
+		}
+		name.OrganizationalUnit = s.OrganizationUnits
+		if s.Locality != "" {
+			name.Locality = []string{s.Locality}
+		}
+		if s.Country != "" {
+			name.Country = []string{s.Country}
+		}
+	}
+	return name.String()
+}
+
+// publicKeyBits returns the size of the certificate's public key, or 0 if
+// it has no meaningful size.
+func publicKeyBits(leaf *x509.Certificate) int {
+	switch pub := leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return pub.N.BitLen()
+	case *ecdsa.PublicKey:
+		return pub.Curve.Params().BitSize
+	}
+	return 0
+}
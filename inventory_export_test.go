@@ -0,0 +1,113 @@
+package digicert
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonhadfield/go-digicert-tlm/report"
+)
+
+func TestCertificatesService_ExportInventory(t *testing.T) {
+	leaf := newTestCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "api.example.com", Organization: []string{"Example"}},
+		NotBefore: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+	certs := []Certificate{
+		{SerialNumber: "0A", CommonName: "api.example.com", Status: "issued", Certificate: leaf.pem, Profile: ProfileRef{ID: "p-1"}},
+		{SerialNumber: "0B", CommonName: "old.example.com", Status: "issued", KeySize: "2048", SignatureAlgorithm: "SHA256-RSA",
+			ValidTo: "2026-06-01T00:00:00Z", IssuingCAName: "Example CA", BusinessUnit: &BusinessUnitRef{ID: "bu-1"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: len(certs)}, Items: certs})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	t.Run("CycloneDX", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.Certificates.ExportInventory(ctx, &buf, ExportFormatCycloneDX, nil); err != nil {
+			t.Fatalf("ExportInventory() error = %v", err)
+		}
+		var bom cdxBOM
+		if err := json.Unmarshal(buf.Bytes(), &bom); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != CycloneDXSpecVersion || !strings.HasPrefix(bom.SerialNumber, "urn:uuid:") {
+			t.Errorf("BOM header = %s %s %s", bom.BOMFormat, bom.SpecVersion, bom.SerialNumber)
+		}
+		if len(bom.Components) != 4 {
+			t.Fatalf("Components = %+v, want two certificates and two algorithms", bom.Components)
+		}
+
+		first := bom.Components[0].CryptoProperties.CertificateProperties
+		if first.SubjectName != "CN=api.example.com,O=Example" || first.NotValidAfter != "2027-01-01T00:00:00Z" ||
+			first.SignatureAlgorithmRef != "crypto/algorithm/ECDSA-SHA256" {
+			t.Errorf("parsed certificate properties = %+v", first)
+		}
+		if !hasCDXProperty(bom.Components[0], "digicert:tlm:key_size", "256") {
+			t.Errorf("properties = %+v, want the parsed key size", bom.Components[0].Properties)
+		}
+
+		second := bom.Components[1]
+		if p := second.CryptoProperties.CertificateProperties; p.SubjectName != "CN=old.example.com" || p.IssuerName != "Example CA" ||
+			p.SignatureAlgorithmRef != "crypto/algorithm/SHA256-RSA" {
+			t.Errorf("API certificate properties = %+v", p)
+		}
+		if !hasCDXProperty(second, "digicert:tlm:business_unit_id", "bu-1") {
+			t.Errorf("properties = %+v, want the business unit", second.Properties)
+		}
+		if alg := bom.Components[2]; alg.BOMRef != "crypto/algorithm/ECDSA-SHA256" || alg.CryptoProperties.AssetType != "algorithm" {
+			t.Errorf("algorithm component = %+v", alg)
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.Certificates.ExportInventory(ctx, &buf, ExportFormatCSV, nil); err != nil {
+			t.Fatalf("ExportInventory() error = %v", err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 3 || lines[2] != "0B,old.example.com,issued,,2026-06-01T00:00:00Z,2048,SHA256-RSA,,Example CA,,bu-1" {
+			t.Errorf("CSV = %q", buf.String())
+		}
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := client.Certificates.ExportInventory(ctx, &buf, ExportFormatCSV, &InventoryExportOptions{
+			Search: &CertificateSearchOptions{Status: "issued"},
+			Report: &report.Options{Columns: []string{"common_name", "valid_to"}, Headers: map[string]string{"valid_to": "Expires"}},
+		})
+		if err != nil {
+			t.Fatalf("ExportInventory() error = %v", err)
+		}
+		if want := "common_name,Expires\napi.example.com,\nold.example.com,2026-06-01T00:00:00Z\n"; buf.String() != want {
+			t.Errorf("CSV = %q, want %q", buf.String(), want)
+		}
+	})
+
+	if err := client.Certificates.ExportInventory(ctx, &bytes.Buffer{}, "xml", nil); err == nil {
+		t.Error("ExportInventory() expected error for an unsupported format")
+	}
+}
+
+func hasCDXProperty(c cdxComponent, name, value string) bool {
+	for _, p := range c.Properties {
+		if p.Name == name && p.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type InvitationsService struct {
+	client *Client
+}
+
+// Invitations service lists the invitations sent to account administrators,
+// business unit administrators and certificate owners that have not been
+// accepted yet, and resends or revokes them
+
+// InvitationType identifies who an invitation was sent to.
+type InvitationType string
+
+const (
+	InvitationTypeAdmin             InvitationType = "admin"
+	InvitationTypeBusinessUnitAdmin InvitationType = "business_unit_admin"
+	InvitationTypeCertificateOwner  InvitationType = "certificate_owner"
+)
+
+type Invitation struct {
+	ID    string         `json:"id"`
+	Type  InvitationType `json:"type"`
+	Email string         `json:"email"`
+	// InviteeID is the administrator or certificate owner invited.
+	InviteeID string `json:"invitee_id,omitempty"`
+	// BusinessUnitID is set for business unit administrator invitations.
+	BusinessUnitID string     `json:"business_unit_id,omitempty"`
+	InvitedBy      string     `json:"invited_by,omitempty"`
+	SentAt         *time.Time `json:"sent_at,omitempty"`
+	LastSentAt     *time.Time `json:"last_sent_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	SendCount      int        `json:"send_count,omitempty"`
+}
+
+// Expired reports whether the invitation can no longer be accepted at now.
+// Expired invitations can still be resent, which renews them.
+func (i *Invitation) Expired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
+}
+
+type InvitationListOptions struct {
+	PaginationParams
+	Type  InvitationType `url:"type,omitempty"`
+	Email string         `url:"email,omitempty"`
+}
+
+type InvitationListResponse struct {
+	ListResponse
+	Invitations []Invitation `json:"invitations"`
+}
+
+// List lists pending invitations
+func (s *InvitationsService) List(ctx context.Context, opts *InvitationListOptions) (*InvitationListResponse, *Response, error) {
+	u := "invitation"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Type != "" {
+			q.Add("type", string(opts.Type))
+		}
+		if opts.Email != "" {
+			q.Add("email", opts.Email)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result InvitationListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// Resend sends an invitation again, renewing its expiry
+func (s *InvitationsService) Resend(ctx context.Context, invitationID string) (*Invitation, *Response, error) {
+	u := fmt.Sprintf("invitation/%s/resend", invitationID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var invitation Invitation
+	resp, err := s.client.Do(ctx, httpReq, &invitation)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &invitation, resp, nil
+}
+
+// Revoke withdraws an invitation so its link can no longer be used
+func (s *InvitationsService) Revoke(ctx context.Context, invitationID string) (*Response, error) {
+	u := fmt.Sprintf("invitation/%s", invitationID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, httpReq, nil)
+}
+
+// ResendOlderThan resends every pending invitation, of the given type or of
+// all types when typ is empty, last sent more than age ago, and returns the
+// resent invitations. It stops at the first failure, returning the
+// invitations resent so far.
+func (s *InvitationsService) ResendOlderThan(ctx context.Context, typ InvitationType, age time.Duration) ([]Invitation, error) {
+	cutoff := time.Now().Add(-age)
+
+	var stale []Invitation
+	opts := &InvitationListOptions{Type: typ}
+	opts.Limit = 100
+	for {
+		page, _, err := s.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range page.Invitations {
+			last := inv.LastSentAt
+			if last == nil {
+				last = inv.SentAt
+			}
+			if last == nil || last.Before(cutoff) {
+				stale = append(stale, inv)
+			}
+		}
+		opts.Offset += len(page.Invitations)
+		if len(page.Invitations) == 0 || opts.Offset >= page.Total {
+			break
+		}
+	}
+
+	resent := make([]Invitation, 0, len(stale))
+	for _, inv := range stale {
+		updated, _, err := s.Resend(ctx, inv.ID)
+		if err != nil {
+			return resent, fmt.Errorf("resend invitation %s to %s: %w", inv.ID, inv.Email, err)
+		}
+		resent = append(resent, *updated)
+	}
+	return resent, nil
+}
@@ -0,0 +1,68 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInvitationsService_ResendOlderThan(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-10*24*time.Hour), now.Add(-time.Hour)
+	var resent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path != "/mpki/api/v1/invitation" || r.URL.Query().Get("type") != "certificate_owner" {
+				t.Errorf("request = %s?%s, want owner invitations", r.URL.Path, r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(InvitationListResponse{
+				ListResponse: ListResponse{Total: 3},
+				Invitations: []Invitation{
+					{ID: "inv-1", Email: "a@example.com", SentAt: &old},
+					{ID: "inv-2", Email: "b@example.com", SentAt: &old, LastSentAt: &recent},
+					{ID: "inv-3", Email: "c@example.com", LastSentAt: &old},
+				},
+			})
+		case http.MethodPost:
+			resent = append(resent, r.URL.Path)
+			json.NewEncoder(w).Encode(Invitation{ID: "resent", LastSentAt: &now})
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	got, err := client.Invitations.ResendOlderThan(context.Background(), InvitationTypeCertificateOwner, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("ResendOlderThan() error = %v", err)
+	}
+	if len(got) != 2 || len(resent) != 2 ||
+		resent[0] != "/mpki/api/v1/invitation/inv-1/resend" || resent[1] != "/mpki/api/v1/invitation/inv-3/resend" {
+		t.Errorf("resent = %v, want inv-1 and inv-3", resent)
+	}
+}
+
+func TestInvitationsService_Revoke(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/mpki/api/v1/invitation/inv-1" {
+			t.Errorf("request = %s %s, want DELETE of inv-1", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	if _, err := client.Invitations.Revoke(context.Background(), "inv-1"); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	expires := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	inv := Invitation{ExpiresAt: &expires}
+	if inv.Expired(expires.Add(-time.Second)) || !inv.Expired(expires) {
+		t.Error("Expired() does not switch at ExpiresAt")
+	}
+}
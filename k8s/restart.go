@@ -0,0 +1,152 @@
+// Package k8s restarts the Deployments that use a Kubernetes TLS secret when
+// sink.KubernetesSecretSink writes a new certificate to it, using the
+// Kubernetes REST API directly so no client-go dependency is required.
+//
+//	s, err := sink.NewInClusterKubernetesSink()
+//	k8s.RestartOnChange(s)
+//	err = s.Store(ctx, "www-tls", certPEM, keyPEM, chainPEM)
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jonhadfield/go-digicert-tlm/internal/kube"
+	"github.com/jonhadfield/go-digicert-tlm/sink"
+)
+
+// AnnotationRestartedAt is the pod template annotation kubectl rollout
+// restart sets; changing it rolls the Deployment.
+const AnnotationRestartedAt = "kubectl.kubernetes.io/restartedAt"
+
+var now = time.Now
+
+// RestartOnChange makes s restart the Deployments using a secret whenever
+// Store changes its certificate.
+func RestartOnChange(s *sink.KubernetesSecretSink) {
+	s.OnChange = func(ctx context.Context, name string) error {
+		_, err := Restart(ctx, s, name)
+		return err
+	}
+}
+
+type deploymentList struct {
+	Items []deployment `json:"items"`
+}
+
+type deployment struct {
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec struct {
+		Template struct {
+			Spec podSpec `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+type podSpec struct {
+	Volumes []struct {
+		Secret *struct {
+			SecretName string `json:"secretName"`
+		} `json:"secret,omitempty"`
+		Projected *struct {
+			Sources []struct {
+				Secret *struct {
+					Name string `json:"name"`
+				} `json:"secret,omitempty"`
+			} `json:"sources"`
+		} `json:"projected,omitempty"`
+	} `json:"volumes"`
+	Containers     []container `json:"containers"`
+	InitContainers []container `json:"initContainers"`
+}
+
+type container struct {
+	Env []struct {
+		ValueFrom *struct {
+			SecretKeyRef *struct {
+				Name string `json:"name"`
+			} `json:"secretKeyRef,omitempty"`
+		} `json:"valueFrom,omitempty"`
+	} `json:"env"`
+	EnvFrom []struct {
+		SecretRef *struct {
+			Name string `json:"name"`
+		} `json:"secretRef,omitempty"`
+	} `json:"envFrom"`
+}
+
+// Restart rolls every Deployment in the sink's namespace that references the
+// secret through a volume, projected volume or environment variable, as
+// kubectl rollout restart does. It returns the names of the restarted
+// Deployments.
+func Restart(ctx context.Context, s *sink.KubernetesSecretSink, secretName string) ([]string, error) {
+	client := &kube.Client{APIServer: s.APIServer, Token: s.Token, Namespace: s.Namespace, HTTPClient: s.HTTPClient}
+	deployments := client.URL("/apis/apps/v1", "deployments")
+
+	var list deploymentList
+	status, err := client.Do(ctx, http.MethodGet, deployments, "", nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("k8s: list deployments in %s: HTTP %d", s.Namespace, status)
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{AnnotationRestartedAt: now().UTC().Format(time.RFC3339)},
+				},
+			},
+		},
+	}
+
+	var restarted []string
+	for _, d := range list.Items {
+		if !d.Spec.Template.Spec.references(secretName) {
+			continue
+		}
+		status, err := client.Do(ctx, http.MethodPatch, deployments+"/"+d.Metadata.Name, "application/strategic-merge-patch+json", patch, nil)
+		if err != nil {
+			return restarted, err
+		}
+		if status < 200 || status >= 300 {
+			return restarted, fmt.Errorf("k8s: restart deployment %s/%s: HTTP %d", s.Namespace, d.Metadata.Name, status)
+		}
+		restarted = append(restarted, d.Metadata.Name)
+	}
+	return restarted, nil
+}
+
+func (s *podSpec) references(name string) bool {
+	for _, v := range s.Volumes {
+		if v.Secret != nil && v.Secret.SecretName == name {
+			return true
+		}
+		if v.Projected != nil {
+			for _, src := range v.Projected.Sources {
+				if src.Secret != nil && src.Secret.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	for _, c := range append(append([]container{}, s.Containers...), s.InitContainers...) {
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil && e.ValueFrom.SecretKeyRef.Name == name {
+				return true
+			}
+		}
+		for _, e := range c.EnvFrom {
+			if e.SecretRef != nil && e.SecretRef.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
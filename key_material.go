@@ -0,0 +1,150 @@
+package digicert
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const redacted = "[REDACTED]"
+
+// KeyMaterial holds private key bytes returned by the API, such as the PEM
+// key of a server-generated certificate. It prints and marshals as
+// "[REDACTED]" so it does not end up in logs or JSON dumps by accident; use
+// Bytes or PrivateKey to read it and Zero to wipe it once stored.
+type KeyMaterial struct {
+	b []byte
+}
+
+// NewKeyMaterial wraps b, which the KeyMaterial then owns and wipes on Zero.
+func NewKeyMaterial(b []byte) *KeyMaterial {
+	return &KeyMaterial{b: b}
+}
+
+// Bytes returns the key material. The slice is shared, so it is wiped by Zero.
+func (k *KeyMaterial) Bytes() []byte {
+	if k == nil {
+		return nil
+	}
+	return k.b
+}
+
+// IsEmpty reports whether k holds no key material, including after Zero.
+func (k *KeyMaterial) IsEmpty() bool {
+	return k == nil || len(k.b) == 0
+}
+
+// Zero overwrites the key material and releases it. The raw body of the
+// response it was decoded from, Response.Body, holds its own copy; clear
+// that too when it is kept.
+func (k *KeyMaterial) Zero() {
+	if k == nil {
+		return
+	}
+	clear(k.b)
+	k.b = nil
+}
+
+// PrivateKey parses the PEM key material as a PKCS#8, PKCS#1 RSA or SEC 1 EC
+// private key. Encrypted keys are not supported.
+func (k *KeyMaterial) PrivateKey() (crypto.PrivateKey, error) {
+	if k.IsEmpty() {
+		return nil, errors.New("digicert: no private key material")
+	}
+	block, _ := pem.Decode(k.b)
+	if block == nil {
+		return nil, errors.New("digicert: private key is not PEM encoded")
+	}
+	defer clear(block.Bytes)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("digicert: unsupported private key type %q", block.Type)
+}
+
+func (k *KeyMaterial) String() string {
+	if k.IsEmpty() {
+		return ""
+	}
+	return redacted
+}
+
+func (k *KeyMaterial) GoString() string { return k.String() }
+
+// MarshalJSON writes "[REDACTED]" rather than the key material.
+func (k *KeyMaterial) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON decodes a JSON string into fresh bytes, without the
+// intermediate Go string encoding/json would leave behind, which could not
+// be wiped.
+func (k *KeyMaterial) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	b, err := unquoteJSONBytes(data)
+	if err != nil {
+		return fmt.Errorf("digicert: private key: %w", err)
+	}
+	k.Zero()
+	k.b = b
+	return nil
+}
+
+// unquoteJSONBytes decodes a JSON string literal into a new byte slice.
+// Escapes outside ASCII are rejected, as PEM is plain ASCII.
+func unquoteJSONBytes(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return nil, errors.New("expected a JSON string")
+	}
+	data = data[1 : len(data)-1]
+	out := make([]byte, 0, len(data))
+	fail := func(err error) ([]byte, error) {
+		clear(out)
+		return nil, err
+	}
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '\\' {
+			out = append(out, c)
+			continue
+		}
+		if i++; i >= len(data) {
+			return fail(errors.New("unterminated escape"))
+		}
+		switch data[i] {
+		case '"', '\\', '/':
+			out = append(out, data[i])
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case 'u':
+			if i+4 >= len(data) {
+				return fail(errors.New("short unicode escape"))
+			}
+			r, err := strconv.ParseUint(string(data[i+1:i+5]), 16, 16)
+			if err != nil || r >= 0x80 {
+				return fail(fmt.Errorf("unsupported escape \\u%s", data[i+1:i+5]))
+			}
+			out = append(out, byte(r))
+			i += 4
+		default:
+			return fail(fmt.Errorf("invalid escape \\%c", data[i]))
+		}
+	}
+	return out, nil
+}
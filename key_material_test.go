@@ -0,0 +1,91 @@
+package digicert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestKeyMaterial(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"certificate": map[string]string{"serial_number": "0A"},
+			"private_key": keyPEM,
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, _, err := client.Certificates.Issue(context.Background(), &CertificateRequest{Profile: ProfileReference{ID: "profile-1"}})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	if string(result.PrivateKey.Bytes()) != keyPEM {
+		t.Errorf("Bytes() = %q, want the PEM key", result.PrivateKey.Bytes())
+	}
+	parsed, err := result.PrivateKey.PrivateKey()
+	if err != nil {
+		t.Fatalf("PrivateKey() error = %v", err)
+	}
+	if !key.Equal(parsed) {
+		t.Error("PrivateKey() returned a different key")
+	}
+
+	for _, out := range []string{
+		fmt.Sprintf("%v", result.PrivateKey),
+		fmt.Sprintf("%+v", *result),
+		fmt.Sprintf("%#v", result.PrivateKey),
+	} {
+		if strings.Contains(out, "PRIVATE KEY") || strings.Contains(out, "MII") {
+			t.Errorf("formatted output leaks key material: %s", out)
+		}
+	}
+	data, _ := json.Marshal(result)
+	if !strings.Contains(string(data), `"private_key":"[REDACTED]"`) {
+		t.Errorf("json.Marshal() = %s, want redacted private_key", data)
+	}
+
+	b := result.PrivateKey.Bytes()
+	result.PrivateKey.Zero()
+	for _, c := range b {
+		if c != 0 {
+			t.Fatal("Zero() did not wipe the key bytes")
+		}
+	}
+	if !result.PrivateKey.IsEmpty() {
+		t.Error("IsEmpty() = false after Zero()")
+	}
+	if _, err := result.PrivateKey.PrivateKey(); err == nil {
+		t.Error("Expected error parsing a wiped key")
+	}
+}
+
+func TestUnquoteJSONBytes(t *testing.T) {
+	for _, s := range []string{"plain", "line\nbreak", "tab\tquote\"slash/back\\", "A"} {
+		data, _ := json.Marshal(s)
+		got, err := unquoteJSONBytes(data)
+		if err != nil || string(got) != s {
+			t.Errorf("unquoteJSONBytes(%s) = %q, %v, want %q", data, got, err, s)
+		}
+	}
+	for _, data := range []string{`plain`, `"bad\x"`, `"\u00e9"`, `"\u12"`} {
+		if _, err := unquoteJSONBytes([]byte(data)); err == nil {
+			t.Errorf("unquoteJSONBytes(%s) expected error", data)
+		}
+	}
+}
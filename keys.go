@@ -0,0 +1,238 @@
+package digicert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// KeyType is a private key algorithm.
+type KeyType string
+
+const (
+	KeyTypeRSA     KeyType = "RSA"
+	KeyTypeECDSA   KeyType = "ECDSA"
+	KeyTypeEd25519 KeyType = "Ed25519"
+)
+
+// Curve names accepted in KeyRequest and KeyPolicy.
+const (
+	CurveP256 = "P-256"
+	CurveP384 = "P-384"
+	CurveP521 = "P-521"
+)
+
+// KeyRequest describes a key to generate.
+type KeyRequest struct {
+	Type KeyType
+	// Bits is the RSA modulus size. Defaults to 3072.
+	Bits int
+	// Curve is the ECDSA curve. Defaults to P-256.
+	Curve string
+}
+
+// KeyPolicy restricts the keys GenerateKey will create and CheckKey will
+// accept. The zero value allows RSA of at least 2048 bits, the NIST curves
+// and Ed25519.
+type KeyPolicy struct {
+	// AllowedTypes limits the key algorithms. All are allowed when empty.
+	AllowedTypes []KeyType
+	// MinRSABits is the smallest RSA modulus allowed. Defaults to 2048.
+	MinRSABits int
+	// MaxRSABits, if set, is the largest RSA modulus allowed.
+	MaxRSABits int
+	// AllowedCurves limits the ECDSA curves. All NIST curves are allowed when
+	// empty.
+	AllowedCurves []string
+	// FIPS restricts keys to those approved for FIPS 186 signatures: RSA of
+	// 2048 bits or more and the NIST P-256, P-384 and P-521 curves. Ed25519
+	// is excluded as most validated modules do not yet support it.
+	FIPS bool
+}
+
+// KeyPolicyError reports a key that violates a KeyPolicy.
+type KeyPolicyError struct {
+	Type   KeyType
+	Reason string
+}
+
+func (e *KeyPolicyError) Error() string {
+	return fmt.Sprintf("%s key violates key policy: %s", e.Type, e.Reason)
+}
+
+// CheckRequest reports whether a key described by req would satisfy the
+// policy. A nil policy allows any supported key.
+func (p *KeyPolicy) CheckRequest(req KeyRequest) error {
+	req = req.withDefaults()
+	if p == nil {
+		p = &KeyPolicy{}
+	}
+
+	if len(p.AllowedTypes) > 0 && !containsKeyType(p.AllowedTypes, req.Type) {
+		return &KeyPolicyError{Type: req.Type, Reason: "algorithm not allowed"}
+	}
+
+	switch req.Type {
+	case KeyTypeRSA:
+		minBits := p.MinRSABits
+		if minBits < 2048 {
+			minBits = 2048
+		}
+		if req.Bits < minBits {
+			return &KeyPolicyError{Type: req.Type, Reason: fmt.Sprintf("%d bits is below the minimum of %d", req.Bits, minBits)}
+		}
+		if p.MaxRSABits > 0 && req.Bits > p.MaxRSABits {
+			return &KeyPolicyError{Type: req.Type, Reason: fmt.Sprintf("%d bits is above the maximum of %d", req.Bits, p.MaxRSABits)}
+		}
+	case KeyTypeECDSA:
+		if curveFor(req.Curve) == nil {
+			return &KeyPolicyError{Type: req.Type, Reason: fmt.Sprintf("unsupported curve %q", req.Curve)}
+		}
+		if len(p.AllowedCurves) > 0 && !containsString(p.AllowedCurves, req.Curve) {
+			return &KeyPolicyError{Type: req.Type, Reason: fmt.Sprintf("curve %s not allowed", req.Curve)}
+		}
+	case KeyTypeEd25519:
+		if p.FIPS {
+			return &KeyPolicyError{Type: req.Type, Reason: "not allowed in FIPS mode"}
+		}
+	default:
+		return &KeyPolicyError{Type: req.Type, Reason: "unsupported algorithm"}
+	}
+	return nil
+}
+
+// CheckKey reports whether an existing public or private key satisfies the
+// policy, e.g. for a key supplied by the caller or held in an HSM.
+func (p *KeyPolicy) CheckKey(key crypto.PublicKey) error {
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+	req, err := keyRequestFor(key)
+	if err != nil {
+		return err
+	}
+	return p.CheckRequest(req)
+}
+
+// GenerateKey creates a private key after checking req against policy, which
+// may be nil.
+func GenerateKey(req KeyRequest, policy *KeyPolicy) (crypto.Signer, error) {
+	req = req.withDefaults()
+	if err := policy.CheckRequest(req); err != nil {
+		return nil, err
+	}
+
+	switch req.Type {
+	case KeyTypeRSA:
+		return rsa.GenerateKey(rand.Reader, req.Bits)
+	case KeyTypeECDSA:
+		return ecdsa.GenerateKey(curveFor(req.Curve), rand.Reader)
+	default:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	}
+}
+
+// CreateCSR returns a PEM encoded certificate signing request for key with
+// the subject and SANs from attrs.
+func CreateCSR(key crypto.Signer, attrs *CertificateAttributes) (string, error) {
+	tmpl := &x509.CertificateRequest{}
+	if attrs != nil {
+		tmpl.Subject = pkix.Name{
+			CommonName:         attrs.CommonName,
+			OrganizationalUnit: attrs.OrganizationalUnit,
+		}
+		if attrs.Organization != "" {
+			tmpl.Subject.Organization = []string{attrs.Organization}
+		}
+		if attrs.Country != "" {
+			tmpl.Subject.Country = []string{attrs.Country}
+		}
+		if attrs.State != "" {
+			tmpl.Subject.Province = []string{attrs.State}
+		}
+		if attrs.Locality != "" {
+			tmpl.Subject.Locality = []string{attrs.Locality}
+		}
+		if sans := attrs.SANs; sans != nil {
+			tmpl.DNSNames = sans.DNSNames
+			tmpl.EmailAddresses = sans.Emails
+			for _, s := range sans.IPAddresses {
+				ip := net.ParseIP(s)
+				if ip == nil {
+					return "", fmt.Errorf("invalid IP address SAN %q", s)
+				}
+				tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+			}
+			for _, s := range sans.URIs {
+				u, err := url.Parse(s)
+				if err != nil {
+					return "", fmt.Errorf("invalid URI SAN %q: %w", s, err)
+				}
+				tmpl.URIs = append(tmpl.URIs, u)
+			}
+		}
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, tmpl, key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+func (r KeyRequest) withDefaults() KeyRequest {
+	switch r.Type {
+	case KeyTypeRSA:
+		if r.Bits == 0 {
+			r.Bits = 3072
+		}
+	case KeyTypeECDSA:
+		if r.Curve == "" {
+			r.Curve = CurveP256
+		}
+	}
+	return r
+}
+
+func keyRequestFor(key crypto.PublicKey) (KeyRequest, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return KeyRequest{Type: KeyTypeRSA, Bits: k.N.BitLen()}, nil
+	case *ecdsa.PublicKey:
+		return KeyRequest{Type: KeyTypeECDSA, Curve: k.Curve.Params().Name}, nil
+	case ed25519.PublicKey:
+		return KeyRequest{Type: KeyTypeEd25519}, nil
+	}
+	return KeyRequest{}, fmt.Errorf("unsupported key type %T", key)
+}
+
+func curveFor(name string) elliptic.Curve {
+	switch name {
+	case CurveP256:
+		return elliptic.P256()
+	case CurveP384:
+		return elliptic.P384()
+	case CurveP521:
+		return elliptic.P521()
+	}
+	return nil
+}
+
+func containsKeyType(list []KeyType, t KeyType) bool {
+	for _, v := range list {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
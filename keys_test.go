@@ -0,0 +1,107 @@
+package digicert
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func TestKeyPolicy_CheckRequest(t *testing.T) {
+	corporate := &KeyPolicy{MinRSABits: 3072, AllowedCurves: []string{CurveP256, CurveP384}}
+	fips := &KeyPolicy{FIPS: true}
+
+	tests := []struct {
+		name    string
+		policy  *KeyPolicy
+		req     KeyRequest
+		wantErr bool
+	}{
+		{"nil policy rsa", nil, KeyRequest{Type: KeyTypeRSA}, false},
+		{"nil policy weak rsa", nil, KeyRequest{Type: KeyTypeRSA, Bits: 1024}, true},
+		{"corporate rsa 3072", corporate, KeyRequest{Type: KeyTypeRSA, Bits: 3072}, false},
+		{"corporate rsa 2048", corporate, KeyRequest{Type: KeyTypeRSA, Bits: 2048}, true},
+		{"corporate p384", corporate, KeyRequest{Type: KeyTypeECDSA, Curve: CurveP384}, false},
+		{"corporate p521", corporate, KeyRequest{Type: KeyTypeECDSA, Curve: CurveP521}, true},
+		{"corporate default curve", corporate, KeyRequest{Type: KeyTypeECDSA}, false},
+		{"max rsa", &KeyPolicy{MaxRSABits: 3072}, KeyRequest{Type: KeyTypeRSA, Bits: 4096}, true},
+		{"allowed types", &KeyPolicy{AllowedTypes: []KeyType{KeyTypeECDSA}}, KeyRequest{Type: KeyTypeRSA}, true},
+		{"unknown curve", nil, KeyRequest{Type: KeyTypeECDSA, Curve: "secp256k1"}, true},
+		{"fips ed25519", fips, KeyRequest{Type: KeyTypeEd25519}, true},
+		{"fips p256", fips, KeyRequest{Type: KeyTypeECDSA}, false},
+		{"unknown type", nil, KeyRequest{Type: "DSA"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.CheckRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var policyErr *KeyPolicyError
+			if err != nil && !errors.As(err, &policyErr) {
+				t.Errorf("CheckRequest() error = %T, want *KeyPolicyError", err)
+			}
+		})
+	}
+}
+
+func TestKeyPolicy_CheckKey(t *testing.T) {
+	policy := &KeyPolicy{AllowedCurves: []string{CurveP384}, FIPS: true}
+
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err := policy.CheckKey(p384); err != nil {
+		t.Errorf("CheckKey(P-384) error = %v", err)
+	}
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err := policy.CheckKey(&p256.PublicKey); err == nil {
+		t.Error("CheckKey(P-256) should fail")
+	}
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	if err := policy.CheckKey(pub); err == nil {
+		t.Error("CheckKey(Ed25519) should fail in FIPS mode")
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	key, err := GenerateKey(KeyRequest{Type: KeyTypeRSA, Bits: 2048}, &KeyPolicy{})
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if rsaKey, ok := key.(*rsa.PrivateKey); !ok || rsaKey.N.BitLen() != 2048 {
+		t.Errorf("GenerateKey() = %T, want 2048 bit RSA key", key)
+	}
+
+	if _, err := GenerateKey(KeyRequest{Type: KeyTypeECDSA, Curve: CurveP521}, &KeyPolicy{AllowedCurves: []string{CurveP256}}); err == nil {
+		t.Error("GenerateKey() should refuse a curve the policy does not allow")
+	}
+
+	key, err = GenerateKey(KeyRequest{Type: KeyTypeECDSA}, nil)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	csrPEM, err := CreateCSR(key, &CertificateAttributes{
+		CommonName:   "www.example.com",
+		Organization: "Example Inc",
+		SANs:         &SubjectAltNames{DNSNames: []string{"www.example.com"}, IPAddresses: []string{"10.0.0.1"}, URIs: []string{"spiffe://example.com/web"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateCSR() error = %v", err)
+	}
+	block, _ := pem.Decode([]byte(csrPEM))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificateRequest() error = %v", err)
+	}
+	if csr.Subject.CommonName != "www.example.com" || len(csr.DNSNames) != 1 || len(csr.IPAddresses) != 1 || len(csr.URIs) != 1 {
+		t.Errorf("CSR = %+v, want subject and SANs from attributes", csr)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		t.Errorf("CheckSignature() error = %v", err)
+	}
+}
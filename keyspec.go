@@ -0,0 +1,124 @@
+package digicert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeySpec is a certificate key description as TLM reports it, such as
+// "RSA_2048", "RSA 2048" or "EC P-256". Some endpoints send the key size as a
+// bare number instead, which decodes to its decimal form, such as "2048".
+type KeySpec string
+
+func (k *KeySpec) UnmarshalJSON(data []byte) error {
+	*k = ""
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+	switch data[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*k = KeySpec(s)
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		var num json.Number
+		if err := json.Unmarshal(data, &num); err != nil {
+			return err
+		}
+		*k = KeySpec(num.String())
+	}
+	return nil
+}
+
+func (k KeySpec) String() string { return string(k) }
+
+// Algorithm returns the key algorithm named by the spec. A bare size larger
+// than any elliptic curve is taken to be RSA; other bare sizes, and specs
+// naming no known algorithm, return "".
+func (k KeySpec) Algorithm() KeyType {
+	s := strings.ToUpper(string(k))
+	switch {
+	case strings.Contains(s, "ED25519"):
+		return KeyTypeEd25519
+	case strings.Contains(s, "RSA"):
+		return KeyTypeRSA
+	case strings.Contains(s, "EC") || strings.Contains(s, "P-") || strings.Contains(s, "PRIME"):
+		return KeyTypeECDSA
+	}
+	if bits, err := strconv.Atoi(strings.TrimSpace(s)); err == nil && bits > 521 {
+		return KeyTypeRSA
+	}
+	return ""
+}
+
+// Bits returns the key size: the modulus size for RSA and the curve size
+// for ECDSA, such as 256 for "EC P-256". It returns 0 if the spec holds no
+// size.
+func (k KeySpec) Bits() int {
+	if k.Algorithm() == KeyTypeEd25519 {
+		return 256
+	}
+	bits, err := strconv.Atoi(digitRun.FindString(string(k)))
+	if err != nil {
+		return 0
+	}
+	return bits
+}
+
+// AtLeast reports whether the spec is an alg key of at least bits, e.g.
+// AtLeast(KeyTypeRSA, 2048).
+func (k KeySpec) AtLeast(alg KeyType, bits int) bool {
+	return k.Algorithm() == alg && k.Bits() >= bits
+}
+
+// keySpecFor describes req in the form TLM uses for key_size.
+func keySpecFor(req KeyRequest) KeySpec {
+	switch req.Type {
+	case KeyTypeRSA:
+		return KeySpec(fmt.Sprintf("RSA_%d", req.Bits))
+	case KeyTypeECDSA:
+		return KeySpec("EC " + req.Curve)
+	}
+	return KeySpec(req.Type)
+}
+
+// curveForBits returns the NIST curve of the given size.
+func curveForBits(bits int) string {
+	switch bits {
+	case 256:
+		return CurveP256
+	case 384:
+		return CurveP384
+	case 521:
+		return CurveP521
+	}
+	return ""
+}
+
+// CheckKeySpec reports whether a key as TLM describes it, such as a
+// certificate's KeySize, satisfies the policy. Specs whose algorithm or size
+// cannot be determined are rejected.
+func (p *KeyPolicy) CheckKeySpec(spec KeySpec) error {
+	req := KeyRequest{Type: spec.Algorithm()}
+	switch req.Type {
+	case KeyTypeRSA:
+		req.Bits = spec.Bits()
+		if req.Bits == 0 {
+			return &KeyPolicyError{Type: req.Type, Reason: fmt.Sprintf("no key size in %q", spec)}
+		}
+	case KeyTypeECDSA:
+		req.Curve = curveForBits(spec.Bits())
+		if req.Curve == "" {
+			return &KeyPolicyError{Type: req.Type, Reason: fmt.Sprintf("unsupported curve in %q", spec)}
+		}
+	case "":
+		return &KeyPolicyError{Type: KeyType(spec), Reason: "unknown key algorithm"}
+	}
+	return p.CheckRequest(req)
+}
@@ -0,0 +1,95 @@
+package digicert
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestKeySpec(t *testing.T) {
+	tests := []struct {
+		spec KeySpec
+		alg  KeyType
+		bits int
+	}{
+		{"RSA_2048", KeyTypeRSA, 2048},
+		{"RSA 4096", KeyTypeRSA, 4096},
+		{"rsa-3072", KeyTypeRSA, 3072},
+		{"EC P-256", KeyTypeECDSA, 256},
+		{"ECDSA_P384", KeyTypeECDSA, 384},
+		{"secp521r1", KeyTypeECDSA, 521},
+		{"prime256v1", KeyTypeECDSA, 256},
+		{"Ed25519", KeyTypeEd25519, 256},
+		{"2048", KeyTypeRSA, 2048},
+		{"256", "", 256},
+		{"", "", 0},
+		{"unknown", "", 0},
+	}
+	for _, tt := range tests {
+		if got := tt.spec.Algorithm(); got != tt.alg {
+			t.Errorf("KeySpec(%q).Algorithm() = %q, want %q", tt.spec, got, tt.alg)
+		}
+		if got := tt.spec.Bits(); got != tt.bits {
+			t.Errorf("KeySpec(%q).Bits() = %v, want %v", tt.spec, got, tt.bits)
+		}
+	}
+
+	if !KeySpec("RSA_3072").AtLeast(KeyTypeRSA, 2048) {
+		t.Error("AtLeast(RSA, 2048) = false for RSA_3072, want true")
+	}
+	if KeySpec("RSA_1024").AtLeast(KeyTypeRSA, 2048) {
+		t.Error("AtLeast(RSA, 2048) = true for RSA_1024, want false")
+	}
+	if KeySpec("EC P-384").AtLeast(KeyTypeRSA, 256) {
+		t.Error("AtLeast(RSA, 256) = true for EC P-384, want false")
+	}
+}
+
+func TestKeyPolicy_CheckKeySpec(t *testing.T) {
+	policy := &KeyPolicy{MinRSABits: 3072, AllowedCurves: []string{CurveP384}}
+	tests := []struct {
+		spec    KeySpec
+		wantErr bool
+	}{
+		{"RSA_4096", false},
+		{"RSA_2048", true},
+		{"EC P-384", false},
+		{"EC P-256", true},
+		{"EC 224", true},
+		{"RSA", true},
+		{"unknown", true},
+	}
+	for _, tt := range tests {
+		err := policy.CheckKeySpec(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckKeySpec(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+		}
+		var policyErr *KeyPolicyError
+		if err != nil && !errors.As(err, &policyErr) {
+			t.Errorf("CheckKeySpec(%q) error = %T, want *KeyPolicyError", tt.spec, err)
+		}
+	}
+}
+
+func TestKeySpec_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want KeySpec
+	}{
+		{`"RSA_2048"`, "RSA_2048"},
+		{`"EC P-256"`, "EC P-256"},
+		{`4096`, "4096"},
+		{`null`, ""},
+		{`false`, ""},
+	}
+	for _, tt := range tests {
+		var got KeySpec
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
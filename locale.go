@@ -0,0 +1,30 @@
+package digicert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WithLocale sends an Accept-Language header, such as "de" or "fr-CA, fr;q=0.8",
+// so the API returns error messages in the operator's language. Branch on
+// APIError.Code, or ErrorCode, rather than the message, which varies with
+// the locale. ContextWithHeader can override the locale for a single call.
+func WithLocale(lang string) ClientOption {
+	return func(c *Client) error {
+		if strings.TrimSpace(lang) == "" {
+			return fmt.Errorf("locale cannot be empty")
+		}
+		return WithDefaultHeader("Accept-Language", lang)(c)
+	}
+}
+
+// ErrorCode returns the machine-readable code of an *APIError in err's
+// chain, which is the same whatever the locale, or "" if there is none.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_WithLocale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Language") != "de" {
+			t.Errorf("Accept-Language = %q, want de", r.Header.Get("Accept-Language"))
+		}
+		w.Header().Set("Content-Language", "de")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": "invalid_csr", "message": "Ungültige CSR"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test-key", WithBaseURL(server.URL+"/"), WithLocale("de"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, _, err = client.Certificates.Get(context.Background(), "0A")
+	if got := ErrorCode(fmt.Errorf("lookup: %w", err)); got != "invalid_csr" {
+		t.Errorf("ErrorCode() = %q, want invalid_csr", got)
+	}
+	apiErr := err.(*APIError)
+	if apiErr.Language != "de" || apiErr.Message != "Ungültige CSR" {
+		t.Errorf("APIError = %q in %q, want the German message", apiErr.Message, apiErr.Language)
+	}
+
+	if _, err := NewClient("test-key", WithLocale(" ")); err == nil {
+		t.Error("WithLocale(\" \") error = nil, want error")
+	}
+	if ErrorCode(&HTTPError{StatusCode: 502}) != "" {
+		t.Error("ErrorCode(HTTPError) should be empty")
+	}
+}
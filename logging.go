@@ -0,0 +1,88 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithLogger logs every API call to logger: method, URL, status and duration
+// at Info, transport failures at Warn and, when Debug is enabled, headers
+// and bodies. Values covered by policy are redacted first; a nil policy
+// uses DefaultRedactionPolicy. The policy also applies to the body excerpt
+// kept on APIError and HTTPError.
+func WithLogger(logger *slog.Logger, policy *RedactionPolicy) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			return fmt.Errorf("logger cannot be nil")
+		}
+		c.logger = logger
+		c.redaction = policy
+		return nil
+	}
+}
+
+// WithRedactionPolicy sets the redaction policy without enabling logging,
+// for the body excerpts kept on errors.
+func WithRedactionPolicy(policy *RedactionPolicy) ClientOption {
+	return func(c *Client) error {
+		c.redaction = policy
+		return nil
+	}
+}
+
+func (c *Client) redactionPolicy() *RedactionPolicy {
+	if c.redaction == nil {
+		return DefaultRedactionPolicy()
+	}
+	return c.redaction
+}
+
+// logRequest records one API call. resp is nil when the request failed
+// before a response arrived.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, resp *http.Response, body []byte, elapsed time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	policy := c.redactionPolicy()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", sanitizeURL(req.URL)),
+		slog.Duration("duration", elapsed),
+	}
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if id := resp.Header.Get("X-Request-Id"); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request_headers", policy.RedactHeader(req.Header)))
+		if req.GetBody != nil {
+			if rc, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(rc)
+				rc.Close()
+				attrs = append(attrs, slog.String("request_body", string(policy.RedactJSON(data))))
+			}
+		}
+		if resp != nil {
+			attrs = append(attrs,
+				slog.Any("response_headers", policy.RedactHeader(resp.Header)),
+				slog.String("response_body", string(policy.RedactJSON(body))),
+			)
+		}
+	}
+
+	level := slog.LevelInfo
+	if resp == nil {
+		level = slog.LevelWarn
+	}
+	c.logger.LogAttrs(ctx, level, "digicert API request", attrs...)
+}
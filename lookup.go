@@ -0,0 +1,117 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// IdentifierKind is the kind of certificate identifier Lookup detected.
+type IdentifierKind string
+
+const (
+	IdentifierSerialNumber IdentifierKind = "serial_number"
+	IdentifierThumbprint   IdentifierKind = "thumbprint"
+	IdentifierID           IdentifierKind = "id"
+	IdentifierUnknown      IdentifierKind = ""
+)
+
+var (
+	certificateIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexDigitsPattern     = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+)
+
+// identifierPrefixes force the kind of an identifier, e.g. "serial:0A1B".
+var identifierPrefixes = map[string]IdentifierKind{
+	"serial:":     IdentifierSerialNumber,
+	"thumbprint:": IdentifierThumbprint,
+	"sha1:":       IdentifierThumbprint,
+	"sha256:":     IdentifierThumbprint,
+	"id:":         IdentifierID,
+}
+
+// DetectIdentifier classifies a certificate identifier and returns it in the
+// form the API expects. UUIDs are certificate IDs, 40 or 64 hex digits are
+// SHA-1 or SHA-256 thumbprints and other hex strings are serial numbers.
+// Colons and spaces between hex bytes are removed. A "serial:",
+// "thumbprint:", "sha1:", "sha256:" or "id:" prefix overrides detection.
+func DetectIdentifier(identifier string) (IdentifierKind, string) {
+	identifier = strings.TrimSpace(identifier)
+	for prefix, kind := range identifierPrefixes {
+		if len(identifier) > len(prefix) && strings.EqualFold(identifier[:len(prefix)], prefix) {
+			value := identifier[len(prefix):]
+			if kind != IdentifierID {
+				value = compactHex(value)
+			}
+			return kind, value
+		}
+	}
+
+	if certificateIDPattern.MatchString(identifier) {
+		return IdentifierID, strings.ToLower(identifier)
+	}
+	compact := compactHex(identifier)
+	if !hexDigitsPattern.MatchString(compact) {
+		return IdentifierUnknown, identifier
+	}
+	if len(compact) == 64 || len(compact) == 40 {
+		return IdentifierThumbprint, strings.ToLower(compact)
+	}
+	return IdentifierSerialNumber, strings.ToUpper(compact)
+}
+
+func compactHex(s string) string {
+	return strings.NewReplacer(":", "", " ", "").Replace(s)
+}
+
+// Lookup retrieves a certificate by serial number, thumbprint or certificate
+// ID, detecting which with DetectIdentifier and calling Get, a thumbprint
+// search or GetCertificate accordingly. A plain 40 digit identifier that
+// matches no thumbprint is retried as a serial number.
+func (s *CertificatesService) Lookup(ctx context.Context, identifier string) (*Certificate, *Response, error) {
+	kind, value := DetectIdentifier(identifier)
+	switch kind {
+	case IdentifierID:
+		return s.GetCertificate(ctx, value)
+	case IdentifierSerialNumber:
+		return s.Get(ctx, value)
+	case IdentifierThumbprint:
+		cert, resp, err := s.getByThumbprint(ctx, value)
+		// 40 plain hex digits may also be a 20 byte serial number.
+		ambiguous := len(value) == 40 && strings.EqualFold(value, strings.TrimSpace(identifier))
+		if ambiguous && IsNotFound(err) {
+			return s.Get(ctx, strings.ToUpper(value))
+		}
+		return cert, resp, err
+	}
+	return nil, nil, fmt.Errorf("digicert: %q is not a serial number, thumbprint or certificate ID", identifier)
+}
+
+// getByThumbprint searches for the single certificate with thumbprint
+func (s *CertificatesService) getByThumbprint(ctx context.Context, thumbprint string) (*Certificate, *Response, error) {
+	result, resp, err := s.Search(ctx, &CertificateSearchOptions{Thumbprint: thumbprint})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var matches []Certificate
+	for _, c := range result.Items {
+		// Servers that ignore the filter return unrelated certificates.
+		if c.Thumbprint == "" || ThumbprintsEqual(c.Thumbprint, thumbprint) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, resp, &APIError{
+			StatusCode: http.StatusNotFound,
+			Code:       "not_found",
+			Message:    fmt.Sprintf("no certificate with thumbprint %s", thumbprint),
+		}
+	case 1:
+		return &matches[0], resp, nil
+	}
+	return nil, resp, fmt.Errorf("digicert: %d certificates match thumbprint %s", len(matches), thumbprint)
+}
@@ -0,0 +1,95 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDetectIdentifier(t *testing.T) {
+	sha256 := strings.Repeat("ab", 32)
+	tests := []struct {
+		in        string
+		wantKind  IdentifierKind
+		wantValue string
+	}{
+		{"0a1b2c", IdentifierSerialNumber, "0A1B2C"},
+		{"0a:1b:2c", IdentifierSerialNumber, "0A1B2C"},
+		{"7C2D4A2B-1E8F-4B6A-9C3D-5E6F7A8B9C0D", IdentifierID, "7c2d4a2b-1e8f-4b6a-9c3d-5e6f7a8b9c0d"},
+		{strings.ToUpper(sha256), IdentifierThumbprint, sha256},
+		{"AB:CD:" + strings.Repeat("00:", 17) + "EF", IdentifierThumbprint, "abcd" + strings.Repeat("00", 17) + "ef"},
+		{"serial:" + sha256, IdentifierSerialNumber, sha256},
+		{"id:cert-1", IdentifierID, "cert-1"},
+		{"www.example.com", IdentifierUnknown, "www.example.com"},
+		{"", IdentifierUnknown, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			kind, value := DetectIdentifier(tt.in)
+			if kind != tt.wantKind || value != tt.wantValue {
+				t.Errorf("DetectIdentifier(%q) = %v, %v, want %v, %v", tt.in, kind, value, tt.wantKind, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestCertificatesService_Lookup(t *testing.T) {
+	sha1 := strings.Repeat("1f", 20)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate/0A1B":
+			w.Write([]byte(`{"id": "by-serial"}`))
+		case "/mpki/api/v1/certificate/" + strings.ToUpper(sha1):
+			w.Write([]byte(`{"id": "by-long-serial"}`))
+		case "/mpki/api/v1/certificate-by-id/7c2d4a2b-1e8f-4b6a-9c3d-5e6f7a8b9c0d":
+			w.Write([]byte(`{"id": "by-id"}`))
+		case "/mpki/api/v1/certificate-search":
+			var items []Certificate
+			switch r.URL.Query().Get("thumbprint") {
+			case strings.Repeat("ab", 32):
+				items = []Certificate{{ID: "by-thumbprint", Thumbprint: strings.Repeat("AB", 32)}}
+			case strings.Repeat("cd", 32):
+				items = []Certificate{{ID: "dup-1"}, {ID: "dup-2"}}
+			}
+			json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: len(items)}, Items: items})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": "not_found", "message": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	for identifier, wantID := range map[string]string{
+		"0a:1b":                                "by-serial",
+		"7c2d4a2b-1e8f-4b6a-9c3d-5e6f7a8b9c0d": "by-id",
+		strings.Repeat("AB", 32):               "by-thumbprint",
+		sha1:                                   "by-long-serial",
+	} {
+		cert, _, err := client.Certificates.Lookup(ctx, identifier)
+		if err != nil {
+			t.Errorf("Lookup(%q) error = %v", identifier, err)
+			continue
+		}
+		if cert.ID != wantID {
+			t.Errorf("Lookup(%q) = %v, want %v", identifier, cert.ID, wantID)
+		}
+	}
+
+	if _, _, err := client.Certificates.Lookup(ctx, "sha1:"+sha1); !IsNotFound(err) {
+		t.Errorf("Lookup(sha1:...) error = %v, want not found", err)
+	}
+	if _, _, err := client.Certificates.Lookup(ctx, strings.Repeat("cd", 32)); err == nil || IsNotFound(err) {
+		t.Errorf("Lookup(ambiguous) error = %v, want multiple match error", err)
+	}
+	if _, _, err := client.Certificates.Lookup(ctx, "www.example.com"); err == nil {
+		t.Error("Expected error for unrecognised identifier")
+	}
+}
@@ -0,0 +1,431 @@
+package digicert
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultOCSPInitialInterval = 2 * time.Second
+	DefaultOCSPMaxInterval     = 30 * time.Second
+
+	// ocspClockSkew is how far the responder's clock may differ from ours
+	// when checking a response's validity period.
+	ocspClockSkew = 5 * time.Minute
+)
+
+var (
+	// ErrOCSPUnknown is returned when the OCSP responder does not know the
+	// certificate, which is usual for a few minutes after issuance.
+	ErrOCSPUnknown = errors.New("OCSP responder does not know the certificate")
+	// ErrOCSPRevoked is returned when the OCSP responder reports the
+	// certificate as revoked.
+	ErrOCSPRevoked = errors.New("OCSP responder reports the certificate as revoked")
+)
+
+// OCSPStatus is the certificate status reported by an OCSP responder.
+type OCSPStatus int
+
+const (
+	OCSPGood OCSPStatus = iota
+	OCSPRevoked
+	OCSPUnknown
+)
+
+func (s OCSPStatus) String() string {
+	switch s {
+	case OCSPGood:
+		return "good"
+	case OCSPRevoked:
+		return "revoked"
+	}
+	return "unknown"
+}
+
+// OCSPResult is a verified OCSP response for a single certificate.
+type OCSPResult struct {
+	Status     OCSPStatus
+	ProducedAt time.Time
+	ThisUpdate time.Time
+	NextUpdate time.Time
+	RevokedAt  time.Time
+	// Raw is the DER encoded response, suitable for stapling.
+	Raw []byte
+}
+
+type OCSPOptions struct {
+	// HTTPClient is used to query the responder. Defaults to a client with a
+	// 10 second timeout.
+	HTTPClient *http.Client
+	// ResponderURL overrides the responder named in the certificate.
+	ResponderURL string
+	// Issuer is the issuing CA certificate. Defaults to the first certificate
+	// in the response chain.
+	Issuer *x509.Certificate
+
+	// InitialInterval is the delay before the first retry in WaitForOCSP,
+	// doubled after each attempt up to MaxInterval.
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	// Timeout bounds WaitForOCSP. Zero waits until ctx is done.
+	Timeout time.Duration
+}
+
+// CheckOCSP queries the certificate's OCSP responder once and returns the
+// verified result. A revoked or unknown status is returned as a result, not
+// an error.
+func (r *CertificateResponse) CheckOCSP(ctx context.Context, opts *OCSPOptions) (*OCSPResult, error) {
+	if opts == nil {
+		opts = &OCSPOptions{}
+	}
+	leaf, issuer, err := r.ocspCertificates(opts)
+	if err != nil {
+		return nil, err
+	}
+	return queryOCSP(ctx, leaf, issuer, opts)
+}
+
+// WaitForOCSP polls the certificate's OCSP responder with exponential
+// backoff until it reports the certificate as good, so it is safe to deploy
+// with stapling enabled. It fails immediately with ErrOCSPRevoked if the
+// certificate is revoked; other failures are retried until the timeout.
+func (r *CertificateResponse) WaitForOCSP(ctx context.Context, opts *OCSPOptions) (*OCSPResult, error) {
+	if opts == nil {
+		opts = &OCSPOptions{}
+	}
+	leaf, issuer, err := r.ocspCertificates(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := opts.InitialInterval
+	if interval <= 0 {
+		interval = DefaultOCSPInitialInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultOCSPMaxInterval
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for {
+		result, err := queryOCSP(ctx, leaf, issuer, opts)
+		if err == nil {
+			switch result.Status {
+			case OCSPGood:
+				return result, nil
+			case OCSPRevoked:
+				return result, ErrOCSPRevoked
+			}
+			err = ErrOCSPUnknown
+		}
+
+		if !sleepCtx(ctx, interval) {
+			return nil, fmt.Errorf("waiting for OCSP: %w (last error: %v)", ctx.Err(), err)
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func (r *CertificateResponse) ocspCertificates(opts *OCSPOptions) (leaf, issuer *x509.Certificate, err error) {
+	if r == nil || r.Certificate == nil || r.Certificate.Certificate == "" {
+		return nil, nil, errors.New("response has no certificate")
+	}
+	if leaf, err = parseCertificatePEM(r.Certificate.Certificate); err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	issuer = opts.Issuer
+	if issuer == nil {
+		if len(r.Chain) == 0 {
+			return nil, nil, errors.New("response has no chain; set OCSPOptions.Issuer")
+		}
+		if issuer, err = parseCertificatePEM(r.Chain[0]); err != nil {
+			return nil, nil, fmt.Errorf("invalid issuer certificate: %w", err)
+		}
+	}
+	if opts.ResponderURL == "" && len(leaf.OCSPServer) == 0 {
+		return nil, nil, errors.New("certificate has no OCSP responder; set OCSPOptions.ResponderURL")
+	}
+	return leaf, issuer, nil
+}
+
+func queryOCSP(ctx context.Context, leaf, issuer *x509.Certificate, opts *OCSPOptions) (*OCSPResult, error) {
+	reqBody, err := createOCSPRequest(leaf, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	responder := opts.ResponderURL
+	if responder == "" {
+		responder = leaf.OCSPServer[0]
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, responder, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OCSP responder returned %s", resp.Status)
+	}
+	return parseOCSPResponse(data, leaf, issuer)
+}
+
+// ASN.1 structures from RFC 6960.
+
+var (
+	oidSHA1              = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidOCSPBasicResponse = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 1}
+)
+
+type ocspCertID struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	NameHash      []byte
+	IssuerKeyHash []byte
+	SerialNumber  *big.Int
+}
+
+type ocspRequestEntry struct {
+	Cert ocspCertID
+}
+
+type ocspTBSRequest struct {
+	Version     int `asn1:"explicit,tag:0,default:0,optional"`
+	RequestList []ocspRequestEntry
+}
+
+type ocspRequest struct {
+	TBSRequest ocspTBSRequest
+}
+
+type ocspResponseASN1 struct {
+	Status   asn1.Enumerated
+	Response ocspResponseBytes `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseBytes struct {
+	ResponseType asn1.ObjectIdentifier
+	Response     []byte
+}
+
+type ocspBasicResponse struct {
+	TBSResponseData    ocspResponseData
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          asn1.BitString
+	Certificates       []asn1.RawValue `asn1:"explicit,tag:0,optional"`
+}
+
+type ocspResponseData struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,default:0,explicit,tag:0"`
+	RawResponderID     asn1.RawValue
+	ProducedAt         time.Time `asn1:"generalized"`
+	Responses          []ocspSingleResponse
+	ResponseExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspSingleResponse struct {
+	CertID           ocspCertID
+	Good             asn1.Flag        `asn1:"tag:0,optional"`
+	Revoked          ocspRevokedInfo  `asn1:"tag:1,optional"`
+	Unknown          asn1.Flag        `asn1:"tag:2,optional"`
+	ThisUpdate       time.Time        `asn1:"generalized"`
+	NextUpdate       time.Time        `asn1:"generalized,explicit,tag:0,optional"`
+	SingleExtensions []pkix.Extension `asn1:"explicit,tag:1,optional"`
+}
+
+type ocspRevokedInfo struct {
+	RevocationTime time.Time       `asn1:"generalized"`
+	Reason         asn1.Enumerated `asn1:"explicit,tag:0,optional"`
+}
+
+// ocspResponseStatus values other than successful.
+var ocspResponseStatusText = map[asn1.Enumerated]string{
+	1: "malformed request",
+	2: "internal error",
+	3: "try later",
+	5: "signature required",
+	6: "unauthorized",
+}
+
+func ocspCertIDFor(leaf, issuer *x509.Certificate) (ocspCertID, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return ocspCertID{}, fmt.Errorf("invalid issuer public key: %w", err)
+	}
+	nameHash := sha1.Sum(issuer.RawSubject)
+	keyHash := sha1.Sum(spki.PublicKey.RightAlign())
+	return ocspCertID{
+		HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA1, Parameters: asn1.NullRawValue},
+		NameHash:      nameHash[:],
+		IssuerKeyHash: keyHash[:],
+		SerialNumber:  leaf.SerialNumber,
+	}, nil
+}
+
+// equal reports whether id and other identify the same certificate.
+func (id ocspCertID) equal(other ocspCertID) bool {
+	return id.HashAlgorithm.Algorithm.Equal(other.HashAlgorithm.Algorithm) &&
+		bytes.Equal(id.NameHash, other.NameHash) &&
+		bytes.Equal(id.IssuerKeyHash, other.IssuerKeyHash) &&
+		id.SerialNumber != nil && id.SerialNumber.Cmp(other.SerialNumber) == 0
+}
+
+func createOCSPRequest(leaf, issuer *x509.Certificate) ([]byte, error) {
+	id, err := ocspCertIDFor(leaf, issuer)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(ocspRequest{TBSRequest: ocspTBSRequest{RequestList: []ocspRequestEntry{{Cert: id}}}})
+}
+
+func parseOCSPResponse(data []byte, leaf, issuer *x509.Certificate) (*OCSPResult, error) {
+	var resp ocspResponseASN1
+	if _, err := asn1.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid OCSP response: %w", err)
+	}
+	if resp.Status != 0 {
+		if text, ok := ocspResponseStatusText[resp.Status]; ok {
+			return nil, fmt.Errorf("OCSP responder error: %s", text)
+		}
+		return nil, fmt.Errorf("OCSP responder error: status %d", resp.Status)
+	}
+	if !resp.Response.ResponseType.Equal(oidOCSPBasicResponse) {
+		return nil, fmt.Errorf("unsupported OCSP response type %v", resp.Response.ResponseType)
+	}
+
+	var basic ocspBasicResponse
+	if _, err := asn1.Unmarshal(resp.Response.Response, &basic); err != nil {
+		return nil, fmt.Errorf("invalid OCSP basic response: %w", err)
+	}
+	if err := verifyOCSPSignature(&basic, issuer); err != nil {
+		return nil, err
+	}
+
+	want, err := ocspCertIDFor(leaf, issuer)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, single := range basic.TBSResponseData.Responses {
+		if !single.CertID.equal(want) {
+			continue
+		}
+		if single.ThisUpdate.After(now.Add(ocspClockSkew)) {
+			return nil, fmt.Errorf("OCSP response is not yet valid: thisUpdate is %s", single.ThisUpdate.Format(time.RFC3339))
+		}
+		if !single.NextUpdate.IsZero() && single.NextUpdate.Before(now.Add(-ocspClockSkew)) {
+			return nil, fmt.Errorf("OCSP response is stale: nextUpdate was %s", single.NextUpdate.Format(time.RFC3339))
+		}
+		result := &OCSPResult{
+			Status:     OCSPUnknown,
+			ProducedAt: basic.TBSResponseData.ProducedAt,
+			ThisUpdate: single.ThisUpdate,
+			NextUpdate: single.NextUpdate,
+			Raw:        data,
+		}
+		switch {
+		case bool(single.Good):
+			result.Status = OCSPGood
+		case !single.Revoked.RevocationTime.IsZero():
+			result.Status = OCSPRevoked
+			result.RevokedAt = single.Revoked.RevocationTime
+		}
+		return result, nil
+	}
+	return nil, errors.New("OCSP response does not cover the certificate")
+}
+
+// verifyOCSPSignature checks the response was signed by the issuer or by a
+// responder certificate the issuer delegated OCSP signing to.
+func verifyOCSPSignature(basic *ocspBasicResponse, issuer *x509.Certificate) error {
+	algo := ocspSignatureAlgorithm(basic.SignatureAlgorithm.Algorithm)
+	if algo == x509.UnknownSignatureAlgorithm {
+		return fmt.Errorf("unsupported OCSP signature algorithm %v", basic.SignatureAlgorithm.Algorithm)
+	}
+	signed := basic.TBSResponseData.Raw
+	signature := basic.Signature.RightAlign()
+
+	if issuer.CheckSignature(algo, signed, signature) == nil {
+		return nil
+	}
+	for _, raw := range basic.Certificates {
+		responder, err := x509.ParseCertificate(raw.FullBytes)
+		if err != nil {
+			continue
+		}
+		if responder.CheckSignatureFrom(issuer) != nil || !containsExtKeyUsage(responder.ExtKeyUsage, x509.ExtKeyUsageOCSPSigning) {
+			continue
+		}
+		if responder.CheckSignature(algo, signed, signature) == nil {
+			return nil
+		}
+	}
+	return errors.New("OCSP response signature is not from the issuer or an authorized responder")
+}
+
+func containsExtKeyUsage(list []x509.ExtKeyUsage, usage x509.ExtKeyUsage) bool {
+	for _, u := range list {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
+
+func ocspSignatureAlgorithm(oid asn1.ObjectIdentifier) x509.SignatureAlgorithm {
+	algorithms := []struct {
+		oid  asn1.ObjectIdentifier
+		algo x509.SignatureAlgorithm
+	}{
+		{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 5}, x509.SHA1WithRSA},
+		{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}, x509.SHA256WithRSA},
+		{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 12}, x509.SHA384WithRSA},
+		{asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 13}, x509.SHA512WithRSA},
+		{asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 1}, x509.ECDSAWithSHA1},
+		{asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}, x509.ECDSAWithSHA256},
+		{asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}, x509.ECDSAWithSHA384},
+		{asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}, x509.ECDSAWithSHA512},
+		{asn1.ObjectIdentifier{1, 3, 101, 112}, x509.PureEd25519},
+	}
+	for _, a := range algorithms {
+		if oid.Equal(a.oid) {
+			return a.algo
+		}
+	}
+	return x509.UnknownSignatureAlgorithm
+}
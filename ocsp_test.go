@@ -0,0 +1,200 @@
+package digicert
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newOCSPResponder serves signed OCSP responses for the leaf, returning
+// statuses[n] for the nth request and the last status thereafter.
+func newOCSPResponder(t *testing.T, issuer *testCA, statuses ...OCSPStatus) (*httptest.Server, *int32) {
+	t.Helper()
+	return newOCSPResponderFunc(t, issuer, func(n int, single *ocspSingleResponse) {
+		if n >= len(statuses) {
+			n = len(statuses) - 1
+		}
+		switch statuses[n] {
+		case OCSPGood:
+			single.Good = true
+		case OCSPRevoked:
+			single.Revoked = ocspRevokedInfo{RevocationTime: single.ThisUpdate.Add(-time.Minute)}
+		default:
+			single.Unknown = true
+		}
+	})
+}
+
+// newOCSPResponderFunc serves signed OCSP responses for the leaf, letting
+// edit set the status of the nth response and change its other fields.
+func newOCSPResponderFunc(t *testing.T, issuer *testCA, edit func(n int, single *ocspSingleResponse)) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+
+		body, _ := io.ReadAll(r.Body)
+		var req ocspRequest
+		if _, err := asn1.Unmarshal(body, &req); err != nil {
+			t.Errorf("Unmarshal(request) error = %v", err)
+			return
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		single := ocspSingleResponse{CertID: req.TBSRequest.RequestList[0].Cert, ThisUpdate: now, NextUpdate: now.Add(time.Hour)}
+		edit(n, &single)
+
+		keyHash := sha256.Sum256(issuer.cert.RawSubjectPublicKeyInfo)
+		responderID, _ := asn1.Marshal(keyHash[:])
+		tbs, err := asn1.Marshal(ocspResponseData{
+			RawResponderID: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: responderID},
+			ProducedAt:     now,
+			Responses:      []ocspSingleResponse{single},
+		})
+		if err != nil {
+			t.Errorf("Marshal(tbs) error = %v", err)
+			return
+		}
+		digest := sha256.Sum256(tbs)
+		sig, _ := issuer.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		basic, _ := asn1.Marshal(struct {
+			TBS       asn1.RawValue
+			Algorithm pkix.AlgorithmIdentifier
+			Signature asn1.BitString
+		}{
+			TBS:       asn1.RawValue{FullBytes: tbs},
+			Algorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}},
+			Signature: asn1.BitString{Bytes: sig, BitLength: len(sig) * 8},
+		})
+		resp, _ := asn1.Marshal(ocspResponseASN1{Response: ocspResponseBytes{ResponseType: oidOCSPBasicResponse, Response: basic}})
+
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		w.Write(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newOCSPTestCertificate(t *testing.T) (*testCA, func(responder string) *CertificateResponse) {
+	t.Helper()
+	now := time.Now()
+	ca := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test ICA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	issue := func(responder string) *CertificateResponse {
+		leaf := newTestCert(t, &x509.Certificate{
+			Subject:    pkix.Name{CommonName: "www.example.com"},
+			NotBefore:  now.Add(-time.Hour),
+			NotAfter:   now.Add(time.Hour),
+			OCSPServer: []string{responder},
+		}, ca)
+		return &CertificateResponse{Certificate: &Certificate{Certificate: leaf.pem}, Chain: []string{ca.pem}}
+	}
+	return ca, issue
+}
+
+func TestCertificateResponse_CheckOCSP(t *testing.T) {
+	ca, issue := newOCSPTestCertificate(t)
+	server, _ := newOCSPResponder(t, ca, OCSPRevoked)
+
+	result, err := issue(server.URL).CheckOCSP(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("CheckOCSP() error = %v", err)
+	}
+	if result.Status != OCSPRevoked || result.RevokedAt.IsZero() || len(result.Raw) == 0 {
+		t.Errorf("CheckOCSP() = %+v, want revoked", result)
+	}
+}
+
+func TestCertificateResponse_WaitForOCSP(t *testing.T) {
+	ctx := context.Background()
+	ca, issue := newOCSPTestCertificate(t)
+
+	t.Run("becomes good", func(t *testing.T) {
+		server, calls := newOCSPResponder(t, ca, OCSPUnknown, OCSPUnknown, OCSPGood)
+		result, err := issue(server.URL).WaitForOCSP(ctx, &OCSPOptions{InitialInterval: time.Millisecond})
+		if err != nil {
+			t.Fatalf("WaitForOCSP() error = %v", err)
+		}
+		if result.Status != OCSPGood || *calls != 3 {
+			t.Errorf("WaitForOCSP() = %v after %d calls, want good after 3", result.Status, *calls)
+		}
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		server, _ := newOCSPResponder(t, ca, OCSPRevoked)
+		_, err := issue(server.URL).WaitForOCSP(ctx, &OCSPOptions{InitialInterval: time.Millisecond})
+		if !errors.Is(err, ErrOCSPRevoked) {
+			t.Errorf("WaitForOCSP() error = %v, want %v", err, ErrOCSPRevoked)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		server, _ := newOCSPResponder(t, ca, OCSPUnknown)
+		_, err := issue(server.URL).WaitForOCSP(ctx, &OCSPOptions{InitialInterval: time.Millisecond, Timeout: 20 * time.Millisecond})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WaitForOCSP() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("wrong signer", func(t *testing.T) {
+		other := newTestCert(t, &x509.Certificate{
+			Subject:   pkix.Name{CommonName: "Other CA"},
+			NotBefore: time.Now().Add(-time.Hour),
+			NotAfter:  time.Now().Add(time.Hour),
+		}, nil)
+		server, _ := newOCSPResponder(t, other, OCSPGood)
+		_, err := issue(server.URL).CheckOCSP(ctx, nil)
+		if err == nil {
+			t.Error("CheckOCSP() with a response signed by another key should fail")
+		}
+	})
+}
+
+func TestCertificateResponse_CheckOCSP_RejectsResponse(t *testing.T) {
+	ca, issue := newOCSPTestCertificate(t)
+
+	tests := []struct {
+		name string
+		edit func(single *ocspSingleResponse)
+	}{
+		{"other issuer with same serial", func(single *ocspSingleResponse) {
+			single.CertID.NameHash = make([]byte, len(single.CertID.NameHash))
+		}},
+		{"stale", func(single *ocspSingleResponse) {
+			single.ThisUpdate = single.ThisUpdate.Add(-2 * time.Hour)
+			single.NextUpdate = single.ThisUpdate.Add(time.Hour)
+		}},
+		{"not yet valid", func(single *ocspSingleResponse) {
+			single.ThisUpdate = single.ThisUpdate.Add(time.Hour)
+			single.NextUpdate = single.ThisUpdate.Add(time.Hour)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newOCSPResponderFunc(t, ca, func(n int, single *ocspSingleResponse) {
+				single.Good = true
+				tt.edit(single)
+			})
+			if result, err := issue(server.URL).CheckOCSP(context.Background(), nil); err == nil {
+				t.Errorf("CheckOCSP() = %+v, want error", result)
+			}
+		})
+	}
+}
@@ -1,8 +1,67 @@
 package digicert
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
 type OrdersService struct {
 	client *Client
 }
 
-// Orders service handles certificate order management
-// This is a placeholder implementation
\ No newline at end of file
+// Orders service retrieves the orders certificates were purchased under, for
+// reconciling the inventory against billing
+
+// ErrNoOrder is returned by GetForCertificate for certificates that were not
+// issued from an order, such as imported or discovered certificates.
+var ErrNoOrder = errors.New("digicert: certificate has no order")
+
+type Order struct {
+	ID            string           `json:"id"`
+	Status        string           `json:"status,omitempty"`
+	ProductName   string           `json:"product_name,omitempty"`
+	CertificateID string           `json:"certificate_id,omitempty"`
+	BusinessUnit  *BusinessUnitRef `json:"business_unit,omitempty"`
+	RequestedBy   string           `json:"requested_by,omitempty"`
+	// ValidityYears is the certificate validity purchased.
+	ValidityYears int `json:"validity_years,omitempty"`
+	// Price is the order total in minor units of Currency, such as cents.
+	Price     int64      `json:"price,omitempty"`
+	Currency  string     `json:"currency,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Get retrieves an order by ID
+func (s *OrdersService) Get(ctx context.Context, orderID string) (*Order, *Response, error) {
+	u := fmt.Sprintf("order/%s", orderID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var order Order
+	resp, err := s.client.Do(ctx, httpReq, &order)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &order, resp, nil
+}
+
+// GetForCertificate retrieves the order a certificate was issued from,
+// looking the certificate up by ID. It returns ErrNoOrder if the certificate
+// has no order.
+func (s *OrdersService) GetForCertificate(ctx context.Context, certificateID string) (*Order, *Response, error) {
+	cert, resp, err := s.client.Certificates.GetCertificate(ctx, certificateID)
+	if err != nil {
+		return nil, resp, err
+	}
+	if cert.OrderID == "" {
+		return nil, resp, fmt.Errorf("%w: %s", ErrNoOrder, certificateID)
+	}
+	return s.Get(ctx, cert.OrderID)
+}
@@ -0,0 +1,44 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOrdersService_GetForCertificate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate-by-id/cert-1":
+			json.NewEncoder(w).Encode(Certificate{ID: "cert-1", OrderID: "ord-9"})
+		case "/mpki/api/v1/certificate-by-id/imported":
+			json.NewEncoder(w).Encode(Certificate{ID: "imported"})
+		case "/mpki/api/v1/order/ord-9":
+			w.Write([]byte(`{"id": "ord-9", "status": "completed", "certificate_id": "cert-1", "price": 19900, "currency": "USD"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	order, _, err := client.Orders.GetForCertificate(ctx, "cert-1")
+	if err != nil {
+		t.Fatalf("GetForCertificate() error = %v", err)
+	}
+	if order.ID != "ord-9" || order.CertificateID != "cert-1" || order.Price != 19900 {
+		t.Errorf("order = %+v, want ord-9 for cert-1 at 19900", order)
+	}
+
+	_, _, err = client.Orders.GetForCertificate(ctx, "imported")
+	if !errors.Is(err, ErrNoOrder) {
+		t.Errorf("GetForCertificate() error = %v, want %v", err, ErrNoOrder)
+	}
+}
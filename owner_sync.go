@@ -0,0 +1,258 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// DirectoryUser is a user as reported by a directory such as LDAP or SCIM,
+// mapped to certificate owner fields. Users are matched to owners by email,
+// case-insensitively.
+type DirectoryUser struct {
+	Email       string
+	FirstName   string
+	LastName    string
+	PhoneNumber string
+	JobTitle    string
+	Company     string
+	Department  string
+}
+
+// UserSource yields the users currently in a directory. Sync reads the
+// whole source before changing anything, so an error part way through
+// leaves the owners untouched.
+type UserSource interface {
+	Users(ctx context.Context) iter.Seq2[DirectoryUser, error]
+}
+
+// UserSourceFunc adapts a function to UserSource.
+type UserSourceFunc func(ctx context.Context) iter.Seq2[DirectoryUser, error]
+
+func (f UserSourceFunc) Users(ctx context.Context) iter.Seq2[DirectoryUser, error] { return f(ctx) }
+
+// StaticUserSource is a UserSource over a fixed list of users, such as one
+// read from an HR export.
+type StaticUserSource []DirectoryUser
+
+func (s StaticUserSource) Users(context.Context) iter.Seq2[DirectoryUser, error] {
+	return func(yield func(DirectoryUser, error) bool) {
+		for _, u := range s {
+			if !yield(u, nil) {
+				return
+			}
+		}
+	}
+}
+
+type OwnerSyncOptions struct {
+	// Manages selects the owners the directory is authoritative for; owners
+	// it rejects are never updated or deactivated. nil manages every owner.
+	Manages func(CertificateOwner) bool
+	// Deactivate deactivates managed owners missing from the directory.
+	// Without it they are only reported.
+	Deactivate bool
+	// Reassign picks the owner, such as a departed user's manager, that
+	// receives the certificates of an owner being deactivated. It returns ""
+	// to deactivate without reassignment, which fails for owners that still
+	// have active certificates. nil never reassigns.
+	Reassign func(ctx context.Context, departed CertificateOwner) (string, error)
+	// DryRun plans the actions without performing them. Reassign is not
+	// called in a dry run.
+	DryRun bool
+}
+
+type OwnerSyncActionType string
+
+const (
+	OwnerSyncCreate     OwnerSyncActionType = "create"
+	OwnerSyncUpdate     OwnerSyncActionType = "update"
+	OwnerSyncDeactivate OwnerSyncActionType = "deactivate"
+	// OwnerSyncDeparted reports a managed owner missing from the directory
+	// that was kept because Deactivate is off.
+	OwnerSyncDeparted OwnerSyncActionType = "departed"
+)
+
+type OwnerSyncAction struct {
+	Type    OwnerSyncActionType `json:"type"`
+	Email   string              `json:"email"`
+	OwnerID string              `json:"owner_id,omitempty"`
+	// Changes names the fields an update changes.
+	Changes []string `json:"changes,omitempty"`
+	// ReassignedTo is the owner chosen to receive a deactivated owner's
+	// active certificates, if it had any.
+	ReassignedTo string `json:"reassigned_to,omitempty"`
+	Err          error  `json:"-"`
+}
+
+// OwnerSyncReport lists the actions taken, or planned in a dry run, in the
+// order create, update, deactivate or departed.
+type OwnerSyncReport struct {
+	Actions []OwnerSyncAction `json:"actions"`
+	// Unchanged holds the emails of owners already matching the directory.
+	Unchanged []string `json:"unchanged"`
+	DryRun    bool     `json:"dry_run"`
+}
+
+// Err joins the errors of every failed action, or returns nil.
+func (r *OwnerSyncReport) Err() error {
+	var errs []error
+	for _, a := range r.Actions {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", a.Type, a.Email, a.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Sync reconciles the active certificate owners on a directory: it creates
+// owners for new users, updates owners whose details changed and, with
+// opts.Deactivate, deactivates owners who left, moving their certificates
+// to the owner chosen by opts.Reassign. Deactivated owners are ignored, so a
+// returning user gets a new owner.
+//
+// Failed actions are recorded in the report rather than stopping the run;
+// check OwnerSyncReport.Err. The returned error is set only if the directory
+// or the owners could not be read.
+func (s *CertificateOwnersService) Sync(ctx context.Context, source UserSource, opts *OwnerSyncOptions) (*OwnerSyncReport, error) {
+	if source == nil {
+		return nil, errors.New("owner sync: user source cannot be nil")
+	}
+	if opts == nil {
+		opts = &OwnerSyncOptions{}
+	}
+
+	users := make(map[string]DirectoryUser)
+	for u, err := range source.Users(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("owner sync: read directory: %w", err)
+		}
+		if u.Email == "" {
+			return nil, errors.New("owner sync: directory user has no email")
+		}
+		key := strings.ToLower(u.Email)
+		if _, dup := users[key]; dup {
+			return nil, fmt.Errorf("owner sync: duplicate directory user %q", u.Email)
+		}
+		users[key] = u
+	}
+
+	all, err := s.listAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("owner sync: list owners: %w", err)
+	}
+	owners := make(map[string]CertificateOwner, len(all))
+	for _, o := range all {
+		if o.IsActive {
+			owners[strings.ToLower(o.Email)] = o
+		}
+	}
+
+	report := &OwnerSyncReport{DryRun: opts.DryRun}
+	var create, update, remove []OwnerSyncAction
+	for _, key := range sortedKeys(users) {
+		u := users[key]
+		o, ok := owners[key]
+		switch {
+		case !ok:
+			create = append(create, OwnerSyncAction{Type: OwnerSyncCreate, Email: u.Email})
+		case opts.Manages != nil && !opts.Manages(o):
+			continue
+		default:
+			if changes := ownerChanges(o, u); len(changes) > 0 {
+				update = append(update, OwnerSyncAction{Type: OwnerSyncUpdate, Email: u.Email, OwnerID: o.ID, Changes: changes})
+			} else {
+				report.Unchanged = append(report.Unchanged, u.Email)
+			}
+		}
+	}
+	for _, key := range sortedKeys(owners) {
+		o := owners[key]
+		if _, ok := users[key]; ok || (opts.Manages != nil && !opts.Manages(o)) {
+			continue
+		}
+		action := OwnerSyncAction{Type: OwnerSyncDeparted, Email: o.Email, OwnerID: o.ID}
+		if opts.Deactivate {
+			action.Type = OwnerSyncDeactivate
+		}
+		remove = append(remove, action)
+	}
+
+	for _, actions := range [][]OwnerSyncAction{create, update, remove} {
+		for i := range actions {
+			a := &actions[i]
+			if opts.DryRun || a.Type == OwnerSyncDeparted {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				a.Err = err
+				continue
+			}
+			key := strings.ToLower(a.Email)
+			switch a.Type {
+			case OwnerSyncCreate:
+				var owner *CertificateOwner
+				if owner, _, a.Err = s.Create(ctx, users[key].request()); a.Err == nil {
+					a.OwnerID = owner.ID
+				}
+			case OwnerSyncUpdate:
+				_, _, a.Err = s.Update(ctx, a.OwnerID, users[key].request())
+			case OwnerSyncDeactivate:
+				a.ReassignedTo, a.Err = s.syncDeactivate(ctx, owners[key], opts.Reassign)
+			}
+		}
+		report.Actions = append(report.Actions, actions...)
+	}
+	return report, nil
+}
+
+func (s *CertificateOwnersService) syncDeactivate(ctx context.Context, o CertificateOwner, reassign func(context.Context, CertificateOwner) (string, error)) (string, error) {
+	var to string
+	if reassign != nil {
+		var err error
+		if to, err = reassign(ctx, o); err != nil {
+			return "", fmt.Errorf("choose reassignment owner: %w", err)
+		}
+	}
+	_, _, err := s.Deactivate(ctx, o.ID, &DeactivateOptions{ReassignTo: to})
+	if err != nil {
+		return "", err
+	}
+	return to, nil
+}
+
+func (u DirectoryUser) request() *CertificateOwnerRequest {
+	return &CertificateOwnerRequest{
+		Email:       u.Email,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		PhoneNumber: u.PhoneNumber,
+		JobTitle:    u.JobTitle,
+		Company:     u.Company,
+		Department:  u.Department,
+	}
+}
+
+// ownerChanges names the owner fields that differ from the directory.
+func ownerChanges(o CertificateOwner, u DirectoryUser) []string {
+	var changes []string
+	for _, f := range []struct {
+		name          string
+		current, want string
+	}{
+		{"email", o.Email, u.Email},
+		{"first_name", o.FirstName, u.FirstName},
+		{"last_name", o.LastName, u.LastName},
+		{"phone_number", o.PhoneNumber, u.PhoneNumber},
+		{"job_title", o.JobTitle, u.JobTitle},
+		{"company", o.Company, u.Company},
+		{"department", o.Department, u.Department},
+	} {
+		if f.current != f.want {
+			changes = append(changes, f.name)
+		}
+	}
+	return changes
+}
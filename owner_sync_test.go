@@ -0,0 +1,108 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCertificateOwnersService_Sync(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(CertificateOwnerListResponse{
+				ListResponse: ListResponse{Total: 5},
+				Owners: []CertificateOwner{
+					{ID: "own-1", Email: "ana@corp.example", FirstName: "Ana", Department: "IT", IsActive: true},
+					{ID: "own-2", Email: "bo@corp.example", FirstName: "Bo", IsActive: true},
+					{ID: "own-3", Email: "cy@corp.example", FirstName: "Cy", IsActive: true},
+					{ID: "own-4", Email: "ext@partner.example", IsActive: true},
+					{ID: "own-5", Email: "dee@corp.example", IsActive: false},
+				},
+			})
+			return
+		}
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/mpki/api/v1/"))
+		var req CertificateOwnerRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(CertificateOwner{ID: "new-" + req.FirstName, Email: req.Email})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	source := StaticUserSource{
+		{Email: "Ana@corp.example", FirstName: "Ana", Department: "Security"},
+		{Email: "bo@corp.example", FirstName: "Bo"},
+		{Email: "dee@corp.example", FirstName: "Dee"},
+	}
+	opts := &OwnerSyncOptions{
+		Manages:    func(o CertificateOwner) bool { return strings.HasSuffix(o.Email, "@corp.example") },
+		Deactivate: true,
+		Reassign: func(_ context.Context, departed CertificateOwner) (string, error) {
+			return "own-2", nil
+		},
+		DryRun: true,
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		report, err := client.CertificateOwners.Sync(context.Background(), source, opts)
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("calls = %v, want none in a dry run", calls)
+		}
+		var got []string
+		for _, a := range report.Actions {
+			got = append(got, string(a.Type)+" "+a.Email)
+		}
+		want := "create dee@corp.example,update Ana@corp.example,deactivate cy@corp.example"
+		if strings.Join(got, ",") != want {
+			t.Errorf("actions = %v, want %v", got, want)
+		}
+		if changes := report.Actions[1].Changes; strings.Join(changes, ",") != "email,department" {
+			t.Errorf("Changes = %v, want email and department", changes)
+		}
+		if len(report.Unchanged) != 1 || report.Unchanged[0] != "bo@corp.example" {
+			t.Errorf("Unchanged = %v", report.Unchanged)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		opts.DryRun = false
+		report, err := client.CertificateOwners.Sync(context.Background(), source, opts)
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if err := report.Err(); err != nil {
+			t.Errorf("Err() = %v", err)
+		}
+		want := "POST certificate-owners,PUT certificate-owners/own-1,POST certificate-owners/own-3/deactivate"
+		if strings.Join(calls, ",") != want {
+			t.Errorf("calls = %v, want %v", calls, want)
+		}
+		if a := report.Actions[0]; a.OwnerID != "new-Dee" {
+			t.Errorf("create OwnerID = %q, want new-Dee", a.OwnerID)
+		}
+		if a := report.Actions[2]; a.ReassignedTo != "own-2" {
+			t.Errorf("ReassignedTo = %q, want own-2", a.ReassignedTo)
+		}
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		failing := UserSourceFunc(func(context.Context) iter.Seq2[DirectoryUser, error] {
+			return func(yield func(DirectoryUser, error) bool) {
+				yield(DirectoryUser{}, errors.New("ldap: connection reset"))
+			}
+		})
+		if _, err := client.CertificateOwners.Sync(context.Background(), failing, nil); err == nil {
+			t.Error("Sync() expected error when the directory cannot be read")
+		}
+	})
+}
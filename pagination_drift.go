@@ -0,0 +1,82 @@
+package digicert
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DriftStrategy chooses how CertificatesService.All handles a page that
+// repeats certificates it has already yielded. Offset pagination drifts this
+// way when certificates are added ahead of the current offset mid-iteration.
+// Whatever the strategy, a certificate is never yielded twice.
+type DriftStrategy int
+
+const (
+	// DriftSkip drops the repeated certificates and carries on.
+	DriftSkip DriftStrategy = iota
+	// DriftRestart carries on from the first page, to pick up certificates
+	// that a deletion may have shifted past the current offset. After
+	// maxDriftRestarts restarts it fails with ErrPaginationDrift.
+	DriftRestart
+	// DriftError stops iteration with ErrPaginationDrift.
+	DriftError
+)
+
+// maxDriftRestarts bounds DriftRestart on an inventory that keeps changing.
+const maxDriftRestarts = 3
+
+// ErrPaginationDrift is returned by CertificatesService.All when results
+// changed during iteration and the DriftStrategy does not tolerate it.
+var ErrPaginationDrift = errors.New("digicert: results changed during pagination")
+
+// driftDetector tracks the IDs of the certificates yielded so far, with the
+// pass over the pages that yielded them. Only a repeat within one pass is
+// drift; pages after a restart are expected to repeat earlier passes.
+// Certificates without an ID, such as those fetched with Fields omitting
+// "id", cannot be tracked and are always yielded.
+type driftDetector struct {
+	strategy DriftStrategy
+	seen     map[string]int
+	restarts int
+}
+
+func newDriftDetector(strategy DriftStrategy) *driftDetector {
+	return &driftDetector{strategy: strategy, seen: make(map[string]int)}
+}
+
+// page returns the certificates of the page fetched at offset that have not
+// been yielded yet. restart reports that iteration should carry on from the
+// first page once they have been yielded.
+func (d *driftDetector) page(items []Certificate, offset int) (fresh []Certificate, restart bool, err error) {
+	fresh = make([]Certificate, 0, len(items))
+	overlap := false
+	for _, c := range items {
+		if c.ID == "" {
+			fresh = append(fresh, c)
+			continue
+		}
+		pass, seen := d.seen[c.ID]
+		d.seen[c.ID] = d.restarts
+		switch {
+		case !seen:
+			fresh = append(fresh, c)
+		case pass == d.restarts:
+			overlap = true
+		}
+	}
+	if !overlap {
+		return fresh, false, nil
+	}
+
+	switch d.strategy {
+	case DriftError:
+		return nil, false, fmt.Errorf("%w: page at offset %d repeats earlier certificates", ErrPaginationDrift, offset)
+	case DriftRestart:
+		if d.restarts >= maxDriftRestarts {
+			return nil, false, fmt.Errorf("%w: still changing after %d restarts", ErrPaginationDrift, d.restarts)
+		}
+		d.restarts++
+		return fresh, true, nil
+	}
+	return fresh, false, nil
+}
@@ -0,0 +1,154 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// driftServer serves certificate search pages of inventory, two to a page
+// unless a limit is given. mutate is called before every request after the
+// first to simulate certificates being issued mid-iteration.
+func driftServer(t *testing.T, inventory []string, mutate func([]string) []string) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if requests > 0 && mutate != nil {
+			inventory = mutate(inventory)
+		}
+		requests++
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			limit = 2
+		}
+		result := CertificateSearchResponse{ListResponse: ListResponse{Total: len(inventory)}}
+		for i := offset; i < offset+limit && i < len(inventory); i++ {
+			result.Items = append(result.Items, Certificate{ID: inventory[i]})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}))
+	t.Cleanup(server.Close)
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	return client
+}
+
+func collectIDs(client *Client, opts *CertificateSearchOptions) ([]string, error) {
+	var ids []string
+	for c, err := range client.Certificates.All(context.Background(), opts) {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
+
+func TestCertificatesService_AllDrift(t *testing.T) {
+	inventory := []string{"c1", "c2", "c3", "c4", "c5"}
+	// insertOnce issues c0 ahead of every other certificate after the first
+	// page, shifting the rest of the inventory back by one.
+	insertOnce := func(ids []string) []string {
+		if ids[0] == "c0" {
+			return ids
+		}
+		return append([]string{"c0"}, ids...)
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		client := driftServer(t, inventory, insertOnce)
+		ids, err := collectIDs(client, &CertificateSearchOptions{PaginationParams: PaginationParams{Limit: 2}})
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		want := []string{"c1", "c2", "c3", "c4", "c5"}
+		if !reflect.DeepEqual(ids, want) {
+			t.Errorf("ids = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("restart", func(t *testing.T) {
+		client := driftServer(t, inventory, insertOnce)
+		ids, err := collectIDs(client, &CertificateSearchOptions{
+			PaginationParams: PaginationParams{Limit: 2},
+			OnDrift:          DriftRestart,
+		})
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		want := []string{"c1", "c2", "c3", "c0", "c4", "c5"}
+		if !reflect.DeepEqual(ids, want) {
+			t.Errorf("ids = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("restart gives up on constant churn", func(t *testing.T) {
+		n := 0
+		client := driftServer(t, inventory, func(ids []string) []string {
+			n++
+			return append([]string{"n" + strconv.Itoa(n)}, ids...)
+		})
+		_, err := collectIDs(client, &CertificateSearchOptions{
+			PaginationParams: PaginationParams{Limit: 2},
+			OnDrift:          DriftRestart,
+		})
+		if !errors.Is(err, ErrPaginationDrift) {
+			t.Errorf("All() error = %v, want %v", err, ErrPaginationDrift)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := driftServer(t, inventory, insertOnce)
+		ids, err := collectIDs(client, &CertificateSearchOptions{
+			PaginationParams: PaginationParams{Limit: 2},
+			OnDrift:          DriftError,
+		})
+		if !errors.Is(err, ErrPaginationDrift) {
+			t.Fatalf("All() error = %v, want %v", err, ErrPaginationDrift)
+		}
+		if want := []string{"c1", "c2"}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("ids = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("prefetch skip", func(t *testing.T) {
+		client := driftServer(t, inventory, insertOnce)
+		ids, err := collectIDs(client, &CertificateSearchOptions{
+			PaginationParams: PaginationParams{Limit: 2},
+			Prefetch:         2,
+		})
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		want := []string{"c1", "c2", "c3", "c4", "c5"}
+		if !reflect.DeepEqual(ids, want) {
+			t.Errorf("ids = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("no drift", func(t *testing.T) {
+		client := driftServer(t, inventory, nil)
+		ids, err := collectIDs(client, &CertificateSearchOptions{
+			PaginationParams: PaginationParams{Limit: 2},
+			OnDrift:          DriftError,
+		})
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		if !reflect.DeepEqual(ids, inventory) {
+			t.Errorf("ids = %v, want %v", ids, inventory)
+		}
+	})
+}
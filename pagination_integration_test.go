@@ -448,8 +448,8 @@ func TestPaginationParameterConsistency(t *testing.T) {
 	}{
 		{"zero values", 0, 0, false},
 		{"negative values", -1, -5, false},
-		{"positive offset only", 10, 0, false}, // Only offset > 0, limit = 0
-		{"positive limit only", 0, 20, false},  // Only limit > 0, offset = 0
+		{"positive offset only", 10, 0, true}, // Only offset > 0, limit = 0
+		{"positive limit only", 0, 20, true},  // Only limit > 0, offset = 0
 		{"both positive", 30, 40, true},
 	}
 
@@ -458,20 +458,21 @@ func TestPaginationParameterConsistency(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				q := r.URL.Query()
 
-				if tc.expectInURL {
-					if tc.offset > 0 && q.Get("offset") != fmt.Sprintf("%d", tc.offset) {
-						t.Errorf("Expected offset=%d in URL, got %s", tc.offset, q.Get("offset"))
-					}
-					if tc.limit > 0 && q.Get("limit") != fmt.Sprintf("%d", tc.limit) {
-						t.Errorf("Expected limit=%d in URL, got %s", tc.limit, q.Get("limit"))
-					}
-				} else {
-					if q.Has("offset") {
-						t.Errorf("offset should not be present in URL for case: %s", tc.name)
-					}
-					if q.Has("limit") {
-						t.Errorf("limit should not be present in URL for case: %s", tc.name)
-					}
+				// Offset and limit are each sent when positive
+				if tc.offset > 0 && q.Get("offset") != fmt.Sprintf("%d", tc.offset) {
+					t.Errorf("Expected offset=%d in URL, got %s", tc.offset, q.Get("offset"))
+				}
+				if tc.offset <= 0 && q.Has("offset") {
+					t.Errorf("offset should not be present in URL for case: %s", tc.name)
+				}
+				if tc.limit > 0 && q.Get("limit") != fmt.Sprintf("%d", tc.limit) {
+					t.Errorf("Expected limit=%d in URL, got %s", tc.limit, q.Get("limit"))
+				}
+				if tc.limit <= 0 && q.Has("limit") {
+					t.Errorf("limit should not be present in URL for case: %s", tc.name)
+				}
+				if !tc.expectInURL && (q.Has("offset") || q.Has("limit")) {
+					t.Errorf("pagination should not be present in URL for case: %s", tc.name)
 				}
 
 				// Return minimal response
@@ -552,9 +553,9 @@ func TestPaginationBoundaryConditions(t *testing.T) {
 	t.Run("very large limit", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			q := r.URL.Query()
-			// Since offset=0 and our pagination logic requires both > 0, no limit should be sent
-			if q.Has("limit") {
-				t.Errorf("limit parameter should not be present when offset is 0")
+			// The limit is sent even on the first page
+			if q.Get("limit") != "999999" {
+				t.Errorf("Expected limit=999999 when offset is 0, got %q", q.Get("limit"))
 			}
 
 			// Server might cap the actual returned items
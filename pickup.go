@@ -0,0 +1,117 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PickupError reports a certificate request that the CA rejected or failed
+// while IssueAndWait or WaitForPickup were waiting for it.
+type PickupError struct {
+	RequestID string
+	Status    string
+}
+
+func (e *PickupError) Error() string {
+	return fmt.Sprintf("digicert: certificate request %s ended with status %s", e.RequestID, e.Status)
+}
+
+// IssueAndWait issues a certificate and, when the CA does not return it
+// straight away, waits for it: it polls Pickup when only a request ID comes
+// back, as with Microsoft CA profiles, and waits on the Operation when
+// issuance is asynchronous. opts.Timeout bounds the wait after Issue. A
+// private key generated by the server is kept on the result even if the
+// pickup response omits it.
+func (s *CertificatesService) IssueAndWait(ctx context.Context, req *CertificateRequest, opts *PollOptions) (*CertificateResponse, *Response, error) {
+	result, resp, err := s.Issue(ctx, req)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if opts != nil && opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+		wait := *opts
+		wait.Timeout = 0
+		opts = &wait
+	}
+
+	if op := result.Operation; op != nil && !hasIssuedCertificate(result) {
+		if err := op.Wait(ctx); err != nil {
+			return nil, resp, err
+		}
+		var done CertificateResponse
+		if err := op.Decode(&done); err != nil {
+			return nil, resp, err
+		}
+		done.Operation = op
+		if done.PrivateKey == nil {
+			done.PrivateKey = result.PrivateKey
+		}
+		result = &done
+	}
+
+	if hasIssuedCertificate(result) {
+		return result, resp, nil
+	}
+	if result.RequestID == "" {
+		return nil, resp, errors.New("digicert: issuance returned neither a certificate nor a request ID")
+	}
+
+	picked, pickupResp, err := s.WaitForPickup(ctx, result.RequestID, opts)
+	if err != nil {
+		return nil, pickupResp, err
+	}
+	if picked.PrivateKey == nil {
+		picked.PrivateKey = result.PrivateKey
+	}
+	return picked, pickupResp, nil
+}
+
+// WaitForPickup polls Pickup until the certificate for requestID has been
+// issued. Not found responses are treated as still pending; a rejected or
+// failed request returns a *PickupError.
+func (s *CertificatesService) WaitForPickup(ctx context.Context, requestID string, opts *PollOptions) (*CertificateResponse, *Response, error) {
+	var result *CertificateResponse
+	var resp *Response
+	err := poll(ctx, opts, func(ctx context.Context) (bool, error) {
+		var err error
+		result, resp, err = s.Pickup(ctx, requestID)
+		if IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if hasIssuedCertificate(result) {
+			return true, nil
+		}
+		if result.Certificate != nil && issuanceFailed(result.Certificate.Status) {
+			return false, &PickupError{RequestID: requestID, Status: result.Certificate.Status}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+func hasIssuedCertificate(resp *CertificateResponse) bool {
+	return resp != nil && resp.Certificate != nil && resp.Certificate.Certificate != ""
+}
+
+// issuanceFailed reports whether a certificate status means the CA will not
+// issue the certificate. Pending statuses, and issued ones whose PEM has not
+// been attached yet, keep the wait going.
+func issuanceFailed(status string) bool {
+	switch strings.ToLower(status) {
+	case "", "pending", "pending_issuance", "pending_approval", "processing", "issued", "active", "valid":
+		return false
+	}
+	return true
+}
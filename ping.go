@@ -0,0 +1,46 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// PingResult reports the outcome of Client.Ping.
+type PingResult struct {
+	// Latency is the round trip time of the request.
+	Latency time.Duration
+	// Authenticated reports whether the API accepted the credentials.
+	Authenticated bool
+	StatusCode    int
+	RequestID     string
+}
+
+// Ping makes a small authenticated request, listing at most one business
+// unit, so callers can check connectivity and credentials at startup rather
+// than on the first real operation. A 401 returns a result with
+// Authenticated false along with the error. A 403 counts as authenticated:
+// the key is valid but not allowed to list business units. If the server
+// could not be reached the result is nil.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	httpReq, err := c.NewRequest(ctx, http.MethodGet, "business-unit?limit=1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.Do(ctx, httpReq, nil)
+	if resp == nil {
+		return nil, err
+	}
+	result := &PingResult{
+		Latency:    time.Since(start),
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if err == nil || IsForbidden(err) {
+		result.Authenticated = true
+		return result, nil
+	}
+	return result, err
+}
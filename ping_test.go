@@ -0,0 +1,62 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Ping(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            int
+		wantAuthenticated bool
+		wantErr           bool
+	}{
+		{"valid key", http.StatusOK, true, false},
+		{"invalid key", http.StatusUnauthorized, false, true},
+		{"key without business unit access", http.StatusForbidden, true, false},
+		{"server error", http.StatusServiceUnavailable, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/mpki/api/v1/business-unit" || r.URL.Query().Get("limit") != "1" {
+					t.Errorf("request = %s, want a one-item business unit list", r.URL)
+				}
+				if r.Header.Get("X-API-Key") != "test-key" {
+					t.Errorf("X-API-Key = %q, want test-key", r.Header.Get("X-API-Key"))
+				}
+				w.Header().Set("X-Request-Id", "req-1")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"code": "x", "message": "y"}`))
+			}))
+			defer server.Close()
+
+			client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+			result, err := client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Authenticated != tt.wantAuthenticated {
+				t.Errorf("Authenticated = %v, want %v", result.Authenticated, tt.wantAuthenticated)
+			}
+			if result.StatusCode != tt.status || result.RequestID != "req-1" || result.Latency <= 0 {
+				t.Errorf("result = %+v, want status %d, request ID and latency", result, tt.status)
+			}
+		})
+	}
+}
+
+func TestClient_PingUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, err := client.Ping(context.Background())
+	if err == nil || result != nil {
+		t.Errorf("Ping() = %v, %v, want nil result and an error", result, err)
+	}
+}
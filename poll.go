@@ -0,0 +1,46 @@
+package digicert
+
+import (
+	"context"
+	"time"
+)
+
+const DefaultPollInterval = 5 * time.Second
+
+// PollOptions controls helpers that wait for asynchronous operations to finish.
+type PollOptions struct {
+	// Interval between attempts. Defaults to DefaultPollInterval.
+	Interval time.Duration
+	// Timeout bounds the total wait. Zero waits until ctx is done.
+	Timeout time.Duration
+}
+
+// poll calls fn until it reports done, returns an error, or the context or
+// timeout expires. fn is called immediately and then once per interval.
+func poll(ctx context.Context, opts *PollOptions, fn func(ctx context.Context) (bool, error)) error {
+	interval := DefaultPollInterval
+	if opts != nil && opts.Interval > 0 {
+		interval = opts.Interval
+	}
+	if opts != nil && opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		done, err := fn(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
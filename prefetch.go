@@ -0,0 +1,113 @@
+package digicert
+
+import (
+	"context"
+	"sync"
+)
+
+// allPrefetched implements All with page.Prefetch pages in flight. The first
+// page is fetched alone to learn the total and the page size the server
+// actually returns, which may be less than page.Limit; the remaining offsets
+// are then fetched concurrently and yielded in order. A page is only started
+// once fewer than Prefetch pages are waiting, bounding memory use. It returns
+// the offset for the caller to carry on from sequentially and true when drift
+// calls for a restart from the first page, or when a page comes back short
+// and the offsets already requested would skip certificates.
+func (s *CertificatesService) allPrefetched(ctx context.Context, page CertificateSearchOptions, drift *driftDetector, yield func(Certificate, error) bool) (int, bool) {
+	first, _, err := s.Search(ctx, &page)
+	if err != nil {
+		yield(Certificate{}, err)
+		return 0, false
+	}
+	items, _, _ := drift.page(first.Items, page.Offset)
+	for _, c := range items {
+		if !yield(c, nil) {
+			return 0, false
+		}
+	}
+	step := len(first.Items)
+	start := page.Offset + step
+	if step == 0 || start >= first.Total {
+		return 0, false
+	}
+	page.Limit = step
+
+	type pageResult struct {
+		items []Certificate
+		err   error
+	}
+	var offsets []int
+	for offset := start; offset < first.Total; offset += step {
+		offsets = append(offsets, offset)
+	}
+	results := make([]chan pageResult, len(offsets))
+	for i := range results {
+		results[i] = make(chan pageResult, 1)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	slots := make(chan struct{}, page.Prefetch)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, offset := range offsets {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				opts := page
+				opts.Offset = offset
+				result, _, err := s.Search(ctx, &opts)
+				if err != nil {
+					results[i] <- pageResult{err: err}
+					return
+				}
+				results[i] <- pageResult{items: result.Items}
+			}()
+		}
+	}()
+
+	for i := range offsets {
+		var r pageResult
+		select {
+		case r = <-results[i]:
+			<-slots
+		case <-ctx.Done():
+			yield(Certificate{}, ctx.Err())
+			return 0, false
+		}
+		if r.err != nil {
+			yield(Certificate{}, r.err)
+			return 0, false
+		}
+		items, restart, err := drift.page(r.items, offsets[i])
+		if err != nil {
+			yield(Certificate{}, err)
+			return 0, false
+		}
+		for _, c := range items {
+			if !yield(c, nil) {
+				return 0, false
+			}
+		}
+		if restart {
+			return page.Offset, true
+		}
+		if len(r.items) == 0 {
+			// The inventory shrank since the first page.
+			return 0, false
+		}
+		if len(r.items) < step && i < len(offsets)-1 {
+			return offsets[i] + len(r.items), true
+		}
+	}
+	return 0, false
+}
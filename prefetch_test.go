@@ -0,0 +1,153 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCertificatesService_AllPrefetch(t *testing.T) {
+	const total = 95
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		// Later pages answer first, to check the stream stays ordered.
+		time.Sleep(time.Duration(total-offset) * 100 * time.Microsecond)
+		var items []Certificate
+		for i := offset; i < min(offset+10, total); i++ {
+			items = append(items, Certificate{ID: fmt.Sprint(i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: total}, Items: items})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	opts := &CertificateSearchOptions{PaginationParams: PaginationParams{Limit: 10}, Prefetch: 3}
+
+	var got []string
+	for c, err := range client.Certificates.All(context.Background(), opts) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		got = append(got, c.ID)
+	}
+	if len(got) != total {
+		t.Fatalf("got %d certificates, want %d", len(got), total)
+	}
+	for i, id := range got {
+		if id != fmt.Sprint(i) {
+			t.Fatalf("certificate %d = %s, want in order", i, id)
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > 3 {
+		t.Errorf("max concurrent requests = %d, want 2-3", maxInFlight)
+	}
+
+	// Stopping early must not leave requests running.
+	count := 0
+	for range client.Certificates.All(context.Background(), opts) {
+		if count++; count == 25 {
+			break
+		}
+	}
+	if n := atomic.LoadInt32(&inFlight); n != 0 {
+		t.Errorf("requests still in flight after break = %d", n)
+	}
+}
+
+func TestCertificatesService_AllPrefetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "20" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code": "internal", "message": "boom"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: 50}, Items: make([]Certificate, 10)})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	opts := &CertificateSearchOptions{PaginationParams: PaginationParams{Limit: 10}, Prefetch: 4}
+
+	count := 0
+	var gotErr error
+	for _, err := range client.Certificates.All(context.Background(), opts) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		count++
+	}
+	if !IsServerError(gotErr) || count != 20 {
+		t.Errorf("All() = %d certificates, error %v, want 20 then the server error", count, gotErr)
+	}
+}
+
+func TestCertificatesService_AllPrefetchShortPages(t *testing.T) {
+	const total = 40
+	tests := []struct {
+		name string
+		// size returns how many certificates the server returns at offset
+		// for the requested limit.
+		size func(offset, limit int) int
+	}{
+		{"server caps page size", func(offset, limit int) int { return min(limit, 7) }},
+		{"short page mid-stream", func(offset, limit int) int {
+			if offset == 10 {
+				return 5
+			}
+			return limit
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				var items []Certificate
+				for i := offset; i < min(offset+tt.size(offset, limit), total); i++ {
+					items = append(items, Certificate{ID: fmt.Sprint(i)})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: total}, Items: items})
+			}))
+			defer server.Close()
+
+			client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+			opts := &CertificateSearchOptions{PaginationParams: PaginationParams{Limit: 10}, Prefetch: 3}
+
+			var got []string
+			for c, err := range client.Certificates.All(context.Background(), opts) {
+				if err != nil {
+					t.Fatalf("All() error = %v", err)
+				}
+				got = append(got, c.ID)
+			}
+			if len(got) != total {
+				t.Fatalf("got %d certificates, want %d: %v", len(got), total, got)
+			}
+			for i, id := range got {
+				if id != fmt.Sprint(i) {
+					t.Fatalf("certificate %d = %s, want in order", i, id)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package digicert
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ViolationKind classifies a ProfileViolation.
+type ViolationKind string
+
+const (
+	// ViolationMissing means the profile requires a field the request omits.
+	ViolationMissing ViolationKind = "missing"
+	// ViolationDisallowed means the request sets a field the profile does not accept.
+	ViolationDisallowed ViolationKind = "disallowed"
+	// ViolationInvalid means the field is accepted but its value is not.
+	ViolationInvalid ViolationKind = "invalid"
+)
+
+// ProfileViolation describes one way a certificate request does not fit a
+// profile. Field uses the profile's names, e.g. "subject.common_name",
+// "san.dns_name", "validity" or "custom_field.cost_center".
+type ProfileViolation struct {
+	Field   string
+	Kind    ViolationKind
+	Message string
+}
+
+func (v ProfileViolation) String() string {
+	return fmt.Sprintf("%s %s: %s", v.Field, v.Kind, v.Message)
+}
+
+// ProfileValidationError is returned by ProfilesService.ValidateRequest when
+// the request does not fit the profile.
+type ProfileValidationError struct {
+	ProfileID  string
+	Violations []ProfileViolation
+}
+
+func (e *ProfileValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.String()
+	}
+	return fmt.Sprintf("certificate request does not match profile %s: %s", e.ProfileID, strings.Join(msgs, "; "))
+}
+
+// ValidateRequest fetches a profile and checks req against its subject DN,
+// SAN, key, validity and custom field constraints before submission. It
+// returns a *ProfileValidationError listing every violation, or nil if none
+// were found.
+func (s *ProfilesService) ValidateRequest(ctx context.Context, profileID string, req *CertificateRequest) (*Response, error) {
+	profile, resp, err := s.Get(ctx, profileID)
+	if err != nil {
+		return resp, err
+	}
+
+	if violations := profile.ValidateRequest(req); len(violations) > 0 {
+		return resp, &ProfileValidationError{ProfileID: profileID, Violations: violations}
+	}
+	return resp, nil
+}
+
+// ValidateRequest checks req against the profile's constraints locally. When
+// req carries a CSR, subject and SAN values missing from Attributes are taken
+// from it, as the API does.
+func (p *Profile) ValidateRequest(req *CertificateRequest) []ProfileViolation {
+	if req == nil {
+		req = &CertificateRequest{}
+	}
+
+	var violations []ProfileViolation
+	add := func(field string, kind ViolationKind, format string, args ...interface{}) {
+		violations = append(violations, ProfileViolation{Field: field, Kind: kind, Message: fmt.Sprintf(format, args...)})
+	}
+
+	csr := parseRequestCSR(req.CSR)
+	if req.CSR != "" && csr == nil {
+		add("csr", ViolationInvalid, "not a PEM encoded certificate request")
+	}
+
+	// Subject DN
+	subject := requestSubject(req.Attributes, csr)
+	dnFields := make(map[string]DNField, len(p.SubjectDNFields))
+	for _, f := range p.SubjectDNFields {
+		dnFields[canonicalDNName(f.Name)] = f
+	}
+	for _, f := range p.SubjectDNFields {
+		name := canonicalDNName(f.Name)
+		value := subject[name]
+		switch {
+		case f.Source == "fixed" && value != "" && f.Value != "" && value != f.Value:
+			add("subject."+name, ViolationInvalid, "profile fixes the value to %q", f.Value)
+		case f.Required && value == "" && f.Value == "":
+			add("subject."+name, ViolationMissing, "required by profile")
+		}
+	}
+	if len(p.SubjectDNFields) > 0 {
+		for _, name := range sortedKeys(subject) {
+			if _, ok := dnFields[name]; !ok && subject[name] != "" {
+				add("subject."+name, ViolationDisallowed, "not a subject field of this profile")
+			}
+		}
+	}
+
+	// Subject alternative names
+	sans := requestSANs(req.Attributes, csr)
+	sanFields := make(map[string]bool, len(p.SANFields))
+	for _, f := range p.SANFields {
+		name := canonicalSANType(f.Type)
+		sanFields[name] = true
+		if f.Required && len(sans[name]) == 0 && len(f.Values) == 0 {
+			add("san."+name, ViolationMissing, "required by profile")
+		}
+	}
+	for _, name := range sortedKeys(sans) {
+		if len(sans[name]) > 0 && !sanFields[name] {
+			add("san."+name, ViolationDisallowed, "profile does not allow %s SANs", name)
+		}
+	}
+
+	// Key
+	if want := p.keySpec(); csr != nil && want.Algorithm() != "" {
+		if kr, err := keyRequestFor(csr.PublicKey); err == nil {
+			if have := keySpecFor(kr); !have.AtLeast(want.Algorithm(), want.Bits()) {
+				add("key", ViolationInvalid, "%s key does not meet the profile's %s", have, want)
+			}
+		}
+	}
+
+	// Validity
+	if err := p.ValidityWindow(time.Now()).Validate(req.Validity); err != nil {
+		add("validity", ViolationInvalid, "%v", err)
+	}
+
+	// Custom fields
+	values := make(map[string]string, len(req.CustomAttributes))
+	for _, a := range req.CustomAttributes {
+		values[a.ID] = a.Value
+	}
+	defined := make(map[string]bool, len(p.CustomFields))
+	for _, f := range p.CustomFields {
+		defined[f.ID] = true
+		value, ok := values[f.ID]
+		switch {
+		case f.Required && (!ok || value == ""):
+			add("custom_field."+customFieldLabel(f), ViolationMissing, "required by profile")
+		case ok && len(f.Options) > 0 && !containsString(f.Options, value):
+			add("custom_field."+customFieldLabel(f), ViolationInvalid, "%q is not one of %s", value, strings.Join(f.Options, ", "))
+		}
+	}
+	for _, a := range req.CustomAttributes {
+		if !defined[a.ID] {
+			add("custom_field."+a.ID, ViolationDisallowed, "not a custom field of this profile")
+		}
+	}
+
+	return violations
+}
+
+// keySpec describes the key the profile requires from its key algorithm and
+// size.
+func (p *Profile) keySpec() KeySpec {
+	if p.KeySize > 0 {
+		return KeySpec(fmt.Sprintf("%s %d", p.KeyAlgorithm, p.KeySize))
+	}
+	return KeySpec(p.KeyAlgorithm)
+}
+
+func customFieldLabel(f CustomFieldDef) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.ID
+}
+
+// canonicalDNName maps the DN field names used by profiles to a single form.
+func canonicalDNName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "cn", "common_name", "commonname":
+		return "common_name"
+	case "o", "organization", "organization_name", "organizationname":
+		return "organization"
+	case "ou", "organizational_unit", "organization_unit", "organization_units", "organizationalunit":
+		return "organizational_unit"
+	case "c", "country", "country_name":
+		return "country"
+	case "st", "state", "state_or_province", "province":
+		return "state"
+	case "l", "locality", "locality_name", "city":
+		return "locality"
+	case "e", "email", "email_address", "emailaddress":
+		return "email"
+	}
+	return name
+}
+
+// canonicalSANType maps the SAN types used by profiles to a single form.
+func canonicalSANType(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "dns", "dns_name", "dns_names", "dnsname":
+		return "dns_name"
+	case "ip", "ip_address", "ip_addresses", "ipaddress":
+		return "ip_address"
+	case "email", "emails", "rfc822", "rfc822_name", "email_address":
+		return "email"
+	case "uri", "uris", "url":
+		return "uri"
+	case "other_name", "other_names", "othername", "upn":
+		return "other_name"
+	}
+	return name
+}
+
+func requestSubject(attrs *CertificateAttributes, csr *x509.CertificateRequest) map[string]string {
+	subject := make(map[string]string)
+	if attrs != nil {
+		subject["common_name"] = attrs.CommonName
+		subject["organization"] = attrs.Organization
+		subject["organizational_unit"] = strings.Join(attrs.OrganizationalUnit, ",")
+		subject["country"] = attrs.Country
+		subject["state"] = attrs.State
+		subject["locality"] = attrs.Locality
+		subject["email"] = attrs.Email
+	}
+	if csr != nil {
+		fromCSR := map[string]string{
+			"common_name":         csr.Subject.CommonName,
+			"organization":        strings.Join(csr.Subject.Organization, ","),
+			"organizational_unit": strings.Join(csr.Subject.OrganizationalUnit, ","),
+			"country":             strings.Join(csr.Subject.Country, ","),
+			"state":               strings.Join(csr.Subject.Province, ","),
+			"locality":            strings.Join(csr.Subject.Locality, ","),
+		}
+		for name, value := range fromCSR {
+			if subject[name] == "" {
+				subject[name] = value
+			}
+		}
+	}
+	return subject
+}
+
+func requestSANs(attrs *CertificateAttributes, csr *x509.CertificateRequest) map[string][]string {
+	sans := make(map[string][]string)
+	if attrs != nil && attrs.SANs != nil {
+		sans["dns_name"] = attrs.SANs.DNSNames
+		sans["ip_address"] = attrs.SANs.IPAddresses
+		sans["email"] = attrs.SANs.Emails
+		sans["uri"] = attrs.SANs.URIs
+		sans["other_name"] = attrs.SANs.OtherNames
+	}
+	if csr != nil {
+		if len(sans["dns_name"]) == 0 {
+			sans["dns_name"] = csr.DNSNames
+		}
+		if len(sans["email"]) == 0 {
+			sans["email"] = csr.EmailAddresses
+		}
+		if len(sans["ip_address"]) == 0 {
+			for _, ip := range csr.IPAddresses {
+				sans["ip_address"] = append(sans["ip_address"], ip.String())
+			}
+		}
+		if len(sans["uri"]) == 0 {
+			for _, uri := range csr.URIs {
+				sans["uri"] = append(sans["uri"], uri.String())
+			}
+		}
+	}
+	return sans
+}
+
+func parseRequestCSR(data string) *x509.CertificateRequest {
+	if data == "" {
+		return nil
+	}
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return csr
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+package digicert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testValidationProfile() *Profile {
+	return &Profile{
+		ID: "profile-123",
+		SubjectDNFields: []DNField{
+			{Name: "common_name", Required: true, Source: "user"},
+			{Name: "organization", Source: "fixed", Value: "Example Inc"},
+		},
+		SANFields: []SANField{
+			{Type: "dns_name", Required: true, Source: "user"},
+		},
+		Validity: ProfileValidity{MaxDays: 398},
+		CustomFields: []CustomFieldDef{
+			{ID: "cf-1", Name: "cost_center", Required: true},
+			{ID: "cf-2", Name: "environment", Options: []string{"prod", "dev"}},
+		},
+	}
+}
+
+func TestProfile_ValidateRequest(t *testing.T) {
+	profile := testValidationProfile()
+
+	tests := []struct {
+		name string
+		req  *CertificateRequest
+		want []string
+	}{
+		{
+			name: "valid",
+			req: &CertificateRequest{
+				Validity: &Validity{Years: 1},
+				Attributes: &CertificateAttributes{
+					CommonName: "example.com",
+					SANs:       &SubjectAltNames{DNSNames: []string{"example.com"}},
+				},
+				CustomAttributes: []CustomAttribute{{ID: "cf-1", Value: "42"}, {ID: "cf-2", Value: "prod"}},
+			},
+		},
+		{
+			name: "missing fields",
+			req:  &CertificateRequest{},
+			want: []string{"subject.common_name missing", "san.dns_name missing", "custom_field.cost_center missing"},
+		},
+		{
+			name: "disallowed and invalid",
+			req: &CertificateRequest{
+				Validity: &Validity{Years: 2},
+				Attributes: &CertificateAttributes{
+					CommonName:   "example.com",
+					Organization: "Other Inc",
+					Locality:     "Leeds",
+					SANs:         &SubjectAltNames{DNSNames: []string{"example.com"}, IPAddresses: []string{"10.0.0.1"}},
+				},
+				CustomAttributes: []CustomAttribute{{ID: "cf-1", Value: "42"}, {ID: "cf-2", Value: "test"}, {ID: "cf-9", Value: "x"}},
+			},
+			want: []string{
+				"subject.organization invalid",
+				"subject.locality disallowed",
+				"san.ip_address disallowed",
+				"validity invalid",
+				"custom_field.environment invalid",
+				"custom_field.cf-9 disallowed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := profile.ValidateRequest(tt.req)
+			if len(violations) != len(tt.want) {
+				t.Fatalf("ValidateRequest() = %v, want %v", violations, tt.want)
+			}
+			for i, v := range violations {
+				if got := v.Field + " " + string(v.Kind); got != tt.want[i] {
+					t.Errorf("violations[%d] = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProfile_ValidateRequest_CSR(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "csr.example.com"},
+		DNSNames: []string{"csr.example.com"},
+	}, key)
+	if err != nil {
+		t.Fatalf("CreateCertificateRequest() error = %v", err)
+	}
+	csr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+
+	req := &CertificateRequest{
+		CSR:              csr,
+		CustomAttributes: []CustomAttribute{{ID: "cf-1", Value: "42"}},
+	}
+	if violations := testValidationProfile().ValidateRequest(req); len(violations) != 0 {
+		t.Errorf("ValidateRequest() = %v, want none", violations)
+	}
+
+	rsaProfile := testValidationProfile()
+	rsaProfile.KeyAlgorithm, rsaProfile.KeySize = "RSA", 2048
+	violations := rsaProfile.ValidateRequest(req)
+	if len(violations) != 1 || violations[0].Field != "key" {
+		t.Errorf("ValidateRequest() = %v, want key violation", violations)
+	}
+	ecProfile := testValidationProfile()
+	ecProfile.KeyAlgorithm, ecProfile.KeySize = "ECDSA", 256
+	if violations := ecProfile.ValidateRequest(req); len(violations) != 0 {
+		t.Errorf("ValidateRequest() = %v, want none", violations)
+	}
+
+	req.CSR = "not a csr"
+	violations = testValidationProfile().ValidateRequest(req)
+	if len(violations) == 0 || violations[0].Field != "csr" {
+		t.Errorf("ValidateRequest() = %v, want csr violation", violations)
+	}
+}
+
+func TestProfilesService_ValidateRequest(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/profiles/profile-123" {
+			t.Errorf("Request path = %v, want %v", r.URL.Path, "/mpki/api/v1/profiles/profile-123")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(testValidationProfile())
+	}))
+	defer server.Close()
+
+	client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+	_, err := client.Profiles.ValidateRequest(ctx, "profile-123", &CertificateRequest{})
+	var validationErr *ProfileValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateRequest() error = %v, want *ProfileValidationError", err)
+	}
+	if validationErr.ProfileID != "profile-123" || len(validationErr.Violations) != 3 {
+		t.Errorf("ProfileValidationError = %+v, want 3 violations", validationErr)
+	}
+}
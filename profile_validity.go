@@ -0,0 +1,119 @@
+package digicert
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProfileValidityFixed is the ProfileValidity type of profiles that issue
+// every certificate with the profile's own validity.
+const ProfileValidityFixed = "fixed"
+
+// ValidityWindow is the range of end dates a profile accepts for a
+// certificate issued at Start.
+type ValidityWindow struct {
+	Start time.Time
+	// Earliest and Latest bound the end date, inclusive. The zero time means
+	// the profile sets no bound.
+	Earliest time.Time
+	Latest   time.Time
+	// Default is the end date given to requests without a validity, or the
+	// zero time if the profile has none.
+	Default time.Time
+	// Fixed reports that requests cannot choose their validity; only
+	// Default is accepted.
+	Fixed bool
+}
+
+// ValidityWindow returns the end dates the profile allows for a certificate
+// issued at start. Day counts are added as calendar days, so the bounds are
+// exact across month ends and leap years.
+func (p *Profile) ValidityWindow(start time.Time) ValidityWindow {
+	v := p.Validity
+	w := ValidityWindow{Start: start}
+	if v.Years > 0 || v.Months > 0 || v.Days > 0 {
+		w.Default = start.AddDate(v.Years, v.Months, v.Days)
+	}
+	if v.MinDays > 0 {
+		w.Earliest = start.AddDate(0, 0, v.MinDays)
+	}
+	if v.MaxDays > 0 {
+		w.Latest = start.AddDate(0, 0, v.MaxDays)
+	}
+	if strings.EqualFold(v.Type, ProfileValidityFixed) && !w.Default.IsZero() {
+		w.Fixed = true
+		w.Earliest, w.Latest = w.Default, w.Default
+	}
+	return w
+}
+
+// Contains reports whether the profile accepts end as a certificate's end
+// date. Dates are compared by calendar day in UTC, so an end date given
+// without a time is accepted on the last allowed day.
+func (w ValidityWindow) Contains(end time.Time) bool {
+	day := utcDay(end)
+	if w.Fixed {
+		return day.Equal(utcDay(w.Default))
+	}
+	if !w.Earliest.IsZero() && day.Before(utcDay(w.Earliest)) {
+		return false
+	}
+	if !w.Latest.IsZero() && day.After(utcDay(w.Latest)) {
+		return false
+	}
+	return true
+}
+
+// Validate checks a requested validity against the window. A nil or empty
+// validity is accepted, as the profile's default then applies.
+func (w ValidityWindow) Validate(v *Validity) error {
+	if v == nil || *v == (Validity{}) {
+		return nil
+	}
+	end, ok := v.End(w.Start)
+	if !ok {
+		return fmt.Errorf("end date %q is not a valid date", v.EndDate)
+	}
+	if w.Contains(end) {
+		return nil
+	}
+	switch {
+	case w.Fixed:
+		return fmt.Errorf("ends %s but the profile fixes the end date at %s", formatDay(end), formatDay(w.Default))
+	case !w.Earliest.IsZero() && utcDay(end).Before(utcDay(w.Earliest)):
+		return fmt.Errorf("ends %s, before the earliest end date of %s", formatDay(end), formatDay(w.Earliest))
+	default:
+		return fmt.Errorf("ends %s, after the latest end date of %s", formatDay(end), formatDay(w.Latest))
+	}
+}
+
+// End returns when a certificate with this validity, issued at start,
+// expires. It reports false if v sets no validity or its end date cannot
+// be parsed.
+func (v *Validity) End(start time.Time) (time.Time, bool) {
+	if v == nil {
+		return time.Time{}, false
+	}
+	if v.EndDate != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if end, err := time.Parse(layout, v.EndDate); err == nil {
+				return end, true
+			}
+		}
+		return time.Time{}, false
+	}
+	if v.Years == 0 && v.Months == 0 && v.Days == 0 {
+		return time.Time{}, false
+	}
+	return start.AddDate(v.Years, v.Months, v.Days), true
+}
+
+func utcDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
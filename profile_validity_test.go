@@ -0,0 +1,59 @@
+package digicert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProfile_ValidityWindow(t *testing.T) {
+	start := time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)
+
+	t.Run("range", func(t *testing.T) {
+		p := &Profile{Validity: ProfileValidity{Years: 1, MinDays: 30, MaxDays: 398}}
+		w := p.ValidityWindow(start)
+		if want := time.Date(2029, 3, 1, 12, 0, 0, 0, time.UTC); !w.Default.Equal(want) {
+			t.Errorf("Default = %v, want %v", w.Default, want)
+		}
+		if want := time.Date(2028, 3, 30, 12, 0, 0, 0, time.UTC); !w.Earliest.Equal(want) {
+			t.Errorf("Earliest = %v, want %v", w.Earliest, want)
+		}
+		if want := time.Date(2029, 4, 2, 12, 0, 0, 0, time.UTC); !w.Latest.Equal(want) {
+			t.Errorf("Latest = %v, want %v", w.Latest, want)
+		}
+
+		tests := []struct {
+			validity *Validity
+			wantErr  bool
+		}{
+			{nil, false},
+			{&Validity{}, false},
+			{&Validity{Days: 398}, false},
+			{&Validity{Days: 399}, true},
+			{&Validity{Days: 29}, true},
+			{&Validity{Months: 13}, false},
+			{&Validity{EndDate: "2029-04-02"}, false},
+			{&Validity{EndDate: "2029-04-03"}, true},
+			{&Validity{EndDate: "2028-03-30T00:00:00Z"}, false},
+			{&Validity{EndDate: "next year"}, true},
+		}
+		for _, tt := range tests {
+			if err := w.Validate(tt.validity); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%+v) error = %v, wantErr %v", tt.validity, err, tt.wantErr)
+			}
+		}
+	})
+
+	t.Run("fixed", func(t *testing.T) {
+		p := &Profile{Validity: ProfileValidity{Type: "FIXED", Days: 90, MaxDays: 398}}
+		w := p.ValidityWindow(start)
+		if !w.Fixed || !w.Latest.Equal(w.Default) {
+			t.Errorf("window = %+v, want fixed at the default", w)
+		}
+		if err := w.Validate(&Validity{Days: 90}); err != nil {
+			t.Errorf("Validate(90 days) error = %v", err)
+		}
+		if err := w.Validate(&Validity{Days: 60}); err == nil {
+			t.Error("Validate(60 days) expected error for fixed profile")
+		}
+	})
+}
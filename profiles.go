@@ -2,6 +2,7 @@ package digicert
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -20,7 +21,7 @@ type Profile struct {
 	EnrollmentMethod       string                 `json:"enrollment_method,omitempty"`
 	AuthenticationMethod   string                 `json:"authentication_method,omitempty"`
 	KeyAlgorithm           string                 `json:"key_algorithm,omitempty"`
-	KeySize                int                    `json:"key_size,omitempty"`
+	KeySize                FlexibleInt            `json:"key_size,omitempty"`
 	SignatureAlgorithm     string                 `json:"signature_algorithm,omitempty"`
 	Validity               ProfileValidity        `json:"validity,omitempty"`
 	SubjectDNFields        []DNField              `json:"subject_dn_fields,omitempty"`
@@ -29,12 +30,36 @@ type Profile struct {
 	CustomFields           []CustomFieldDef       `json:"custom_fields,omitempty"`
 	RequireApproval        bool                   `json:"require_approval,omitempty"`
 	AutoRenew              bool                   `json:"auto_renew,omitempty"`
+	RenewalWindowDays      FlexibleInt            `json:"renewal_window_days,omitempty"`
 	AllowDuplicateCN       bool                   `json:"allow_duplicate_cn,omitempty"`
 	Tags                   []string               `json:"tags,omitempty"`
 	CreatedAt              *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt              *time.Time             `json:"updated_at,omitempty"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	raw json.RawMessage
 }
 
+// UnmarshalJSON decodes a profile, keeping unmodelled fields in Extra
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	type alias Profile
+	extra, err := unmarshalWithExtra(data, (*alias)(p))
+	if err != nil {
+		return err
+	}
+	p.Extra = extra
+	p.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Raw returns the JSON the Profile was decoded from, or nil if it was not
+// decoded from a response
+func (p *Profile) Raw() json.RawMessage { return p.raw }
+
+func (p *Profile) unknownFields() map[string]json.RawMessage { return p.Extra }
+
 type ProfileValidity struct {
 	Type    string `json:"type,omitempty"`
 	Years   int    `json:"years,omitempty"`
@@ -99,6 +124,30 @@ type ProfileTemplate struct {
 	Provider    string `json:"provider"`
 }
 
+type SCEPEnrollmentInfo struct {
+	ProfileID          string     `json:"profile_id,omitempty"`
+	URL                string     `json:"url"`
+	ChallengePassword  string     `json:"challenge_password,omitempty"`
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+	CACapabilities     []string   `json:"ca_capabilities,omitempty"`
+}
+
+type ESTEnrollmentInfo struct {
+	ProfileID            string   `json:"profile_id,omitempty"`
+	URL                  string   `json:"url"`
+	Label                string   `json:"label,omitempty"`
+	AuthenticationMethod string   `json:"authentication_method,omitempty"`
+	Username             string   `json:"username,omitempty"`
+	Password             string   `json:"password,omitempty"`
+	CACapabilities       []string `json:"ca_capabilities,omitempty"`
+}
+
+type SCEPChallengeRequest struct {
+	// ValidityMinutes is how long the new challenge password remains valid.
+	// Zero uses the profile default.
+	ValidityMinutes int `json:"validity_minutes,omitempty"`
+}
+
 // List lists certificate profiles
 func (s *ProfilesService) List(ctx context.Context, opts *ProfileListOptions) (*ProfileListResponse, *Response, error) {
 	u := "profiles"
@@ -197,4 +246,61 @@ func (s *ProfilesService) ListTemplates(ctx context.Context) (*ProfileTemplateLi
 	}
 
 	return &result, resp, nil
-}
\ No newline at end of file
+}
+
+// GetSCEPEnrollment retrieves the SCEP enrollment URL, challenge password and CA
+// capabilities for a profile
+func (s *ProfilesService) GetSCEPEnrollment(ctx context.Context, profileID string) (*SCEPEnrollmentInfo, *Response, error) {
+	u := fmt.Sprintf("profiles/%s/scep", profileID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var info SCEPEnrollmentInfo
+	resp, err := s.client.Do(ctx, httpReq, &info)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &info, resp, nil
+}
+
+// RotateSCEPChallenge replaces the SCEP challenge password for a profile and
+// returns the updated enrollment details
+func (s *ProfilesService) RotateSCEPChallenge(ctx context.Context, profileID string, req *SCEPChallengeRequest) (*SCEPEnrollmentInfo, *Response, error) {
+	u := fmt.Sprintf("profiles/%s/scep/challenge", profileID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var info SCEPEnrollmentInfo
+	resp, err := s.client.Do(ctx, httpReq, &info)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &info, resp, nil
+}
+
+// GetESTEnrollment retrieves the EST enrollment URL, credentials and CA
+// capabilities for a profile using the EST enrollment method
+func (s *ProfilesService) GetESTEnrollment(ctx context.Context, profileID string) (*ESTEnrollmentInfo, *Response, error) {
+	u := fmt.Sprintf("profiles/%s/est", profileID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var info ESTEnrollmentInfo
+	resp, err := s.client.Do(ctx, httpReq, &info)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &info, resp, nil
+}
@@ -589,4 +589,98 @@ func TestProfileResponseValidation(t *testing.T) {
 	if profile.UpdatedAt == nil {
 		t.Error("UpdatedAt should not be nil")
 	}
-}
\ No newline at end of file
+}
+func TestProfilesService_EnrollmentMetadata(t *testing.T) {
+	client, _ := NewClient("test-key")
+	ctx := context.Background()
+
+	t.Run("get SCEP enrollment", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/profiles/profile-123/scep" {
+				t.Errorf("Expected path /mpki/api/v1/profiles/profile-123/scep, got %s", r.URL.Path)
+			}
+			if r.Method != http.MethodGet {
+				t.Errorf("Expected GET request, got %s", r.Method)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(SCEPEnrollmentInfo{
+				URL:               "https://one.digicert.com/mpki/api/v1/scep/profile-123",
+				ChallengePassword: "challenge-1",
+				CACapabilities:    []string{"POSTPKIOperation", "SHA-256", "Renewal"},
+			})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		info, _, err := client.Profiles.GetSCEPEnrollment(ctx, "profile-123")
+		if err != nil {
+			t.Fatalf("GetSCEPEnrollment() error = %v", err)
+		}
+		if info.ChallengePassword != "challenge-1" {
+			t.Errorf("ChallengePassword = %v, want %v", info.ChallengePassword, "challenge-1")
+		}
+		if len(info.CACapabilities) != 3 {
+			t.Errorf("CACapabilities length = %v, want %v", len(info.CACapabilities), 3)
+		}
+	})
+
+	t.Run("rotate SCEP challenge", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/profiles/profile-123/scep/challenge" {
+				t.Errorf("Expected path /mpki/api/v1/profiles/profile-123/scep/challenge, got %s", r.URL.Path)
+			}
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST request, got %s", r.Method)
+			}
+
+			var req SCEPChallengeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if req.ValidityMinutes != 60 {
+				t.Errorf("ValidityMinutes = %v, want %v", req.ValidityMinutes, 60)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(SCEPEnrollmentInfo{ChallengePassword: "challenge-2"})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		info, _, err := client.Profiles.RotateSCEPChallenge(ctx, "profile-123", &SCEPChallengeRequest{ValidityMinutes: 60})
+		if err != nil {
+			t.Fatalf("RotateSCEPChallenge() error = %v", err)
+		}
+		if info.ChallengePassword != "challenge-2" {
+			t.Errorf("ChallengePassword = %v, want %v", info.ChallengePassword, "challenge-2")
+		}
+	})
+
+	t.Run("get EST enrollment", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mpki/api/v1/profiles/profile-123/est" {
+				t.Errorf("Expected path /mpki/api/v1/profiles/profile-123/est, got %s", r.URL.Path)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ESTEnrollmentInfo{
+				URL:                  "https://one.digicert.com/.well-known/est/profile-123",
+				AuthenticationMethod: "HTTP_BASIC",
+			})
+		}))
+		defer server.Close()
+
+		client.BaseURL, _ = client.BaseURL.Parse(server.URL + "/")
+
+		info, _, err := client.Profiles.GetESTEnrollment(ctx, "profile-123")
+		if err != nil {
+			t.Fatalf("GetESTEnrollment() error = %v", err)
+		}
+		if info.AuthenticationMethod != "HTTP_BASIC" {
+			t.Errorf("AuthenticationMethod = %v, want %v", info.AuthenticationMethod, "HTTP_BASIC")
+		}
+	})
+}
@@ -0,0 +1,266 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultReconcileRenewBefore      = 30 * 24 * time.Hour
+	DefaultReconcileRevocationReason = "cessation_of_operation"
+)
+
+// DesiredCertificate declares a certificate that should exist. Certificates
+// are matched to existing ones by common name, case-insensitively.
+type DesiredCertificate struct {
+	CommonName string           `json:"common_name"`
+	SANs       *SubjectAltNames `json:"sans,omitempty"`
+	ProfileID  string           `json:"profile_id"`
+	OwnerIDs   []string         `json:"owner_ids,omitempty"`
+	Tags       []string         `json:"tags,omitempty"`
+	// CSR is sent when issuing. Profiles that generate keys server side can
+	// leave it empty.
+	CSR string `json:"csr,omitempty"`
+}
+
+type ReconcileOptions struct {
+	// ManagedTag marks the certificates the reconciler owns. It is added to
+	// every certificate issued, and only certificates carrying it are renewed
+	// or revoked. Required.
+	ManagedTag string
+	// RenewBefore renews certificates expiring within this window. Defaults
+	// to DefaultReconcileRenewBefore.
+	RenewBefore time.Duration
+	// Prune revokes managed certificates whose common name is no longer
+	// desired. Without it they are only reported.
+	Prune bool
+	// RevocationReason is used when pruning. Defaults to
+	// DefaultReconcileRevocationReason.
+	RevocationReason string
+	// DryRun plans the actions without performing them.
+	DryRun bool
+	// Now overrides the current time, for tests.
+	Now func() time.Time
+}
+
+type ReconcileActionType string
+
+const (
+	ReconcileIssue  ReconcileActionType = "issue"
+	ReconcileRenew  ReconcileActionType = "renew"
+	ReconcileRevoke ReconcileActionType = "revoke"
+	// ReconcileOrphan reports a managed certificate that is no longer desired
+	// but was kept because Prune is off.
+	ReconcileOrphan ReconcileActionType = "orphan"
+)
+
+type ReconcileAction struct {
+	Type       ReconcileActionType `json:"type"`
+	CommonName string              `json:"common_name"`
+	// SerialNumber is the existing certificate renewed or revoked.
+	SerialNumber string `json:"serial_number,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	// Result is the issued or renewed certificate.
+	Result *CertificateResponse `json:"result,omitempty"`
+	Err    error                `json:"-"`
+}
+
+// ReconcileReport lists the actions taken, or planned in a dry run, in the
+// order issue, renew, revoke, orphan.
+type ReconcileReport struct {
+	Actions []ReconcileAction `json:"actions"`
+	// Unchanged holds the common names already in the desired state.
+	Unchanged []string `json:"unchanged"`
+	DryRun    bool     `json:"dry_run"`
+}
+
+// Diff renders the report one action per line, prefixed + for issue, ~ for
+// renew, - for revoke and ? for an orphan.
+func (r *ReconcileReport) Diff() string {
+	prefixes := map[ReconcileActionType]string{
+		ReconcileIssue:  "+",
+		ReconcileRenew:  "~",
+		ReconcileRevoke: "-",
+		ReconcileOrphan: "?",
+	}
+	var b strings.Builder
+	for _, a := range r.Actions {
+		fmt.Fprintf(&b, "%s %s %s", prefixes[a.Type], a.Type, a.CommonName)
+		if a.SerialNumber != "" {
+			fmt.Fprintf(&b, " (%s)", a.SerialNumber)
+		}
+		if a.Reason != "" {
+			fmt.Fprintf(&b, ": %s", a.Reason)
+		}
+		if a.Err != nil {
+			fmt.Fprintf(&b, " FAILED: %v", a.Err)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Err joins the errors of every failed action, or returns nil.
+func (r *ReconcileReport) Err() error {
+	var errs []error
+	for _, a := range r.Actions {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", a.Type, a.CommonName, a.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reconcile converges the certificates tagged with opts.ManagedTag on the
+// desired inventory: it issues certificates that are missing, renews those
+// expiring within RenewBefore, reissues those whose profile changed and, with
+// Prune, revokes those no longer desired. When several active certificates
+// share a common name only the one expiring last is considered, so
+// certificates superseded by a renewal are left to expire.
+//
+// Failed actions are recorded in the report rather than stopping the run;
+// check ReconcileReport.Err. The returned error is set only if the inventory
+// could not be read.
+func (s *CertificatesService) Reconcile(ctx context.Context, desired []DesiredCertificate, opts *ReconcileOptions) (*ReconcileReport, error) {
+	if opts == nil || opts.ManagedTag == "" {
+		return nil, errors.New("reconcile: ManagedTag is required")
+	}
+	renewBefore := opts.RenewBefore
+	if renewBefore <= 0 {
+		renewBefore = DefaultReconcileRenewBefore
+	}
+	reason := opts.RevocationReason
+	if reason == "" {
+		reason = DefaultReconcileRevocationReason
+	}
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	wanted := make(map[string]DesiredCertificate, len(desired))
+	for _, d := range desired {
+		if d.CommonName == "" {
+			return nil, errors.New("reconcile: desired certificate has no common name")
+		}
+		key := strings.ToLower(d.CommonName)
+		if _, dup := wanted[key]; dup {
+			return nil, fmt.Errorf("reconcile: duplicate desired certificate %q", d.CommonName)
+		}
+		wanted[key] = d
+	}
+
+	current, err := s.managedCertificates(ctx, opts.ManagedTag)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{DryRun: opts.DryRun}
+	var issue, renew, revoke []ReconcileAction
+	for _, key := range sortedKeys(wanted) {
+		d := wanted[key]
+		existing, ok := current[key]
+		switch {
+		case !ok:
+			issue = append(issue, ReconcileAction{Type: ReconcileIssue, CommonName: d.CommonName, Reason: "missing"})
+		case d.ProfileID != "" && existing.Profile.ID != "" && existing.Profile.ID != d.ProfileID:
+			issue = append(issue, ReconcileAction{
+				Type:         ReconcileIssue,
+				CommonName:   d.CommonName,
+				SerialNumber: existing.SerialNumber,
+				Reason:       fmt.Sprintf("profile changed from %s", existing.Profile.ID),
+			})
+		default:
+			expires, err := time.Parse(time.RFC3339, existing.ValidTo)
+			if err != nil || expires.Sub(now) < renewBefore {
+				action := ReconcileAction{Type: ReconcileRenew, CommonName: d.CommonName, SerialNumber: existing.SerialNumber}
+				if err != nil {
+					action.Reason = fmt.Sprintf("unknown expiry %q", existing.ValidTo)
+				} else {
+					action.Reason = "expires " + expires.UTC().Format(time.RFC3339)
+				}
+				renew = append(renew, action)
+			} else {
+				report.Unchanged = append(report.Unchanged, d.CommonName)
+			}
+		}
+	}
+	for _, key := range sortedKeys(current) {
+		if _, ok := wanted[key]; ok {
+			continue
+		}
+		c := current[key]
+		action := ReconcileAction{Type: ReconcileOrphan, CommonName: c.CommonName, SerialNumber: c.SerialNumber, Reason: "no longer desired"}
+		if opts.Prune {
+			action.Type = ReconcileRevoke
+		}
+		revoke = append(revoke, action)
+	}
+
+	for _, actions := range [][]ReconcileAction{issue, renew, revoke} {
+		for i := range actions {
+			a := &actions[i]
+			if opts.DryRun {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				a.Err = err
+				continue
+			}
+			d := wanted[strings.ToLower(a.CommonName)]
+			switch a.Type {
+			case ReconcileIssue:
+				a.Result, _, a.Err = s.Issue(ctx, &CertificateRequest{
+					Profile:        ProfileReference{ID: d.ProfileID},
+					CSR:            d.CSR,
+					IncludeCAChain: true,
+					Attributes:     &CertificateAttributes{CommonName: d.CommonName, SANs: d.SANs},
+					Tags:           withTag(d.Tags, opts.ManagedTag),
+					CertOwnerIDs:   d.OwnerIDs,
+				})
+			case ReconcileRenew:
+				a.Result, _, a.Err = s.Renew(ctx, a.SerialNumber, &RenewRequest{
+					CSR:            d.CSR,
+					IncludeCAChain: true,
+					Attributes:     &CertificateAttributes{CommonName: d.CommonName, SANs: d.SANs},
+					Tags:           withTag(d.Tags, opts.ManagedTag),
+				})
+			case ReconcileRevoke:
+				_, a.Err = s.Revoke(ctx, a.SerialNumber, &RevokeRequest{Reason: reason, Comment: "no longer in desired inventory"})
+			}
+		}
+		report.Actions = append(report.Actions, actions...)
+	}
+	return report, nil
+}
+
+// managedCertificates returns the active certificates tagged tag, keyed by
+// lower-cased common name, keeping the one expiring last for each name.
+func (s *CertificatesService) managedCertificates(ctx context.Context, tag string) (map[string]Certificate, error) {
+	current := make(map[string]Certificate)
+	err := s.searchAll(ctx, &CertificateSearchOptions{Tags: []string{tag}}, func(c Certificate) {
+		switch strings.ToLower(c.Status) {
+		case "revoked", "expired":
+			return
+		}
+		key := strings.ToLower(c.CommonName)
+		// RFC 3339 timestamps in the same zone sort lexically.
+		if prev, ok := current[key]; !ok || c.ValidTo > prev.ValidTo {
+			current[key] = c
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reconcile: list managed certificates: %w", err)
+	}
+	return current, nil
+}
+
+func withTag(tags []string, tag string) []string {
+	if containsString(tags, tag) {
+		return tags
+	}
+	return append(append([]string(nil), tags...), tag)
+}
@@ -0,0 +1,118 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCertificatesService_Reconcile(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/mpki/api/v1/"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/mpki/api/v1/certificate-search":
+			if r.URL.Query().Get("tags") != "gitops" {
+				t.Errorf("tags = %v, want gitops", r.URL.Query().Get("tags"))
+			}
+			w.Write([]byte(`{"total": 6, "items": [
+				{"serial_number": "01", "common_name": "fresh.example.com", "status": "issued", "profile": {"id": "p1"}, "valid_to": "2027-01-01T00:00:00Z"},
+				{"serial_number": "02", "common_name": "expiring.example.com", "status": "issued", "profile": {"id": "p1"}, "valid_to": "2026-10-20T00:00:00Z"},
+				{"serial_number": "03", "common_name": "Renewed.example.com", "status": "issued", "profile": {"id": "p1"}, "valid_to": "2026-10-20T00:00:00Z"},
+				{"serial_number": "04", "common_name": "renewed.example.com", "status": "issued", "profile": {"id": "p1"}, "valid_to": "2027-10-20T00:00:00Z"},
+				{"serial_number": "05", "common_name": "moved.example.com", "status": "issued", "profile": {"id": "p0"}, "valid_to": "2027-01-01T00:00:00Z"},
+				{"serial_number": "06", "common_name": "old.example.com", "status": "issued", "profile": {"id": "p1"}, "valid_to": "2027-01-01T00:00:00Z"}
+			]}`))
+		case r.URL.Path == "/mpki/api/v1/certificate":
+			var req CertificateRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if !containsString(req.Tags, "gitops") || !containsString(req.Tags, "web") {
+				t.Errorf("Tags = %v, want web and gitops", req.Tags)
+			}
+			w.Write([]byte(`{"certificate": {"serial_number": "10"}}`))
+		case strings.HasSuffix(r.URL.Path, "/renew"):
+			w.Write([]byte(`{"certificate": {"serial_number": "11"}}`))
+		case strings.HasSuffix(r.URL.Path, "/revoke"):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	desired := []DesiredCertificate{
+		{CommonName: "fresh.example.com", ProfileID: "p1"},
+		{CommonName: "expiring.example.com", ProfileID: "p1"},
+		{CommonName: "renewed.example.com", ProfileID: "p1"},
+		{CommonName: "moved.example.com", ProfileID: "p1", Tags: []string{"web"}},
+		{CommonName: "new.example.com", ProfileID: "p1", Tags: []string{"web"}},
+	}
+	opts := &ReconcileOptions{
+		ManagedTag: "gitops",
+		DryRun:     true,
+		Now:        func() time.Time { return time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC) },
+	}
+
+	report, err := client.Certificates.Reconcile(context.Background(), desired, opts)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	wantDiff := "+ issue moved.example.com (05): profile changed from p0\n" +
+		"+ issue new.example.com: missing\n" +
+		"~ renew expiring.example.com (02): expires 2026-10-20T00:00:00Z\n" +
+		"? orphan old.example.com (06): no longer desired\n"
+	if got := report.Diff(); got != wantDiff {
+		t.Errorf("Diff() = %q, want %q", got, wantDiff)
+	}
+	if strings.Join(report.Unchanged, ",") != "fresh.example.com,renewed.example.com" {
+		t.Errorf("Unchanged = %v, want fresh and renewed", report.Unchanged)
+	}
+	if len(calls) != 1 {
+		t.Errorf("dry run calls = %v, want only the search", calls)
+	}
+
+	calls = nil
+	opts.DryRun = false
+	opts.Prune = true
+	report, err = client.Certificates.Reconcile(context.Background(), desired, opts)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := report.Err(); err != nil {
+		t.Errorf("report.Err() = %v", err)
+	}
+	want := []string{
+		"GET certificate-search",
+		"POST certificate",
+		"POST certificate",
+		"POST certificate/02/renew",
+		"PUT certificate/06/revoke",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if report.Actions[2].Result == nil || report.Actions[2].Result.Certificate.SerialNumber != "11" {
+		t.Errorf("renew Result = %+v, want serial 11", report.Actions[2].Result)
+	}
+
+	if _, err := client.Certificates.Reconcile(context.Background(), desired, &ReconcileOptions{}); err == nil {
+		t.Error("Expected error without ManagedTag")
+	}
+	dup := append(desired, DesiredCertificate{CommonName: "NEW.example.com"})
+	if _, err := client.Certificates.Reconcile(context.Background(), dup, opts); err == nil {
+		t.Error("Expected error for duplicate desired certificate")
+	}
+}
@@ -0,0 +1,124 @@
+package digicert
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// RedactionPolicy lists the JSON fields and HTTP headers whose values are
+// replaced with "[REDACTED]" before request and response details are
+// logged or attached to errors. Names match case-insensitively, at any
+// depth. Objects under a listed field are searched rather than hidden, so
+// "certificate" hides a PEM string but not certificate metadata.
+type RedactionPolicy struct {
+	Fields  []string
+	Headers []string
+}
+
+// DefaultRedactionPolicy covers the key material, CSRs, certificates,
+// enrollment secrets and credentials this package sends and receives.
+func DefaultRedactionPolicy() *RedactionPolicy {
+	return &RedactionPolicy{
+		Fields: []string{
+			"csr", "certificate", "private_key", "enrollment_code",
+			"password", "challenge_password", "token",
+		},
+		Headers: []string{
+			"X-API-Key", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie",
+			SignatureHeader,
+		},
+	}
+}
+
+// With returns a copy of p that also redacts the given field names.
+func (p *RedactionPolicy) With(fields ...string) *RedactionPolicy {
+	out := &RedactionPolicy{}
+	if p != nil {
+		out.Fields = append(out.Fields, p.Fields...)
+		out.Headers = append(out.Headers, p.Headers...)
+	}
+	out.Fields = append(out.Fields, fields...)
+	return out
+}
+
+// WithHeaders returns a copy of p that also redacts the given headers.
+func (p *RedactionPolicy) WithHeaders(headers ...string) *RedactionPolicy {
+	out := p.With()
+	out.Headers = append(out.Headers, headers...)
+	return out
+}
+
+var pemBlockPattern = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]+-----[\s\S]*?-----END [A-Z0-9 ]+-----`)
+
+// RedactJSON returns body with the policy's fields redacted. PEM blocks are
+// redacted wherever they appear, including in bodies that are not JSON.
+func (p *RedactionPolicy) RedactJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return pemBlockPattern.ReplaceAll(body, []byte(redacted))
+	}
+	out, err := json.Marshal(p.redactValue(v))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return out
+}
+
+func (p *RedactionPolicy) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if _, isObject := child.(map[string]interface{}); !isObject && p.redactsField(k) && child != nil {
+				v[k] = redacted
+				continue
+			}
+			v[k] = p.redactValue(child)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = p.redactValue(v[i])
+		}
+		return v
+	case string:
+		return pemBlockPattern.ReplaceAllString(v, redacted)
+	}
+	return v
+}
+
+func (p *RedactionPolicy) redactsField(name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, f := range p.Fields {
+		if strings.EqualFold(f, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactHeader returns a copy of h with the policy's headers redacted.
+func (p *RedactionPolicy) RedactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	if p == nil {
+		return out
+	}
+	for name := range out {
+		for _, sensitive := range p.Headers {
+			if strings.EqualFold(name, sensitive) {
+				out[name] = []string{redacted}
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// BusinessUnitRef is a business unit nested in another object, such as
+// Certificate.BusinessUnit, where the API returns only its ID and name
+// unless the request asked for ExpandBusinessUnit. Use
+// BusinessUnitsService.Expand to get the full BusinessUnit.
+type BusinessUnitRef struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Expanded holds the full business unit when the API returned it inline.
+	Expanded *BusinessUnit `json:"-"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a business unit reference, keeping unmodelled fields
+// in Extra
+func (r *BusinessUnitRef) UnmarshalJSON(data []byte) error {
+	type alias BusinessUnitRef
+	extra, err := unmarshalWithExtra(data, (*alias)(r))
+	if err != nil {
+		return err
+	}
+	if len(extra) == 0 {
+		r.Extra = nil
+		return nil
+	}
+
+	var bu BusinessUnit
+	if err := json.Unmarshal(data, &bu); err != nil {
+		return err
+	}
+	r.Expanded = &bu
+	r.Extra = bu.Extra
+	return nil
+}
+
+func (r *BusinessUnitRef) unknownFields() map[string]json.RawMessage { return r.Extra }
+
+// ProfileRef is a profile nested in another object, such as
+// Certificate.Profile, where the API returns only its ID and name unless
+// the request asked for ExpandProfile. Use ProfilesService.Expand to get the
+// full Profile. Requests refer to profiles with ProfileReference.
+type ProfileRef struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Expanded holds the full profile when the API returned it inline.
+	Expanded *Profile `json:"-"`
+
+	// Extra holds response fields this struct does not model yet.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a profile reference, keeping unmodelled fields in
+// Extra
+func (r *ProfileRef) UnmarshalJSON(data []byte) error {
+	type alias ProfileRef
+	extra, err := unmarshalWithExtra(data, (*alias)(r))
+	if err != nil {
+		return err
+	}
+	if len(extra) == 0 {
+		r.Extra = nil
+		return nil
+	}
+
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	r.Expanded = &p
+	r.Extra = p.Extra
+	return nil
+}
+
+func (r *ProfileRef) unknownFields() map[string]json.RawMessage { return r.Extra }
+
+// Expand returns the full business unit a reference points to, fetching it
+// unless it was returned inline, in which case the Response is nil
+func (s *BusinessUnitsService) Expand(ctx context.Context, ref *BusinessUnitRef) (*BusinessUnit, *Response, error) {
+	if ref != nil && ref.Expanded != nil {
+		return ref.Expanded, nil, nil
+	}
+	if ref == nil || ref.ID == "" {
+		return nil, nil, errors.New("digicert: business unit reference has no ID")
+	}
+	return s.Get(ctx, ref.ID)
+}
+
+// Expand returns the full profile a reference points to, fetching it unless
+// it was returned inline, in which case the Response is nil
+func (s *ProfilesService) Expand(ctx context.Context, ref *ProfileRef) (*Profile, *Response, error) {
+	if ref != nil && ref.Expanded != nil {
+		return ref.Expanded, nil, nil
+	}
+	if ref == nil || ref.ID == "" {
+		return nil, nil, errors.New("digicert: profile reference has no ID")
+	}
+	return s.Get(ctx, ref.ID)
+}
@@ -0,0 +1,109 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCertificate_NestedReferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate/0A":
+			w.Write([]byte(`{"id": "cert-1", "profile": {"id": "p-1", "name": "Web TLS"}, "business_unit": {"id": "bu-1", "name": "Retail"}}`))
+		case "/mpki/api/v1/business-unit/bu-1":
+			json.NewEncoder(w).Encode(BusinessUnit{ID: "bu-1", Name: "Retail", LicensedSeats: 50})
+		case "/mpki/api/v1/profiles/p-1":
+			w.Write([]byte(`{"id": "p-1", "name": "Web TLS", "status": "active"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	cert, _, err := client.Certificates.Get(ctx, "0A")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if cert.Profile.Name != "Web TLS" || cert.BusinessUnit.Name != "Retail" {
+		t.Errorf("references = %+v, %+v, want names decoded", cert.Profile, cert.BusinessUnit)
+	}
+
+	bu, _, err := client.BusinessUnits.Expand(ctx, cert.BusinessUnit)
+	if err != nil {
+		t.Fatalf("BusinessUnits.Expand() error = %v", err)
+	}
+	if bu.LicensedSeats != 50 {
+		t.Errorf("LicensedSeats = %v, want 50", bu.LicensedSeats)
+	}
+
+	profile, _, err := client.Profiles.Expand(ctx, &cert.Profile)
+	if err != nil {
+		t.Fatalf("Profiles.Expand() error = %v", err)
+	}
+	if profile.Status != "active" {
+		t.Errorf("Status = %v, want active", profile.Status)
+	}
+
+	if _, _, err := client.BusinessUnits.Expand(ctx, nil); err == nil {
+		t.Error("BusinessUnits.Expand(nil) error = nil, want error")
+	}
+	if _, _, err := client.Profiles.Expand(ctx, &ProfileRef{}); err == nil {
+		t.Error("Profiles.Expand(empty) error = nil, want error")
+	}
+}
+
+func TestCertificatesService_Expand(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate/0A":
+			if got := r.URL.Query().Get("expand"); got != "profile,business_unit" {
+				t.Errorf("expand = %q, want profile,business_unit", got)
+			}
+			w.Write([]byte(`{"id": "cert-1",
+				"profile": {"id": "p-1", "name": "Web TLS", "status": "active"},
+				"business_unit": {"id": "bu-1", "name": "Retail", "licensed_seats": 50}}`))
+		case "/mpki/api/v1/certificate-search":
+			if got := r.URL.Query().Get("expand"); got != "profile" {
+				t.Errorf("expand = %q, want profile", got)
+			}
+			w.Write([]byte(`{"total": 1, "items": [{"id": "cert-1", "profile": {"id": "p-1", "name": "Web TLS", "status": "active"}}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	cert, _, err := client.Certificates.GetWithOptions(ctx, "0A", &CertificateGetOptions{Expand: []string{ExpandProfile, ExpandBusinessUnit}})
+	if err != nil {
+		t.Fatalf("GetWithOptions() error = %v", err)
+	}
+	if cert.Profile.Expanded == nil || cert.Profile.Expanded.Status != "active" || cert.Profile.Extra != nil {
+		t.Errorf("Profile = %+v, want the expanded profile", cert.Profile)
+	}
+	bu, resp, err := client.BusinessUnits.Expand(ctx, cert.BusinessUnit)
+	if err != nil || resp != nil || bu.LicensedSeats != 50 {
+		t.Errorf("Expand() = %+v, %v, %v, want the inline business unit without a request", bu, resp, err)
+	}
+
+	result, _, err := client.Certificates.Search(ctx, &CertificateSearchOptions{Expand: []string{ExpandProfile}})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if p := result.Items[0].Profile.Expanded; p == nil || p.Status != "active" {
+		t.Errorf("Profile.Expanded = %+v, want the inline profile", p)
+	}
+	if len(requests) != 2 {
+		t.Errorf("requests = %v, want only the Get and Search", requests)
+	}
+}
@@ -0,0 +1,134 @@
+package digicert
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type ReKeyRequest struct {
+	CSR            string    `json:"csr"`
+	Validity       *Validity `json:"validity,omitempty"`
+	IncludeCAChain bool      `json:"include_ca_chain,omitempty"`
+}
+
+// ReKey issues a replacement for a certificate on the key in csr, keeping
+// the certificate's profile, subject and SANs
+func (s *CertificatesService) ReKey(ctx context.Context, serialNumber string, csr string) (*CertificateResponse, *Response, error) {
+	if err := s.client.requireCapability("ReKey", CapabilityIssueCertificates); err != nil {
+		return nil, nil, err
+	}
+	if csr == "" {
+		return nil, nil, errors.New("digicert: ReKey requires a CSR")
+	}
+
+	u := fmt.Sprintf("certificate/%s/rekey", serialNumber)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, &ReKeyRequest{CSR: csr, IncludeCAChain: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.client.setIdempotencyKey(ctx, httpReq); err != nil {
+		return nil, nil, err
+	}
+
+	var cert CertificateResponse
+	resp, err := s.client.Do(ctx, httpReq, &cert)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &cert, resp, nil
+}
+
+// ReKeyWithKey re-keys a certificate onto key, creating the CSR from the
+// subject and SANs of the existing certificate.
+func (s *CertificatesService) ReKeyWithKey(ctx context.Context, serialNumber string, key crypto.Signer) (*CertificateResponse, *Response, error) {
+	old, resp, err := s.Get(ctx, serialNumber)
+	if err != nil {
+		return nil, resp, err
+	}
+	attrs, err := AttributesFromCertificate(old)
+	if err != nil {
+		return nil, nil, err
+	}
+	csr, err := CreateCSR(key, attrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.ReKey(ctx, serialNumber, csr)
+}
+
+// AttributesFromCertificate returns the subject and SANs of an issued
+// certificate as CertificateAttributes, read from its PEM.
+func AttributesFromCertificate(c *Certificate) (*CertificateAttributes, error) {
+	if c == nil || c.Certificate == "" {
+		return nil, errors.New("digicert: certificate PEM is required to copy its subject")
+	}
+	cert, err := parseCertificatePEM(c.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("digicert: invalid certificate: %w", err)
+	}
+	return attributesFromX509(cert), nil
+}
+
+func attributesFromX509(cert *x509.Certificate) *CertificateAttributes {
+	attrs := &CertificateAttributes{
+		CommonName:         cert.Subject.CommonName,
+		OrganizationalUnit: cert.Subject.OrganizationalUnit,
+		Organization:       first(cert.Subject.Organization),
+		Country:            first(cert.Subject.Country),
+		State:              first(cert.Subject.Province),
+		Locality:           first(cert.Subject.Locality),
+	}
+	sans := &SubjectAltNames{DNSNames: cert.DNSNames, Emails: cert.EmailAddresses}
+	for _, ip := range cert.IPAddresses {
+		sans.IPAddresses = append(sans.IPAddresses, ip.String())
+	}
+	for _, u := range cert.URIs {
+		sans.URIs = append(sans.URIs, u.String())
+	}
+	if len(sans.DNSNames)+len(sans.Emails)+len(sans.IPAddresses)+len(sans.URIs) > 0 {
+		attrs.SANs = sans
+	}
+	return attrs
+}
+
+// LikeForLikeRequest builds an issuance request that reproduces an existing
+// certificate on a new key: the same profile, business unit, subject and
+// SANs, with a CSR signed by key. Use it where ReKey is not available, or to
+// adjust the request before issuing.
+func LikeForLikeRequest(old *Certificate, key crypto.Signer) (*CertificateRequest, error) {
+	attrs, err := AttributesFromCertificate(old)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := CreateCSR(key, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &CertificateRequest{
+		Profile:        ProfileReference{ID: old.Profile.ID},
+		CSR:            csr,
+		IncludeCAChain: true,
+		Attributes:     attrs,
+	}
+	if old.BusinessUnit != nil {
+		req.BusinessUnitID = old.BusinessUnit.ID
+	}
+	if old.Seat != nil && old.Seat.SeatID != "" {
+		req.Seat = &SeatReference{SeatID: old.Seat.SeatID}
+	}
+	return req, nil
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,107 @@
+package digicert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCertificatesService_ReKey(t *testing.T) {
+	old := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "www.example.com", Organization: []string{"Example Inc"}, Country: []string{"GB"}},
+		DNSNames:    []string{"www.example.com", "example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(time.Hour),
+	}, nil)
+
+	var gotCSR *x509.CertificateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/mpki/api/v1/certificate/0A1B":
+			json.NewEncoder(w).Encode(Certificate{SerialNumber: "0A1B", Certificate: old.pem})
+		case r.Method == http.MethodPost && r.URL.Path == "/mpki/api/v1/certificate/0A1B/rekey":
+			var req ReKeyRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			block, _ := pem.Decode([]byte(req.CSR))
+			if block != nil {
+				gotCSR, _ = x509.ParseCertificateRequest(block.Bytes)
+			}
+			json.NewEncoder(w).Encode(CertificateResponse{Certificate: &Certificate{SerialNumber: "0C1D"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	result, _, err := client.Certificates.ReKeyWithKey(context.Background(), "0A1B", key)
+	if err != nil {
+		t.Fatalf("ReKeyWithKey() error = %v", err)
+	}
+	if result.Certificate.SerialNumber != "0C1D" {
+		t.Errorf("SerialNumber = %v, want 0C1D", result.Certificate.SerialNumber)
+	}
+	if gotCSR == nil {
+		t.Fatal("rekey request carried no valid CSR")
+	}
+	if gotCSR.Subject.CommonName != "www.example.com" || gotCSR.Subject.Organization[0] != "Example Inc" {
+		t.Errorf("CSR subject = %v, want the old certificate's subject", gotCSR.Subject)
+	}
+	if len(gotCSR.DNSNames) != 2 || len(gotCSR.IPAddresses) != 1 {
+		t.Errorf("CSR SANs = %v %v, want the old certificate's SANs", gotCSR.DNSNames, gotCSR.IPAddresses)
+	}
+	if !gotCSR.PublicKey.(*ecdsa.PublicKey).Equal(&key.PublicKey) {
+		t.Error("CSR is not for the new key")
+	}
+
+	if _, _, err := client.Certificates.ReKey(context.Background(), "0A1B", ""); err == nil {
+		t.Error("Expected error for empty CSR")
+	}
+}
+
+func TestLikeForLikeRequest(t *testing.T) {
+	old := newTestCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "api.example.com"},
+		DNSNames:  []string{"api.example.com"},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+	}, nil)
+	key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+	req, err := LikeForLikeRequest(&Certificate{
+		Certificate:  old.pem,
+		Profile:      ProfileRef{ID: "profile-1"},
+		BusinessUnit: &BusinessUnitRef{ID: "bu-1"},
+		Seat:         &Seat{SeatID: "api.example.com"},
+	}, key)
+	if err != nil {
+		t.Fatalf("LikeForLikeRequest() error = %v", err)
+	}
+	if req.Profile.ID != "profile-1" || req.BusinessUnitID != "bu-1" || req.Seat.SeatID != "api.example.com" {
+		t.Errorf("request = %+v, want profile, business unit and seat copied", req)
+	}
+	if req.Attributes.CommonName != "api.example.com" || req.Attributes.SANs.DNSNames[0] != "api.example.com" {
+		t.Errorf("Attributes = %+v, want subject and SANs copied", req.Attributes)
+	}
+	if req.CSR == "" {
+		t.Error("CSR is empty")
+	}
+
+	if _, err := LikeForLikeRequest(&Certificate{}, key); err == nil {
+		t.Error("Expected error without certificate PEM")
+	}
+}
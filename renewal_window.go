@@ -0,0 +1,89 @@
+package digicert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultRenewalWindowDays is the number of days before expiry in which TLM
+// accepts renewals for profiles that do not set RenewalWindowDays.
+const DefaultRenewalWindowDays = 90
+
+// ErrRenewalNotAllowed is matched by errors for renewals outside the
+// profile's renewal period, whether reported by RenewalWindow.Check or by the
+// API as RENEWAL_NOT_ALLOWED.
+var ErrRenewalNotAllowed = errors.New("digicert: renewal not allowed")
+
+// RenewalWindow is when a certificate can and should be renewed under its
+// profile's policy.
+type RenewalWindow struct {
+	// Opens and Closes bound the period in which TLM accepts a renewal;
+	// Closes is the certificate's expiry.
+	Opens  time.Time
+	Closes time.Time
+	// Recommended is when automation should renew: two thirds of the way
+	// through the certificate's lifetime, but not before Opens.
+	Recommended time.Time
+	// AutoRenew reports that TLM renews the certificate itself.
+	AutoRenew bool
+}
+
+// RenewalWindow computes the renewal period of c under the profile. It
+// fails if c has no parseable valid_to date.
+func (p *Profile) RenewalWindow(c *Certificate) (RenewalWindow, error) {
+	if c == nil {
+		return RenewalWindow{}, fmt.Errorf("certificate cannot be nil")
+	}
+	expires, err := time.Parse(time.RFC3339, c.ValidTo)
+	if err != nil {
+		return RenewalWindow{}, fmt.Errorf("digicert: certificate %s has no valid expiry: %w", c.SerialNumber, err)
+	}
+
+	days := DefaultRenewalWindowDays
+	if p != nil && p.RenewalWindowDays > 0 {
+		days = int(p.RenewalWindowDays)
+	}
+	w := RenewalWindow{
+		Opens:     expires.AddDate(0, 0, -days),
+		Closes:    expires,
+		AutoRenew: p != nil && p.AutoRenew,
+	}
+
+	w.Recommended = w.Opens
+	if issued, err := time.Parse(time.RFC3339, c.ValidFrom); err == nil && issued.Before(expires) {
+		if at := issued.Add(expires.Sub(issued) * 2 / 3); at.After(w.Opens) {
+			w.Recommended = at
+		}
+	}
+	return w, nil
+}
+
+// Allows reports whether TLM accepts a renewal at t.
+func (w RenewalWindow) Allows(t time.Time) bool {
+	return !t.Before(w.Opens) && t.Before(w.Closes)
+}
+
+// Due reports whether automation should renew at t.
+func (w RenewalWindow) Due(t time.Time) bool {
+	return !t.Before(w.Recommended)
+}
+
+// Check returns an error matching ErrRenewalNotAllowed if a renewal at t
+// would be refused.
+func (w RenewalWindow) Check(t time.Time) error {
+	switch {
+	case t.Before(w.Opens):
+		return fmt.Errorf("%w: renewal period opens %s", ErrRenewalNotAllowed, w.Opens.Format(time.DateOnly))
+	case !t.Before(w.Closes):
+		return fmt.Errorf("%w: certificate expired %s", ErrRenewalNotAllowed, w.Closes.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// IsRenewalNotAllowed reports whether err is a renewal refused for being
+// outside the renewal period, by the API or by RenewalWindow.Check.
+func IsRenewalNotAllowed(err error) bool {
+	return errors.Is(err, ErrRenewalNotAllowed) || strings.EqualFold(ErrorCode(err), "renewal_not_allowed")
+}
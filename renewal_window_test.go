@@ -0,0 +1,69 @@
+package digicert
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProfile_RenewalWindow(t *testing.T) {
+	cert := &Certificate{
+		SerialNumber: "0A",
+		ValidFrom:    "2026-01-01T00:00:00Z",
+		ValidTo:      "2026-12-31T00:00:00Z",
+	}
+
+	w, err := (&Profile{AutoRenew: true}).RenewalWindow(cert)
+	if err != nil {
+		t.Fatalf("RenewalWindow() error = %v", err)
+	}
+	if want := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC); !w.Opens.Equal(want) {
+		t.Errorf("Opens = %v, want %v", w.Opens, want)
+	}
+	if want := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC); !w.Closes.Equal(want) {
+		t.Errorf("Closes = %v, want %v", w.Closes, want)
+	}
+	if !w.Recommended.Equal(w.Opens) || !w.AutoRenew {
+		t.Errorf("Recommended = %v, AutoRenew = %v, want the window opening and true", w.Recommended, w.AutoRenew)
+	}
+
+	w, _ = (&Profile{RenewalWindowDays: 180}).RenewalWindow(cert)
+	if want := time.Date(2026, 8, 31, 16, 0, 0, 0, time.UTC); !w.Recommended.Equal(want) {
+		t.Errorf("Recommended = %v, want two thirds of the lifetime %v", w.Recommended, want)
+	}
+
+	tests := []struct {
+		at      time.Time
+		allowed bool
+		due     bool
+	}{
+		{time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), false, false},
+		{time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC), true, false},
+		{time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), true, true},
+		{time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), false, true},
+	}
+	for _, tt := range tests {
+		if got := w.Allows(tt.at); got != tt.allowed {
+			t.Errorf("Allows(%v) = %v, want %v", tt.at, got, tt.allowed)
+		}
+		if got := w.Due(tt.at); got != tt.due {
+			t.Errorf("Due(%v) = %v, want %v", tt.at, got, tt.due)
+		}
+		if err := w.Check(tt.at); (err == nil) != tt.allowed || (err != nil && !IsRenewalNotAllowed(err)) {
+			t.Errorf("Check(%v) error = %v, want allowed %v", tt.at, err, tt.allowed)
+		}
+	}
+
+	if _, err := (*Profile)(nil).RenewalWindow(&Certificate{ValidTo: "soon"}); err == nil {
+		t.Error("RenewalWindow() expected error for unparseable valid_to")
+	}
+}
+
+func TestIsRenewalNotAllowed(t *testing.T) {
+	if !IsRenewalNotAllowed(&APIError{StatusCode: 400, Code: "RENEWAL_NOT_ALLOWED"}) {
+		t.Error("IsRenewalNotAllowed(RENEWAL_NOT_ALLOWED) = false, want true")
+	}
+	if IsRenewalNotAllowed(&APIError{StatusCode: 400, Code: "invalid_csr"}) || IsRenewalNotAllowed(errors.New("boom")) {
+		t.Error("IsRenewalNotAllowed() = true for an unrelated error")
+	}
+}
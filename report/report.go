@@ -0,0 +1,108 @@
+// Package report renders tabular results, such as certificate searches, as
+// CSV or XLSX spreadsheets with a chosen set of columns and headers.
+//
+//	err := report.Write(w, report.XLSX, columns, records, &report.Options{
+//		Columns: []string{"common_name", "valid_to"},
+//		Headers: map[string]string{"valid_to": "Expires"},
+//	})
+//
+// Records are keyed by column key; the caller lists the keys it can fill,
+// in their default order, and Options picks and renames them. XLSX files are
+// written with archive/zip and encoding/xml, so no spreadsheet library is
+// needed.
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format is a spreadsheet file format.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// Record is one row, keyed by column key. Missing keys render as empty
+// cells.
+type Record map[string]string
+
+type Options struct {
+	// Columns lists the column keys to include, in order. Every available
+	// column is included when empty.
+	Columns []string
+	// Headers maps column keys to header text. Columns without an entry
+	// use their key.
+	Headers map[string]string
+	// SheetName names the XLSX worksheet. Defaults to "Report".
+	SheetName string
+}
+
+// Write renders records to w in format, with the columns chosen from
+// available by opts, which may be nil.
+func Write(w io.Writer, format Format, available []string, records []Record, opts *Options) error {
+	switch format {
+	case CSV:
+		return WriteCSV(w, available, records, opts)
+	case XLSX:
+		return WriteXLSX(w, available, records, opts)
+	}
+	return fmt.Errorf("unsupported report format %q", format)
+}
+
+// WriteCSV renders records as CSV with a header row.
+func WriteCSV(w io.Writer, available []string, records []Record, opts *Options) error {
+	headers, rows, err := table(available, records, opts)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// table resolves the selected columns into a header row and data rows.
+func table(available []string, records []Record, opts *Options) ([]string, [][]string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	columns := available
+	if len(opts.Columns) > 0 {
+		known := make(map[string]bool, len(available))
+		for _, key := range available {
+			known[key] = true
+		}
+		for _, key := range opts.Columns {
+			if !known[key] {
+				return nil, nil, fmt.Errorf("unknown report column %q (available: %s)", key, strings.Join(available, ", "))
+			}
+		}
+		columns = opts.Columns
+	}
+
+	headers := make([]string, len(columns))
+	for i, key := range columns {
+		headers[i] = key
+		if h, ok := opts.Headers[key]; ok {
+			headers[i] = h
+		}
+	}
+	rows := make([][]string, len(records))
+	for i, rec := range records {
+		row := make([]string, len(columns))
+		for j, key := range columns {
+			row[j] = rec[key]
+		}
+		rows[i] = row
+	}
+	return headers, rows, nil
+}
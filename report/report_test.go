@@ -0,0 +1,89 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	testColumns = []string{"serial", "name", "expires"}
+	testRecords = []Record{
+		{"serial": "0012", "name": "a, \"quoted\" <name>", "expires": "2026-01-01"},
+		{"serial": "0013", "name": "=cmd()"},
+	}
+)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, CSV, testColumns, testRecords, &Options{
+		Columns: []string{"name", "serial"},
+		Headers: map[string]string{"name": "Common Name"},
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	want := "Common Name,serial\n\"a, \"\"quoted\"\" <name>\",0012\n=cmd(),0013\n"
+	if buf.String() != want {
+		t.Errorf("Write() = %q, want %q", buf.String(), want)
+	}
+
+	if err := Write(&buf, CSV, testColumns, testRecords, &Options{Columns: []string{"owner"}}); err == nil {
+		t.Error("Write() expected error for an unknown column")
+	}
+	if err := Write(&buf, "ods", testColumns, testRecords, nil); err == nil {
+		t.Error("Write() expected error for an unsupported format")
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, testColumns, testRecords, &Options{SheetName: "Audit 2026"}); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("workbook is missing %s", name)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="Audit 2026"`) {
+		t.Errorf("workbook.xml = %s, want the sheet name", files["xl/workbook.xml"])
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="C1" t="inlineStr"><is><t xml:space="preserve">expires</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">0012</t></is></c>`,
+		`a, &#34;quoted&#34; &lt;name&gt;`,
+		`<c r="B3" t="inlineStr"><is><t xml:space="preserve">=cmd()</t></is></c></row>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml is missing %s", want)
+		}
+	}
+
+	if err := WriteXLSX(&buf, testColumns, testRecords, &Options{SheetName: "a/b"}); err == nil {
+		t.Error("WriteXLSX() expected error for an invalid sheet name")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package digicert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// Backoff chooses the delay before a retry. attempt counts retries from 1
+// and previous is the delay chosen for the last retry, zero before the
+// first.
+type Backoff interface {
+	Next(attempt int, previous time.Duration) time.Duration
+}
+
+// ExponentialBackoff doubles Base on each retry, up to Max, with "full
+// jitter": the delay is drawn uniformly from zero to that bound unless
+// NoJitter is set.
+type ExponentialBackoff struct {
+	Base     time.Duration
+	Max      time.Duration
+	NoJitter bool
+}
+
+func (b ExponentialBackoff) Next(attempt int, _ time.Duration) time.Duration {
+	base, maxDelay := b.Base, b.Max
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	d := maxDelay
+	if shift := attempt - 1; shift < 62 && base <= maxDelay>>shift {
+		d = base << shift
+	}
+	if b.NoJitter {
+		return d
+	}
+	return rand.N(d + 1)
+}
+
+// ConstantBackoff waits the same delay before every retry.
+type ConstantBackoff time.Duration
+
+func (b ConstantBackoff) Next(int, time.Duration) time.Duration { return time.Duration(b) }
+
+// DecorrelatedJitterBackoff draws each delay between Base and three times
+// the previous one, up to Max, which spreads out clients that failed
+// together better than exponential backoff does.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b DecorrelatedJitterBackoff) Next(_ int, previous time.Duration) time.Duration {
+	base, maxDelay := b.Base, b.Max
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	upper := max(previous*3, base)
+	return min(base+rand.N(upper-base+1), maxDelay)
+}
+
+// RetryPolicy configures the retries Client.Do makes. Errors are retried
+// when RetryableError says so, unless StatusCodes overrides the decision
+// for their status.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// Backoff chooses the delay between attempts. Defaults to
+	// ExponentialBackoff with the default base and maximum delays.
+	Backoff Backoff
+	// StatusCodes overrides the decision for a response status: true always
+	// retries it, whatever the method, and false never does. For example
+	// {400: false, 429: true}.
+	StatusCodes map[int]bool
+	// RetryNonIdempotent also retries POST and PATCH requests that carry no
+	// idempotency key. Without it they are retried only for statuses set
+	// to true in StatusCodes, as the server may have acted on them.
+	RetryNonIdempotent bool
+	// IgnoreRetryAfter uses the backoff delay even when the server asks
+	// for a longer wait with Retry-After.
+	IgnoreRetryAfter bool
+}
+
+// WithRetry retries failed requests according to policy.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxRetries < 0 {
+			return fmt.Errorf("max retries cannot be negative")
+		}
+		if policy.Backoff == nil {
+			policy.Backoff = ExponentialBackoff{}
+		}
+		c.retry = &policy
+		return nil
+	}
+}
+
+// do calls send for req until it succeeds, fails with an error the policy
+// does not retry, or runs out of retries, and returns the last result.
+func (p *RetryPolicy) do(ctx context.Context, req *http.Request, send func(*http.Request) (*Response, error)) (*Response, error) {
+	var previous time.Duration
+	for attempt := 1; ; attempt++ {
+		resp, err := send(req)
+		if err == nil || attempt > p.MaxRetries || ctx.Err() != nil || !p.retryable(req, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		delay := p.Backoff.Next(attempt, previous)
+		if wait := retryAfterOf(err); wait > delay && !p.IgnoreRetryAfter {
+			delay = wait
+		}
+		previous = delay
+		if !sleepCtx(ctx, delay) {
+			return resp, err
+		}
+	}
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, err error) bool {
+	if status := StatusCode(err); status != 0 {
+		if retry, ok := p.StatusCodes[status]; ok {
+			return retry
+		}
+	}
+	if !RetryableError(err) {
+		return false
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return p.RetryNonIdempotent || req.Header.Get(IdempotencyKeyHeader) != ""
+	}
+	return true
+}
+
+// retryAfterOf returns the Retry-After delay carried by an API error.
+func retryAfterOf(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.RetryAfter
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package digicert
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoffStrategies(t *testing.T) {
+	exp := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second, NoJitter: true}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 5: time.Second, 100: time.Second} {
+		if got := exp.Next(attempt, 0); got != want {
+			t.Errorf("ExponentialBackoff.Next(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	jittered := ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+	if got := jittered.Next(3, 0); got < 0 || got > 400*time.Millisecond {
+		t.Errorf("jittered Next(3) = %v, want within [0, 400ms]", got)
+	}
+
+	if got := ConstantBackoff(time.Second).Next(7, 0); got != time.Second {
+		t.Errorf("ConstantBackoff.Next() = %v, want 1s", got)
+	}
+
+	decorrelated := DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Max: 2 * time.Second}
+	previous := time.Duration(0)
+	for attempt := 1; attempt <= 20; attempt++ {
+		d := decorrelated.Next(attempt, previous)
+		if d < 100*time.Millisecond || d > 2*time.Second || (previous > 0 && d > previous*3) {
+			t.Fatalf("DecorrelatedJitterBackoff.Next(%d, %v) = %v out of range", attempt, previous, d)
+		}
+		previous = d
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if n < 3 {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	newClient := func(policy RetryPolicy) *Client {
+		policy.Backoff = ConstantBackoff(time.Millisecond)
+		client, err := NewClient("test-key", WithBaseURL(server.URL+"/"), WithRetry(policy))
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		return client
+	}
+	call := func(client *Client, method string) error {
+		calls.Store(0)
+		bodies = nil
+		req, _ := client.NewRequest(ctx, method, "certificate", map[string]string{"a": "b"})
+		_, err := client.Do(ctx, req, nil)
+		return err
+	}
+
+	client := newClient(RetryPolicy{MaxRetries: 3})
+	if err := call(client, http.MethodPut); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if calls.Load() != 3 || bodies[2] != bodies[0] || bodies[0] == "" {
+		t.Errorf("calls = %d, bodies = %q, want 3 attempts with the body replayed", calls.Load(), bodies)
+	}
+
+	if err := call(newClient(RetryPolicy{MaxRetries: 1}), http.MethodGet); StatusCode(err) != http.StatusServiceUnavailable || calls.Load() != 2 {
+		t.Errorf("Do() error = %v after %d calls, want 503 after 2", err, calls.Load())
+	}
+
+	if err := call(client, http.MethodPost); err == nil || calls.Load() != 1 {
+		t.Errorf("POST made %d calls, want no retry without an idempotency key", calls.Load())
+	}
+
+	status = http.StatusTooManyRequests
+	if err := call(newClient(RetryPolicy{MaxRetries: 3, StatusCodes: map[int]bool{429: true}}), http.MethodPost); err != nil || calls.Load() != 3 {
+		t.Errorf("POST error = %v after %d calls, want 429 always retried", err, calls.Load())
+	}
+
+	status = http.StatusBadGateway
+	if err := call(newClient(RetryPolicy{MaxRetries: 3, StatusCodes: map[int]bool{502: false}}), http.MethodGet); err == nil || calls.Load() != 1 {
+		t.Errorf("GET made %d calls, want 502 never retried", calls.Load())
+	}
+
+	status = http.StatusBadRequest
+	if err := call(client, http.MethodGet); err == nil || calls.Load() != 1 {
+		t.Errorf("GET made %d calls, want 400 not retried", calls.Load())
+	}
+
+	if _, err := NewClient("test-key", WithRetry(RetryPolicy{MaxRetries: -1})); err == nil {
+		t.Error("WithRetry() expected error for negative retries")
+	}
+}
@@ -0,0 +1,84 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Revocation reasons accepted in RevokeRequest.Reason.
+const (
+	RevocationReasonUnspecified          = "unspecified"
+	RevocationReasonKeyCompromise        = "key_compromise"
+	RevocationReasonAffiliationChanged   = "affiliation_changed"
+	RevocationReasonSuperseded           = "superseded"
+	RevocationReasonCessationOfOperation = "cessation_of_operation"
+	RevocationReasonCertificateHold      = "certificate_hold"
+	RevocationReasonPrivilegeWithdrawn   = "privilege_withdrawn"
+)
+
+// RevokeByID revokes a certificate by its certificate ID rather than its
+// serial number
+func (s *CertificatesService) RevokeByID(ctx context.Context, certificateID string, req *RevokeRequest) (*Response, error) {
+	if err := s.client.requireCapability("RevokeByID", CapabilityRevokeCertificates); err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("certificate-by-id/%s/revoke", certificateID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPut, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, httpReq, nil)
+}
+
+// UnrevokeByID unrevokes a certificate by its certificate ID. req may be
+// nil.
+func (s *CertificatesService) UnrevokeByID(ctx context.Context, certificateID string, req *UnrevokeRequest) (*Response, error) {
+	if err := s.client.requireCapability("UnrevokeByID", CapabilityRevokeCertificates); err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("certificate-by-id/%s/revoke", certificateID)
+
+	var body interface{}
+	if req != nil {
+		body = req
+	}
+	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, httpReq, nil)
+}
+
+// RevokeAt schedules the revocation of a certificate for effective. The
+// certificate stays valid until then, so revocations can be queued ahead of
+// a change window.
+func (s *CertificatesService) RevokeAt(ctx context.Context, serialNumber string, effective time.Time, req *RevokeRequest) (*Response, error) {
+	scheduled := RevokeRequest{Reason: RevocationReasonUnspecified}
+	if req != nil {
+		scheduled = *req
+	}
+	scheduled.EffectiveDate = &effective
+	return s.Revoke(ctx, serialNumber, &scheduled)
+}
+
+// Hold suspends a certificate with the certificate_hold reason. Unlike other
+// revocations a hold can be lifted with Release.
+func (s *CertificatesService) Hold(ctx context.Context, serialNumber string, comment string) (*Response, error) {
+	return s.Revoke(ctx, serialNumber, &RevokeRequest{Reason: RevocationReasonCertificateHold, Comment: comment})
+}
+
+// Release lifts a certificate hold placed with Hold, recording comment in
+// the audit log. The API rejects the request for certificates revoked with
+// any other reason.
+func (s *CertificatesService) Release(ctx context.Context, serialNumber string, comment string) (*Response, error) {
+	var req *UnrevokeRequest
+	if comment != "" {
+		req = &UnrevokeRequest{Comment: comment}
+	}
+	return s.UnrevokeWithRequest(ctx, serialNumber, req)
+}
@@ -0,0 +1,101 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertificatesService_RevokeVariants(t *testing.T) {
+	type call struct {
+		method, path string
+		body         RevokeRequest
+	}
+	var calls []call
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{method: r.Method, path: r.URL.Path}
+		json.NewDecoder(r.Body).Decode(&c.body)
+		calls = append(calls, c)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	window := time.Date(2026, 10, 17, 22, 0, 0, 0, time.UTC)
+
+	if _, err := client.Certificates.RevokeByID(ctx, "cert-1", &RevokeRequest{Reason: RevocationReasonSuperseded}); err != nil {
+		t.Fatalf("RevokeByID() error = %v", err)
+	}
+	if _, err := client.Certificates.RevokeAt(ctx, "0A", window, &RevokeRequest{Reason: RevocationReasonCessationOfOperation}); err != nil {
+		t.Fatalf("RevokeAt() error = %v", err)
+	}
+	if _, err := client.Certificates.Hold(ctx, "0B", "investigating"); err != nil {
+		t.Fatalf("Hold() error = %v", err)
+	}
+	if _, err := client.Certificates.Release(ctx, "0B", "false alarm"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := client.Certificates.UnrevokeByID(ctx, "cert-1", nil); err != nil {
+		t.Fatalf("UnrevokeByID() error = %v", err)
+	}
+
+	want := []call{
+		{http.MethodPut, "/mpki/api/v1/certificate-by-id/cert-1/revoke", RevokeRequest{Reason: RevocationReasonSuperseded}},
+		{http.MethodPut, "/mpki/api/v1/certificate/0A/revoke", RevokeRequest{Reason: RevocationReasonCessationOfOperation, EffectiveDate: &window}},
+		{http.MethodPut, "/mpki/api/v1/certificate/0B/revoke", RevokeRequest{Reason: RevocationReasonCertificateHold, Comment: "investigating"}},
+		{http.MethodDelete, "/mpki/api/v1/certificate/0B/revoke", RevokeRequest{Comment: "false alarm"}},
+		{http.MethodDelete, "/mpki/api/v1/certificate-by-id/cert-1/revoke", RevokeRequest{}},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %+v, want %d", calls, len(want))
+	}
+	for i, w := range want {
+		got := calls[i]
+		if got.method != w.method || got.path != w.path || got.body.Reason != w.body.Reason || got.body.Comment != w.body.Comment {
+			t.Errorf("call %d = %+v, want %+v", i, got, w)
+		}
+		if (got.body.EffectiveDate == nil) != (w.body.EffectiveDate == nil) ||
+			got.body.EffectiveDate != nil && !got.body.EffectiveDate.Equal(*w.body.EffectiveDate) {
+			t.Errorf("call %d EffectiveDate = %v, want %v", i, got.body.EffectiveDate, w.body.EffectiveDate)
+		}
+	}
+}
+
+func TestCertificatesService_UnrevokeWithRequest(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/mpki/api/v1/certificate/0A/revoke" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(data)))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	if _, err := client.Certificates.Unrevoke(ctx, "0A"); err != nil {
+		t.Fatalf("Unrevoke() error = %v", err)
+	}
+	if _, err := client.Certificates.UnrevokeWithRequest(ctx, "0A", &UnrevokeRequest{Reason: "revoked in error", Comment: "CHG-1234"}); err != nil {
+		t.Fatalf("UnrevokeWithRequest() error = %v", err)
+	}
+
+	want := []string{"", `{"reason":"revoked in error","comment":"CHG-1234"}`}
+	if len(bodies) != len(want) {
+		t.Fatalf("bodies = %q, want %q", bodies, want)
+	}
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Errorf("body %d = %q, want %q", i, bodies[i], want[i])
+		}
+	}
+}
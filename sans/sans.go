@@ -0,0 +1,235 @@
+// Package sans builds validated subject alternative names for certificate
+// requests.
+//
+//	names, err := sans.New().
+//		DNS("www.example.com", "*.api.example.com").
+//		IP("10.0.0.1").
+//		URI("spiffe://example.com/web").
+//		Build()
+//
+// Values are normalized as they are added: DNS names are lower-cased, stripped
+// of trailing dots and IDNA encoded, IP addresses are written in canonical
+// form, and duplicates are dropped. Build reports every invalid value at once.
+package sans
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+	"github.com/jonhadfield/go-digicert-tlm/internal/idna"
+)
+
+// Type identifies a kind of subject alternative name.
+type Type string
+
+const (
+	TypeDNS   Type = "dns_name"
+	TypeIP    Type = "ip_address"
+	TypeEmail Type = "email"
+	TypeURI   Type = "uri"
+)
+
+// InvalidError reports a value that could not be added to a Builder.
+type InvalidError struct {
+	Type   Type
+	Value  string
+	Reason string
+}
+
+func (e *InvalidError) Error() string {
+	return fmt.Sprintf("invalid %s SAN %q: %s", e.Type, e.Value, e.Reason)
+}
+
+// Builder accumulates subject alternative names. The zero value is not
+// usable; create one with New.
+type Builder struct {
+	dns    []string
+	ips    []string
+	emails []string
+	uris   []string
+
+	seen map[Type]map[string]bool
+	errs []error
+}
+
+func New() *Builder {
+	return &Builder{seen: make(map[Type]map[string]bool)}
+}
+
+// DNS adds DNS names. A wildcard is only accepted as the whole left-most
+// label and must be followed by at least two labels, e.g. *.example.com.
+func (b *Builder) DNS(names ...string) *Builder {
+	for _, name := range names {
+		normalized, err := NormalizeDNSName(name)
+		if err != nil {
+			b.fail(TypeDNS, name, err)
+			continue
+		}
+		b.add(TypeDNS, normalized, &b.dns)
+	}
+	return b
+}
+
+// IP adds IPv4 or IPv6 addresses.
+func (b *Builder) IP(addrs ...string) *Builder {
+	for _, addr := range addrs {
+		ip, err := netip.ParseAddr(strings.TrimSpace(addr))
+		if err != nil {
+			b.fail(TypeIP, addr, errors.New("not an IP address"))
+			continue
+		}
+		if ip.Zone() != "" {
+			b.fail(TypeIP, addr, errors.New("zoned addresses are not allowed"))
+			continue
+		}
+		b.add(TypeIP, ip.Unmap().String(), &b.ips)
+	}
+	return b
+}
+
+// Email adds RFC 822 email addresses. Display names are not allowed and the
+// domain is IDNA encoded.
+func (b *Builder) Email(addrs ...string) *Builder {
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil || parsed.Name != "" || parsed.Address != addr {
+			b.fail(TypeEmail, addr, errors.New("not a bare email address"))
+			continue
+		}
+		local, domain, _ := strings.Cut(parsed.Address, "@")
+		domain, err = NormalizeDNSName(domain)
+		if err != nil || strings.HasPrefix(domain, "*") {
+			b.fail(TypeEmail, addr, errors.New("invalid domain"))
+			continue
+		}
+		b.add(TypeEmail, local+"@"+domain, &b.emails)
+	}
+	return b
+}
+
+// URI adds absolute URIs, such as SPIFFE IDs. The host, if any, is IDNA
+// encoded.
+func (b *Builder) URI(uris ...string) *Builder {
+	for _, raw := range uris {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || u.Scheme == "" {
+			b.fail(TypeURI, raw, errors.New("not an absolute URI"))
+			continue
+		}
+		if u.Host != "" {
+			host := u.Hostname()
+			if _, err := netip.ParseAddr(host); err != nil {
+				normalized, err := NormalizeDNSName(host)
+				if err != nil {
+					b.fail(TypeURI, raw, fmt.Errorf("invalid host: %v", err))
+					continue
+				}
+				if port := u.Port(); port != "" {
+					normalized += ":" + port
+				}
+				u.Host = normalized
+			}
+		}
+		b.add(TypeURI, u.String(), &b.uris)
+	}
+	return b
+}
+
+// Err returns the validation errors recorded so far, or nil.
+func (b *Builder) Err() error {
+	return errors.Join(b.errs...)
+}
+
+// Build returns the accumulated names, or an error wrapping an InvalidError
+// for every rejected value.
+func (b *Builder) Build() (*digicert.SubjectAltNames, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+	return &digicert.SubjectAltNames{
+		DNSNames:    b.dns,
+		IPAddresses: b.ips,
+		Emails:      b.emails,
+		URIs:        b.uris,
+	}, nil
+}
+
+func (b *Builder) add(t Type, value string, list *[]string) {
+	key := strings.ToLower(value)
+	if b.seen[t] == nil {
+		b.seen[t] = make(map[string]bool)
+	}
+	if b.seen[t][key] {
+		return
+	}
+	b.seen[t][key] = true
+	*list = append(*list, value)
+}
+
+func (b *Builder) fail(t Type, value string, err error) {
+	b.errs = append(b.errs, &InvalidError{Type: t, Value: value, Reason: err.Error()})
+}
+
+// NormalizeDNSName returns name lower-cased, without a trailing dot and IDNA
+// encoded, or an error if it is not a valid host name or wildcard.
+func NormalizeDNSName(name string) (string, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "", errors.New("empty name")
+	}
+	if _, err := netip.ParseAddr(name); err == nil {
+		return "", errors.New("IP addresses must be added as IP SANs")
+	}
+
+	ascii, err := idna.ToASCII(name)
+	if err != nil {
+		return "", err
+	}
+	if len(ascii) > 253 {
+		return "", errors.New("name is longer than 253 characters")
+	}
+
+	labels := strings.Split(ascii, ".")
+	for i, label := range labels {
+		if label == "*" {
+			if i != 0 {
+				return "", errors.New("wildcard must be the left-most label")
+			}
+			if len(labels) < 3 {
+				return "", errors.New("wildcard must be followed by at least two labels")
+			}
+			continue
+		}
+		if err := validateLabel(label); err != nil {
+			return "", err
+		}
+	}
+	return ascii, nil
+}
+
+func validateLabel(label string) error {
+	switch {
+	case label == "":
+		return errors.New("empty label")
+	case len(label) > 63:
+		return fmt.Errorf("label %q is longer than 63 characters", label)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return fmt.Errorf("label %q starts or ends with a hyphen", label)
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if c == '*' {
+			return errors.New("partial wildcards are not allowed")
+		}
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return fmt.Errorf("label %q contains invalid character %q", label, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package sans
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	names, err := New().
+		DNS("WWW.Example.com.", "www.example.com", "*.api.example.com", "bücher.example").
+		IP("10.0.0.1", "2001:DB8::1", "::ffff:10.0.0.1").
+		Email("admin@Bücher.example").
+		URI("spiffe://example.com/web", "https://Bücher.example:8443/path").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if want := []string{"www.example.com", "*.api.example.com", "xn--bcher-kva.example"}; !reflect.DeepEqual(names.DNSNames, want) {
+		t.Errorf("DNSNames = %v, want %v", names.DNSNames, want)
+	}
+	if want := []string{"10.0.0.1", "2001:db8::1"}; !reflect.DeepEqual(names.IPAddresses, want) {
+		t.Errorf("IPAddresses = %v, want %v", names.IPAddresses, want)
+	}
+	if want := []string{"admin@xn--bcher-kva.example"}; !reflect.DeepEqual(names.Emails, want) {
+		t.Errorf("Emails = %v, want %v", names.Emails, want)
+	}
+	if want := []string{"spiffe://example.com/web", "https://xn--bcher-kva.example:8443/path"}; !reflect.DeepEqual(names.URIs, want) {
+		t.Errorf("URIs = %v, want %v", names.URIs, want)
+	}
+}
+
+func TestBuilder_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*Builder) *Builder
+	}{
+		{"partial wildcard", func(b *Builder) *Builder { return b.DNS("w*.example.com") }},
+		{"nested wildcard", func(b *Builder) *Builder { return b.DNS("www.*.example.com") }},
+		{"wildcard tld", func(b *Builder) *Builder { return b.DNS("*.com") }},
+		{"ip as dns", func(b *Builder) *Builder { return b.DNS("10.0.0.1") }},
+		{"hyphen label", func(b *Builder) *Builder { return b.DNS("-bad.example.com") }},
+		{"empty label", func(b *Builder) *Builder { return b.DNS("a..example.com") }},
+		{"space", func(b *Builder) *Builder { return b.DNS("exa mple.com") }},
+		{"bad ip", func(b *Builder) *Builder { return b.IP("10.0.0.256") }},
+		{"zoned ip", func(b *Builder) *Builder { return b.IP("fe80::1%eth0") }},
+		{"display name", func(b *Builder) *Builder { return b.Email("Admin <admin@example.com>") }},
+		{"relative uri", func(b *Builder) *Builder { return b.URI("/web") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build(New()).Build()
+			var invalid *InvalidError
+			if !errors.As(err, &invalid) {
+				t.Errorf("Build() error = %v, want *InvalidError", err)
+			}
+		})
+	}
+}
+
+func TestBuilder_ReportsAllErrors(t *testing.T) {
+	b := New().DNS("ok.example.com", "w*.example.com").IP("nope")
+	if b.Err() == nil {
+		t.Fatal("Err() = nil, want errors")
+	}
+	joined, ok := b.Err().(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("Err() = %v, want 2 errors", b.Err())
+	}
+}
@@ -0,0 +1,162 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// ErrSavedFilterNotFound is returned when a saved filter name is not in the
+// registry.
+var ErrSavedFilterNotFound = errors.New("digicert: saved filter not found")
+
+// SavedFilter is a named certificate search. The TLM API has no saved
+// searches of its own, so filters are kept client side in SavedFilters and
+// shared between teams as JSON, keeping queries such as "expiring
+// production certificates" consistent across tools.
+type SavedFilter struct {
+	Name        string
+	Description string
+	Options     CertificateSearchOptions
+}
+
+type savedFilterJSON struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description,omitempty"`
+	Query       certificateSearchV2Request `json:"query"`
+}
+
+// MarshalJSON writes the filter with its query in the API's snake_case
+// search field names. Options.Prefetch is not saved.
+func (f SavedFilter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(savedFilterJSON{
+		Name:        f.Name,
+		Description: f.Description,
+		Query:       *newCertificateSearchV2Request(&f.Options),
+	})
+}
+
+func (f *SavedFilter) UnmarshalJSON(data []byte) error {
+	var v savedFilterJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = SavedFilter{Name: v.Name, Description: v.Description, Options: v.Query.options()}
+	return nil
+}
+
+// SavedFilters is a registry of saved filters, keyed by name. It is safe
+// for concurrent use and marshals to a JSON array sorted by name.
+type SavedFilters struct {
+	mu      sync.RWMutex
+	filters map[string]SavedFilter
+}
+
+// NewSavedFilters returns a registry holding filters.
+func NewSavedFilters(filters ...SavedFilter) (*SavedFilters, error) {
+	r := &SavedFilters{filters: make(map[string]SavedFilter, len(filters))}
+	for _, f := range filters {
+		if err := r.Save(f); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// LoadSavedFilters reads a registry written by SavedFilters.MarshalJSON.
+func LoadSavedFilters(rd io.Reader) (*SavedFilters, error) {
+	r := &SavedFilters{}
+	if err := json.NewDecoder(rd).Decode(r); err != nil {
+		return nil, fmt.Errorf("digicert: load saved filters: %w", err)
+	}
+	return r, nil
+}
+
+// Save adds f, replacing any filter with the same name.
+func (r *SavedFilters) Save(f SavedFilter) error {
+	if f.Name == "" {
+		return fmt.Errorf("saved filter name cannot be empty")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.filters == nil {
+		r.filters = make(map[string]SavedFilter)
+	}
+	r.filters[f.Name] = f
+	return nil
+}
+
+// Get returns the filter saved as name.
+func (r *SavedFilters) Get(name string) (SavedFilter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.filters[name]
+	return f, ok
+}
+
+// Delete removes the filter saved as name, if any.
+func (r *SavedFilters) Delete(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.filters, name)
+}
+
+// List returns the saved filters sorted by name.
+func (r *SavedFilters) List() []SavedFilter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]SavedFilter, 0, len(r.filters))
+	for _, f := range r.filters {
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (r *SavedFilters) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.List())
+}
+
+// UnmarshalJSON replaces the registry's filters. Duplicate or empty names
+// are rejected, so a hand-edited file does not silently lose a filter.
+func (r *SavedFilters) UnmarshalJSON(data []byte) error {
+	var list []SavedFilter
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	filters := make(map[string]SavedFilter, len(list))
+	for _, f := range list {
+		if f.Name == "" {
+			return fmt.Errorf("saved filter name cannot be empty")
+		}
+		if _, dup := filters[f.Name]; dup {
+			return fmt.Errorf("duplicate saved filter %q", f.Name)
+		}
+		filters[f.Name] = f
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.filters = filters
+	return nil
+}
+
+// SearchSaved runs the search saved as name. page, when not nil, overrides
+// the saved offset and limit.
+func (s *CertificatesService) SearchSaved(ctx context.Context, filters *SavedFilters, name string, page *PaginationParams) (*CertificateSearchResponse, *Response, error) {
+	if filters == nil {
+		return nil, nil, fmt.Errorf("saved filters cannot be nil")
+	}
+	f, ok := filters.Get(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrSavedFilterNotFound, name)
+	}
+	opts := f.Options
+	if page != nil {
+		opts.PaginationParams = *page
+	}
+	return s.Search(ctx, &opts)
+}
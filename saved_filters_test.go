@@ -0,0 +1,85 @@
+package digicert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSavedFilters_RoundTrip(t *testing.T) {
+	filters, err := NewSavedFilters(
+		SavedFilter{Name: "prod-expiring", Description: "Production certificates", Options: CertificateSearchOptions{
+			Status: "issued", Tags: []string{"env:prod"}, SortBy: "valid_to", Prefetch: 4,
+		}},
+		SavedFilter{Name: "acme", Options: CertificateSearchOptions{ProfileID: "p-1"}},
+	)
+	if err != nil {
+		t.Fatalf("NewSavedFilters() error = %v", err)
+	}
+
+	data, err := json.Marshal(filters)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), `[{"name":"acme"`) || !strings.Contains(string(data), `"sort_by":"valid_to"`) {
+		t.Errorf("Marshal() = %s, want filters sorted by name with snake_case queries", data)
+	}
+
+	loaded, err := LoadSavedFilters(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadSavedFilters() error = %v", err)
+	}
+	f, ok := loaded.Get("prod-expiring")
+	if !ok || f.Description != "Production certificates" || f.Options.Status != "issued" || f.Options.Tags[0] != "env:prod" {
+		t.Errorf("Get() = %+v, %v", f, ok)
+	}
+	if f.Options.Prefetch != 0 {
+		t.Errorf("Prefetch = %d, want it not saved", f.Options.Prefetch)
+	}
+
+	loaded.Delete("acme")
+	if got := loaded.List(); len(got) != 1 {
+		t.Errorf("List() = %+v, want one filter after Delete", got)
+	}
+
+	if _, err := LoadSavedFilters(strings.NewReader(`[{"name":"a"},{"name":"a"}]`)); err == nil {
+		t.Error("LoadSavedFilters() expected error for duplicate names")
+	}
+	if err := loaded.Save(SavedFilter{}); err == nil {
+		t.Error("Save() expected error for an empty name")
+	}
+}
+
+func TestCertificatesService_SearchSaved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("status") != "issued" || q.Get("offset") != "50" || q.Get("limit") != "25" {
+			t.Errorf("query = %v, want the saved status and the given page", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"items":[{"serial_number":"0A"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	filters, _ := NewSavedFilters(SavedFilter{Name: "issued", Options: CertificateSearchOptions{
+		Status: "issued", PaginationParams: PaginationParams{Limit: 10},
+	}})
+
+	result, _, err := client.Certificates.SearchSaved(context.Background(), filters, "issued", &PaginationParams{Offset: 50, Limit: 25})
+	if err != nil {
+		t.Fatalf("SearchSaved() error = %v", err)
+	}
+	if len(result.Items) != 1 {
+		t.Errorf("Items = %+v", result.Items)
+	}
+
+	if _, _, err := client.Certificates.SearchSaved(context.Background(), filters, "missing", nil); !errors.Is(err, ErrSavedFilterNotFound) {
+		t.Errorf("SearchSaved() error = %v, want ErrSavedFilterNotFound", err)
+	}
+}
@@ -0,0 +1,16 @@
+package digicert
+
+// WithBusinessUnit scopes the client to a business unit. Issuance, certificate
+// search and enrollment calls that do not name a business unit themselves are
+// sent with this one, so a multi-tenant service can hold one client per tenant
+func WithBusinessUnit(buID string) ClientOption {
+	return func(c *Client) error {
+		c.businessUnitID = buID
+		return nil
+	}
+}
+
+// BusinessUnitID returns the business unit the client is scoped to, if any.
+func (c *Client) BusinessUnitID() string {
+	return c.businessUnitID
+}
@@ -0,0 +1,74 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithBusinessUnit(t *testing.T) {
+	ctx := context.Background()
+
+	var bodyBU, queryBU string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queryBU = r.URL.Query().Get("business_unit_id")
+		bodyBU = ""
+		if r.Body != nil {
+			var body struct {
+				BusinessUnitID string `json:"business_unit_id"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			bodyBU = body.BusinessUnitID
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithBusinessUnit("bu-tenant"))
+	if client.BusinessUnitID() != "bu-tenant" {
+		t.Errorf("BusinessUnitID() = %v, want %v", client.BusinessUnitID(), "bu-tenant")
+	}
+
+	t.Run("issue", func(t *testing.T) {
+		req := &CertificateRequest{Profile: ProfileReference{ID: "p-1"}}
+		client.Certificates.Issue(ctx, req)
+		if bodyBU != "bu-tenant" {
+			t.Errorf("business_unit_id = %v, want %v", bodyBU, "bu-tenant")
+		}
+		if req.BusinessUnitID != "" {
+			t.Error("Issue() modified the caller's request")
+		}
+	})
+
+	t.Run("explicit business unit wins", func(t *testing.T) {
+		client.Enrollments.Create(ctx, &EnrollmentRequest{BusinessUnitID: "bu-other"})
+		if bodyBU != "bu-other" {
+			t.Errorf("business_unit_id = %v, want %v", bodyBU, "bu-other")
+		}
+	})
+
+	t.Run("search with nil options", func(t *testing.T) {
+		client.Certificates.Search(ctx, nil)
+		if queryBU != "bu-tenant" {
+			t.Errorf("business_unit_id = %v, want %v", queryBU, "bu-tenant")
+		}
+	})
+
+	t.Run("enrollment details", func(t *testing.T) {
+		client.Enrollments.ListDetails(ctx, &EnrollmentDetailsOptions{Status: EnrollmentStatusPending})
+		if queryBU != "bu-tenant" {
+			t.Errorf("business_unit_id = %v, want %v", queryBU, "bu-tenant")
+		}
+	})
+
+	t.Run("unscoped client", func(t *testing.T) {
+		unscoped, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		unscoped.Certificates.Search(ctx, nil)
+		if queryBU != "" {
+			t.Errorf("business_unit_id = %v, want none", queryBU)
+		}
+	})
+}
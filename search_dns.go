@@ -0,0 +1,115 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// SearchByDNSName returns every certificate whose DNS names cover host,
+// including wildcard certificates such as *.example.com for
+// www.example.com. opts narrows the search further and may be nil.
+//
+// The server side dns_name filter is used when available; servers that
+// reject it are searched without it. Either way the results are checked
+// client side against the SANs in each certificate's PEM, falling back to
+// the common name, so the returned certificates always cover host.
+func (s *CertificatesService) SearchByDNSName(ctx context.Context, host string, opts *CertificateSearchOptions) ([]Certificate, error) {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	if !s.client.disableIDNA {
+		ascii, err := normalizeHostname(host)
+		if err != nil {
+			return nil, err
+		}
+		host = ascii
+	}
+
+	base := CertificateSearchOptions{}
+	if opts != nil {
+		base = *opts
+	}
+	queries := []string{host}
+	if i := strings.Index(host, "."); i > 0 && strings.Contains(host[i+1:], ".") {
+		queries = append(queries, "*"+host[i:])
+	}
+
+	var matches []Certificate
+	seen := make(map[string]bool)
+	collect := func(c Certificate) {
+		key := c.ID
+		if key == "" {
+			key = c.SerialNumber
+		}
+		if seen[key] || !certificateCoversHost(c, host) {
+			return
+		}
+		seen[key] = true
+		matches = append(matches, c)
+	}
+
+	for _, q := range queries {
+		page := base
+		page.DNSName = q
+		err := s.searchAll(ctx, &page, collect)
+		if IsBadRequest(err) {
+			// The filter is not supported; scan the unfiltered results.
+			page.DNSName = ""
+			return matches, s.searchAll(ctx, &page, collect)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// certificateCoversHost reports whether a DNS name of c matches host.
+func certificateCoversHost(c Certificate, host string) bool {
+	for _, name := range certificateDNSNames(c) {
+		if matchHostname(name, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// certificateDNSNames returns the DNS SANs of c from its PEM or, when the
+// search response omits the PEM, from a sans or dns_names response field.
+// The common name is used if no SANs are found.
+func certificateDNSNames(c Certificate) []string {
+	if c.Certificate != "" {
+		if cert, err := parseCertificatePEM(c.Certificate); err == nil && len(cert.DNSNames) > 0 {
+			return cert.DNSNames
+		}
+	}
+	if raw, ok := c.Extra["sans"]; ok {
+		var sans SubjectAltNames
+		if json.Unmarshal(raw, &sans) == nil && len(sans.DNSNames) > 0 {
+			return sans.DNSNames
+		}
+	}
+	if raw, ok := c.Extra["dns_names"]; ok {
+		var names []string
+		if json.Unmarshal(raw, &names) == nil && len(names) > 0 {
+			return names
+		}
+	}
+	if looksLikeHostname(c.CommonName) {
+		return []string{c.CommonName}
+	}
+	return nil
+}
+
+// matchHostname matches host against a certificate DNS name, allowing a
+// wildcard only as the whole left-most label.
+func matchHostname(name, host string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == host {
+		return true
+	}
+	if !strings.HasPrefix(name, "*.") {
+		return false
+	}
+	i := strings.Index(host, ".")
+	return i > 0 && host[i:] == name[1:]
+}
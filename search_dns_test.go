@@ -0,0 +1,98 @@
+package digicert
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMatchHostname(t *testing.T) {
+	tests := []struct {
+		name, host string
+		want       bool
+	}{
+		{"www.example.com", "www.example.com", true},
+		{"WWW.Example.com.", "www.example.com", true},
+		{"*.example.com", "www.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "a.b.example.com", false},
+		{"w*.example.com", "www.example.com", false},
+		{"api.example.com", "www.example.com", false},
+	}
+	for _, tt := range tests {
+		if got := matchHostname(tt.name, tt.host); got != tt.want {
+			t.Errorf("matchHostname(%q, %q) = %v, want %v", tt.name, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestCertificatesService_SearchByDNSName(t *testing.T) {
+	multi := newTestCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "example.com"},
+		DNSNames:  []string{"example.com", "www.example.com"},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+	}, nil)
+	pemJSON, _ := json.Marshal(multi.pem)
+	inventory := `{"total": 4, "items": [
+		{"id": "multi", "common_name": "example.com", "certificate": ` + string(pemJSON) + `},
+		{"id": "wildcard", "common_name": "*.example.com"},
+		{"id": "sans", "common_name": "other", "sans": {"dns_names": ["www.example.com"]}},
+		{"id": "unrelated", "common_name": "api.example.com"}
+	]}`
+
+	t.Run("server filter", func(t *testing.T) {
+		var queries []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query().Get("dns_name")
+			queries = append(queries, q)
+			// Return the whole inventory to check client side filtering too.
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(inventory))
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		certs, err := client.Certificates.SearchByDNSName(context.Background(), "WWW.example.com.", nil)
+		if err != nil {
+			t.Fatalf("SearchByDNSName() error = %v", err)
+		}
+		if len(queries) != 2 || queries[0] != "www.example.com" || queries[1] != "*.example.com" {
+			t.Errorf("queries = %v, want host and wildcard", queries)
+		}
+		var ids []string
+		for _, c := range certs {
+			ids = append(ids, c.ID)
+		}
+		if len(ids) != 3 || ids[0] != "multi" || ids[1] != "wildcard" || ids[2] != "sans" {
+			t.Errorf("SearchByDNSName() = %v, want [multi wildcard sans]", ids)
+		}
+	})
+
+	t.Run("client side fallback", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("dns_name") != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code": "invalid_parameter", "message": "unknown parameter dns_name"}`))
+				return
+			}
+			w.Write([]byte(inventory))
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		certs, err := client.Certificates.SearchByDNSName(context.Background(), "api.example.com", nil)
+		if err != nil {
+			t.Fatalf("SearchByDNSName() error = %v", err)
+		}
+		if len(certs) != 2 || certs[0].ID != "wildcard" || certs[1].ID != "unrelated" {
+			t.Errorf("SearchByDNSName() = %+v, want wildcard and api certificates", certs)
+		}
+	})
+}
@@ -0,0 +1,201 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jonhadfield/go-digicert-tlm/report"
+)
+
+const DefaultSeatProjectionMonths = 3
+
+// SeatUsageColumns lists the columns SeatUsageReport.Write can write to CSV
+// and XLSX, in their default order.
+var SeatUsageColumns = []string{
+	"business_unit_id", "business_unit", "seat_type", "total", "used", "available",
+	"utilization_pct", "projected_used", "shortfall", "monthly_cost", "shortfall_cost",
+}
+
+type SeatUsageOptions struct {
+	// SeatPrices maps a seat type to its price per seat per month, in the
+	// currency the caller bills in. Seat types without a price cost nothing
+	// and are listed in UnpricedSeatTypes.
+	SeatPrices map[string]float64
+	// MonthlyGrowth is the expected monthly growth in used seats, such as
+	// 0.05 for 5%. Zero projects current usage.
+	MonthlyGrowth float64
+	// ProjectionMonths is how far ahead used seats are projected. Defaults to
+	// DefaultSeatProjectionMonths.
+	ProjectionMonths int
+	// Now overrides the current time, for tests.
+	Now func() time.Time
+}
+
+// SeatUsage is the usage of one seat type in one business unit, or across
+// every business unit in SeatUsageReport.Totals. SeatType is empty for
+// business units that do not break their seats down by type.
+type SeatUsage struct {
+	BusinessUnitID   string `json:"business_unit_id,omitempty"`
+	BusinessUnitName string `json:"business_unit_name,omitempty"`
+	SeatType         string `json:"seat_type"`
+	Total            int    `json:"total"`
+	Used             int    `json:"used"`
+	Available        int    `json:"available"`
+	// Utilization is Used as a fraction of Total, or zero without seats.
+	Utilization float64 `json:"utilization"`
+	// ProjectedUsed is Used grown by MonthlyGrowth over ProjectionMonths.
+	ProjectedUsed int `json:"projected_used"`
+	// Shortfall is the seats to buy to cover ProjectedUsed. In Totals it
+	// sums the shortfalls of the business units, as seats are not shared.
+	Shortfall     int     `json:"shortfall"`
+	MonthlyCost   float64 `json:"monthly_cost"`
+	ShortfallCost float64 `json:"shortfall_cost"`
+}
+
+type SeatUsageReport struct {
+	GeneratedAt      time.Time `json:"generated_at"`
+	ProjectionMonths int       `json:"projection_months"`
+	MonthlyGrowth    float64   `json:"monthly_growth"`
+	// Usage holds one entry per business unit and seat type, ordered by
+	// business unit name and then seat type.
+	Usage []SeatUsage `json:"usage"`
+	// Totals sums Usage per seat type.
+	Totals            []SeatUsage `json:"totals"`
+	MonthlyCost       float64     `json:"monthly_cost"`
+	ShortfallCost     float64     `json:"shortfall_cost"`
+	UnpricedSeatTypes []string    `json:"unpriced_seat_types,omitempty"`
+}
+
+// ReportSeatUsage combines the licensed seats of every business unit with
+// the caller's seat prices into the utilization, cost and projected
+// shortfall per business unit and seat type that finance reviews monthly.
+// It makes one GetLicensedSeats request per business unit.
+func (s *BusinessUnitsService) ReportSeatUsage(ctx context.Context, opts *SeatUsageOptions) (*SeatUsageReport, error) {
+	if opts == nil {
+		opts = &SeatUsageOptions{}
+	}
+	months := opts.ProjectionMonths
+	if months <= 0 {
+		months = DefaultSeatProjectionMonths
+	}
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	units, err := s.listAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("seat usage: list business units: %w", err)
+	}
+	sort.SliceStable(units, func(i, j int) bool { return units[i].Name < units[j].Name })
+
+	r := &SeatUsageReport{GeneratedAt: now, ProjectionMonths: months, MonthlyGrowth: opts.MonthlyGrowth}
+	growth := math.Pow(1+opts.MonthlyGrowth, float64(months))
+	totals := make(map[string]*SeatUsage)
+	unpriced := make(map[string]bool)
+
+	for _, bu := range units {
+		seats, _, err := s.GetLicensedSeats(ctx, bu.ID)
+		if err != nil {
+			return nil, fmt.Errorf("seat usage: business unit %s: %w", bu.ID, err)
+		}
+		allocations := seats.SeatTypes
+		if len(allocations) == 0 {
+			allocations = []SeatTypeAllocation{{Total: seats.TotalSeats, Used: seats.UsedSeats, Available: seats.AvailableSeats}}
+		}
+		sort.SliceStable(allocations, func(i, j int) bool { return allocations[i].Type < allocations[j].Type })
+
+		for _, a := range allocations {
+			price, ok := opts.SeatPrices[a.Type]
+			if !ok {
+				unpriced[a.Type] = true
+			}
+			// The epsilon keeps float error from adding a seat to exact
+			// projections.
+			projected := int(math.Ceil(float64(a.Used)*growth - 1e-9))
+			u := SeatUsage{
+				BusinessUnitID:   bu.ID,
+				BusinessUnitName: bu.Name,
+				SeatType:         a.Type,
+				Total:            a.Total,
+				Used:             a.Used,
+				Available:        a.Available,
+				Utilization:      utilization(a.Used, a.Total),
+				ProjectedUsed:    projected,
+				Shortfall:        max(0, projected-a.Total),
+			}
+			u.MonthlyCost = float64(u.Total) * price
+			u.ShortfallCost = float64(u.Shortfall) * price
+			r.Usage = append(r.Usage, u)
+
+			t := totals[a.Type]
+			if t == nil {
+				t = &SeatUsage{SeatType: a.Type}
+				totals[a.Type] = t
+			}
+			t.Total += u.Total
+			t.Used += u.Used
+			t.Available += u.Available
+			t.ProjectedUsed += u.ProjectedUsed
+			t.Shortfall += u.Shortfall
+			t.MonthlyCost += u.MonthlyCost
+			t.ShortfallCost += u.ShortfallCost
+		}
+	}
+
+	for _, seatType := range sortedKeys(totals) {
+		t := totals[seatType]
+		t.Utilization = utilization(t.Used, t.Total)
+		r.Totals = append(r.Totals, *t)
+		r.MonthlyCost += t.MonthlyCost
+		r.ShortfallCost += t.ShortfallCost
+	}
+	r.UnpricedSeatTypes = sortedKeys(unpriced)
+	return r, nil
+}
+
+func utilization(used, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(used) / float64(total)
+}
+
+// Write renders the report to w. JSON writes the whole report, and CSV and
+// XLSX one row per business unit and seat type, with columns chosen from
+// SeatUsageColumns by opts.
+func (r *SeatUsageReport) Write(w io.Writer, format ExportFormat, opts *report.Options) error {
+	switch format {
+	case ExportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case ExportFormatCSV, ExportFormatXLSX:
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+
+	records := make([]report.Record, 0, len(r.Usage))
+	for _, u := range r.Usage {
+		records = append(records, report.Record{
+			"business_unit_id": u.BusinessUnitID,
+			"business_unit":    u.BusinessUnitName,
+			"seat_type":        u.SeatType,
+			"total":            strconv.Itoa(u.Total),
+			"used":             strconv.Itoa(u.Used),
+			"available":        strconv.Itoa(u.Available),
+			"utilization_pct":  strconv.FormatFloat(u.Utilization*100, 'f', 1, 64),
+			"projected_used":   strconv.Itoa(u.ProjectedUsed),
+			"shortfall":        strconv.Itoa(u.Shortfall),
+			"monthly_cost":     strconv.FormatFloat(u.MonthlyCost, 'f', 2, 64),
+			"shortfall_cost":   strconv.FormatFloat(u.ShortfallCost, 'f', 2, 64),
+		})
+	}
+	return report.Write(w, report.Format(format), SeatUsageColumns, records, opts)
+}
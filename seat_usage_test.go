@@ -0,0 +1,92 @@
+package digicert
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBusinessUnitsService_ReportSeatUsage(t *testing.T) {
+	seats := map[string]LicensedSeats{
+		"bu-ops": {SeatTypes: []SeatTypeAllocation{
+			{Type: "server", Total: 100, Used: 95, Available: 5},
+			{Type: "user", Total: 50, Used: 10, Available: 40},
+		}},
+		"bu-dev": {TotalSeats: 20, UsedSeats: 20},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mpki/api/v1/business-unit":
+			json.NewEncoder(w).Encode(BusinessUnitListResponse{
+				ListResponse:  ListResponse{Total: 2},
+				BusinessUnits: []BusinessUnit{{ID: "bu-ops", Name: "Ops"}, {ID: "bu-dev", Name: "Dev"}},
+			})
+		case "/mpki/api/v1/business-unit/bu-ops/licensed-seats":
+			json.NewEncoder(w).Encode(seats["bu-ops"])
+		case "/mpki/api/v1/business-unit/bu-dev/licensed-seats":
+			json.NewEncoder(w).Encode(seats["bu-dev"])
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	r, err := client.BusinessUnits.ReportSeatUsage(context.Background(), &SeatUsageOptions{
+		SeatPrices:       map[string]float64{"server": 10, "user": 2.5},
+		MonthlyGrowth:    0.1,
+		ProjectionMonths: 1,
+		Now:              func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("ReportSeatUsage() error = %v", err)
+	}
+
+	want := []SeatUsage{
+		{BusinessUnitID: "bu-dev", BusinessUnitName: "Dev", Total: 20, Used: 20, Utilization: 1, ProjectedUsed: 22, Shortfall: 2},
+		{BusinessUnitID: "bu-ops", BusinessUnitName: "Ops", SeatType: "server", Total: 100, Used: 95, Available: 5,
+			Utilization: 0.95, ProjectedUsed: 105, Shortfall: 5, MonthlyCost: 1000, ShortfallCost: 50},
+		{BusinessUnitID: "bu-ops", BusinessUnitName: "Ops", SeatType: "user", Total: 50, Used: 10, Available: 40,
+			Utilization: 0.2, ProjectedUsed: 11, MonthlyCost: 125},
+	}
+	if !reflect.DeepEqual(r.Usage, want) {
+		t.Errorf("Usage = %+v, want %+v", r.Usage, want)
+	}
+	if len(r.Totals) != 3 || r.Totals[1].SeatType != "server" || r.Totals[1].Shortfall != 5 {
+		t.Errorf("Totals = %+v, want one per seat type", r.Totals)
+	}
+	if r.MonthlyCost != 1125 || r.ShortfallCost != 50 {
+		t.Errorf("costs = %v, %v, want 1125, 50", r.MonthlyCost, r.ShortfallCost)
+	}
+	if !reflect.DeepEqual(r.UnpricedSeatTypes, []string{""}) {
+		t.Errorf("UnpricedSeatTypes = %q, want [\"\"]", r.UnpricedSeatTypes)
+	}
+	if !r.GeneratedAt.Equal(now) {
+		t.Errorf("GeneratedAt = %v, want %v", r.GeneratedAt, now)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf, ExportFormatCSV, nil); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(rows) != 4 || !reflect.DeepEqual(rows[0], SeatUsageColumns) {
+		t.Fatalf("rows = %v, want header and 3 rows", rows)
+	}
+	if got := rows[2]; got[6] != "95.0" || got[9] != "1000.00" {
+		t.Errorf("row = %v, want utilization 95.0 and cost 1000.00", got)
+	}
+}
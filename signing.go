@@ -0,0 +1,174 @@
+package digicert
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the base64 encoded request signature.
+	SignatureHeader = "X-DC-Signature"
+	// SignatureTimestampHeader carries the time the request was signed and is
+	// part of the signed content to limit replay.
+	SignatureTimestampHeader = "X-DC-Signature-Timestamp"
+	// SignatureKeyIDHeader identifies the key used to sign the request.
+	SignatureKeyIDHeader = "X-DC-Signature-Key-ID"
+	// SignatureAlgorithmHeader names the signature algorithm.
+	SignatureAlgorithmHeader = "X-DC-Signature-Algorithm"
+)
+
+// RequestSigner attaches a signature to an outgoing request. body holds the
+// exact bytes that will be sent and is empty for requests without a body.
+// Implementations may wrap an HSM or KMS; see NewCryptoSigner.
+type RequestSigner interface {
+	SignRequest(req *http.Request, body []byte) error
+}
+
+// RequestSignerFunc adapts a function to the RequestSigner interface.
+type RequestSignerFunc func(req *http.Request, body []byte) error
+
+func (f RequestSignerFunc) SignRequest(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// WithRequestSigner signs every request with s before it is sent
+func WithRequestSigner(s RequestSigner) ClientOption {
+	return func(c *Client) error {
+		if s == nil {
+			return fmt.Errorf("request signer cannot be nil")
+		}
+		c.signer = s
+		return nil
+	}
+}
+
+// HMACSigner signs requests with HMAC-SHA256 using a shared secret.
+type HMACSigner struct {
+	Key   []byte
+	KeyID string
+}
+
+// NewHMACSigner returns a signer using key as the shared secret
+func NewHMACSigner(key []byte, keyID string) *HMACSigner {
+	return &HMACSigner{Key: key, KeyID: keyID}
+}
+
+func (s *HMACSigner) SignRequest(req *http.Request, body []byte) error {
+	if len(s.Key) == 0 {
+		return fmt.Errorf("HMAC signing key is empty")
+	}
+	payload := prepareSignature(req, body, "hmac-sha256", s.KeyID)
+
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write(payload)
+	req.Header.Set(SignatureHeader, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+// CryptoSigner signs requests with an asymmetric key held behind a
+// crypto.Signer, which lets the private key stay inside an HSM or KMS.
+type CryptoSigner struct {
+	signer crypto.Signer
+	keyID  string
+	alg    string
+}
+
+// NewCryptoSigner returns a signer backed by s, which must hold an RSA, ECDSA
+// or Ed25519 key
+func NewCryptoSigner(s crypto.Signer, keyID string) (*CryptoSigner, error) {
+	if s == nil {
+		return nil, fmt.Errorf("crypto signer cannot be nil")
+	}
+
+	var alg string
+	switch s.Public().(type) {
+	case *rsa.PublicKey:
+		alg = "rsa-sha256"
+	case *ecdsa.PublicKey:
+		alg = "ecdsa-sha256"
+	case ed25519.PublicKey:
+		alg = "ed25519"
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", s.Public())
+	}
+
+	return &CryptoSigner{signer: s, keyID: keyID, alg: alg}, nil
+}
+
+func (s *CryptoSigner) SignRequest(req *http.Request, body []byte) error {
+	payload := prepareSignature(req, body, s.alg, s.keyID)
+
+	var (
+		sig []byte
+		err error
+	)
+	if s.alg == "ed25519" {
+		sig, err = s.signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(payload)
+		sig, err = s.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	req.Header.Set(SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+	return nil
+}
+
+// prepareSignature sets the signature metadata headers and returns the
+// content to sign: the method, request URI, timestamp and hex SHA-256 of the
+// body, separated by newlines.
+func prepareSignature(req *http.Request, body []byte, alg, keyID string) []byte {
+	ts := req.Header.Get(SignatureTimestampHeader)
+	if ts == "" {
+		ts = time.Now().UTC().Format(time.RFC3339)
+		req.Header.Set(SignatureTimestampHeader, ts)
+	}
+	req.Header.Set(SignatureAlgorithmHeader, alg)
+	if keyID != "" {
+		req.Header.Set(SignatureKeyIDHeader, keyID)
+	}
+
+	bodyHash := sha256.Sum256(body)
+
+	var buf bytes.Buffer
+	buf.WriteString(req.Method)
+	buf.WriteByte('\n')
+	buf.WriteString(req.URL.RequestURI())
+	buf.WriteByte('\n')
+	buf.WriteString(ts)
+	buf.WriteByte('\n')
+	buf.WriteString(hex.EncodeToString(bodyHash[:]))
+	return buf.Bytes()
+}
+
+// signRequest runs the configured signer over req, reading the body through
+// GetBody so the request can still be sent afterwards.
+func (c *Client) signRequest(req *http.Request) error {
+	var body []byte
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		body, err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return c.signer.SignRequest(req, body)
+}
@@ -0,0 +1,138 @@
+package digicert
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// expectedSigningString rebuilds the signed content from a received request.
+func expectedSigningString(r *http.Request, body []byte) []byte {
+	h := sha256.Sum256(body)
+	return []byte(r.Method + "\n" + r.URL.RequestURI() + "\n" + r.Header.Get(SignatureTimestampHeader) + "\n" + hex.EncodeToString(h[:]))
+}
+
+func TestWithRequestSigner_HMAC(t *testing.T) {
+	key := []byte("shared-secret")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Header.Get(SignatureKeyIDHeader) != "key-1" {
+			t.Errorf("%s = %v, want %v", SignatureKeyIDHeader, r.Header.Get(SignatureKeyIDHeader), "key-1")
+		}
+		if r.Header.Get(SignatureAlgorithmHeader) != "hmac-sha256" {
+			t.Errorf("%s = %v, want %v", SignatureAlgorithmHeader, r.Header.Get(SignatureAlgorithmHeader), "hmac-sha256")
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write(expectedSigningString(r, body))
+		want := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		if got := r.Header.Get(SignatureHeader); got != want {
+			t.Errorf("%s = %v, want %v", SignatureHeader, got, want)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "bu-1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithRequestSigner(NewHMACSigner(key, "key-1")))
+
+	t.Run("request with body", func(t *testing.T) {
+		if _, _, err := client.BusinessUnits.Create(ctx, &BusinessUnitRequest{Name: "Engineering"}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	})
+
+	t.Run("request without body", func(t *testing.T) {
+		if _, _, err := client.BusinessUnits.Get(ctx, "bu-1"); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	})
+}
+
+func TestWithRequestSigner_Crypto(t *testing.T) {
+	ctx := context.Background()
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name   string
+		key    crypto.Signer
+		alg    string
+		verify func(msg, sig []byte) bool
+	}{
+		{
+			name: "ECDSA",
+			key:  ecKey,
+			alg:  "ecdsa-sha256",
+			verify: func(msg, sig []byte) bool {
+				digest := sha256.Sum256(msg)
+				return ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], sig)
+			},
+		},
+		{
+			name: "Ed25519",
+			key:  edKey,
+			alg:  "ed25519",
+			verify: func(msg, sig []byte) bool {
+				return ed25519.Verify(edKey.Public().(ed25519.PublicKey), msg, sig)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Header.Get(SignatureAlgorithmHeader) != tt.alg {
+					t.Errorf("%s = %v, want %v", SignatureAlgorithmHeader, r.Header.Get(SignatureAlgorithmHeader), tt.alg)
+				}
+				sig, err := base64.StdEncoding.DecodeString(r.Header.Get(SignatureHeader))
+				if err != nil || !tt.verify(expectedSigningString(r, body), sig) {
+					t.Error("signature did not verify")
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			signer, err := NewCryptoSigner(tt.key, "hsm-key")
+			if err != nil {
+				t.Fatalf("NewCryptoSigner() error = %v", err)
+			}
+			client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithRequestSigner(signer))
+
+			if _, err := client.Certificates.Revoke(ctx, "01", &RevokeRequest{Reason: "superseded"}); err != nil {
+				t.Fatalf("Revoke() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestWithRequestSigner_Error(t *testing.T) {
+	if _, err := NewClient("test-key", WithRequestSigner(nil)); err == nil {
+		t.Error("Expected error for nil signer")
+	}
+
+	client, _ := NewClient("test-key", WithRequestSigner(RequestSignerFunc(func(req *http.Request, body []byte) error {
+		return io.ErrUnexpectedEOF
+	})))
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "profiles", nil)
+	if _, err := client.Do(context.Background(), req, nil); err != io.ErrUnexpectedEOF {
+		t.Errorf("Do() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
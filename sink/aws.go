@@ -0,0 +1,412 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+var (
+	_ digicert.CertificateSink = (*AWSACMSink)(nil)
+	_ digicert.CertificateSink = (*AWSSecretsManagerSink)(nil)
+)
+
+// AWSCredentials are the credentials used to sign requests to AWS with
+// Signature Version 4.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWSCredentialsFromEnv reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN.
+func AWSCredentialsFromEnv() (AWSCredentials, error) {
+	creds := AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return AWSCredentials{}, fmt.Errorf("sink: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+	return creds, nil
+}
+
+// AWSACMSink imports certificates into AWS Certificate Manager using the ACM
+// API directly, so no AWS SDK dependency is required. The first import of a
+// name creates a certificate tagged Name=<name>; later imports of the same
+// name re-import over it, keeping its ARN and any load balancer bindings.
+// The certificate is found by its tags when the name is not yet in
+// CertificateARNs, so a restarted process does not import a duplicate.
+type AWSACMSink struct {
+	Region      string
+	Credentials AWSCredentials
+	// CertificateARNs maps sink names to existing ACM certificate ARNs to
+	// re-import over. Imports and tag lookups update it.
+	CertificateARNs map[string]string
+	// Endpoint overrides https://acm.<region>.amazonaws.com.
+	Endpoint   string
+	HTTPClient *http.Client
+
+	mu sync.Mutex
+}
+
+func NewAWSACMSink(region string, creds AWSCredentials) *AWSACMSink {
+	return &AWSACMSink{Region: region, Credentials: creds, CertificateARNs: make(map[string]string)}
+}
+
+type acmImportRequest struct {
+	Certificate      []byte   `json:"Certificate"`
+	PrivateKey       []byte   `json:"PrivateKey"`
+	CertificateChain []byte   `json:"CertificateChain,omitempty"`
+	CertificateArn   string   `json:"CertificateArn,omitempty"`
+	Tags             []awsTag `json:"Tags,omitempty"`
+}
+
+type awsTag struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+type acmListRequest struct {
+	Includes  acmListFilters `json:"Includes"`
+	MaxItems  int            `json:"MaxItems"`
+	NextToken string         `json:"NextToken,omitempty"`
+}
+
+type acmListFilters struct {
+	KeyTypes []string `json:"keyTypes"`
+}
+
+type acmListResponse struct {
+	CertificateSummaryList []struct {
+		CertificateArn string `json:"CertificateArn"`
+		DomainName     string `json:"DomainName"`
+	} `json:"CertificateSummaryList"`
+	NextToken string `json:"NextToken"`
+}
+
+// acmKeyTypes lists every key type, as ListCertificates returns only RSA 2048
+// certificates by default.
+var acmKeyTypes = []string{"RSA_1024", "RSA_2048", "RSA_3072", "RSA_4096", "EC_prime256v1", "EC_secp384r1", "EC_secp521r1"}
+
+func (s *AWSACMSink) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	client := awsJSONClient{
+		service:     "acm",
+		region:      s.Region,
+		endpoint:    s.Endpoint,
+		credentials: s.Credentials,
+		httpClient:  s.HTTPClient,
+	}
+
+	s.mu.Lock()
+	arn := s.CertificateARNs[name]
+	s.mu.Unlock()
+	if arn == "" {
+		var err error
+		if arn, err = findACMCertificate(ctx, client, name, cert); err != nil {
+			return fmt.Errorf("sink: ACM lookup %s: %w", name, err)
+		}
+	}
+
+	req := acmImportRequest{Certificate: cert, PrivateKey: key, CertificateChain: chain, CertificateArn: arn}
+	if arn == "" {
+		// Tags may only be given when importing a new certificate.
+		req.Tags = []awsTag{{Key: "Name", Value: name}, {Key: "managed-by", Value: "go-digicert"}}
+	}
+
+	var out struct {
+		CertificateArn string `json:"CertificateArn"`
+	}
+	if err := client.call(ctx, "CertificateManager.ImportCertificate", req, &out); err != nil {
+		return fmt.Errorf("sink: ACM import %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	if s.CertificateARNs == nil {
+		s.CertificateARNs = make(map[string]string)
+	}
+	s.CertificateARNs[name] = out.CertificateArn
+	s.mu.Unlock()
+	return nil
+}
+
+// findACMCertificate returns the ARN of the certificate an earlier import
+// tagged with name, or "" if there is none. Only certificates for the same
+// domain as cert have their tags read.
+func findACMCertificate(ctx context.Context, client awsJSONClient, name string, cert []byte) (string, error) {
+	var domain string
+	if block, _ := pem.Decode(cert); block != nil {
+		if leaf, err := x509.ParseCertificate(block.Bytes); err == nil {
+			domain = leaf.Subject.CommonName
+			if domain == "" && len(leaf.DNSNames) > 0 {
+				domain = leaf.DNSNames[0]
+			}
+		}
+	}
+
+	req := acmListRequest{Includes: acmListFilters{KeyTypes: acmKeyTypes}, MaxItems: 1000}
+	for {
+		var list acmListResponse
+		if err := client.call(ctx, "CertificateManager.ListCertificates", req, &list); err != nil {
+			return "", err
+		}
+		for _, c := range list.CertificateSummaryList {
+			if domain != "" && c.DomainName != domain {
+				continue
+			}
+			var tags struct {
+				Tags []awsTag `json:"Tags"`
+			}
+			if err := client.call(ctx, "CertificateManager.ListTagsForCertificate", map[string]string{"CertificateArn": c.CertificateArn}, &tags); err != nil {
+				return "", err
+			}
+			if hasTag(tags.Tags, "Name", name) && hasTag(tags.Tags, "managed-by", "go-digicert") {
+				return c.CertificateArn, nil
+			}
+		}
+		if list.NextToken == "" {
+			return "", nil
+		}
+		req.NextToken = list.NextToken
+	}
+}
+
+func hasTag(tags []awsTag, key, value string) bool {
+	for _, t := range tags {
+		if t.Key == key && t.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// AWSSecretsManagerSink stores certificate material in AWS Secrets Manager as
+// a JSON secret named <NamePrefix><name> with the keys "certificate",
+// "private_key" and "chain". The secret is created if missing.
+type AWSSecretsManagerSink struct {
+	Region      string
+	Credentials AWSCredentials
+	NamePrefix  string
+	// KMSKeyID is the KMS key used to encrypt newly created secrets. The
+	// account default is used when empty.
+	KMSKeyID string
+	// Endpoint overrides https://secretsmanager.<region>.amazonaws.com.
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+func NewAWSSecretsManagerSink(region string, creds AWSCredentials) *AWSSecretsManagerSink {
+	return &AWSSecretsManagerSink{Region: region, Credentials: creds}
+}
+
+func (s *AWSSecretsManagerSink) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	secret, err := json.Marshal(map[string]string{
+		"certificate": string(cert),
+		"private_key": string(key),
+		"chain":       string(chain),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := awsJSONClient{
+		service:     "secretsmanager",
+		region:      s.Region,
+		endpoint:    s.Endpoint,
+		credentials: s.Credentials,
+		httpClient:  s.HTTPClient,
+	}
+	id := s.NamePrefix + name
+	err = client.call(ctx, "secretsmanager.PutSecretValue", map[string]string{
+		"SecretId":     id,
+		"SecretString": string(secret),
+	}, nil)
+
+	var awsErr *awsError
+	if errors.As(err, &awsErr) && awsErr.Type == "ResourceNotFoundException" {
+		create := map[string]interface{}{
+			"Name":         id,
+			"SecretString": string(secret),
+			"Tags":         []awsTag{{Key: "managed-by", Value: "go-digicert"}},
+		}
+		if s.KMSKeyID != "" {
+			create["KmsKeyId"] = s.KMSKeyID
+		}
+		err = client.call(ctx, "secretsmanager.CreateSecret", create, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("sink: secrets manager %s: %w", id, err)
+	}
+	return nil
+}
+
+// awsError is an error returned by an AWS JSON protocol API.
+type awsError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *awsError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s: %s", e.StatusCode, e.Type, e.Message)
+}
+
+// awsJSONClient calls AWS services using the JSON 1.1 protocol.
+type awsJSONClient struct {
+	service     string
+	region      string
+	endpoint    string
+	credentials AWSCredentials
+	httpClient  *http.Client
+}
+
+func (c awsJSONClient) call(ctx context.Context, target string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	endpoint := c.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", c.service, c.region)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+	signAWSRequest(req, body, c.credentials, c.region, c.service, time.Now())
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(data, &e)
+		// __type may be qualified, e.g. "com.amazonaws...#ResourceNotFoundException".
+		if i := strings.LastIndex(e.Type, "#"); i >= 0 {
+			e.Type = e.Type[i+1:]
+		}
+		return &awsError{StatusCode: resp.StatusCode, Type: e.Type, Message: e.Message}
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers to req, signing the
+// host and every header already set.
+func signAWSRequest(req *http.Request, body []byte, creds AWSCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscape percent-encodes s as SigV4 requires: everything except unreserved
+// characters, with spaces as %20.
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,165 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+var _ digicert.CertificateSink = (*AzureKeyVaultSink)(nil)
+
+const azureKeyVaultAPIVersion = "7.4"
+
+// azureCertificateName is the character set Key Vault allows in object names.
+var azureCertificateName = regexp.MustCompile(`^[0-9A-Za-z-]{1,127}$`)
+
+// AzureTokenFunc returns a bearer token for the https://vault.azure.net
+// resource.
+type AzureTokenFunc func(ctx context.Context) (string, error)
+
+// AzureKeyVaultSink imports certificates into Azure Key Vault using the REST
+// API directly, so no Azure SDK dependency is required. Each Store creates a
+// new version of the certificate <name>, with the key, certificate and chain
+// imported as a single PEM bundle. Key Vault names may contain only letters,
+// digits and hyphens.
+type AzureKeyVaultSink struct {
+	// VaultURL is the vault's base URL, e.g. https://my-vault.vault.azure.net.
+	VaultURL   string
+	Token      AzureTokenFunc
+	Tags       map[string]string
+	HTTPClient *http.Client
+}
+
+func NewAzureKeyVaultSink(vaultURL string, token AzureTokenFunc) *AzureKeyVaultSink {
+	return &AzureKeyVaultSink{VaultURL: vaultURL, Token: token}
+}
+
+type azureImportRequest struct {
+	Value  string            `json:"value"`
+	Policy azurePolicy       `json:"policy"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+type azurePolicy struct {
+	SecretProps struct {
+		ContentType string `json:"contentType"`
+	} `json:"secret_props"`
+}
+
+func (s *AzureKeyVaultSink) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	if !azureCertificateName.MatchString(name) {
+		return fmt.Errorf("sink: invalid Key Vault certificate name %q", name)
+	}
+	if s.Token == nil {
+		return fmt.Errorf("sink: AzureKeyVaultSink has no token source")
+	}
+	token, err := s.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("sink: azure token: %w", err)
+	}
+
+	var bundle bytes.Buffer
+	for _, part := range [][]byte{key, cert, chain} {
+		if len(part) > 0 {
+			bundle.Write(bytes.TrimSpace(part))
+			bundle.WriteByte('\n')
+		}
+	}
+	body := azureImportRequest{Value: bundle.String(), Tags: s.Tags}
+	body.Policy.SecretProps.ContentType = "application/x-pem-file"
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	importURL := fmt.Sprintf("%s/certificates/%s/import?api-version=%s", strings.TrimSuffix(s.VaultURL, "/"), name, azureKeyVaultAPIVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, importURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sink: key vault import %s: HTTP %d: %s", name, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// AzureClientCredentials returns a token source using the OAuth 2.0 client
+// credentials flow for a service principal. Tokens are cached until shortly
+// before they expire.
+func AzureClientCredentials(tenantID, clientID, clientSecret string) AzureTokenFunc {
+	return azureClientCredentials("https://login.microsoftonline.com", tenantID, clientID, clientSecret, http.DefaultClient)
+}
+
+func azureClientCredentials(authority, tenantID, clientID, clientSecret string, httpClient *http.Client) AzureTokenFunc {
+	var (
+		mu      sync.Mutex
+		token   string
+		expires time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if token != "" && time.Now().Before(expires) {
+			return token, nil
+		}
+
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"scope":         {"https://vault.azure.net/.default"},
+		}
+		tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", authority, url.PathEscape(tenantID))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		var result struct {
+			AccessToken      string `json:"access_token"`
+			ExpiresIn        int    `json:"expires_in"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+			return "", fmt.Errorf("invalid token response: HTTP %d", resp.StatusCode)
+		}
+		if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+			return "", fmt.Errorf("token request failed: HTTP %d: %s", resp.StatusCode, result.ErrorDescription)
+		}
+
+		token = result.AccessToken
+		expires = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - time.Minute)
+		return token, nil
+	}
+}
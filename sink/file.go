@@ -0,0 +1,96 @@
+// Package sink provides digicert.CertificateSink implementations for common
+// destinations of issued certificate material.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+var _ digicert.CertificateSink = (*FileSink)(nil)
+
+// FileSink writes certificate material as PEM files in a directory:
+// <name>.crt, <name>.key and <name>.chain.pem. The key file is always written
+// before the certificate so readers never observe a certificate without its key.
+type FileSink struct {
+	Dir string
+
+	// CertMode is applied to the certificate and chain files. Defaults to 0644.
+	CertMode os.FileMode
+	// KeyMode is applied to the private key file. Defaults to 0600.
+	KeyMode os.FileMode
+	// FullChain appends the chain to the certificate file, as expected by
+	// servers such as nginx.
+	FullChain bool
+}
+
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{Dir: dir, CertMode: 0644, KeyMode: 0600}
+}
+
+func (s *FileSink) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return err
+	}
+
+	certMode, keyMode := s.CertMode, s.KeyMode
+	if certMode == 0 {
+		certMode = 0644
+	}
+	if keyMode == 0 {
+		keyMode = 0600
+	}
+
+	if len(key) > 0 {
+		if err := writeFileAtomic(filepath.Join(s.Dir, name+".key"), key, keyMode); err != nil {
+			return err
+		}
+	}
+	if len(chain) > 0 {
+		if err := writeFileAtomic(filepath.Join(s.Dir, name+".chain.pem"), chain, certMode); err != nil {
+			return err
+		}
+	}
+
+	certData := cert
+	if s.FullChain && len(chain) > 0 {
+		certData = append(append(append([]byte{}, cert...), '\n'), chain...)
+	}
+	return writeFileAtomic(filepath.Join(s.Dir, name+".crt"), certData, certMode)
+}
+
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func validateName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("sink: invalid name %q", name)
+	}
+	return nil
+}
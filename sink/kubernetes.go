@@ -0,0 +1,188 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+	"github.com/jonhadfield/go-digicert-tlm/internal/kube"
+)
+
+var _ digicert.CertificateSink = (*KubernetesSecretSink)(nil)
+
+// Annotations set on secrets written by KubernetesSecretSink, describing the
+// certificate they hold.
+const (
+	AnnotationSerialNumber = "digicert.com/serial-number"
+	AnnotationNotBefore    = "digicert.com/not-before"
+	AnnotationNotAfter     = "digicert.com/not-after"
+	AnnotationCommonName   = "digicert.com/common-name"
+	AnnotationFingerprint  = "digicert.com/sha256-fingerprint"
+)
+
+// KubernetesSecretSink stores certificate material in a kubernetes.io/tls
+// Secret using the Kubernetes REST API directly, so no client-go dependency is
+// required. The secret is created if missing and replaced otherwise, and is
+// annotated with the certificate's serial number and validity. Storing the
+// material a secret already holds is a no-op, so Store can be called on every
+// reconcile.
+type KubernetesSecretSink struct {
+	// APIServer is the base URL of the Kubernetes API, e.g. https://10.0.0.1:443.
+	APIServer  string
+	Token      string
+	Namespace  string
+	HTTPClient *http.Client
+
+	// Labels and Annotations are added to every secret written.
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// OnChange, if set, is called after Store creates a secret or changes
+	// its contents, e.g. to restart the workloads using it.
+	OnChange func(ctx context.Context, name string) error
+}
+
+// NewInClusterKubernetesSink configures a sink from the pod's service account.
+func NewInClusterKubernetesSink() (*KubernetesSecretSink, error) {
+	c, err := kube.InCluster()
+	if err != nil {
+		return nil, err
+	}
+	return &KubernetesSecretSink{
+		APIServer:  c.APIServer,
+		Token:      c.Token,
+		Namespace:  c.Namespace,
+		HTTPClient: c.HTTPClient,
+	}, nil
+}
+
+type kubernetesSecret struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   kubernetesMeta    `json:"metadata"`
+	Type       string            `json:"type"`
+	Data       map[string][]byte `json:"data"`
+}
+
+type kubernetesMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+func (s *KubernetesSecretSink) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	annotations, err := certificateAnnotations(cert)
+	if err != nil {
+		return err
+	}
+
+	tlsCrt := cert
+	if len(chain) > 0 {
+		tlsCrt = append(append(append([]byte{}, bytes.TrimSpace(cert)...), '\n'), chain...)
+	}
+
+	secret := kubernetesSecret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: kubernetesMeta{
+			Name:        name,
+			Namespace:   s.Namespace,
+			Labels:      map[string]string{"app.kubernetes.io/managed-by": "go-digicert"},
+			Annotations: annotations,
+		},
+		Type: "kubernetes.io/tls",
+		Data: map[string][]byte{
+			"tls.crt": tlsCrt,
+			"tls.key": key,
+		},
+	}
+	if len(chain) > 0 {
+		secret.Data["ca.crt"] = chain
+	}
+	for k, v := range s.Labels {
+		secret.Metadata.Labels[k] = v
+	}
+	for k, v := range s.Annotations {
+		secret.Metadata.Annotations[k] = v
+	}
+
+	client := s.client()
+	itemURL := client.URL("/api/v1", "secrets") + "/" + name
+	var existing kubernetesSecret
+	status, err := client.Do(ctx, http.MethodGet, itemURL, "", nil, &existing)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case http.StatusOK:
+		if sameData(existing.Data, secret.Data) {
+			return nil
+		}
+		// Replace only the version we read so concurrent writers conflict
+		// rather than overwrite each other.
+		secret.Metadata.ResourceVersion = existing.Metadata.ResourceVersion
+		status, err = client.Do(ctx, http.MethodPut, itemURL, "application/json", secret, nil)
+	case http.StatusNotFound:
+		status, err = client.Do(ctx, http.MethodPost, client.URL("/api/v1", "secrets"), "application/json", secret, nil)
+	default:
+		return fmt.Errorf("sink: get kubernetes secret %s/%s: HTTP %d", s.Namespace, name, status)
+	}
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("sink: kubernetes secret %s/%s: HTTP %d", s.Namespace, name, status)
+	}
+
+	if s.OnChange != nil {
+		return s.OnChange(ctx, name)
+	}
+	return nil
+}
+
+func (s *KubernetesSecretSink) client() *kube.Client {
+	return &kube.Client{APIServer: s.APIServer, Token: s.Token, Namespace: s.Namespace, HTTPClient: s.HTTPClient}
+}
+
+// sameData reports whether a secret already holds the given material.
+func sameData(existing, data map[string][]byte) bool {
+	if len(existing) != len(data) {
+		return false
+	}
+	for k, v := range data {
+		if !bytes.Equal(existing[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// certificateAnnotations describes the leaf certificate in cert.
+func certificateAnnotations(cert []byte) (map[string]string, error) {
+	block, _ := pem.Decode(cert)
+	if block == nil {
+		return nil, fmt.Errorf("sink: certificate is not PEM encoded")
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("sink: invalid certificate: %w", err)
+	}
+	fingerprint := sha256.Sum256(leaf.Raw)
+	return map[string]string{
+		AnnotationSerialNumber: strings.ToUpper(leaf.SerialNumber.Text(16)),
+		AnnotationNotBefore:    leaf.NotBefore.UTC().Format(time.RFC3339),
+		AnnotationNotAfter:     leaf.NotAfter.UTC().Format(time.RFC3339),
+		AnnotationCommonName:   leaf.Subject.CommonName,
+		AnnotationFingerprint:  hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
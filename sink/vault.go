@@ -0,0 +1,82 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	digicert "github.com/jonhadfield/go-digicert-tlm"
+)
+
+var _ digicert.CertificateSink = (*VaultKVSink)(nil)
+
+// VaultKVSink stores certificate material in a HashiCorp Vault KV version 2
+// secrets engine at <Mount>/data/<PathPrefix>/<name>. The secret holds the
+// keys "certificate", "private_key" and "chain".
+type VaultKVSink struct {
+	Address    string
+	Token      string
+	Mount      string
+	PathPrefix string
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace  string
+	HTTPClient *http.Client
+}
+
+func NewVaultKVSink(address, token, mount string) *VaultKVSink {
+	return &VaultKVSink{Address: address, Token: token, Mount: mount}
+}
+
+func (s *VaultKVSink) Store(ctx context.Context, name string, cert, key, chain []byte) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	path := strings.Trim(s.Mount, "/") + "/data/"
+	if prefix := strings.Trim(s.PathPrefix, "/"); prefix != "" {
+		path += prefix + "/"
+	}
+	path += url.PathEscape(name)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			"certificate": string(cert),
+			"private_key": string(key),
+			"chain":       string(chain),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := strings.TrimSuffix(s.Address, "/") + "/v1/" + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", s.Token)
+	if s.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.Namespace)
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sink: vault write %s: HTTP %d: %s", path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type TagsService struct {
+	client *Client
+}
+
+// Tags service lists the tags in use on certificates, business units and
+// agents, and renames or deletes them across every resource at once, so tag
+// hygiene can be automated
+
+// Resource types counted in Tag.Counts.
+const (
+	TagResourceCertificate  = "certificate"
+	TagResourceBusinessUnit = "business_unit"
+	TagResourceAgent        = "agent"
+)
+
+type Tag struct {
+	Name string `json:"name"`
+	// Counts holds the number of resources carrying the tag, by resource
+	// type.
+	Counts map[string]int `json:"counts,omitempty"`
+	Total  int            `json:"total"`
+}
+
+// InUse reports whether any resource carries the tag.
+func (t Tag) InUse() bool {
+	return t.Total > 0
+}
+
+type TagListOptions struct {
+	PaginationParams
+	ResourceType string `url:"resource_type,omitempty"`
+	Prefix       string `url:"prefix,omitempty"`
+}
+
+type TagListResponse struct {
+	ListResponse
+	Tags []Tag `json:"tags"`
+}
+
+type TagRenameRequest struct {
+	Name string `json:"name"`
+}
+
+// TagRenameResult reports a rename. Merged is set when the new name was
+// already in use, in which case the two tags are now one.
+type TagRenameResult struct {
+	Name    string `json:"name"`
+	Updated int    `json:"updated"`
+	Merged  bool   `json:"merged,omitempty"`
+}
+
+// List lists the tags in use with their resource counts
+func (s *TagsService) List(ctx context.Context, opts *TagListOptions) (*TagListResponse, *Response, error) {
+	u := "tag"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.ResourceType != "" {
+			q.Add("resource_type", opts.ResourceType)
+		}
+		if opts.Prefix != "" {
+			q.Add("prefix", opts.Prefix)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result TagListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// Rename renames a tag on every resource carrying it
+func (s *TagsService) Rename(ctx context.Context, name, newName string) (*TagRenameResult, *Response, error) {
+	if name == "" || newName == "" {
+		return nil, nil, fmt.Errorf("tag names cannot be empty")
+	}
+
+	u := fmt.Sprintf("tag/%s/rename", url.PathEscape(name))
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, &TagRenameRequest{Name: newName})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result TagRenameResult
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// Delete deletes a tag. The API refuses, with a conflict, to delete a tag
+// that is still in use.
+func (s *TagsService) Delete(ctx context.Context, name string) (*Response, error) {
+	if name == "" {
+		return nil, fmt.Errorf("tag name cannot be empty")
+	}
+
+	u := fmt.Sprintf("tag/%s", url.PathEscape(name))
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, httpReq, nil)
+}
+
+// DeleteUnused deletes every tag no resource carries and returns the names
+// deleted. A tag that came into use since it was listed is skipped rather
+// than treated as a failure.
+func (s *TagsService) DeleteUnused(ctx context.Context) ([]string, error) {
+	var unused []string
+	opts := &TagListOptions{PaginationParams: PaginationParams{Limit: 100}}
+	for {
+		page, _, err := s.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range page.Tags {
+			if !tag.InUse() {
+				unused = append(unused, tag.Name)
+			}
+		}
+		opts.Offset += len(page.Tags)
+		if len(page.Tags) == 0 || opts.Offset >= page.Total {
+			break
+		}
+	}
+
+	var deleted []string
+	for _, name := range unused {
+		if _, err := s.Delete(ctx, name); err != nil {
+			if IsConflict(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("delete tag %q: %w", name, err)
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,92 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTagsService_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/tag" {
+			t.Errorf("Expected path /mpki/api/v1/tag, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("resource_type") != TagResourceCertificate || r.URL.Query().Get("prefix") != "env:" {
+			t.Errorf("query = %v, want resource type and prefix filters", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total":1,"tags":[{"name":"env:prod","counts":{"certificate":12,"agent":2},"total":14}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, _, err := client.Tags.List(context.Background(), &TagListOptions{ResourceType: TagResourceCertificate, Prefix: "env:"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(result.Tags) != 1 || result.Tags[0].Counts[TagResourceCertificate] != 12 || !result.Tags[0].InUse() {
+		t.Errorf("Tags = %+v", result.Tags)
+	}
+}
+
+func TestTagsService_Rename(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.EscapedPath() != "/mpki/api/v1/tag/team%2Fweb/rename" {
+			t.Errorf("request = %s %s, want POST of the escaped tag", r.Method, r.URL.EscapedPath())
+		}
+		var body TagRenameRequest
+		json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TagRenameResult{Name: body.Name, Updated: 7})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, _, err := client.Tags.Rename(context.Background(), "team/web", "team:web")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if result.Name != "team:web" || result.Updated != 7 {
+		t.Errorf("Rename() = %+v, want 7 resources renamed to team:web", result)
+	}
+	if _, _, err := client.Tags.Rename(context.Background(), "team/web", ""); err == nil {
+		t.Error("Rename() expected error for an empty name")
+	}
+}
+
+func TestTagsService_DeleteUnused(t *testing.T) {
+	var deletes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("offset") == "":
+			w.Write([]byte(`{"total":3,"tags":[{"name":"old","total":0},{"name":"prod","total":4}]}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"total":3,"tags":[{"name":"racy","total":0}]}`))
+		case r.Method == http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			if r.URL.Path == "/mpki/api/v1/tag/racy" {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"code":"tag_in_use"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	deleted, err := client.Tags.DeleteUnused(context.Background())
+	if err != nil {
+		t.Fatalf("DeleteUnused() error = %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "old" {
+		t.Errorf("deleted = %v, want [old]", deleted)
+	}
+	if len(deletes) != 2 {
+		t.Errorf("deletes = %v, want old and racy attempted", deletes)
+	}
+}
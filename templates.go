@@ -0,0 +1,196 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type TemplatesService struct {
+	client *Client
+}
+
+// Templates service manages the email templates sent for enrollments and
+// certificate notifications, so branding can be rolled out to many accounts
+// from code
+
+// TemplateType identifies the event a notification template is sent for.
+type TemplateType string
+
+const (
+	TemplateTypeEnrollmentInvite   TemplateType = "enrollment_invite"
+	TemplateTypeEnrollmentApproved TemplateType = "enrollment_approved"
+	TemplateTypeCertificateIssued  TemplateType = "certificate_issued"
+	TemplateTypeExpiryNotice       TemplateType = "expiry_notice"
+	TemplateTypeRevocationNotice   TemplateType = "revocation_notice"
+)
+
+type Template struct {
+	ID             string       `json:"id,omitempty"`
+	Name           string       `json:"name,omitempty"`
+	Type           TemplateType `json:"type,omitempty"`
+	Locale         string       `json:"locale,omitempty"`
+	BusinessUnitID string       `json:"business_unit_id,omitempty"`
+	Subject        string       `json:"subject,omitempty"`
+	Body           string       `json:"body,omitempty"`
+	// Format is "html" or "text".
+	Format    string `json:"format,omitempty"`
+	IsDefault bool   `json:"is_default,omitempty"`
+	// Variables lists the placeholders, such as "common_name", the body may use.
+	Variables []string   `json:"variables,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type TemplateListOptions struct {
+	PaginationParams
+	Type           TemplateType `url:"type,omitempty"`
+	BusinessUnitID string       `url:"business_unit_id,omitempty"`
+	Locale         string       `url:"locale,omitempty"`
+}
+
+type TemplateListResponse struct {
+	ListResponse
+	Templates []Template `json:"templates"`
+}
+
+// TemplateUpdateRequest changes the fields that are set, leaving the others
+// as they are.
+type TemplateUpdateRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Subject *string `json:"subject,omitempty"`
+	Body    *string `json:"body,omitempty"`
+	Format  *string `json:"format,omitempty"`
+}
+
+// TemplatePreviewRequest renders a template with sample values. Subject and
+// Body, when set, are rendered in place of the saved ones, so a change can be
+// checked before it is saved.
+type TemplatePreviewRequest struct {
+	Subject   string            `json:"subject,omitempty"`
+	Body      string            `json:"body,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+type TemplatePreview struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	Format  string `json:"format,omitempty"`
+}
+
+// List lists notification templates
+func (s *TemplatesService) List(ctx context.Context, opts *TemplateListOptions) (*TemplateListResponse, *Response, error) {
+	u := "notification-template"
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Add query parameters
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Type != "" {
+			q.Add("type", string(opts.Type))
+		}
+		if opts.BusinessUnitID != "" {
+			q.Add("business_unit_id", opts.BusinessUnitID)
+		}
+		if opts.Locale != "" {
+			q.Add("locale", opts.Locale)
+		}
+		if opts.Offset > 0 && opts.Limit > 0 {
+			q.Add("offset", fmt.Sprintf("%d", opts.Offset))
+			q.Add("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
+	var result TemplateListResponse
+	resp, err := s.client.Do(ctx, httpReq, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// Get retrieves a notification template by ID
+func (s *TemplatesService) Get(ctx context.Context, templateID string) (*Template, *Response, error) {
+	u := fmt.Sprintf("notification-template/%s", templateID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var template Template
+	resp, err := s.client.Do(ctx, httpReq, &template)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &template, resp, nil
+}
+
+// Update changes the subject, body, name or format of a template
+func (s *TemplatesService) Update(ctx context.Context, templateID string, req *TemplateUpdateRequest) (*Template, *Response, error) {
+	if req == nil {
+		return nil, nil, fmt.Errorf("template update request cannot be nil")
+	}
+
+	u := fmt.Sprintf("notification-template/%s", templateID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPatch, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var template Template
+	resp, err := s.client.Do(ctx, httpReq, &template)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &template, resp, nil
+}
+
+// Preview renders a template without sending it. req may be nil to render
+// the saved template with the server's sample values.
+func (s *TemplatesService) Preview(ctx context.Context, templateID string, req *TemplatePreviewRequest) (*TemplatePreview, *Response, error) {
+	u := fmt.Sprintf("notification-template/%s/preview", templateID)
+
+	if req == nil {
+		req = &TemplatePreviewRequest{}
+	}
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var preview TemplatePreview
+	resp, err := s.client.Do(ctx, httpReq, &preview)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &preview, resp, nil
+}
+
+// Reset restores a template to the DigiCert default
+func (s *TemplatesService) Reset(ctx context.Context, templateID string) (*Template, *Response, error) {
+	u := fmt.Sprintf("notification-template/%s/reset", templateID)
+
+	httpReq, err := s.client.NewRequest(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var template Template
+	resp, err := s.client.Do(ctx, httpReq, &template)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &template, resp, nil
+}
@@ -0,0 +1,97 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTemplatesService_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mpki/api/v1/notification-template" {
+			t.Errorf("Expected path /mpki/api/v1/notification-template, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("type") != "expiry_notice" || r.URL.Query().Get("locale") != "fr" {
+			t.Errorf("query = %v, want type and locale filters", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TemplateListResponse{
+			ListResponse: ListResponse{Total: 1},
+			Templates:    []Template{{ID: "tpl-1", Type: TemplateTypeExpiryNotice, Locale: "fr", Variables: []string{"common_name"}}},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	result, _, err := client.Templates.List(context.Background(), &TemplateListOptions{Type: TemplateTypeExpiryNotice, Locale: "fr"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(result.Templates) != 1 || result.Templates[0].ID != "tpl-1" || result.Templates[0].Variables[0] != "common_name" {
+		t.Errorf("Templates = %+v", result.Templates)
+	}
+}
+
+func TestTemplatesService_Update(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/mpki/api/v1/notification-template/tpl-1" {
+			t.Errorf("request = %s %s, want PATCH of tpl-1", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["subject"] != "Your certificate for {{common_name}} expires soon" {
+			t.Errorf("body = %v, want only the subject", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Template{ID: "tpl-1", Subject: body["subject"].(string)})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	template, _, err := client.Templates.Update(context.Background(), "tpl-1", &TemplateUpdateRequest{
+		Subject: String("Your certificate for {{common_name}} expires soon"),
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if template.Subject != "Your certificate for {{common_name}} expires soon" {
+		t.Errorf("Subject = %v", template.Subject)
+	}
+
+	if _, _, err := client.Templates.Update(context.Background(), "tpl-1", nil); err == nil {
+		t.Error("Update(nil) error = nil, want error")
+	}
+}
+
+func TestTemplatesService_Preview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/mpki/api/v1/notification-template/tpl-1/preview" {
+			t.Errorf("request = %s %s, want POST preview of tpl-1", r.Method, r.URL.Path)
+		}
+		var req TemplatePreviewRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Body != "<p>Hello {{common_name}}</p>" || req.Variables["common_name"] != "www.example.com" {
+			t.Errorf("preview request = %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(TemplatePreview{Subject: "Expiry", Body: "<p>Hello www.example.com</p>", Format: "html"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	preview, _, err := client.Templates.Preview(context.Background(), "tpl-1", &TemplatePreviewRequest{
+		Body:      "<p>Hello {{common_name}}</p>",
+		Variables: map[string]string{"common_name": "www.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if preview.Body != "<p>Hello www.example.com</p>" {
+		t.Errorf("Body = %v", preview.Body)
+	}
+}
@@ -0,0 +1,105 @@
+package digicert
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ThumbprintSHA1 returns the lowercase hex SHA-1 thumbprint of a PEM or DER
+// encoded certificate, the form TLM reports in Certificate.Thumbprint.
+func ThumbprintSHA1(cert []byte) (string, error) {
+	der, err := certificateDER(cert)
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ThumbprintSHA256 returns the lowercase hex SHA-256 thumbprint of a PEM or
+// DER encoded certificate.
+func ThumbprintSHA256(cert []byte) (string, error) {
+	der, err := certificateDER(cert)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NormalizeThumbprint returns a thumbprint as lowercase hex without
+// separators, accepting the colon or space separated and upper case forms
+// printed by browsers, openssl and Windows.
+func NormalizeThumbprint(thumbprint string) string {
+	return strings.ToLower(compactHex(strings.TrimSpace(thumbprint)))
+}
+
+// FormatThumbprint returns a thumbprint as colon separated upper case hex
+// pairs, as openssl x509 -fingerprint prints it.
+func FormatThumbprint(thumbprint string) string {
+	t := strings.ToUpper(NormalizeThumbprint(thumbprint))
+	var b strings.Builder
+	for i := 0; i < len(t); i += 2 {
+		if i > 0 {
+			b.WriteByte(':')
+		}
+		b.WriteString(t[i:min(i+2, len(t))])
+	}
+	return b.String()
+}
+
+// ThumbprintsEqual reports whether two thumbprints are equal, ignoring case
+// and separators.
+func ThumbprintsEqual(a, b string) bool {
+	return NormalizeThumbprint(a) == NormalizeThumbprint(b)
+}
+
+// certificateDER returns the DER bytes of the first certificate in a PEM or
+// DER encoded input.
+func certificateDER(data []byte) ([]byte, error) {
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			return block.Bytes, nil
+		}
+	}
+	if _, err := x509.ParseCertificate(data); err != nil {
+		return nil, errors.New("digicert: input is not a PEM or DER encoded certificate")
+	}
+	return data, nil
+}
+
+// GetByThumbprint searches for the certificate with a SHA-1 or SHA-256
+// thumbprint, in any format NormalizeThumbprint accepts. It returns a not
+// found APIError if no certificate matches.
+func (s *CertificatesService) GetByThumbprint(ctx context.Context, thumbprint string) (*Certificate, *Response, error) {
+	thumbprint = NormalizeThumbprint(thumbprint)
+	if len(thumbprint) != 40 && len(thumbprint) != 64 || !hexDigitsPattern.MatchString(thumbprint) {
+		return nil, nil, fmt.Errorf("digicert: %q is not a SHA-1 or SHA-256 thumbprint", thumbprint)
+	}
+	return s.getByThumbprint(ctx, thumbprint)
+}
+
+// Match finds the inventory entry for a certificate seen in the wild, e.g.
+// on a TLS endpoint, by its SHA-1 and then its SHA-256 thumbprint.
+func (s *CertificatesService) Match(ctx context.Context, cert *x509.Certificate) (*Certificate, *Response, error) {
+	sum1 := sha1.Sum(cert.Raw)
+	found, resp, err := s.getByThumbprint(ctx, hex.EncodeToString(sum1[:]))
+	if !IsNotFound(err) {
+		return found, resp, err
+	}
+	sum256 := sha256.Sum256(cert.Raw)
+	return s.getByThumbprint(ctx, hex.EncodeToString(sum256[:]))
+}
@@ -0,0 +1,88 @@
+package digicert
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestThumbprints(t *testing.T) {
+	ca := newTestCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "www.example.com"},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+	}, nil)
+	sum1 := sha1.Sum(ca.cert.Raw)
+	sum256 := sha256.Sum256(ca.cert.Raw)
+
+	for name, input := range map[string][]byte{"pem": []byte(ca.pem), "der": ca.cert.Raw} {
+		got, err := ThumbprintSHA1(input)
+		if err != nil || got != hex.EncodeToString(sum1[:]) {
+			t.Errorf("ThumbprintSHA1(%s) = %v, %v, want %x", name, got, err, sum1)
+		}
+		got, err = ThumbprintSHA256(input)
+		if err != nil || got != hex.EncodeToString(sum256[:]) {
+			t.Errorf("ThumbprintSHA256(%s) = %v, %v, want %x", name, got, err, sum256)
+		}
+	}
+	if _, err := ThumbprintSHA1([]byte("not a certificate")); err == nil {
+		t.Error("Expected error for invalid certificate")
+	}
+
+	if got := NormalizeThumbprint(" AB:CD ef "); got != "abcdef" {
+		t.Errorf("NormalizeThumbprint() = %v, want abcdef", got)
+	}
+	if got := FormatThumbprint("abcdef"); got != "AB:CD:EF" {
+		t.Errorf("FormatThumbprint() = %v, want AB:CD:EF", got)
+	}
+	if !ThumbprintsEqual("AB:CD:EF", "abcdef") || ThumbprintsEqual("abcdef", "abcdee") {
+		t.Error("ThumbprintsEqual() should ignore case and separators only")
+	}
+}
+
+func TestCertificatesService_Match(t *testing.T) {
+	ca := newTestCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "www.example.com"},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+	}, nil)
+	sha256Thumbprint, _ := ThumbprintSHA256(ca.cert.Raw)
+
+	var searched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		thumbprint := r.URL.Query().Get("thumbprint")
+		searched = append(searched, thumbprint)
+		var items []Certificate
+		if thumbprint == sha256Thumbprint {
+			items = []Certificate{{ID: "cert-1", Thumbprint: FormatThumbprint(sha256Thumbprint)}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: len(items)}, Items: items})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	cert, _, err := client.Certificates.Match(context.Background(), ca.cert)
+	if err != nil {
+		t.Fatalf("Match() error = %v", err)
+	}
+	if cert.ID != "cert-1" || len(searched) != 2 {
+		t.Errorf("Match() = %v after %d searches, want cert-1 after SHA-1 then SHA-256", cert.ID, len(searched))
+	}
+
+	cert, _, err = client.Certificates.GetByThumbprint(context.Background(), FormatThumbprint(sha256Thumbprint))
+	if err != nil || cert.ID != "cert-1" {
+		t.Errorf("GetByThumbprint() = %v, %v, want cert-1", cert, err)
+	}
+	if _, _, err := client.Certificates.GetByThumbprint(context.Background(), "abc"); err == nil {
+		t.Error("Expected error for malformed thumbprint")
+	}
+}
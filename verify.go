@@ -0,0 +1,260 @@
+package digicert
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChainFailureReason classifies a ChainFailure.
+type ChainFailureReason string
+
+const (
+	ChainMalformed    ChainFailureReason = "malformed"
+	ChainNotYetValid  ChainFailureReason = "not_yet_valid"
+	ChainExpired      ChainFailureReason = "expired"
+	ChainExpiringSoon ChainFailureReason = "expiring_soon"
+	ChainKeyUsage     ChainFailureReason = "key_usage"
+	ChainHostname     ChainFailureReason = "hostname"
+	ChainUntrusted    ChainFailureReason = "untrusted"
+	ChainInvalid      ChainFailureReason = "invalid"
+)
+
+// ChainFailure is one problem found by CertificateResponse.Verify. Subject is
+// the subject of the certificate the failure applies to, if known.
+type ChainFailure struct {
+	Reason  ChainFailureReason
+	Subject string
+	Message string
+	Err     error
+}
+
+func (f ChainFailure) String() string {
+	if f.Subject == "" {
+		return fmt.Sprintf("%s: %s", f.Reason, f.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Reason, f.Subject, f.Message)
+}
+
+// ChainVerificationError lists every failure found verifying a chain.
+type ChainVerificationError struct {
+	Failures []ChainFailure
+}
+
+func (e *ChainVerificationError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = f.String()
+	}
+	return "certificate chain verification failed: " + strings.Join(msgs, "; ")
+}
+
+// Has reports whether any failure has the given reason.
+func (e *ChainVerificationError) Has(reason ChainFailureReason) bool {
+	for _, f := range e.Failures {
+		if f.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+type VerifyOptions struct {
+	// Roots are the trusted CAs. The system roots are used when nil.
+	Roots *x509.CertPool
+	// KeyUsages the leaf must be valid for. Defaults to server authentication;
+	// use x509.ExtKeyUsageAny to accept any.
+	KeyUsages []x509.ExtKeyUsage
+	// DNSName, if set, must be covered by the leaf.
+	DNSName string
+	// CurrentTime is the time to check validity at. Defaults to now.
+	CurrentTime time.Time
+	// MinRemaining fails verification if the leaf expires within this
+	// duration of CurrentTime.
+	MinRemaining time.Duration
+}
+
+// Verify checks the issued certificate and its chain against opts, returning
+// the verified chains or a *ChainVerificationError describing every problem.
+// Validity periods are reported separately from trust, so an expired
+// certificate from an untrusted CA reports both failures.
+func (r *CertificateResponse) Verify(opts *VerifyOptions) ([][]*x509.Certificate, error) {
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+	if r == nil || r.Certificate == nil || r.Certificate.Certificate == "" {
+		return nil, &ChainVerificationError{Failures: []ChainFailure{{Reason: ChainMalformed, Message: "response has no certificate"}}}
+	}
+
+	var failures []ChainFailure
+	leaf, err := parseCertificatePEM(r.Certificate.Certificate)
+	if err != nil {
+		return nil, &ChainVerificationError{Failures: []ChainFailure{{Reason: ChainMalformed, Message: "leaf: " + err.Error(), Err: err}}}
+	}
+	intermediates := x509.NewCertPool()
+	chain := []*x509.Certificate{leaf}
+	for i, c := range r.Chain {
+		cert, err := parseCertificatePEM(c)
+		if err != nil {
+			failures = append(failures, ChainFailure{Reason: ChainMalformed, Message: fmt.Sprintf("chain[%d]: %v", i, err), Err: err})
+			continue
+		}
+		intermediates.AddCert(cert)
+		chain = append(chain, cert)
+	}
+
+	now := opts.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var latestStart time.Time
+	outOfValidity := false
+	for _, cert := range chain {
+		subject := cert.Subject.String()
+		switch {
+		case now.Before(cert.NotBefore):
+			outOfValidity = true
+			failures = append(failures, ChainFailure{Reason: ChainNotYetValid, Subject: subject, Message: "valid from " + cert.NotBefore.UTC().Format(time.RFC3339)})
+		case now.After(cert.NotAfter):
+			outOfValidity = true
+			failures = append(failures, ChainFailure{Reason: ChainExpired, Subject: subject, Message: "expired at " + cert.NotAfter.UTC().Format(time.RFC3339)})
+		case cert == leaf && opts.MinRemaining > 0 && cert.NotAfter.Sub(now) < opts.MinRemaining:
+			failures = append(failures, ChainFailure{Reason: ChainExpiringSoon, Subject: subject, Message: "expires at " + cert.NotAfter.UTC().Format(time.RFC3339)})
+		}
+		if cert.NotBefore.After(latestStart) {
+			latestStart = cert.NotBefore
+		}
+	}
+
+	// When a certificate is outside its validity period, evaluate trust at a
+	// time every certificate was valid, if there is one, so trust failures
+	// are still reported.
+	checkTime := now
+	if outOfValidity {
+		checkTime = latestStart
+		for _, cert := range chain {
+			if checkTime.After(cert.NotAfter) {
+				checkTime = now
+				break
+			}
+		}
+	}
+
+	usages := opts.KeyUsages
+	if len(usages) == 0 {
+		usages = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+	leafUsageOK := hasExtKeyUsage(leaf, usages)
+	if !leafUsageOK {
+		failures = append(failures, ChainFailure{Reason: ChainKeyUsage, Subject: leaf.Subject.String(), Message: "certificate is not valid for the requested extended key usage"})
+	}
+
+	if opts.DNSName != "" {
+		if err := leaf.VerifyHostname(opts.DNSName); err != nil {
+			failures = append(failures, ChainFailure{Reason: ChainHostname, Subject: leaf.Subject.String(), Message: err.Error(), Err: err})
+		}
+	}
+
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         opts.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   checkTime,
+		KeyUsages:     usages,
+	})
+	if err != nil {
+		if f, ok := classifyVerifyError(err, leafUsageOK); ok {
+			failures = append(failures, f)
+		}
+	}
+
+	if len(failures) > 0 {
+		return chains, &ChainVerificationError{Failures: failures}
+	}
+	return chains, nil
+}
+
+// classifyVerifyError maps an x509 verification error to a failure, reporting
+// false for errors already covered by the checks in Verify.
+func classifyVerifyError(err error, leafUsageOK bool) (ChainFailure, bool) {
+	var unknown x509.UnknownAuthorityError
+	if errors.As(err, &unknown) {
+		f := ChainFailure{Reason: ChainUntrusted, Message: "certificate signed by unknown authority", Err: err}
+		if unknown.Cert != nil {
+			f.Subject = unknown.Cert.Subject.String()
+		}
+		return f, true
+	}
+
+	var invalid x509.CertificateInvalidError
+	if errors.As(err, &invalid) {
+		f := ChainFailure{Reason: ChainInvalid, Message: invalid.Error(), Err: err}
+		if invalid.Cert != nil {
+			f.Subject = invalid.Cert.Subject.String()
+		}
+		switch invalid.Reason {
+		case x509.Expired:
+			return f, false
+		case x509.IncompatibleUsage:
+			if !leafUsageOK {
+				return f, false
+			}
+			// The leaf allows the usage but an intermediate does not.
+			f.Reason = ChainKeyUsage
+		}
+		return f, true
+	}
+
+	return ChainFailure{Reason: ChainInvalid, Message: err.Error(), Err: err}, true
+}
+
+func hasExtKeyUsage(cert *x509.Certificate, usages []x509.ExtKeyUsage) bool {
+	if len(cert.ExtKeyUsage) == 0 && len(cert.UnknownExtKeyUsage) == 0 {
+		return true
+	}
+	for _, have := range cert.ExtKeyUsage {
+		if have == x509.ExtKeyUsageAny {
+			return true
+		}
+		for _, want := range usages {
+			if want == x509.ExtKeyUsageAny || want == have {
+				return true
+			}
+		}
+	}
+	for _, want := range usages {
+		if want == x509.ExtKeyUsageAny {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCertificatePEM(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// parseCertificatesPEM returns every certificate that parses in a PEM bundle.
+func parseCertificatesPEM(data string) []*x509.Certificate {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package digicert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *testCA) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))}
+}
+
+func newTestChain(t *testing.T, leafTmpl *x509.Certificate) (*CertificateResponse, *x509.CertPool) {
+	t.Helper()
+	now := time.Now()
+	root := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	ica := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Test ICA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(5 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, root)
+	leaf := newTestCert(t, leafTmpl, ica)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(root.cert)
+	return &CertificateResponse{
+		Certificate: &Certificate{Certificate: leaf.pem},
+		Chain:       []string{ica.pem},
+	}, pool
+}
+
+func TestCertificateResponse_Verify(t *testing.T) {
+	now := time.Now()
+	serverLeaf := func() *x509.Certificate {
+		return &x509.Certificate{
+			Subject:     pkix.Name{CommonName: "www.example.com"},
+			DNSNames:    []string{"www.example.com"},
+			NotBefore:   now.Add(-time.Hour),
+			NotAfter:    now.Add(90 * 24 * time.Hour),
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		resp, roots := newTestChain(t, serverLeaf())
+		chains, err := resp.Verify(&VerifyOptions{Roots: roots, DNSName: "www.example.com"})
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if len(chains) != 1 || len(chains[0]) != 3 {
+			t.Errorf("Verify() chains = %v, want one chain of 3", chains)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		leaf  func() *x509.Certificate
+		opts  func(roots *x509.CertPool) *VerifyOptions
+		wants []ChainFailureReason
+	}{
+		{
+			name:  "untrusted",
+			leaf:  serverLeaf,
+			opts:  func(*x509.CertPool) *VerifyOptions { return &VerifyOptions{Roots: x509.NewCertPool()} },
+			wants: []ChainFailureReason{ChainUntrusted},
+		},
+		{
+			name: "expired and untrusted",
+			leaf: func() *x509.Certificate {
+				c := serverLeaf()
+				c.NotBefore, c.NotAfter = now.Add(-2*time.Hour), now.Add(-time.Minute)
+				return c
+			},
+			opts:  func(*x509.CertPool) *VerifyOptions { return &VerifyOptions{Roots: x509.NewCertPool()} },
+			wants: []ChainFailureReason{ChainExpired, ChainUntrusted},
+		},
+		{
+			name: "expiring soon",
+			leaf: serverLeaf,
+			opts: func(roots *x509.CertPool) *VerifyOptions {
+				return &VerifyOptions{Roots: roots, MinRemaining: 120 * 24 * time.Hour}
+			},
+			wants: []ChainFailureReason{ChainExpiringSoon},
+		},
+		{
+			name: "wrong key usage",
+			leaf: func() *x509.Certificate {
+				c := serverLeaf()
+				c.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+				return c
+			},
+			opts:  func(roots *x509.CertPool) *VerifyOptions { return &VerifyOptions{Roots: roots} },
+			wants: []ChainFailureReason{ChainKeyUsage},
+		},
+		{
+			name: "hostname",
+			leaf: serverLeaf,
+			opts: func(roots *x509.CertPool) *VerifyOptions {
+				return &VerifyOptions{Roots: roots, DNSName: "other.example.com"}
+			},
+			wants: []ChainFailureReason{ChainHostname},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, roots := newTestChain(t, tt.leaf())
+			_, err := resp.Verify(tt.opts(roots))
+			var verr *ChainVerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Verify() error = %v, want *ChainVerificationError", err)
+			}
+			for _, want := range tt.wants {
+				if !verr.Has(want) {
+					t.Errorf("Verify() failures = %v, want %v", verr.Failures, want)
+				}
+			}
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		resp := &CertificateResponse{Certificate: &Certificate{Certificate: "not pem"}}
+		_, err := resp.Verify(nil)
+		var verr *ChainVerificationError
+		if !errors.As(err, &verr) || !verr.Has(ChainMalformed) {
+			t.Errorf("Verify() error = %v, want malformed", err)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package digicert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	APIVersionV1 = "v1"
+	APIVersionV2 = "v2"
+)
+
+// APIService identifies a group of endpoints whose API version can be chosen
+// independently of the client default.
+type APIService string
+
+const (
+	ServiceCertificates      APIService = "certificates"
+	ServiceEnrollments       APIService = "enrollments"
+	ServiceBusinessUnits     APIService = "business_units"
+	ServiceCertificateOwners APIService = "certificate_owners"
+	ServiceProfiles          APIService = "profiles"
+	ServiceAgents            APIService = "agents"
+	ServiceAutomation        APIService = "automation"
+	ServiceAuditLog          APIService = "audit_log"
+	ServiceACME              APIService = "acme"
+	ServiceTemplates         APIService = "templates"
+	ServiceTags              APIService = "tags"
+	ServiceAdmins            APIService = "admins"
+	ServiceInvitations       APIService = "invitations"
+	ServiceOrders            APIService = "orders"
+)
+
+// servicePaths maps the first segment of an endpoint path to its service.
+var servicePaths = map[string]APIService{
+	"certificate":           ServiceCertificates,
+	"certificate-search":    ServiceCertificates,
+	"certificate-by-id":     ServiceCertificates,
+	"certificate-pickup":    ServiceCertificates,
+	"enrollment":            ServiceEnrollments,
+	"enrollment-details":    ServiceEnrollments,
+	"manual-enrollment":     ServiceEnrollments,
+	"business-unit":         ServiceBusinessUnits,
+	"certificate-owners":    ServiceCertificateOwners,
+	"profiles":              ServiceProfiles,
+	"agents":                ServiceAgents,
+	"automation":            ServiceAutomation,
+	"audit-log":             ServiceAuditLog,
+	"acme":                  ServiceACME,
+	"notification-template": ServiceTemplates,
+	"tag":                   ServiceTags,
+	"admin":                 ServiceAdmins,
+	"invitation":            ServiceInvitations,
+	"order":                 ServiceOrders,
+}
+
+// libraryVersions lists the API versions this library can speak for each
+// service, oldest first. Services not listed only support v1.
+var libraryVersions = map[APIService][]string{
+	ServiceCertificates: {APIVersionV1, APIVersionV2},
+}
+
+// APICapabilities describes the API versions offered by the server.
+type APICapabilities struct {
+	Versions []string                `json:"versions"`
+	Services map[APIService][]string `json:"services,omitempty"`
+}
+
+// WithAPIVersion sets the API version used for every service without a more
+// specific override
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) error {
+		if !isKnownAPIVersion(version) {
+			return fmt.Errorf("unsupported API version %q", version)
+		}
+		c.apiVersion = version
+		return nil
+	}
+}
+
+// WithServiceAPIVersion pins the API version used for a single service.
+// Pinned services are left unchanged by NegotiateAPIVersions
+func WithServiceAPIVersion(service APIService, version string) ClientOption {
+	return func(c *Client) error {
+		if !isKnownAPIVersion(version) {
+			return fmt.Errorf("unsupported API version %q", version)
+		}
+		if c.serviceVersions == nil {
+			c.serviceVersions = make(map[APIService]string)
+		}
+		c.serviceVersions[service] = version
+		return nil
+	}
+}
+
+// APIVersionFor returns the API version requests for service are sent to
+func (c *Client) APIVersionFor(service APIService) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if v, ok := c.serviceVersions[service]; ok {
+		return v
+	}
+	if v, ok := c.negotiatedVersions[service]; ok {
+		return v
+	}
+	if c.apiVersion != "" {
+		return c.apiVersion
+	}
+	return APIVersion
+}
+
+// GetCapabilities probes the server for the API versions it supports
+func (c *Client) GetCapabilities(ctx context.Context) (*APICapabilities, *Response, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		c.BaseURL.Path += "/"
+	}
+	u, err := c.BaseURL.Parse("mpki/api/versions")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpReq, err := c.newAbsoluteRequest(ctx, http.MethodGet, u.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var caps APICapabilities
+	resp, err := c.Do(ctx, httpReq, &caps)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &caps, resp, nil
+}
+
+// NegotiateAPIVersions probes the server and, for every service that has not
+// been pinned with WithServiceAPIVersion, selects the newest version supported
+// by both the server and this library. Servers that do not expose the probe
+// endpoint are treated as v1 only.
+func (c *Client) NegotiateAPIVersions(ctx context.Context) (*APICapabilities, error) {
+	caps, _, err := c.GetCapabilities(ctx)
+	if err != nil {
+		if !IsNotFound(err) {
+			return nil, err
+		}
+		caps = &APICapabilities{Versions: []string{APIVersionV1}}
+	}
+
+	negotiated := make(map[APIService]string, len(libraryVersions))
+	for service, supported := range libraryVersions {
+		offered := caps.Versions
+		if v, ok := caps.Services[service]; ok {
+			offered = v
+		}
+		for i := len(supported) - 1; i >= 0; i-- {
+			if containsString(offered, supported[i]) {
+				negotiated[service] = supported[i]
+				break
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.negotiatedVersions = negotiated
+	c.mu.Unlock()
+
+	return caps, nil
+}
+
+// serviceForPath returns the service owning an endpoint path.
+func serviceForPath(path string) APIService {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	segment, _, _ = strings.Cut(segment, "?")
+	return servicePaths[segment]
+}
+
+func isKnownAPIVersion(version string) bool {
+	return version == APIVersionV1 || version == APIVersionV2
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
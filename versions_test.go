@@ -0,0 +1,139 @@
+package digicert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIVersionOptions(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		client, _ := NewClient("test-key")
+		if v := client.APIVersionFor(ServiceCertificates); v != APIVersionV1 {
+			t.Errorf("APIVersionFor() = %v, want %v", v, APIVersionV1)
+		}
+	})
+
+	t.Run("client and service overrides", func(t *testing.T) {
+		client, err := NewClient("test-key",
+			WithAPIVersion(APIVersionV2),
+			WithServiceAPIVersion(ServiceProfiles, APIVersionV1),
+		)
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		if v := client.APIVersionFor(ServiceCertificates); v != APIVersionV2 {
+			t.Errorf("APIVersionFor(certificates) = %v, want %v", v, APIVersionV2)
+		}
+		if v := client.APIVersionFor(ServiceProfiles); v != APIVersionV1 {
+			t.Errorf("APIVersionFor(profiles) = %v, want %v", v, APIVersionV1)
+		}
+
+		req, _ := client.NewRequest(context.Background(), http.MethodGet, "profiles/p-1", nil)
+		if req.URL.Path != "/mpki/api/v1/profiles/p-1" {
+			t.Errorf("URL.Path = %v, want %v", req.URL.Path, "/mpki/api/v1/profiles/p-1")
+		}
+		req, _ = client.NewRequest(context.Background(), http.MethodGet, "certificate/01", nil)
+		if req.URL.Path != "/mpki/api/v2/certificate/01" {
+			t.Errorf("URL.Path = %v, want %v", req.URL.Path, "/mpki/api/v2/certificate/01")
+		}
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		if _, err := NewClient("test-key", WithAPIVersion("v9")); err == nil {
+			t.Error("Expected error for unknown API version")
+		}
+		if _, err := NewClient("test-key", WithServiceAPIVersion(ServiceCertificates, "v0")); err == nil {
+			t.Error("Expected error for unknown API version")
+		}
+	})
+}
+
+func TestClient_NegotiateAPIVersions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("selects v2 when offered", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/mpki/api/versions":
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(APICapabilities{Versions: []string{"v1", "v2"}})
+			case "/mpki/api/v2/certificate-search":
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				var body certificateSearchV2Request
+				json.NewDecoder(r.Body).Decode(&body)
+				if body.CommonName != "example.com" || body.Limit != 10 {
+					t.Errorf("body = %+v, want common_name and limit", body)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(CertificateSearchResponse{
+					ListResponse: ListResponse{Total: 1},
+					Items:        []Certificate{{ID: "cert-1"}},
+				})
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		caps, err := client.NegotiateAPIVersions(ctx)
+		if err != nil {
+			t.Fatalf("NegotiateAPIVersions() error = %v", err)
+		}
+		if len(caps.Versions) != 2 {
+			t.Errorf("Versions = %v, want v1 and v2", caps.Versions)
+		}
+		if v := client.APIVersionFor(ServiceCertificates); v != APIVersionV2 {
+			t.Errorf("APIVersionFor(certificates) = %v, want %v", v, APIVersionV2)
+		}
+		if v := client.APIVersionFor(ServiceProfiles); v != APIVersionV1 {
+			t.Errorf("APIVersionFor(profiles) = %v, want %v", v, APIVersionV1)
+		}
+
+		opts := &CertificateSearchOptions{CommonName: "example.com"}
+		opts.Limit = 10
+		result, _, err := client.Certificates.Search(ctx, opts)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(result.Items) != 1 {
+			t.Errorf("Items = %v, want 1 item", result.Items)
+		}
+	})
+
+	t.Run("pinned service is not changed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(APICapabilities{Versions: []string{"v1", "v2"}})
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"), WithServiceAPIVersion(ServiceCertificates, APIVersionV1))
+		if _, err := client.NegotiateAPIVersions(ctx); err != nil {
+			t.Fatalf("NegotiateAPIVersions() error = %v", err)
+		}
+		if v := client.APIVersionFor(ServiceCertificates); v != APIVersionV1 {
+			t.Errorf("APIVersionFor(certificates) = %v, want %v", v, APIVersionV1)
+		}
+	})
+
+	t.Run("missing probe endpoint falls back to v1", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+		if _, err := client.NegotiateAPIVersions(ctx); err != nil {
+			t.Fatalf("NegotiateAPIVersions() error = %v", err)
+		}
+		if v := client.APIVersionFor(ServiceCertificates); v != APIVersionV1 {
+			t.Errorf("APIVersionFor(certificates) = %v, want %v", v, APIVersionV1)
+		}
+	})
+}
@@ -0,0 +1,315 @@
+package digicert
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultWeakCryptoMinRSABits  = 2048
+	DefaultWeakCryptoICAExpiryIn = 90 * 24 * time.Hour
+)
+
+// WeakCryptoIssue is a weakness found by FindWeakCrypto.
+type WeakCryptoIssue string
+
+const (
+	WeakCryptoRSAKey        WeakCryptoIssue = "weak_rsa_key"
+	WeakCryptoSHA1Signature WeakCryptoIssue = "sha1_signature"
+	WeakCryptoICAExpired    WeakCryptoIssue = "ica_expired"
+	WeakCryptoICAExpiring   WeakCryptoIssue = "ica_expiring"
+)
+
+type WeakCryptoFinding struct {
+	CertificateID  string            `json:"certificate_id"`
+	SerialNumber   string            `json:"serial_number"`
+	CommonName     string            `json:"common_name"`
+	BusinessUnitID string            `json:"business_unit_id,omitempty"`
+	OwnerIDs       []string          `json:"owner_ids,omitempty"`
+	Issues         []WeakCryptoIssue `json:"issues"`
+	// Details explains each issue, e.g. "RSA key of 1024 bits", in the
+	// same order as Issues.
+	Details []string `json:"details"`
+}
+
+// Has reports whether the finding includes issue.
+func (f *WeakCryptoFinding) Has(issue WeakCryptoIssue) bool {
+	for _, i := range f.Issues {
+		if i == issue {
+			return true
+		}
+	}
+	return false
+}
+
+// WeakCryptoGroup holds the findings for one business unit and owner.
+// OwnerID is empty for certificates without an owner, or when owners were
+// not resolved.
+type WeakCryptoGroup struct {
+	BusinessUnitID string              `json:"business_unit_id"`
+	OwnerID        string              `json:"owner_id"`
+	Findings       []WeakCryptoFinding `json:"findings"`
+}
+
+type WeakCryptoReport struct {
+	// Findings lists affected certificates in inventory order.
+	Findings []WeakCryptoFinding `json:"findings"`
+	// Scanned counts the active certificates checked.
+	Scanned int `json:"scanned"`
+	// Groups holds the findings by business unit and then owner. A
+	// certificate with several owners appears in each owner's group.
+	Groups []WeakCryptoGroup `json:"groups"`
+}
+
+type WeakCryptoOptions struct {
+	// Search selects the certificates checked.
+	Search CertificateSearchOptions
+	// MinRSABits flags smaller RSA keys. Defaults to
+	// DefaultWeakCryptoMinRSABits.
+	MinRSABits int
+	// ICAExpiryWindow flags certificates whose issuing CA expires within
+	// this window. Defaults to DefaultWeakCryptoICAExpiryIn.
+	ICAExpiryWindow time.Duration
+	// ResolveOwners lists every certificate owner and their certificates to
+	// group findings by owner, costing one request per owner.
+	ResolveOwners bool
+	// Now overrides the current time, for tests.
+	Now func() time.Time
+}
+
+// FindWeakCrypto checks the active certificate inventory for RSA keys below
+// MinRSABits, SHA-1 signatures and issuing CAs that have expired or expire
+// within ICAExpiryWindow, the usual questions of a periodic security review.
+//
+// Keys and signatures are read from each certificate's PEM when returned and
+// otherwise from the key_size and signature_algorithm fields. An issuing CA's
+// expiry is read from the ica field or, when absent, from the chain returned
+// by GetAdditionalFormats for one certificate per CA.
+func (s *CertificatesService) FindWeakCrypto(ctx context.Context, opts *WeakCryptoOptions) (*WeakCryptoReport, error) {
+	if opts == nil {
+		opts = &WeakCryptoOptions{}
+	}
+	minBits := opts.MinRSABits
+	if minBits <= 0 {
+		minBits = DefaultWeakCryptoMinRSABits
+	}
+	window := opts.ICAExpiryWindow
+	if window <= 0 {
+		window = DefaultWeakCryptoICAExpiryIn
+	}
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	var owners map[string][]string
+	if opts.ResolveOwners {
+		var err error
+		if owners, err = s.client.CertificateOwners.ownersByCertificate(ctx); err != nil {
+			return nil, fmt.Errorf("weak crypto: resolve owners: %w", err)
+		}
+	}
+
+	// icaExpiry caches the expiry of each issuing CA, by ICA ID.
+	icaExpiry := make(map[string]time.Time)
+	report := &WeakCryptoReport{}
+	for c, err := range s.All(ctx, &opts.Search) {
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(c.Status) {
+		case "revoked", "expired":
+			continue
+		}
+		report.Scanned++
+
+		finding := WeakCryptoFinding{
+			CertificateID: c.ID,
+			SerialNumber:  c.SerialNumber,
+			CommonName:    c.CommonName,
+			OwnerIDs:      owners[c.ID],
+		}
+		if c.BusinessUnit != nil {
+			finding.BusinessUnitID = c.BusinessUnit.ID
+		}
+		add := func(issue WeakCryptoIssue, detail string) {
+			finding.Issues = append(finding.Issues, issue)
+			finding.Details = append(finding.Details, detail)
+		}
+
+		var leaf *x509.Certificate
+		if c.Certificate != "" {
+			leaf, _ = parseCertificatePEM(c.Certificate)
+		}
+		if bits, ok := rsaKeyBits(c, leaf); ok && bits < minBits {
+			add(WeakCryptoRSAKey, fmt.Sprintf("RSA key of %d bits", bits))
+		}
+		if alg, ok := sha1Signature(c, leaf); ok {
+			add(WeakCryptoSHA1Signature, fmt.Sprintf("signed with %s", alg))
+		}
+		if c.ICA != nil && c.ICA.ID != "" {
+			expiry, ok := icaExpiry[c.ICA.ID]
+			if !ok {
+				expiry = s.icaNotAfter(ctx, c, leaf)
+				icaExpiry[c.ICA.ID] = expiry
+			}
+			switch {
+			case expiry.IsZero():
+			case !now.Before(expiry):
+				add(WeakCryptoICAExpired, fmt.Sprintf("issuing CA %s expired %s", icaLabel(c.ICA), expiry.UTC().Format(time.RFC3339)))
+			case expiry.Sub(now) < window:
+				add(WeakCryptoICAExpiring, fmt.Sprintf("issuing CA %s expires %s", icaLabel(c.ICA), expiry.UTC().Format(time.RFC3339)))
+			}
+		}
+
+		if len(finding.Issues) > 0 {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+	report.Groups = groupWeakCryptoFindings(report.Findings)
+	return report, nil
+}
+
+// rsaKeyBits returns the RSA modulus size of c. Without the PEM, key_size
+// is trusted as RSA when it says so or is larger than any elliptic curve,
+// and a bare size is taken as RSA when the signature algorithm is.
+func rsaKeyBits(c Certificate, leaf *x509.Certificate) (int, bool) {
+	if leaf != nil {
+		if pub, ok := leaf.PublicKey.(*rsa.PublicKey); ok {
+			return pub.N.BitLen(), true
+		}
+		return 0, false
+	}
+	bits := c.KeySize.Bits()
+	if bits == 0 {
+		return 0, false
+	}
+	switch c.KeySize.Algorithm() {
+	case KeyTypeRSA:
+		return bits, true
+	case "":
+		sigAlg := strings.ToUpper(c.SignatureAlgorithm)
+		if strings.Contains(sigAlg, "RSA") && !strings.Contains(sigAlg, "ECDSA") {
+			return bits, true
+		}
+	}
+	return 0, false
+}
+
+// sha1Signature reports the signature algorithm of c if it uses SHA-1.
+func sha1Signature(c Certificate, leaf *x509.Certificate) (string, bool) {
+	if leaf != nil {
+		switch leaf.SignatureAlgorithm {
+		case x509.SHA1WithRSA, x509.ECDSAWithSHA1, x509.DSAWithSHA1:
+			return leaf.SignatureAlgorithm.String(), true
+		}
+		return "", false
+	}
+	alg := strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(c.SignatureAlgorithm))
+	if strings.Contains(alg, "sha1") {
+		return c.SignatureAlgorithm, true
+	}
+	return "", false
+}
+
+// icaNotAfter returns when the CA that issued c expires, or the zero time if
+// that cannot be determined.
+func (s *CertificatesService) icaNotAfter(ctx context.Context, c Certificate, leaf *x509.Certificate) time.Time {
+	if c.ICA.ValidTo != "" {
+		if t, err := time.Parse(time.RFC3339, c.ICA.ValidTo); err == nil {
+			return t
+		}
+	}
+	if c.SerialNumber == "" {
+		return time.Time{}
+	}
+	formats, _, err := s.GetAdditionalFormats(ctx, c.SerialNumber)
+	if err != nil {
+		return time.Time{}
+	}
+	var expiry time.Time
+	for _, name := range sortedKeys(formats.Formats) {
+		for _, cert := range parseCertificatesPEM(formats.Formats[name]) {
+			if !cert.IsCA || cert.CheckSignatureFrom(cert) == nil {
+				// Skip the leaf and self-signed roots.
+				continue
+			}
+			if leaf != nil && cert.Subject.String() != leaf.Issuer.String() {
+				continue
+			}
+			if expiry.IsZero() || cert.NotAfter.Before(expiry) {
+				expiry = cert.NotAfter
+			}
+		}
+	}
+	return expiry
+}
+
+func icaLabel(ica *ICA) string {
+	if ica.Name != "" {
+		return ica.Name
+	}
+	return ica.ID
+}
+
+func groupWeakCryptoFindings(findings []WeakCryptoFinding) []WeakCryptoGroup {
+	type groupKey struct{ bu, owner string }
+	index := make(map[groupKey]int)
+	var groups []WeakCryptoGroup
+	for _, f := range findings {
+		owners := f.OwnerIDs
+		if len(owners) == 0 {
+			owners = []string{""}
+		}
+		for _, owner := range owners {
+			k := groupKey{f.BusinessUnitID, owner}
+			i, ok := index[k]
+			if !ok {
+				i = len(groups)
+				index[k] = i
+				groups = append(groups, WeakCryptoGroup{BusinessUnitID: k.bu, OwnerID: k.owner})
+			}
+			groups[i].Findings = append(groups[i].Findings, f)
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].BusinessUnitID != groups[j].BusinessUnitID {
+			return groups[i].BusinessUnitID < groups[j].BusinessUnitID
+		}
+		return groups[i].OwnerID < groups[j].OwnerID
+	})
+	return groups
+}
+
+// ownersByCertificate maps certificate IDs to the IDs of their owners
+func (s *CertificateOwnersService) ownersByCertificate(ctx context.Context) (map[string][]string, error) {
+	owners := make(map[string][]string)
+	opts := &CertificateOwnerListOptions{}
+	opts.Limit = 100
+	seen := 0
+	for {
+		page, _, err := s.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range page.Owners {
+			ids, err := s.listAllCertificateIDs(ctx, owner.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				owners[id] = append(owners[id], owner.ID)
+			}
+		}
+		seen += len(page.Owners)
+		if len(page.Owners) == 0 || seen >= page.Total {
+			return owners, nil
+		}
+		opts.Offset = seen
+	}
+}
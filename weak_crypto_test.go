@@ -0,0 +1,121 @@
+package digicert
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCertificatesService_FindWeakCrypto(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "legacy.example.com"}, NotBefore: now, NotAfter: now.Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &weakKey.PublicKey, weakKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	weakPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	root := newTestCert(t, &x509.Certificate{
+		Subject: pkix.Name{CommonName: "Test Root"}, NotBefore: now.Add(-time.Hour), NotAfter: now.AddDate(10, 0, 0),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, nil)
+	ica := newTestCert(t, &x509.Certificate{
+		Subject: pkix.Name{CommonName: "Test ICA"}, NotBefore: now.Add(-time.Hour), NotAfter: now.AddDate(0, 0, 30),
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, root)
+
+	inventory := []Certificate{
+		{ID: "weak-pem", SerialNumber: "01", Certificate: weakPEM, BusinessUnit: &BusinessUnitRef{ID: "bu-1"}},
+		{ID: "sha1", SerialNumber: "02", KeySize: "2048", SignatureAlgorithm: "sha1WithRSAEncryption", BusinessUnit: &BusinessUnitRef{ID: "bu-1"}},
+		{ID: "ecdsa", SerialNumber: "03", KeySize: "256", SignatureAlgorithm: "ecdsa-with-SHA256"},
+		{ID: "old-ica", SerialNumber: "04", KeySize: "RSA 4096", ICA: &ICA{ID: "ica-old", Name: "Old ICA", ValidTo: "2026-01-01T00:00:00Z"}},
+		{ID: "expiring-ica", SerialNumber: "05", KeySize: "3072", ICA: &ICA{ID: "ica-2"}, BusinessUnit: &BusinessUnitRef{ID: "bu-2"}},
+		{ID: "same-ica", SerialNumber: "06", KeySize: "3072", ICA: &ICA{ID: "ica-2"}, BusinessUnit: &BusinessUnitRef{ID: "bu-2"}},
+		{ID: "revoked", SerialNumber: "07", KeySize: "1024", Status: "revoked"},
+	}
+
+	formatsRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/mpki/api/v1/certificate-search":
+			json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: len(inventory)}, Items: inventory})
+		case "/mpki/api/v1/certificate/05/additional-formats":
+			formatsRequests++
+			json.NewEncoder(w).Encode(AdditionalFormatsResponse{Formats: map[string]string{"pem_chain": ica.pem + root.pem}})
+		case "/mpki/api/v1/certificate-owners":
+			json.NewEncoder(w).Encode(CertificateOwnerListResponse{ListResponse: ListResponse{Total: 2}, Owners: []CertificateOwner{{ID: "alice"}, {ID: "bob"}}})
+		case "/mpki/api/v1/certificate-owners/alice/certificates":
+			json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: 2}, Items: []Certificate{{ID: "weak-pem"}, {ID: "sha1"}}})
+		case "/mpki/api/v1/certificate-owners/bob/certificates":
+			json.NewEncoder(w).Encode(CertificateSearchResponse{ListResponse: ListResponse{Total: 1}, Items: []Certificate{{ID: "sha1"}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	report, err := client.Certificates.FindWeakCrypto(context.Background(), &WeakCryptoOptions{
+		ResolveOwners: true,
+		Now:           func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("FindWeakCrypto() error = %v", err)
+	}
+
+	if report.Scanned != 6 {
+		t.Errorf("Scanned = %v, want 6", report.Scanned)
+	}
+	want := map[string]WeakCryptoIssue{
+		"weak-pem":     WeakCryptoRSAKey,
+		"sha1":         WeakCryptoSHA1Signature,
+		"old-ica":      WeakCryptoICAExpired,
+		"expiring-ica": WeakCryptoICAExpiring,
+		"same-ica":     WeakCryptoICAExpiring,
+	}
+	if len(report.Findings) != len(want) {
+		t.Errorf("Findings = %+v, want %d", report.Findings, len(want))
+	}
+	for _, f := range report.Findings {
+		if issue, ok := want[f.CertificateID]; !ok || len(f.Issues) != 1 || !f.Has(issue) {
+			t.Errorf("finding %s = %v (%v), want %v", f.CertificateID, f.Issues, f.Details, issue)
+		}
+	}
+	if formatsRequests != 1 {
+		t.Errorf("additional formats requests = %v, want 1 per ICA", formatsRequests)
+	}
+
+	type groupSummary struct {
+		bu, owner string
+		count     int
+	}
+	var groups []groupSummary
+	for _, g := range report.Groups {
+		groups = append(groups, groupSummary{g.BusinessUnitID, g.OwnerID, len(g.Findings)})
+	}
+	wantGroups := []groupSummary{{"", "", 1}, {"bu-1", "alice", 2}, {"bu-1", "bob", 1}, {"bu-2", "", 2}}
+	if len(groups) != len(wantGroups) {
+		t.Fatalf("Groups = %+v, want %+v", groups, wantGroups)
+	}
+	for i := range wantGroups {
+		if groups[i] != wantGroups[i] {
+			t.Errorf("Groups[%d] = %+v, want %+v", i, groups[i], wantGroups[i])
+		}
+	}
+}
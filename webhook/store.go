@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Store persists events until they have been processed.
+type Store interface {
+	// Save stores a received event. Saving an ID again keeps the stored
+	// event and its history.
+	Save(ctx context.Context, id string, body []byte) error
+	// Done removes a processed event.
+	Done(ctx context.Context, id string) error
+	// Fail records a failed attempt to process an event.
+	Fail(ctx context.Context, id string, err error) error
+	// Pending returns the events not yet processed, oldest first. These are
+	// the dead letters once their attempts have failed.
+	Pending(ctx context.Context) ([]StoredEvent, error)
+}
+
+// StoredEvent is an event kept by a Store.
+type StoredEvent struct {
+	ID         string          `json:"id"`
+	ReceivedAt time.Time       `json:"received_at"`
+	Attempts   int             `json:"attempts"`
+	LastError  string          `json:"last_error,omitempty"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// DirStore keeps each event as a JSON file in a directory, written
+// atomically so a crash never leaves a partial event behind.
+type DirStore struct {
+	Dir string
+
+	now func() time.Time
+}
+
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{Dir: dir, now: time.Now}
+}
+
+func (s *DirStore) Save(ctx context.Context, id string, body []byte) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	return s.write(path, StoredEvent{ID: id, ReceivedAt: now().UTC(), Body: body})
+}
+
+func (s *DirStore) Done(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *DirStore) Fail(ctx context.Context, id string, cause error) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	event, err := s.read(path)
+	if err != nil {
+		return err
+	}
+	event.Attempts++
+	event.LastError = cause.Error()
+	return s.write(path, event)
+}
+
+func (s *DirStore) Pending(ctx context.Context) ([]StoredEvent, error) {
+	paths, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]StoredEvent, 0, len(paths))
+	for _, path := range paths {
+		event, err := s.read(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Processed since the directory was listed.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].ReceivedAt.Before(events[j].ReceivedAt) })
+	return events, nil
+}
+
+// path returns the file holding the event with id, rejecting IDs that could
+// escape the directory.
+func (s *DirStore) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return "", fmt.Errorf("webhook: invalid event ID %q", id)
+	}
+	return filepath.Join(s.Dir, id+".json"), nil
+}
+
+func (s *DirStore) read(path string) (StoredEvent, error) {
+	var event StoredEvent
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return event, err
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return event, fmt.Errorf("webhook: %s: %w", path, err)
+	}
+	return event, nil
+}
+
+func (s *DirStore) write(path string, event StoredEvent) error {
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.Dir, ".event-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
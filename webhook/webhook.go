@@ -0,0 +1,143 @@
+// Package webhook receives TLM event notifications and keeps every event
+// until it has been processed, so consumers can recover from downtime.
+//
+// This library has no API for webhook delivery history, so failed
+// deliveries cannot be listed or replayed from the server. Instead Receiver
+// persists each event to a Store before handing it on. Events whose handler
+// fails stay in the store as dead letters and are reprocessed by Replay:
+//
+//	store := webhook.NewDirStore("/var/lib/tlm-events")
+//	recv := &webhook.Receiver{Store: store, Handler: handle}
+//	http.Handle("/tlm/events", recv)
+//
+//	// After an outage, for example from a ticker or on start-up:
+//	result, err := recv.Replay(ctx)
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// MaxEventSize is the largest request body Receiver accepts.
+const MaxEventSize = 1 << 20
+
+// Event is one event notification. Data holds the type-specific payload.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt *time.Time      `json:"created_at,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// Decode unmarshals the event payload into v.
+func (e *Event) Decode(v interface{}) error {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+// Handler processes an event. Returning an error leaves the event in the
+// store for Replay. Events can be delivered more than once, so handlers
+// should be idempotent.
+type Handler func(ctx context.Context, event Event) error
+
+// Receiver is an http.Handler that persists incoming events to Store, then
+// processes them with Handler. It answers 200 once the event is stored,
+// whether or not Handler succeeds, and 500 if it cannot be stored so the
+// sender retries.
+type Receiver struct {
+	Store   Store
+	Handler Handler
+	// Verify, if set, authenticates a request from its body, for example by
+	// checking a shared secret header. Requests it rejects get 401.
+	Verify func(r *http.Request, body []byte) error
+}
+
+func (recv *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxEventSize))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if recv.Verify != nil {
+		if err := recv.Verify(r, body); err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil || event.ID == "" {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if err := recv.Store.Save(ctx, event.ID, body); err != nil {
+		http.Error(w, "failed to store event", http.StatusInternalServerError)
+		return
+	}
+	// The event is safe in the store, so a failure here is left for Replay.
+	_ = recv.process(ctx, event)
+	w.WriteHeader(http.StatusOK)
+}
+
+// process runs Handler on a stored event and records the outcome.
+func (recv *Receiver) process(ctx context.Context, event Event) error {
+	if err := recv.Handler(ctx, event); err != nil {
+		if ferr := recv.Store.Fail(ctx, event.ID, err); ferr != nil {
+			return errors.Join(err, ferr)
+		}
+		return err
+	}
+	return recv.Store.Done(ctx, event.ID)
+}
+
+// ReplayResult summarises a Replay.
+type ReplayResult struct {
+	Processed int
+	// Failed lists the events whose handler failed again.
+	Failed []StoredEvent
+}
+
+// Replay reprocesses every event left in the store, oldest first. Events
+// that fail again stay in the store with their attempt count raised. It
+// returns early only if ctx ends or the store cannot be read.
+func (recv *Receiver) Replay(ctx context.Context) (*ReplayResult, error) {
+	pending, err := recv.Store.Pending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: list pending events: %w", err)
+	}
+
+	result := &ReplayResult{}
+	for _, stored := range pending {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		var event Event
+		err := json.Unmarshal(stored.Body, &event)
+		if err == nil {
+			err = recv.process(ctx, event)
+		}
+		if err != nil {
+			stored.Attempts++
+			stored.LastError = err.Error()
+			result.Failed = append(result.Failed, stored)
+			continue
+		}
+		result.Processed++
+	}
+	return result, nil
+}
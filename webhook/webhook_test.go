@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func post(t *testing.T, h http.Handler, body string) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body)))
+	return rec.Code
+}
+
+func TestReceiver(t *testing.T) {
+	ctx := context.Background()
+	store := NewDirStore(t.TempDir())
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	down := true
+	var handled []string
+	recv := &Receiver{Store: store, Handler: func(ctx context.Context, e Event) error {
+		if down && e.Type == "certificate.issued" {
+			return errors.New("downstream unavailable")
+		}
+		var data struct {
+			SerialNumber string `json:"serial_number"`
+		}
+		if err := e.Decode(&data); err != nil {
+			return err
+		}
+		handled = append(handled, e.ID+":"+data.SerialNumber)
+		return nil
+	}}
+
+	if code := post(t, recv, `{"id": "evt-1", "type": "certificate.issued", "data": {"serial_number": "0A"}}`); code != http.StatusOK {
+		t.Errorf("status = %v, want %v", code, http.StatusOK)
+	}
+	if code := post(t, recv, `{"id": "evt-2", "type": "certificate.revoked", "data": {"serial_number": "0B"}}`); code != http.StatusOK {
+		t.Errorf("status = %v, want %v", code, http.StatusOK)
+	}
+	if code := post(t, recv, `{"id": "evt-3", "type": "certificate.issued", "data": {"serial_number": "0C"}}`); code != http.StatusOK {
+		t.Errorf("status = %v, want %v", code, http.StatusOK)
+	}
+	if code := post(t, recv, `not json`); code != http.StatusBadRequest {
+		t.Errorf("status = %v, want %v", code, http.StatusBadRequest)
+	}
+
+	pending, err := store.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != "evt-1" || pending[1].ID != "evt-3" {
+		t.Fatalf("pending = %+v, want evt-1 and evt-3", pending)
+	}
+	if pending[0].Attempts != 1 || pending[0].LastError != "downstream unavailable" {
+		t.Errorf("pending[0] = %+v, want one failed attempt", pending[0])
+	}
+
+	result, err := recv.Replay(ctx)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if result.Processed != 0 || len(result.Failed) != 2 || result.Failed[0].Attempts != 2 {
+		t.Errorf("Replay() = %+v, want 2 failed on their second attempt", result)
+	}
+
+	down = false
+	result, err = recv.Replay(ctx)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if result.Processed != 2 || len(result.Failed) != 0 {
+		t.Errorf("Replay() = %+v, want 2 processed", result)
+	}
+	want := "evt-2:0B evt-1:0A evt-3:0C"
+	if got := strings.Join(handled, " "); got != want {
+		t.Errorf("handled = %v, want %v", got, want)
+	}
+	if pending, _ := store.Pending(ctx); len(pending) != 0 {
+		t.Errorf("pending = %+v, want none", pending)
+	}
+}
+
+func TestReceiver_Rejects(t *testing.T) {
+	recv := &Receiver{
+		Store:   NewDirStore(t.TempDir()),
+		Handler: func(ctx context.Context, e Event) error { return nil },
+		Verify: func(r *http.Request, body []byte) error {
+			if r.Header.Get("X-Secret") != "s3cret" {
+				return errors.New("bad secret")
+			}
+			return nil
+		},
+	}
+
+	if code := post(t, recv, `{"id": "evt-1"}`); code != http.StatusUnauthorized {
+		t.Errorf("status = %v, want %v", code, http.StatusUnauthorized)
+	}
+
+	rec := httptest.NewRecorder()
+	recv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %v, want %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestDirStore_InvalidID(t *testing.T) {
+	store := NewDirStore(t.TempDir())
+	for _, id := range []string{"", "..", "../escape", `a\b`} {
+		if err := store.Save(context.Background(), id, []byte(`{}`)); err == nil {
+			t.Errorf("Save(%q) error = nil, want error", id)
+		}
+	}
+}
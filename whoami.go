@@ -0,0 +1,80 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Identity describes the account behind the API key a client presents.
+type Identity struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Type      string `json:"type,omitempty"` // e.g. "service_account" or "user"
+	AccountID string `json:"account_id,omitempty"`
+
+	Roles         []string               `json:"roles,omitempty"`
+	Permissions   []string               `json:"permissions,omitempty"`
+	BusinessUnits []IdentityBusinessUnit `json:"business_units,omitempty"`
+}
+
+// IdentityBusinessUnit is a business unit the key can access, with the roles
+// it holds there.
+type IdentityBusinessUnit struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// WhoAmI returns the identity, roles, permissions and business units of the
+// API key in use, so callers can check permissions before attempting
+// changes that would fail with a 403.
+func (c *Client) WhoAmI(ctx context.Context) (*Identity, *Response, error) {
+	httpReq, err := c.NewRequest(ctx, http.MethodGet, "user/me", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var identity Identity
+	resp, err := c.Do(ctx, httpReq, &identity)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &identity, resp, nil
+}
+
+// HasPermission reports whether the identity holds permission, compared
+// case-insensitively
+func (id *Identity) HasPermission(permission string) bool {
+	return id != nil && containsFold(id.Permissions, permission)
+}
+
+// HasRole reports whether the identity holds role at account level
+func (id *Identity) HasRole(role string) bool {
+	return id != nil && containsFold(id.Roles, role)
+}
+
+// CanAccessBusinessUnit reports whether the business unit with the given ID
+// is accessible to the identity
+func (id *Identity) CanAccessBusinessUnit(buID string) bool {
+	if id == nil {
+		return false
+	}
+	for _, bu := range id.BusinessUnits {
+		if bu.ID == buID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
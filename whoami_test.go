@@ -0,0 +1,53 @@
+package digicert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_WhoAmI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/mpki/api/v1/user/me" {
+			t.Errorf("request = %s %s, want GET /mpki/api/v1/user/me", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"id": "sa-1",
+			"name": "deploy-bot",
+			"type": "service_account",
+			"account_id": "acct-1",
+			"roles": ["Manager"],
+			"permissions": ["VIEW_CERTIFICATE", "issue_certificate"],
+			"business_units": [{"id": "bu-1", "name": "Web", "roles": ["Manager"]}]
+		}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL+"/"))
+	identity, _, err := client.WhoAmI(context.Background())
+	if err != nil {
+		t.Fatalf("WhoAmI() error = %v", err)
+	}
+
+	if identity.Name != "deploy-bot" || identity.Type != "service_account" || identity.AccountID != "acct-1" {
+		t.Errorf("identity = %+v, want deploy-bot service account", identity)
+	}
+	if !identity.HasPermission("issue_certificate") || !identity.HasPermission("view_certificate") {
+		t.Error("HasPermission() = false for a granted permission")
+	}
+	if identity.HasPermission("REVOKE_CERTIFICATE") {
+		t.Error("HasPermission(REVOKE_CERTIFICATE) = true, want false")
+	}
+	if !identity.HasRole("manager") {
+		t.Error("HasRole(manager) = false, want true")
+	}
+	if !identity.CanAccessBusinessUnit("bu-1") || identity.CanAccessBusinessUnit("bu-2") {
+		t.Error("CanAccessBusinessUnit() did not match the listed business units")
+	}
+
+	var none *Identity
+	if none.HasPermission("x") || none.CanAccessBusinessUnit("bu-1") {
+		t.Error("nil Identity should have no access")
+	}
+}